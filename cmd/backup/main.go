@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"project/internal/backup"
+	"project/internal/domain/errors"
+	"project/internal/server"
+	db "project/repository/db"
+)
+
+const usage = "использование: taskbackup [флаги] backup <файл|-> | restore <файл|->"
+
+type command struct {
+	name string
+	path string
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, errors.ErrBackupCommand
+	}
+	switch args[0] {
+	case "backup", "restore":
+	default:
+		return command{}, errors.ErrBackupCommand
+	}
+	if len(args) != 2 || args[1] == "" {
+		return command{}, errors.ErrBackupArgument
+	}
+	return command{name: args[0], path: args[1]}, nil
+}
+
+func run(ctx context.Context, store backup.Store, cmd command) error {
+	switch cmd.name {
+	case "backup":
+		var out io.Writer = os.Stdout
+		if cmd.path != "-" {
+			file, err := os.Create(cmd.path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		stats, err := backup.Write(ctx, out, store)
+		if err != nil {
+			return err
+		}
+		log.Printf("[SUCCESS] Резервная копия создана: пользователей %d, задач %d", stats.Users, stats.Tasks)
+	case "restore":
+		var in io.Reader = os.Stdin
+		if cmd.path != "-" {
+			file, err := os.Open(cmd.path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			in = file
+		}
+		stats, err := backup.Restore(ctx, in, store)
+		if err != nil {
+			return err
+		}
+		log.Printf("[SUCCESS] Данные восстановлены: пользователей %d, задач %d", stats.Users, stats.Tasks)
+	}
+	return nil
+}
+
+func main() {
+	cfg := server.ReadConfig()
+
+	cmd, err := parseCommand(flag.Args())
+	if err != nil {
+		log.Fatalf("[ERROR] %v\n%s", err, usage)
+	}
+
+	storage, err := db.NewStorage(cfg.DBStr, db.PoolConfig{
+		MinConns:          int32(cfg.DBMinConns),
+		MaxConns:          int32(cfg.DBMaxConns),
+		HealthCheckPeriod: cfg.DBHealthCheckPeriod,
+		Retry: db.RetryPolicy{
+			MaxAttempts:    cfg.DBRetryAttempts,
+			InitialBackoff: cfg.DBRetryInitialBackoff,
+			MaxBackoff:     cfg.DBRetryMaxBackoff,
+		},
+		Timeouts: db.QueryTimeouts{
+			Read:       cfg.DBReadTimeout,
+			Write:      cfg.DBWriteTimeout,
+			Operations: cfg.DBQueryTimeouts,
+		},
+	})
+	if err != nil {
+		log.Fatal("[ERROR] Не удалось подключиться к БД:", err)
+	}
+
+	err = run(context.Background(), storage, cmd)
+	storage.Close()
+	if err != nil {
+		log.Fatalf("[ERROR] Ошибка выполнения команды %s: %v", cmd.name, err)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"project/internal/domain/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    command
+		wantErr error
+	}{
+		{name: "backup to file", args: []string{"backup", "tasks.ndjson"}, want: command{name: "backup", path: "tasks.ndjson"}},
+		{name: "restore from stdin", args: []string{"restore", "-"}, want: command{name: "restore", path: "-"}},
+		{name: "no command", args: nil, wantErr: errors.ErrBackupCommand},
+		{name: "unknown command", args: []string{"dump", "tasks.ndjson"}, wantErr: errors.ErrBackupCommand},
+		{name: "missing path", args: []string{"backup"}, wantErr: errors.ErrBackupArgument},
+		{name: "extra argument", args: []string{"restore", "a", "b"}, wantErr: errors.ErrBackupArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCommand(tt.args)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
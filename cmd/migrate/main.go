@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"project/internal/domain/errors"
+	"project/internal/server"
+	db "project/repository/db"
+	"strconv"
+)
+
+const usage = "использование: taskmigrate [флаги] up | down [N] | to <версия> | status"
+
+type command struct {
+	name    string
+	steps   int
+	version uint
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, errors.ErrMigrationCommand
+	}
+	cmd := command{name: args[0]}
+	switch cmd.name {
+	case "up", "status":
+		if len(args) != 1 {
+			return command{}, errors.ErrMigrationArgument
+		}
+	case "down":
+		cmd.steps = 1
+		if len(args) > 2 {
+			return command{}, errors.ErrMigrationArgument
+		}
+		if len(args) == 2 {
+			steps, err := strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return command{}, errors.ErrMigrationArgument
+			}
+			cmd.steps = steps
+		}
+	case "to":
+		if len(args) != 2 {
+			return command{}, errors.ErrMigrationArgument
+		}
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil || version == 0 {
+			return command{}, errors.ErrMigrationArgument
+		}
+		cmd.version = uint(version)
+	default:
+		return command{}, errors.ErrMigrationCommand
+	}
+	return cmd, nil
+}
+
+func run(cfg *server.Config, cmd command) error {
+	switch cmd.name {
+	case "up":
+		if err := db.Migration(cfg.DBStr, cfg.MigratePath); err != nil {
+			return err
+		}
+		log.Println("[SUCCESS] Миграции применены успешно")
+	case "down":
+		if err := db.MigrateDown(cfg.DBStr, cfg.MigratePath, cmd.steps); err != nil {
+			return err
+		}
+		log.Printf("[SUCCESS] Откачено миграций: %d", cmd.steps)
+	case "to":
+		if err := db.MigrateTo(cfg.DBStr, cfg.MigratePath, cmd.version); err != nil {
+			return err
+		}
+		log.Printf("[SUCCESS] Схема приведена к версии %d", cmd.version)
+	}
+
+	status, err := db.GetMigrationStatus(cfg.DBStr, cfg.MigratePath)
+	if err != nil {
+		return err
+	}
+	if !status.Applied {
+		fmt.Println("Миграции еще не применялись")
+		return nil
+	}
+	fmt.Printf("Текущая версия схемы: %d\n", status.Version)
+	if status.Dirty {
+		fmt.Println("Внимание: последняя миграция завершилась с ошибкой, схема в состоянии dirty")
+	}
+	return nil
+}
+
+func main() {
+	cfg := server.ReadConfig()
+
+	cmd, err := parseCommand(flag.Args())
+	if err != nil {
+		log.Fatalf("[ERROR] %v\n%s", err, usage)
+	}
+	if err := run(cfg, cmd); err != nil {
+		log.Fatalf("[ERROR] Ошибка выполнения миграции: %v", err)
+	}
+}
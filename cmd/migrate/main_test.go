@@ -0,0 +1,38 @@
+package main
+
+import (
+	"project/internal/domain/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    command
+		wantErr error
+	}{
+		{name: "up", args: []string{"up"}, want: command{name: "up"}},
+		{name: "status", args: []string{"status"}, want: command{name: "status"}},
+		{name: "down defaults to one step", args: []string{"down"}, want: command{name: "down", steps: 1}},
+		{name: "down several steps", args: []string{"down", "3"}, want: command{name: "down", steps: 3}},
+		{name: "to version", args: []string{"to", "12"}, want: command{name: "to", version: 12}},
+		{name: "no command", args: nil, wantErr: errors.ErrMigrationCommand},
+		{name: "unknown command", args: []string{"drop"}, wantErr: errors.ErrMigrationCommand},
+		{name: "down zero steps", args: []string{"down", "0"}, wantErr: errors.ErrMigrationArgument},
+		{name: "down not a number", args: []string{"down", "all"}, wantErr: errors.ErrMigrationArgument},
+		{name: "to without version", args: []string{"to"}, wantErr: errors.ErrMigrationArgument},
+		{name: "to zero version", args: []string{"to", "0"}, wantErr: errors.ErrMigrationArgument},
+		{name: "up with extra argument", args: []string{"up", "1"}, wantErr: errors.ErrMigrationArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCommand(tt.args)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"project/internal/seed"
+	"project/internal/server"
+	db "project/repository/db"
+)
+
+var (
+	userCount    = flag.Int("users", 10, "количество демо-пользователей")
+	taskCount    = flag.Int("tasks", 20, "количество задач на каждого пользователя")
+	userPrefix   = flag.String("prefix", "demo", "префикс имен демо-пользователей")
+	seedPassword = flag.String("password", "password123", "пароль демо-пользователей")
+)
+
+func main() {
+	cfg := server.ReadConfig()
+
+	storage, err := db.NewStorage(cfg.DBStr, db.PoolConfig{
+		MinConns:          int32(cfg.DBMinConns),
+		MaxConns:          int32(cfg.DBMaxConns),
+		HealthCheckPeriod: cfg.DBHealthCheckPeriod,
+		Retry: db.RetryPolicy{
+			MaxAttempts:    cfg.DBRetryAttempts,
+			InitialBackoff: cfg.DBRetryInitialBackoff,
+			MaxBackoff:     cfg.DBRetryMaxBackoff,
+		},
+		Timeouts: db.QueryTimeouts{
+			Read:       cfg.DBReadTimeout,
+			Write:      cfg.DBWriteTimeout,
+			Operations: cfg.DBQueryTimeouts,
+		},
+	})
+	if err != nil {
+		log.Fatal("[ERROR] Не удалось подключиться к БД:", err)
+	}
+
+	res, err := seed.Run(context.Background(), storage, seed.Options{
+		Users:    *userCount,
+		Tasks:    *taskCount,
+		Prefix:   *userPrefix,
+		Password: *seedPassword,
+	})
+	storage.Close()
+	if err != nil {
+		log.Fatalf("[ERROR] Ошибка заполнения демо-данными (создано пользователей: %d, задач: %d): %v", res.Users, res.Tasks, err)
+	}
+	log.Printf("[SUCCESS] Создано пользователей: %d, задач: %d, пропущено существующих пользователей: %d", res.Users, res.Tasks, res.Skipped)
+}
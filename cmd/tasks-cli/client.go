@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"project/internal/domain/models"
+	"strings"
+	"time"
+)
+
+const tokenCookie = "jwt_token"
+
+type APIError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("сервер вернул %d", e.Status)
+	}
+	return fmt.Sprintf("%s (%d)", e.Message, e.Status)
+}
+
+type Client struct {
+	BaseURL string
+	Token   string
+	HTTP    *http.Client
+}
+
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.AddCookie(&http.Cookie{Name: tokenCookie, Value: c.Token})
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{Status: resp.StatusCode}
+		var envelope models.ErrorEnvelope
+		if json.NewDecoder(resp.Body).Decode(&envelope) == nil {
+			apiErr.Code = envelope.Error.Code
+			apiErr.Message = envelope.Error.Message
+		}
+		return resp, apiErr
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+func (c *Client) Login(ctx context.Context, username, password string) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/users/login", models.LoginRequest{Username: username, Password: password}, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == tokenCookie && cookie.Value != "" {
+			c.Token = cookie.Value
+			return cookie.Value, nil
+		}
+	}
+	return "", &APIError{Status: resp.StatusCode, Message: "сервер не вернул токен"}
+}
+
+func (c *Client) ListTasks(ctx context.Context, status string) ([]models.Task, error) {
+	path := "/tasks"
+	if status != "" {
+		path += "?status=" + url.QueryEscape(status)
+	}
+	var response struct {
+		Tasks []models.Task `json:"tasks"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.Code == "tasks_not_found" {
+			return []models.Task{}, nil
+		}
+		return nil, err
+	}
+	return response.Tasks, nil
+}
+
+type taskResponse struct {
+	Task models.Task `json:"task"`
+}
+
+func (c *Client) CreateTask(ctx context.Context, title, description string) (*models.Task, error) {
+	var response taskResponse
+	if _, err := c.do(ctx, http.MethodPost, "/tasks", models.CreateTaskRequest{Title: title, Description: description}, &response); err != nil {
+		return nil, err
+	}
+	return &response.Task, nil
+}
+
+func (c *Client) CompleteTask(ctx context.Context, id string) (*models.Task, error) {
+	var response taskResponse
+	if _, err := c.do(ctx, http.MethodPatch, "/tasks/"+url.PathEscape(id), map[string]string{"status": "done"}, &response); err != nil {
+		return nil, err
+	}
+	return &response.Task, nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/tasks/"+url.PathEscape(id), nil, nil)
+	return err
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	opLogin  = "login"
+	opList   = "list"
+	opCreate = "create"
+	opDone   = "done"
+	opDelete = "delete"
+)
+
+type weightedOp struct {
+	name   string
+	weight int
+}
+
+var scenarios = map[string][]weightedOp{
+	"login-heavy": {{opLogin, 70}, {opList, 20}, {opCreate, 10}},
+	"read-heavy":  {{opList, 85}, {opCreate, 10}, {opDone, 5}},
+	"write-heavy": {{opCreate, 45}, {opDone, 25}, {opDelete, 20}, {opList, 10}},
+}
+
+type loadOptions struct {
+	scenario    string
+	concurrency int
+	duration    time.Duration
+	requests    int
+	username    string
+	password    string
+}
+
+type loadSample struct {
+	op      string
+	latency time.Duration
+	failed  bool
+}
+
+type OperationStats struct {
+	Operation string  `json:"operation"`
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	P50       float64 `json:"p50_ms"`
+	P90       float64 `json:"p90_ms"`
+	P99       float64 `json:"p99_ms"`
+	Max       float64 `json:"max_ms"`
+}
+
+type LoadReport struct {
+	Scenario    string           `json:"scenario"`
+	Concurrency int              `json:"concurrency"`
+	Elapsed     float64          `json:"elapsed_seconds"`
+	Requests    int              `json:"requests"`
+	Errors      int              `json:"errors"`
+	RPS         float64          `json:"rps"`
+	Operations  []OperationStats `json:"operations"`
+	Total       OperationStats   `json:"total"`
+}
+
+func parseLoadOptions(args []string) (loadOptions, error) {
+	opts := loadOptions{}
+	fs := newFlagSet("loadtest")
+	fs.StringVar(&opts.scenario, "scenario", "read-heavy", "")
+	fs.IntVar(&opts.concurrency, "concurrency", 10, "")
+	fs.DurationVar(&opts.duration, "duration", 30*time.Second, "")
+	fs.IntVar(&opts.requests, "requests", 0, "")
+	fs.StringVar(&opts.username, "username", "", "")
+	fs.StringVar(&opts.password, "password", "", "")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return loadOptions{}, errors.ErrCommandArgument
+	}
+	if opts.password == "" {
+		opts.password = os.Getenv("TASKS_PASSWORD")
+	}
+	if _, ok := scenarios[opts.scenario]; !ok || opts.concurrency < 1 || opts.duration <= 0 || opts.requests < 0 {
+		return loadOptions{}, errors.ErrCommandArgument
+	}
+	if opts.username == "" || opts.password == "" {
+		return loadOptions{}, errors.ErrCommandArgument
+	}
+	return opts, nil
+}
+
+func loadTest(ctx context.Context, opts options, client *Client, args []string) error {
+	load, err := parseLoadOptions(args)
+	if err != nil {
+		return err
+	}
+	report, err := runLoadTest(ctx, client.BaseURL, load)
+	if err != nil {
+		return err
+	}
+	return printLoadReport(opts, report)
+}
+
+func runLoadTest(ctx context.Context, baseURL string, load loadOptions) (LoadReport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = load.concurrency
+	transport.MaxIdleConnsPerHost = load.concurrency
+	httpClient := &http.Client{Timeout: 30 * time.Second, Transport: transport}
+	defer transport.CloseIdleConnections()
+
+	probe := NewClient(baseURL, "")
+	probe.HTTP = httpClient
+	token, err := probe.Login(ctx, load.username, load.password)
+	if err != nil {
+		return LoadReport{}, err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, load.duration)
+	defer cancel()
+
+	var issued atomic.Int64
+	results := make([][]loadSample, load.concurrency)
+	var wg sync.WaitGroup
+	started := time.Now()
+	for i := 0; i < load.concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			client := NewClient(baseURL, token)
+			client.HTTP = httpClient
+			w := &loadWorker{
+				client: client,
+				load:   load,
+				ops:    scenarios[load.scenario],
+				rnd:    rand.New(rand.NewSource(time.Now().UnixNano() + int64(worker))),
+			}
+			for runCtx.Err() == nil {
+				if load.requests > 0 && issued.Add(1) > int64(load.requests) {
+					return
+				}
+				sample := w.step(runCtx)
+				if runCtx.Err() != nil && sample.failed {
+					return
+				}
+				results[worker] = append(results[worker], sample)
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(started)
+
+	var samples []loadSample
+	for _, res := range results {
+		samples = append(samples, res...)
+	}
+	return buildLoadReport(load, samples, elapsed), nil
+}
+
+type loadWorker struct {
+	client *Client
+	load   loadOptions
+	ops    []weightedOp
+	rnd    *rand.Rand
+	ids    []string
+	seq    int
+}
+
+func (w *loadWorker) pick() string {
+	total := 0
+	for _, op := range w.ops {
+		total += op.weight
+	}
+	n := w.rnd.Intn(total)
+	for _, op := range w.ops {
+		if n < op.weight {
+			return op.name
+		}
+		n -= op.weight
+	}
+	return w.ops[len(w.ops)-1].name
+}
+
+func (w *loadWorker) step(ctx context.Context) loadSample {
+	op := w.pick()
+	if (op == opDone || op == opDelete) && len(w.ids) == 0 {
+		op = opCreate
+	}
+
+	started := time.Now()
+	var err error
+	switch op {
+	case opLogin:
+		_, err = w.client.Login(ctx, w.load.username, w.load.password)
+	case opList:
+		_, err = w.client.ListTasks(ctx, "")
+	case opCreate:
+		w.seq++
+		var task *models.Task
+		if task, err = w.client.CreateTask(ctx, fmt.Sprintf("Нагрузочный тест %d", w.seq), ""); err == nil {
+			w.ids = append(w.ids, task.ID)
+		}
+	case opDone:
+		_, err = w.client.CompleteTask(ctx, w.ids[w.rnd.Intn(len(w.ids))])
+	case opDelete:
+		last := len(w.ids) - 1
+		err = w.client.DeleteTask(ctx, w.ids[last])
+		w.ids = w.ids[:last]
+	}
+	return loadSample{op: op, latency: time.Since(started), failed: err != nil}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+func millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+func summarize(name string, samples []loadSample) OperationStats {
+	stats := OperationStats{Operation: name, Requests: len(samples)}
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, s := range samples {
+		if s.failed {
+			stats.Errors++
+		}
+		latencies = append(latencies, s.latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	stats.P50 = millis(percentile(latencies, 0.50))
+	stats.P90 = millis(percentile(latencies, 0.90))
+	stats.P99 = millis(percentile(latencies, 0.99))
+	if len(latencies) > 0 {
+		stats.Max = millis(latencies[len(latencies)-1])
+	}
+	return stats
+}
+
+func buildLoadReport(load loadOptions, samples []loadSample, elapsed time.Duration) LoadReport {
+	byOp := map[string][]loadSample{}
+	for _, s := range samples {
+		byOp[s.op] = append(byOp[s.op], s)
+	}
+	report := LoadReport{
+		Scenario:    load.scenario,
+		Concurrency: load.concurrency,
+		Elapsed:     math.Round(elapsed.Seconds()*1000) / 1000,
+		Operations:  []OperationStats{},
+	}
+	for _, op := range []string{opLogin, opList, opCreate, opDone, opDelete} {
+		if len(byOp[op]) > 0 {
+			report.Operations = append(report.Operations, summarize(op, byOp[op]))
+		}
+	}
+	report.Total = summarize("total", samples)
+	report.Requests = report.Total.Requests
+	report.Errors = report.Total.Errors
+	if elapsed > 0 {
+		report.RPS = math.Round(float64(report.Requests)/elapsed.Seconds()*10) / 10
+	}
+	return report
+}
+
+func printLoadReport(opts options, report LoadReport) error {
+	if opts.output == "json" {
+		encoder := json.NewEncoder(opts.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	fmt.Fprintf(opts.out, "Сценарий %s, потоков %d, время %.1fс: запросов %d, ошибок %d, %.1f запр/с\n",
+		report.Scenario, report.Concurrency, report.Elapsed, report.Requests, report.Errors, report.RPS)
+	w := tabwriter.NewWriter(opts.out, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "ОПЕРАЦИЯ\tЗАПРОСОВ\tОШИБОК\tP50 МС\tP90 МС\tP99 МС\tMAX МС\t")
+	for _, stats := range append(report.Operations, report.Total) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f\t%.2f\t%.2f\t%.2f\t\n", stats.Operation, stats.Requests, stats.Errors, stats.P50, stats.P90, stats.P99, stats.Max)
+	}
+	return w.Flush()
+}
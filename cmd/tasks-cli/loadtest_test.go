@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"project/internal/domain/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLoadOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		want    loadOptions
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			args: []string{"-username", "alice", "-password", "secret1"},
+			want: loadOptions{scenario: "read-heavy", concurrency: 10, duration: 30 * time.Second, username: "alice", password: "secret1"},
+		},
+		{
+			name: "password from environment",
+			args: []string{"-username", "alice", "-scenario", "write-heavy", "-concurrency", "2", "-requests", "100"},
+			env:  "secret1",
+			want: loadOptions{scenario: "write-heavy", concurrency: 2, duration: 30 * time.Second, requests: 100, username: "alice", password: "secret1"},
+		},
+		{name: "unknown scenario", args: []string{"-username", "alice", "-password", "secret1", "-scenario", "mixed"}, wantErr: errors.ErrCommandArgument},
+		{name: "zero concurrency", args: []string{"-username", "alice", "-password", "secret1", "-concurrency", "0"}, wantErr: errors.ErrCommandArgument},
+		{name: "without username", args: []string{"-password", "secret1"}, wantErr: errors.ErrCommandArgument},
+		{name: "without password", args: []string{"-username", "alice"}, wantErr: errors.ErrCommandArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TASKS_PASSWORD", tt.env)
+			got, err := parseLoadOptions(tt.args)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadTest(t *testing.T) {
+	srv := fakeAPI(t)
+
+	for scenario, ops := range scenarios {
+		t.Run(scenario, func(t *testing.T) {
+			var out bytes.Buffer
+			opts := options{server: srv.URL, output: "json", sessionPath: filepath.Join(t.TempDir(), "session.json"), out: &out}
+			args := []string{"loadtest", "-username", "alice", "-password", "secret1", "-scenario", scenario, "-concurrency", "3", "-requests", "60"}
+			require.NoError(t, run(context.Background(), opts, args))
+
+			var report LoadReport
+			require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+			assert.Equal(t, scenario, report.Scenario)
+			assert.Equal(t, 60, report.Requests)
+			assert.Zero(t, report.Errors)
+			assert.Equal(t, 60, report.Total.Requests)
+
+			allowed := map[string]bool{}
+			for _, op := range ops {
+				allowed[op.name] = true
+			}
+			total := 0
+			for _, stats := range report.Operations {
+				assert.True(t, allowed[stats.Operation], stats.Operation)
+				assert.LessOrEqual(t, stats.P50, stats.P99)
+				assert.LessOrEqual(t, stats.P99, stats.Max)
+				total += stats.Requests
+			}
+			assert.Equal(t, 60, total)
+		})
+	}
+}
+
+func TestLoadTestRejectsBadCredentials(t *testing.T) {
+	srv := fakeAPI(t)
+	opts := options{server: srv.URL, output: "table", sessionPath: filepath.Join(t.TempDir(), "session.json"), out: io.Discard}
+
+	var apiErr *APIError
+	err := run(context.Background(), opts, []string{"loadtest", "-username", "alice", "-password", "wrong12", "-requests", "5"})
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestBuildLoadReport(t *testing.T) {
+	samples := []loadSample{
+		{op: opList, latency: 10 * time.Millisecond},
+		{op: opList, latency: 20 * time.Millisecond},
+		{op: opList, latency: 30 * time.Millisecond, failed: true},
+		{op: opCreate, latency: 40 * time.Millisecond},
+	}
+	report := buildLoadReport(loadOptions{scenario: "read-heavy", concurrency: 2}, samples, 2*time.Second)
+
+	assert.Equal(t, 4, report.Requests)
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 2.0, report.RPS)
+	require.Len(t, report.Operations, 2)
+	assert.Equal(t, OperationStats{Operation: opList, Requests: 3, Errors: 1, P50: 20, P90: 30, P99: 30, Max: 30}, report.Operations[0])
+	assert.Equal(t, OperationStats{Operation: opCreate, Requests: 1, P50: 40, P90: 40, P99: 40, Max: 40}, report.Operations[1])
+	assert.Equal(t, 40.0, report.Total.Max)
+}
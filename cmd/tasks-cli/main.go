@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"strings"
+	"text/tabwriter"
+)
+
+const usage = `использование: tasks-cli [флаги] команда
+
+команды:
+  login -username U [-password P]    войти и сохранить токен (пароль также из TASKS_PASSWORD)
+  logout                             удалить сохраненный токен
+  tasks list [-status S]             показать задачи
+  tasks add [-description D] TITLE   создать задачу
+  tasks done ID                      отметить задачу выполненной
+  tasks rm ID                        удалить задачу
+  loadtest -username U [-password P] [-scenario S] [-concurrency N] [-duration D] [-requests N]
+                                     нагрузочный тест: login-heavy, read-heavy (по умолчанию) или write-heavy`
+
+const defaultServer = "http://localhost:8080"
+
+type options struct {
+	server      string
+	output      string
+	sessionPath string
+	out         io.Writer
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func (o options) serverURL(saved session) string {
+	switch {
+	case o.server != "":
+		return o.server
+	case os.Getenv("TASKS_SERVER") != "":
+		return os.Getenv("TASKS_SERVER")
+	case saved.Server != "":
+		return saved.Server
+	}
+	return defaultServer
+}
+
+func run(ctx context.Context, opts options, args []string) error {
+	if opts.output != "table" && opts.output != "json" {
+		return errors.ErrOutputFormat
+	}
+	if len(args) == 0 {
+		return errors.ErrCommand
+	}
+	saved, err := loadSession(opts.sessionPath)
+	if err != nil {
+		return err
+	}
+	client := NewClient(opts.serverURL(saved), saved.Token)
+
+	switch args[0] {
+	case "login":
+		return login(ctx, opts, client, args[1:])
+	case "logout":
+		if len(args) != 1 {
+			return errors.ErrCommandArgument
+		}
+		return removeSession(opts.sessionPath)
+	case "tasks":
+		if client.Token == "" {
+			return errors.ErrNotLoggedIn
+		}
+		return tasksCommand(ctx, opts, client, args[1:])
+	case "loadtest":
+		return loadTest(ctx, opts, client, args[1:])
+	}
+	return errors.ErrCommand
+}
+
+func login(ctx context.Context, opts options, client *Client, args []string) error {
+	fs := newFlagSet("login")
+	username := fs.String("username", "", "")
+	password := fs.String("password", "", "")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 || *username == "" {
+		return errors.ErrCommandArgument
+	}
+	if *password == "" {
+		*password = os.Getenv("TASKS_PASSWORD")
+	}
+	if *password == "" {
+		return errors.ErrCommandArgument
+	}
+	token, err := client.Login(ctx, *username, *password)
+	if err != nil {
+		return err
+	}
+	if err := saveSession(opts.sessionPath, session{Server: client.BaseURL, Token: token}); err != nil {
+		return err
+	}
+	fmt.Fprintln(opts.out, "Вход выполнен:", *username)
+	return nil
+}
+
+func tasksCommand(ctx context.Context, opts options, client *Client, args []string) error {
+	if len(args) == 0 {
+		return errors.ErrCommandArgument
+	}
+	switch args[0] {
+	case "list":
+		fs := newFlagSet("list")
+		status := fs.String("status", "", "")
+		if err := fs.Parse(args[1:]); err != nil || fs.NArg() != 0 {
+			return errors.ErrCommandArgument
+		}
+		tasks, err := client.ListTasks(ctx, *status)
+		if err != nil {
+			return err
+		}
+		return printTasks(opts, tasks)
+	case "add":
+		fs := newFlagSet("add")
+		description := fs.String("description", "", "")
+		if err := fs.Parse(args[1:]); err != nil || fs.NArg() == 0 {
+			return errors.ErrCommandArgument
+		}
+		task, err := client.CreateTask(ctx, strings.Join(fs.Args(), " "), *description)
+		if err != nil {
+			return err
+		}
+		return printTasks(opts, []models.Task{*task})
+	case "done":
+		if len(args) != 2 {
+			return errors.ErrCommandArgument
+		}
+		task, err := client.CompleteTask(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return printTasks(opts, []models.Task{*task})
+	case "rm":
+		if len(args) != 2 {
+			return errors.ErrCommandArgument
+		}
+		if err := client.DeleteTask(ctx, args[1]); err != nil {
+			return err
+		}
+		if opts.output == "json" {
+			return json.NewEncoder(opts.out).Encode(map[string]string{"deleted": args[1]})
+		}
+		fmt.Fprintln(opts.out, "Задача удалена:", args[1])
+		return nil
+	}
+	return errors.ErrCommand
+}
+
+func printTasks(opts options, tasks []models.Task) error {
+	if opts.output == "json" {
+		encoder := json.NewEncoder(opts.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tasks)
+	}
+	w := tabwriter.NewWriter(opts.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tСТАТУС\tНАЗВАНИЕ")
+	for _, task := range tasks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", task.ID, task.Status, task.Title)
+	}
+	return w.Flush()
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	server := flag.String("server", "", "адрес API (по умолчанию TASKS_SERVER, адрес из сессии или "+defaultServer+")")
+	output := flag.String("output", "table", "формат вывода: table или json")
+	sessionPath := flag.String("session", "", "файл сессии с токеном (по умолчанию в каталоге конфигурации пользователя)")
+	flag.Parse()
+
+	log.SetFlags(0)
+	if *sessionPath == "" {
+		path, err := defaultSessionPath()
+		if err != nil {
+			log.Fatalln("[ERROR] Не удалось определить путь к файлу сессии:", err)
+		}
+		*sessionPath = path
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	opts := options{server: *server, output: *output, sessionPath: *sessionPath, out: os.Stdout}
+	if err := run(ctx, opts, flag.Args()); err != nil {
+		if err == errors.ErrCommand || err == errors.ErrCommandArgument {
+			log.Fatalf("[ERROR] %v\n%s", err, usage)
+		}
+		log.Fatalln("[ERROR]", err)
+	}
+}
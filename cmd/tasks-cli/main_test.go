@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeAPI(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/login", func(w http.ResponseWriter, r *http.Request) {
+		var req models.LoginRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Password != "secret1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(models.ErrorEnvelope{Error: models.ErrorResponse{Code: "invalid_credentials", Message: "неверный логин или пароль"}})
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: tokenCookie, Value: "token-" + req.Username})
+		_, _ = io.WriteString(w, `{"message":"ok"}`)
+	})
+	authorized := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if cookie, err := r.Cookie(tokenCookie); err != nil || cookie.Value != "token-alice" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+	mux.HandleFunc("GET /tasks", authorized(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status") == "done" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(models.ErrorEnvelope{Error: models.ErrorResponse{Code: "tasks_not_found", Message: "задачи не найдены"}})
+			return
+		}
+		_, _ = io.WriteString(w, `{"tasks":[{"id":"t1","title":"Купить молоко","status":"new"}]}`)
+	}))
+	mux.HandleFunc("POST /tasks", authorized(func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateTaskRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(taskResponse{Task: models.Task{ID: "t2", Title: req.Title, Description: req.Description, Status: "new"}})
+	}))
+	mux.HandleFunc("PATCH /tasks/{id}", authorized(func(w http.ResponseWriter, r *http.Request) {
+		var patch map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+		_ = json.NewEncoder(w).Encode(taskResponse{Task: models.Task{ID: r.PathValue("id"), Title: "Купить молоко", Status: patch["status"]}})
+	}))
+	mux.HandleFunc("DELETE /tasks/{id}", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"message":"ok"}`)
+	}))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRun(t *testing.T) {
+	srv := fakeAPI(t)
+
+	tests := []struct {
+		name    string
+		output  string
+		login   bool
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "list as table", output: "table", login: true, args: []string{"tasks", "list"}, want: "ID  СТАТУС  НАЗВАНИЕ\nt1  new     Купить молоко\n"},
+		{name: "empty list", output: "table", login: true, args: []string{"tasks", "list", "-status", "done"}, want: "ID  СТАТУС  НАЗВАНИЕ\n"},
+		{name: "add as json", output: "json", login: true, args: []string{"tasks", "add", "-description", "2 л", "Купить", "молоко"}, want: "\"title\": \"Купить молоко\""},
+		{name: "done", output: "table", login: true, args: []string{"tasks", "done", "t1"}, want: "t1  done"},
+		{name: "rm", output: "table", login: true, args: []string{"tasks", "rm", "t1"}, want: "Задача удалена: t1\n"},
+		{name: "tasks without login", output: "table", args: []string{"tasks", "list"}, wantErr: errors.ErrNotLoggedIn},
+		{name: "unknown output", output: "yaml", args: []string{"tasks", "list"}, wantErr: errors.ErrOutputFormat},
+		{name: "unknown command", output: "table", args: []string{"projects"}, wantErr: errors.ErrCommand},
+		{name: "done without id", output: "table", login: true, args: []string{"tasks", "done"}, wantErr: errors.ErrCommandArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			opts := options{server: srv.URL, output: tt.output, sessionPath: filepath.Join(t.TempDir(), "session.json"), out: &out}
+			if tt.login {
+				require.NoError(t, run(context.Background(), opts, []string{"login", "-username", "alice", "-password", "secret1"}))
+				out.Reset()
+			}
+
+			err := run(context.Background(), opts, tt.args)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.want)
+		})
+	}
+}
+
+func TestLoginStoresSession(t *testing.T) {
+	srv := fakeAPI(t)
+	path := filepath.Join(t.TempDir(), "cli", "session.json")
+	opts := options{server: srv.URL, output: "table", sessionPath: path, out: io.Discard}
+
+	var apiErr *APIError
+	err := run(context.Background(), opts, []string{"login", "-username", "alice", "-password", "wrong12"})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.Status)
+
+	t.Setenv("TASKS_PASSWORD", "secret1")
+	require.NoError(t, run(context.Background(), opts, []string{"login", "-username", "alice"}))
+	saved, err := loadSession(path)
+	require.NoError(t, err)
+	assert.Equal(t, session{Server: srv.URL, Token: "token-alice"}, saved)
+
+	opts.server = ""
+	require.NoError(t, run(context.Background(), opts, []string{"tasks", "list"}))
+
+	require.NoError(t, run(context.Background(), opts, []string{"logout"}))
+	assert.Equal(t, errors.ErrNotLoggedIn, run(context.Background(), opts, []string{"tasks", "list"}))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type session struct {
+	Server string `json:"server"`
+	Token  string `json:"token"`
+}
+
+func defaultSessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tasks-cli", "session.json"), nil
+}
+
+func loadSession(path string) (session, error) {
+	var s session
+	data, err := os.ReadFile(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	return s, json.Unmarshal(data, &s)
+}
+
+func saveSession(path string, s session) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func removeSession(path string) error {
+	err := os.Remove(path)
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/jsoncodec"
+	"project/internal/purge"
+	"project/internal/seed"
+	"project/internal/server"
+	db "project/repository/db"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const usage = `использование: taskapp [флаги] [команда]
+
+команды:
+  serve                          запустить HTTP-сервис (по умолчанию)
+  migrate up | down [N] | status применить, откатить или показать миграции
+  seed [-users N] [-tasks N] [-prefix P] [-password P]
+                                 заполнить БД демо-данными
+  create-admin -username U -email E [-password P]
+                                 создать администратора (пароль также из ADMIN_PASSWORD)
+  purge                          однократно очистить корзину по сроку хранения
+  version                        показать версию сборки`
+
+var version = "dev"
+
+type adminOptions struct {
+	Username string
+	Email    string
+	Password string
+}
+
+type command struct {
+	name    string
+	migrate string
+	steps   int
+	seed    seed.Options
+	admin   adminOptions
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{name: "serve"}, nil
+	}
+	cmd := command{name: args[0]}
+	rest := args[1:]
+	switch cmd.name {
+	case "serve", "purge", "version":
+		if len(rest) != 0 {
+			return command{}, errors.ErrCommandArgument
+		}
+	case "migrate":
+		if len(rest) == 0 {
+			return command{}, errors.ErrCommandArgument
+		}
+		cmd.migrate = rest[0]
+		switch cmd.migrate {
+		case "up", "status":
+			if len(rest) != 1 {
+				return command{}, errors.ErrCommandArgument
+			}
+		case "down":
+			cmd.steps = 1
+			if len(rest) > 2 {
+				return command{}, errors.ErrCommandArgument
+			}
+			if len(rest) == 2 {
+				steps, err := strconv.Atoi(rest[1])
+				if err != nil || steps < 1 {
+					return command{}, errors.ErrCommandArgument
+				}
+				cmd.steps = steps
+			}
+		default:
+			return command{}, errors.ErrCommandArgument
+		}
+	case "seed":
+		fs := newFlagSet(cmd.name)
+		fs.IntVar(&cmd.seed.Users, "users", 10, "количество демо-пользователей")
+		fs.IntVar(&cmd.seed.Tasks, "tasks", 20, "количество задач на каждого пользователя")
+		fs.StringVar(&cmd.seed.Prefix, "prefix", "demo", "префикс имен демо-пользователей")
+		fs.StringVar(&cmd.seed.Password, "password", "password123", "пароль демо-пользователей")
+		if err := fs.Parse(rest); err != nil || fs.NArg() != 0 {
+			return command{}, errors.ErrCommandArgument
+		}
+	case "create-admin":
+		fs := newFlagSet(cmd.name)
+		fs.StringVar(&cmd.admin.Username, "username", "", "имя администратора")
+		fs.StringVar(&cmd.admin.Email, "email", "", "email администратора")
+		fs.StringVar(&cmd.admin.Password, "password", "", "пароль администратора")
+		if err := fs.Parse(rest); err != nil || fs.NArg() != 0 {
+			return command{}, errors.ErrCommandArgument
+		}
+		if cmd.admin.Username == "" || cmd.admin.Email == "" {
+			return command{}, errors.ErrCommandArgument
+		}
+	default:
+		return command{}, errors.ErrCommand
+	}
+	return cmd, nil
+}
+
+func versionString() string {
+	v := version
+	if v == "dev" {
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			v = info.Main.Version
+		}
+	}
+	return fmt.Sprintf("taskapp %s (%s, %s)", v, runtime.Version(), jsoncodec.Name)
+}
+
+func poolConfig(cfg *server.Config) db.PoolConfig {
+	return db.PoolConfig{
+		MinConns:          int32(cfg.DBMinConns),
+		MaxConns:          int32(cfg.DBMaxConns),
+		HealthCheckPeriod: cfg.DBHealthCheckPeriod,
+		ReplicaDSN:        cfg.DBReplicaStr,
+		Retry: db.RetryPolicy{
+			MaxAttempts:    cfg.DBRetryAttempts,
+			InitialBackoff: cfg.DBRetryInitialBackoff,
+			MaxBackoff:     cfg.DBRetryMaxBackoff,
+		},
+		Timeouts: db.QueryTimeouts{
+			Read:       cfg.DBReadTimeout,
+			Write:      cfg.DBWriteTimeout,
+			Operations: cfg.DBQueryTimeouts,
+		},
+		Breaker: db.BreakerPolicy{
+			Threshold: cfg.DBBreakerThreshold,
+			Cooldown:  cfg.DBBreakerCooldown,
+		},
+		SlowQuery:   cfg.DBSlowQueryThreshold,
+		SQLComments: cfg.DBSQLComments,
+	}
+}
+
+func OpenStorage(cfg *server.Config) (*db.Storage, error) {
+	return db.NewStorage(cfg.DBStr, poolConfig(cfg))
+}
+
+func RunMigrate(cfg *server.Config, cmd command) error {
+	switch cmd.migrate {
+	case "up":
+		if err := RunMigrations(cfg); err != nil {
+			return err
+		}
+	case "down":
+		if err := db.MigrateDown(cfg.DBStr, cfg.MigratePath, cmd.steps); err != nil {
+			return err
+		}
+		log.Printf("[SUCCESS] Откачено миграций: %d", cmd.steps)
+	}
+
+	status, err := db.GetMigrationStatus(cfg.DBStr, cfg.MigratePath)
+	if err != nil {
+		return err
+	}
+	if !status.Applied {
+		fmt.Println("Миграции еще не применялись")
+		return nil
+	}
+	fmt.Printf("Текущая версия схемы: %d\n", status.Version)
+	if status.Dirty {
+		fmt.Println("Внимание: последняя миграция завершилась с ошибкой, схема в состоянии dirty")
+	}
+	return nil
+}
+
+func RunSeed(ctx context.Context, store seed.Store, opts seed.Options) error {
+	res, err := seed.Run(ctx, store, opts)
+	if err != nil {
+		log.Printf("[WARN] Заполнение прервано: создано пользователей %d, задач %d", res.Users, res.Tasks)
+		return err
+	}
+	log.Printf("[SUCCESS] Создано пользователей: %d, задач: %d, пропущено существующих пользователей: %d", res.Users, res.Tasks, res.Skipped)
+	return nil
+}
+
+type UserCreator interface {
+	CreateUser(ctx context.Context, user *models.User) error
+}
+
+func CreateAdmin(ctx context.Context, store UserCreator, opts adminOptions) (*models.User, error) {
+	if opts.Password == "" {
+		opts.Password = os.Getenv("ADMIN_PASSWORD")
+	}
+	req := models.RegisterRequest{Username: opts.Username, Email: opts.Email, Password: opts.Password}
+	if err := server.ValidateRequest(&req); err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{
+		ID:       uuid.New().String(),
+		Username: req.Username,
+		Email:    req.Email,
+		Password: string(hash),
+		Role:     models.RoleAdmin,
+	}
+	if err := store.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	log.Printf("[SUCCESS] Администратор %s создан (id %s)", user.Username, user.ID)
+	return user, nil
+}
+
+func RunPurge(ctx context.Context, source purge.Source, retention time.Duration) (int64, error) {
+	purged, err := source.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return purged, err
+	}
+	log.Printf("[SUCCESS] Из корзины удалено задач старше %v: %d", retention, purged)
+	return purged, nil
+}
+
+func run(cfg *server.Config, cmd command) error {
+	switch cmd.name {
+	case "serve":
+		Serve(cfg)
+		return nil
+	case "migrate":
+		return RunMigrate(cfg, cmd)
+	}
+
+	storage, err := OpenStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	ctx := context.Background()
+	switch cmd.name {
+	case "seed":
+		return RunSeed(ctx, storage, cmd.seed)
+	case "create-admin":
+		_, err = CreateAdmin(ctx, storage, cmd.admin)
+	case "purge":
+		_, err = RunPurge(ctx, storage, cfg.PurgeRetention)
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/seed"
+	inmemory "project/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestParseCommand(t *testing.T) {
+	defaultSeed := seed.Options{Users: 10, Tasks: 20, Prefix: "demo", Password: "password123"}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    command
+		wantErr error
+	}{
+		{name: "serve by default", args: nil, want: command{name: "serve"}},
+		{name: "serve", args: []string{"serve"}, want: command{name: "serve"}},
+		{name: "version", args: []string{"version"}, want: command{name: "version"}},
+		{name: "purge", args: []string{"purge"}, want: command{name: "purge"}},
+		{name: "migrate up", args: []string{"migrate", "up"}, want: command{name: "migrate", migrate: "up"}},
+		{name: "migrate status", args: []string{"migrate", "status"}, want: command{name: "migrate", migrate: "status"}},
+		{name: "migrate down defaults to one step", args: []string{"migrate", "down"}, want: command{name: "migrate", migrate: "down", steps: 1}},
+		{name: "migrate down several steps", args: []string{"migrate", "down", "2"}, want: command{name: "migrate", migrate: "down", steps: 2}},
+		{name: "seed defaults", args: []string{"seed"}, want: command{name: "seed", seed: defaultSeed}},
+		{
+			name: "seed with flags",
+			args: []string{"seed", "-users", "3", "-tasks", "0", "-prefix", "qa"},
+			want: command{name: "seed", seed: seed.Options{Users: 3, Prefix: "qa", Password: "password123"}},
+		},
+		{
+			name: "create admin",
+			args: []string{"create-admin", "-username", "root", "-email", "root@example.com"},
+			want: command{name: "create-admin", admin: adminOptions{Username: "root", Email: "root@example.com"}},
+		},
+		{name: "unknown command", args: []string{"drop"}, wantErr: errors.ErrCommand},
+		{name: "serve with extra argument", args: []string{"serve", "now"}, wantErr: errors.ErrCommandArgument},
+		{name: "migrate without action", args: []string{"migrate"}, wantErr: errors.ErrCommandArgument},
+		{name: "migrate unknown action", args: []string{"migrate", "redo"}, wantErr: errors.ErrCommandArgument},
+		{name: "migrate down zero steps", args: []string{"migrate", "down", "0"}, wantErr: errors.ErrCommandArgument},
+		{name: "seed unknown flag", args: []string{"seed", "-force"}, wantErr: errors.ErrCommandArgument},
+		{name: "create admin without email", args: []string{"create-admin", "-username", "root"}, wantErr: errors.ErrCommandArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCommand(tt.args)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     adminOptions
+		env      string
+		wantErr  bool
+		username string
+	}{
+		{name: "password flag", opts: adminOptions{Username: "Root", Email: "Root@Example.com", Password: "secret123"}, username: "root"},
+		{name: "password from environment", opts: adminOptions{Username: "ops", Email: "ops@example.com"}, env: "secret123", username: "ops"},
+		{name: "missing password", opts: adminOptions{Username: "ops", Email: "ops@example.com"}, wantErr: true},
+		{name: "invalid email", opts: adminOptions{Username: "ops", Email: "ops", Password: "secret123"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_PASSWORD", tt.env)
+			storage := inmemory.NewStorage()
+
+			user, err := CreateAdmin(context.Background(), storage, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.RoleAdmin, user.Role)
+
+			stored, err := storage.GetUserByUsername(context.Background(), tt.username)
+			require.NoError(t, err)
+			assert.Equal(t, models.RoleAdmin, stored.Role)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte("secret123")))
+		})
+	}
+}
+
+func TestCreateAdminDuplicate(t *testing.T) {
+	storage := inmemory.NewStorage()
+	opts := adminOptions{Username: "root", Email: "root@example.com", Password: "secret123"}
+
+	_, err := CreateAdmin(context.Background(), storage, opts)
+	require.NoError(t, err)
+	_, err = CreateAdmin(context.Background(), storage, opts)
+	assert.Equal(t, errors.ErrUserAlreadyExists, err)
+}
+
+func TestRunPurge(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.NewStorage()
+	task := &models.Task{Title: "old", Status: models.StatusNew, UserID: "user123"}
+	require.NoError(t, storage.CreateTask(ctx, task))
+	require.NoError(t, storage.DeleteTask(ctx, task.ID))
+
+	purged, err := RunPurge(ctx, storage, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	purged, err = RunPurge(ctx, storage, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
@@ -2,24 +2,216 @@ package main
 
 import (
 	"context"
+	stderrors "errors"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"project/internal/cache"
+	"project/internal/logging"
+	"project/internal/realtime"
+	"project/internal/reminder"
 	"project/internal/server"
+	"project/internal/tracing"
+	"project/internal/webhook"
+	"project/repository"
 	db "project/repository/db"
 	inmemory "project/repository/inmemory"
+	"strings"
 	"syscall"
 	"time"
 )
 
-func InitializeRepositories(cfg *server.Config) (server.Repository, server.TaskRepository, error) {
-	dbStorage, err := db.NewStorage(cfg.DBStr)
+func InitializeRepositories(cfg *server.Config) (repository.Storage, error) {
+	dbStorage, err := OpenStorage(cfg)
 	if err != nil {
 		log.Println("[WARN] Не удалось подключиться к БД, используем память:", err)
-		inmem := inmemory.NewStorage()
-		return inmem, inmem, nil
+		return inmemory.NewStorage(), nil
+	}
+	return dbStorage, nil
+}
+
+func InitializeTracing(cfg *server.Config) func(ctx context.Context) error {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:    cfg.TracingEndpoint,
+		Insecure:    cfg.TracingInsecure,
+		SampleRatio: cfg.TracingSampleRatio,
+		ServiceName: cfg.TracingService,
+	})
+	if err != nil {
+		log.Println("[WARN] Не удалось настроить трассировку, спаны не экспортируются:", err)
+		return func(ctx context.Context) error { return nil }
+	}
+	return shutdown
+}
+
+func InitializeCache(cfg *server.Config, storage repository.Storage) (repository.Storage, func()) {
+	if cfg.RedisAddr == "" {
+		return storage, func() {}
+	}
+
+	redisCache := cache.NewRedisCache(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := redisCache.Ping(ctx); err != nil {
+		log.Println("[WARN] Не удалось подключиться к Redis, кэширование отключено:", err)
+		redisCache.Close()
+		return storage, func() {}
+	}
+
+	log.Printf("[SUCCESS] Кэширование в Redis включено (%s, TTL %v)", cfg.RedisAddr, cfg.CacheTTL)
+	closeCache := func() {
+		if err := redisCache.Close(); err != nil {
+			log.Println("[ERROR] Ошибка закрытия соединения с Redis:", err)
+		}
+	}
+	return cache.NewStorage(storage, redisCache, cfg.CacheTTL), closeCache
+}
+
+func InitializeUserCache(cfg *server.Config, storage repository.Storage) repository.Storage {
+	if cfg.UserCacheSize <= 0 {
+		log.Println("[INFO] Кэш пользователей в памяти отключен")
+		return storage
+	}
+	log.Printf("[SUCCESS] Кэш пользователей в памяти включен (%d записей, TTL %v)", cfg.UserCacheSize, cfg.UserCacheTTL)
+	log.Printf("[INFO] Блокировка и смена роли через другие реплики применяются с задержкой до %v", cfg.UserCacheTTL)
+	return cache.NewUserStorage(storage, cfg.UserCacheSize, cfg.UserCacheTTL)
+}
+
+func InitializeResponseCache(cfg *server.Config, api *server.TaskAPI) func() {
+	switch cfg.ResponseCache {
+	case server.ResponseCacheMemory:
+		api.SetResponseCache(cache.NewLRUCache(cfg.ResponseCacheSize), cfg.ResponseCacheTTL)
+		log.Printf("[SUCCESS] Кэширование ответов в памяти включено (%d записей, TTL %v)", cfg.ResponseCacheSize, cfg.ResponseCacheTTL)
+		return func() {}
+	case server.ResponseCacheRedis:
+		if cfg.RedisAddr == "" {
+			log.Println("[WARN] Адрес Redis не задан, кэширование ответов отключено")
+			return func() {}
+		}
+	default:
+		return func() {}
+	}
+
+	redisCache := cache.NewRedisCache(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := redisCache.Ping(ctx); err != nil {
+		log.Println("[WARN] Не удалось подключиться к Redis, кэширование ответов отключено:", err)
+		redisCache.Close()
+		return func() {}
+	}
+
+	api.SetResponseCache(redisCache, cfg.ResponseCacheTTL)
+	log.Printf("[SUCCESS] Кэширование ответов в Redis включено (%s, TTL %v)", cfg.RedisAddr, cfg.ResponseCacheTTL)
+	return func() {
+		if err := redisCache.Close(); err != nil {
+			log.Println("[ERROR] Ошибка закрытия соединения с Redis:", err)
+		}
+	}
+}
+
+func InitializeReminders(cfg *server.Config, storage repository.Storage) *reminder.Scheduler {
+	source, ok := storage.(reminder.Source)
+	if !ok {
+		log.Println("[WARN] Хранилище задач не поддерживает напоминания")
+		return nil
+	}
+
+	var notifiers []reminder.Notifier
+	for _, channel := range strings.Split(cfg.ReminderChannels, ",") {
+		switch strings.ToLower(strings.TrimSpace(channel)) {
+		case "":
+		case reminder.ChannelLog:
+			notifiers = append(notifiers, reminder.LogNotifier{})
+		case reminder.ChannelEmail:
+			if cfg.SMTPAddr == "" || cfg.SMTPFrom == "" {
+				log.Println("[WARN] Канал email для напоминаний требует SMTP_ADDR и SMTP_FROM")
+				continue
+			}
+			notifiers = append(notifiers, reminder.NewEmailNotifier(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword))
+		case reminder.ChannelWebhook:
+			if cfg.ReminderWebhookURL == "" {
+				log.Println("[WARN] Канал webhook для напоминаний требует REMINDER_WEBHOOK_URL")
+				continue
+			}
+			notifiers = append(notifiers, reminder.NewWebhookNotifier(cfg.ReminderWebhookURL))
+		default:
+			log.Println("[WARN] Неизвестный канал напоминаний:", channel)
+		}
+	}
+	if len(notifiers) == 0 {
+		log.Println("[INFO] Напоминания отключены: не настроено ни одного канала")
+		return nil
+	}
+
+	return reminder.NewScheduler(source, storage, notifiers, cfg.ReminderInterval, cfg.ReminderDefaultOffset)
+}
+
+type PurgeWorkerOwner interface {
+	StartPurgeWorker(interval, retention time.Duration)
+	StopPurgeWorker(ctx context.Context) error
+}
+
+func StartPurgeWorker(cfg *server.Config, storage repository.Storage) func(ctx context.Context) error {
+	owner, ok := storage.(PurgeWorkerOwner)
+	if !ok {
+		log.Println("[WARN] Хранилище задач не поддерживает автоочистку корзины")
+		return func(ctx context.Context) error { return nil }
+	}
+	owner.StartPurgeWorker(cfg.PurgeInterval, cfg.PurgeRetention)
+	return owner.StopPurgeWorker
+}
+
+func StartWebhooks(api *server.TaskAPI, storage repository.Storage) func(ctx context.Context) error {
+	store, ok := storage.(webhook.Store)
+	if !ok {
+		log.Println("[WARN] Хранилище задач не поддерживает вебхуки")
+		return func(ctx context.Context) error { return nil }
+	}
+	dispatcher := webhook.NewDispatcher(store, 0, 0)
+	dispatcher.Start()
+	api.SetWebhookDispatcher(dispatcher)
+	return dispatcher.Close
+}
+
+func StartRealtime(api *server.TaskAPI, storage repository.Storage) func() {
+	source, ok := storage.(realtime.Source)
+	if !ok {
+		log.Println("[WARN] Хранилище задач не поддерживает события в реальном времени")
+		return func() {}
+	}
+	hub := realtime.NewHub()
+	api.SetEventHub(hub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := source.ListenTaskEvents(ctx, hub.Publish); err != nil {
+			log.Println("[ERROR] Подписка на события задач завершилась с ошибкой:", err)
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+		hub.Close()
+	}
+}
+
+func ConfigureHealth(api *server.TaskAPI, storage repository.Storage) {
+	if checker, ok := storage.(server.HealthChecker); ok {
+		api.SetHealthChecker(checker)
+	} else {
+		log.Println("[WARN] Хранилище задач работает в памяти, /health сообщит о деградации")
+	}
+	if source, ok := storage.(server.PurgeStatsSource); ok {
+		api.SetPurgeStatsSource(source)
+	}
+	if source, ok := storage.(server.MetricsSource); ok {
+		api.RegisterMetrics(source)
 	}
-	return dbStorage, dbStorage, nil
 }
 
 func RunMigrations(cfg *server.Config) error {
@@ -31,6 +223,52 @@ func RunMigrations(cfg *server.Config) error {
 	return nil
 }
 
+type StorageCloser interface {
+	Close()
+}
+
+type shutdownStep struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+type ShutdownChain struct {
+	steps []shutdownStep
+}
+
+func (c *ShutdownChain) Add(name string, close func(ctx context.Context) error) {
+	c.steps = append(c.steps, shutdownStep{name: name, close: close})
+}
+
+func (c *ShutdownChain) AddFunc(name string, stop func()) {
+	c.Add(name, func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			stop()
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+func (c *ShutdownChain) Close(ctx context.Context) error {
+	var errs []error
+	for i := len(c.steps) - 1; i >= 0; i-- {
+		step := c.steps[i]
+		if err := step.close(ctx); err != nil {
+			log.Printf("[ERROR] Ошибка остановки (%s): %v", step.name, err)
+			errs = append(errs, err)
+		}
+	}
+	c.steps = nil
+	return stderrors.Join(errs...)
+}
+
 type TaskAPIInterface interface {
 	Start() error
 	Shutdown(ctx context.Context) error
@@ -42,7 +280,11 @@ func StartServer(api TaskAPIInterface, cfg *server.Config) (chan os.Signal, chan
 
 	serverErr := make(chan error, 1)
 	go func() {
-		log.Printf("Сервис запущен на %s:%d", cfg.Addr, cfg.Port)
+		if strings.HasPrefix(cfg.Addr, "unix:") {
+			log.Printf("Сервис запущен на %s", cfg.Addr)
+		} else {
+			log.Printf("Сервис запущен на %s:%d", cfg.Addr, cfg.Port)
+		}
 		if err := api.Start(); err != nil {
 			serverErr <- err
 		}
@@ -51,13 +293,17 @@ func StartServer(api TaskAPIInterface, cfg *server.Config) (chan os.Signal, chan
 	return sigChan, serverErr
 }
 
-func HandleShutdown(api TaskAPIInterface, sig os.Signal) error {
+func HandleShutdown(api TaskAPIInterface, sig os.Signal, chain *ShutdownChain) error {
 	log.Printf("[INFO] Получен сигнал %v, начинаем graceful shutdown...", sig)
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
-	if err := api.Shutdown(shutdownCtx); err != nil {
+	err := api.Shutdown(shutdownCtx)
+	if chain != nil {
+		err = stderrors.Join(err, chain.Close(shutdownCtx))
+	}
+	if err != nil {
 		log.Printf("[ERROR] Ошибка при graceful shutdown: %v", err)
 		return err
 	}
@@ -65,40 +311,96 @@ func HandleShutdown(api TaskAPIInterface, sig os.Signal) error {
 	return nil
 }
 
-func main() {
+func Serve(cfg *server.Config) {
 	log.Println("Запуск сервиса задач...")
 
-	cfg := server.ReadConfig()
-
 	if err := RunMigrations(cfg); err != nil {
 		log.Fatalf("[ERROR] Ошибка применения миграций: %v", err)
 	}
 
-	userRepo, taskRepo, err := InitializeRepositories(cfg)
+	storage, err := InitializeRepositories(cfg)
 	if err != nil {
 		log.Fatal("[ERROR] Не удалось инициализировать репозитории:", err)
 	}
 
-	api := server.NewTaskAPI(userRepo, taskRepo, cfg)
+	chain := &ShutdownChain{}
+	if closer, ok := storage.(StorageCloser); ok {
+		chain.AddFunc("подключения к БД", closer.Close)
+	}
+	chain.Add("трассировка", InitializeTracing(cfg))
+
+	cachedStorage, closeCache := InitializeCache(cfg, storage)
+	chain.AddFunc("кэш", closeCache)
+
+	api := server.NewTaskAPI(InitializeUserCache(cfg, cachedStorage), cfg)
 	if api == nil {
 		log.Fatal("[ERROR] Не удалось инициализировать API")
 	}
+	chain.AddFunc("кэш ответов", InitializeResponseCache(cfg, api))
+
+	ConfigureHealth(api, storage)
 
 	_, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	scheduler := InitializeReminders(cfg, storage)
+	if scheduler != nil {
+		scheduler.Start()
+		chain.AddFunc("планировщик напоминаний", scheduler.Stop)
+	}
+
+	if api.FeatureEnabled(server.FeaturePurgeWorker) {
+		chain.Add("очистка корзины", StartPurgeWorker(cfg, storage))
+	} else {
+		log.Println("[INFO] Автоочистка корзины отключена флагом функции")
+	}
+	chain.Add("доставка вебхуков", StartWebhooks(api, storage))
+	chain.AddFunc("события в реальном времени", StartRealtime(api, storage))
+
 	sigChan, serverErr := StartServer(api, cfg)
 
 	select {
 	case sig := <-sigChan:
-		if err := HandleShutdown(api, sig); err != nil {
+		if err := HandleShutdown(api, sig, chain); err != nil {
 			log.Printf("[ERROR] Ошибка при shutdown: %v", err)
 		}
 
 	case err := <-serverErr:
 		log.Printf("[ERROR] Ошибка сервера: %v", err)
 		cancel()
+
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := chain.Close(closeCtx); err != nil {
+			log.Printf("[ERROR] Ошибка при shutdown: %v", err)
+		}
+		closeCancel()
 	}
 
 	log.Println("Сервис завершен")
 }
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cmd, err := parseCommand(flag.Args())
+	if err != nil {
+		log.Fatalf("[ERROR] %v\n%s", err, usage)
+	}
+	if cmd.name == "version" {
+		fmt.Println(versionString())
+		return
+	}
+
+	cfg := server.ReadConfig()
+	logging.Setup(cfg.LogLevel, cfg.LogFormat)
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("[ERROR] Некорректная конфигурация:\n%v", err)
+	}
+	if err := run(cfg, cmd); err != nil {
+		log.Fatalf("[ERROR] Ошибка выполнения команды %s: %v", cmd.name, err)
+	}
+}
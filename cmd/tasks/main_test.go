@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"project/internal/cache"
 	"project/internal/server"
 	inmemory "project/repository/inmemory"
 
@@ -279,10 +280,9 @@ func TestInitializeRepositories(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userRepo, taskRepo, err := InitializeRepositories(tt.cfg)
+			storage, err := InitializeRepositories(tt.cfg)
 			assert.NoError(t, err, "Should not return error")
-			assert.NotNil(t, userRepo, "User repository should be created")
-			assert.NotNil(t, taskRepo, "Task repository should be created")
+			assert.NotNil(t, storage, "Storage should be created")
 			assert.True(t, tt.want.canInitialize, "Repositories should be initializable")
 		})
 	}
@@ -322,10 +322,9 @@ func TestInitializeRepositoriesErrorScenarios(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userRepo, taskRepo, err := InitializeRepositories(tt.cfg)
+			storage, err := InitializeRepositories(tt.cfg)
 			assert.NoError(t, err, "Should not return error due to fallback")
-			assert.NotNil(t, userRepo, "User repository should be created")
-			assert.NotNil(t, taskRepo, "Task repository should be created")
+			assert.NotNil(t, storage, "Storage should be created")
 		})
 	}
 }
@@ -483,7 +482,7 @@ func TestHandleShutdown(t *testing.T) {
 			mockAPI := &MockTaskAPI{}
 			mockAPI.On("Shutdown", mock.Anything).Return(nil)
 
-			err := HandleShutdown(mockAPI, tt.sig)
+			err := HandleShutdown(mockAPI, tt.sig, nil)
 			assert.NoError(t, err, "Shutdown should not return error")
 			assert.True(t, tt.want.canShutdown, "Shutdown should be handleable")
 		})
@@ -514,7 +513,7 @@ func TestHandleShutdownWithError(t *testing.T) {
 			mockAPI := &MockTaskAPI{}
 			mockAPI.On("Shutdown", mock.Anything).Return(assert.AnError)
 
-			err := HandleShutdown(mockAPI, tt.sig)
+			err := HandleShutdown(mockAPI, tt.sig, nil)
 			assert.Error(t, err, "Shutdown should return error")
 			assert.True(t, tt.want.shouldError, "Shutdown should return error")
 		})
@@ -626,7 +625,7 @@ func TestAPIIntialization(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			inmem := inmemory.NewStorage()
-			api := server.NewTaskAPI(inmem, inmem, &server.Config{})
+			api := server.NewTaskAPI(inmem, &server.Config{})
 			assert.NotNil(t, api, "API should be created")
 			assert.True(t, tt.want.apiAvailable, "API should be available")
 		})
@@ -770,3 +769,117 @@ func TestMainAdditionalScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeReminders(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *server.Config
+		wantNil bool
+	}{
+		{
+			name: "log channel enables scheduler",
+			cfg:  &server.Config{ReminderChannels: "log", ReminderInterval: time.Minute},
+		},
+		{
+			name:    "no channels disables scheduler",
+			cfg:     &server.Config{ReminderChannels: ""},
+			wantNil: true,
+		},
+		{
+			name:    "email without smtp settings is skipped",
+			cfg:     &server.Config{ReminderChannels: "email"},
+			wantNil: true,
+		},
+		{
+			name: "webhook with url enables scheduler",
+			cfg:  &server.Config{ReminderChannels: "webhook, unknown", ReminderWebhookURL: "http://localhost/hook"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := inmemory.NewStorage()
+			scheduler := InitializeReminders(tt.cfg, storage)
+			if tt.wantNil {
+				assert.Nil(t, scheduler)
+			} else {
+				assert.NotNil(t, scheduler)
+			}
+		})
+	}
+}
+
+func TestInitializeUserCache(t *testing.T) {
+	storage := inmemory.NewStorage()
+	assert.Same(t, storage, InitializeUserCache(&server.Config{}, storage))
+
+	cached := InitializeUserCache(&server.Config{UserCacheSize: 10, UserCacheTTL: time.Minute}, storage)
+	assert.IsType(t, &cache.UserStorage{}, cached)
+}
+
+func TestStartPurgeWorker(t *testing.T) {
+	storage := inmemory.NewStorage()
+	stop := StartPurgeWorker(&server.Config{PurgeInterval: time.Hour, PurgeRetention: time.Hour}, storage)
+	assert.NotNil(t, stop)
+	assert.NoError(t, stop(context.Background()))
+	assert.Equal(t, int64(1), storage.PurgeStats().Runs)
+}
+
+func TestStartWebhooks(t *testing.T) {
+	storage := inmemory.NewStorage()
+	api := server.NewTaskAPI(storage, &server.Config{})
+	stop := StartWebhooks(api, storage)
+	assert.NotNil(t, stop)
+	assert.NoError(t, stop(context.Background()))
+}
+
+func TestStartRealtimeUnsupportedStorage(t *testing.T) {
+	storage := inmemory.NewStorage()
+	api := server.NewTaskAPI(storage, &server.Config{})
+	stop := StartRealtime(api, storage)
+	assert.NotNil(t, stop)
+	assert.NotPanics(t, stop)
+}
+
+func TestShutdownChainClosesInReverseOrder(t *testing.T) {
+	var order []string
+	chain := &ShutdownChain{}
+	chain.AddFunc("db", func() { order = append(order, "db") })
+	chain.Add("purge", func(ctx context.Context) error {
+		order = append(order, "purge")
+		return assert.AnError
+	})
+	chain.AddFunc("webhooks", func() { order = append(order, "webhooks") })
+
+	err := chain.Close(context.Background())
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, []string{"webhooks", "purge", "db"}, order)
+	assert.NoError(t, chain.Close(context.Background()))
+}
+
+func TestShutdownChainRespectsDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	chain := &ShutdownChain{}
+	chain.AddFunc("stuck", func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, chain.Close(ctx), context.DeadlineExceeded)
+}
+
+func TestHandleShutdownRunsChain(t *testing.T) {
+	mockAPI := &MockTaskAPI{}
+	mockAPI.On("Shutdown", mock.Anything).Return(nil)
+	closed := false
+	chain := &ShutdownChain{}
+	chain.AddFunc("worker", func() { closed = true })
+
+	err := HandleShutdown(mockAPI, syscall.SIGTERM, chain)
+
+	assert.NoError(t, err)
+	assert.True(t, closed)
+	mockAPI.AssertExpectations(t)
+}
@@ -0,0 +1,113 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+)
+
+const (
+	Format  = "tasks-backup"
+	Version = 1
+
+	maxLineSize = 4 << 20
+)
+
+type Store interface {
+	BackupUsers(ctx context.Context, fn func(models.User) error) error
+	BackupTasks(ctx context.Context, fn func(models.Task) error) error
+	RestoreBackup(ctx context.Context, users []models.User, tasks []models.Task) error
+}
+
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type record struct {
+	User *models.User `json:"user,omitempty"`
+	Task *models.Task `json:"task,omitempty"`
+}
+
+type Stats struct {
+	Users int
+	Tasks int
+}
+
+func Write(ctx context.Context, w io.Writer, store Store) (Stats, error) {
+	var stats Stats
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(header{Format: Format, Version: Version, CreatedAt: time.Now().UTC()}); err != nil {
+		return stats, err
+	}
+	err := store.BackupUsers(ctx, func(user models.User) error {
+		stats.Users++
+		return enc.Encode(record{User: &user})
+	})
+	if err != nil {
+		return stats, err
+	}
+	err = store.BackupTasks(ctx, func(task models.Task) error {
+		task.Tags = nil
+		task.Checklist = nil
+		stats.Tasks++
+		return enc.Encode(record{Task: &task})
+	})
+	return stats, err
+}
+
+func Read(r io.Reader) ([]models.User, []models.Task, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.ErrBackupFormat
+	}
+	var h header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil || h.Format != Format || h.Version != Version {
+		return nil, nil, errors.ErrBackupFormat
+	}
+
+	users := []models.User{}
+	tasks := []models.Task{}
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, nil, errors.ErrBackupFormat
+		}
+		switch {
+		case rec.User != nil && rec.Task == nil:
+			users = append(users, *rec.User)
+		case rec.Task != nil && rec.User == nil:
+			tasks = append(tasks, *rec.Task)
+		default:
+			return nil, nil, errors.ErrBackupFormat
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return users, tasks, nil
+}
+
+func Restore(ctx context.Context, r io.Reader, store Store) (Stats, error) {
+	users, tasks, err := Read(r)
+	if err != nil {
+		return Stats{}, err
+	}
+	if err := store.RestoreBackup(ctx, users, tasks); err != nil {
+		return Stats{}, err
+	}
+	return Stats{Users: len(users), Tasks: len(tasks)}, nil
+}
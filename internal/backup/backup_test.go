@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	inmemory "project/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seededStorage(t *testing.T) *inmemory.Storage {
+	ctx := context.Background()
+	storage := inmemory.NewStorage()
+	user := &models.User{Username: "alice", Email: "alice@example.com", Password: "hash", Role: "user"}
+	require.NoError(t, storage.CreateUser(ctx, user))
+
+	parent := &models.Task{Title: "Parent", Status: models.StatusNew, UserID: user.ID}
+	require.NoError(t, storage.CreateTask(ctx, parent))
+	child := &models.Task{Title: "Child", Status: models.StatusDone, UserID: user.ID, ParentID: parent.ID}
+	require.NoError(t, storage.CreateTask(ctx, child))
+	trashed := &models.Task{Title: "Trashed", Status: models.StatusNew, UserID: user.ID}
+	require.NoError(t, storage.CreateTask(ctx, trashed))
+	require.NoError(t, storage.DeleteTask(ctx, trashed.ID))
+	return storage
+}
+
+func collect(t *testing.T, store Store) ([]models.User, []models.Task) {
+	var users []models.User
+	var tasks []models.Task
+	require.NoError(t, store.BackupUsers(context.Background(), func(user models.User) error {
+		users = append(users, user)
+		return nil
+	}))
+	require.NoError(t, store.BackupTasks(context.Background(), func(task models.Task) error {
+		if task.DeletedAt != nil {
+			deletedAt := task.DeletedAt.UTC()
+			task.DeletedAt = &deletedAt
+		}
+		tasks = append(tasks, task)
+		return nil
+	}))
+	return users, tasks
+}
+
+func TestWriteAndRestore(t *testing.T) {
+	source := seededStorage(t)
+
+	var archive bytes.Buffer
+	stats, err := Write(context.Background(), &archive, source)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Users: 1, Tasks: 3}, stats)
+	assert.Equal(t, 5, strings.Count(archive.String(), "\n"))
+
+	target := inmemory.NewStorage()
+	stats, err = Restore(context.Background(), bytes.NewReader(archive.Bytes()), target)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Users: 1, Tasks: 3}, stats)
+
+	wantUsers, wantTasks := collect(t, source)
+	gotUsers, gotTasks := collect(t, target)
+	assert.Equal(t, wantUsers, gotUsers)
+	assert.Equal(t, wantTasks, gotTasks)
+
+	_, err = Restore(context.Background(), bytes.NewReader(archive.Bytes()), target)
+	assert.Equal(t, errors.ErrUserAlreadyExists, err)
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		users   int
+		tasks   int
+		wantErr error
+	}{
+		{
+			name:  "header only",
+			input: `{"format":"tasks-backup","version":1}` + "\n",
+		},
+		{
+			name:  "users and tasks",
+			input: `{"format":"tasks-backup","version":1}` + "\n" + `{"user":{"id":"u1"}}` + "\n\n" + `{"task":{"id":"t1","user_id":"u1"}}` + "\n",
+			users: 1,
+			tasks: 1,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: errors.ErrBackupFormat,
+		},
+		{
+			name:    "unknown version",
+			input:   `{"format":"tasks-backup","version":2}` + "\n",
+			wantErr: errors.ErrBackupFormat,
+		},
+		{
+			name:    "record without payload",
+			input:   `{"format":"tasks-backup","version":1}` + "\n" + `{}` + "\n",
+			wantErr: errors.ErrBackupFormat,
+		},
+		{
+			name:    "malformed record",
+			input:   `{"format":"tasks-backup","version":1}` + "\n" + `{"user":` + "\n",
+			wantErr: errors.ErrBackupFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, tasks, err := Read(strings.NewReader(tt.input))
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Len(t, users, tt.users)
+				assert.Len(t, tasks, tt.tasks)
+			}
+		})
+	}
+}
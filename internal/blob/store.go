@@ -0,0 +1,129 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"project/internal/domain/errors"
+)
+
+type Object struct {
+	Key         string
+	ContentType string
+	Data        []byte
+}
+
+type Store interface {
+	Put(ctx context.Context, obj Object) error
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type MemoryStore struct {
+	mu      sync.RWMutex
+	objects map[string]Object
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{objects: make(map[string]Object)}
+}
+
+func (s *MemoryStore) Put(ctx context.Context, obj Object) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj.Data = append([]byte(nil), obj.Data...)
+	s.objects[obj.Key] = obj
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Object, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, exists := s.objects[key]
+	if !exists {
+		return nil, errors.ErrBlobNotFound
+	}
+	obj.Data = append([]byte(nil), obj.Data...)
+	return &obj, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.objects[key]; !exists {
+		return errors.ErrBlobNotFound
+	}
+	delete(s.objects, key)
+	return nil
+}
+
+const contentTypeSuffix = ".type"
+
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || strings.HasSuffix(clean, contentTypeSuffix) {
+		return "", errors.ErrBlobNotFound
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+func (s *FileStore) Put(ctx context.Context, obj Object) error {
+	path, err := s.path(obj.Key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, obj.Data, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(path+contentTypeSuffix, []byte(obj.ContentType), 0o644)
+}
+
+func (s *FileStore) Get(ctx context.Context, key string) (*Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := os.ReadFile(path + contentTypeSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &Object{Key: key, ContentType: string(contentType), Data: data}, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return errors.ErrBlobNotFound
+		}
+		return err
+	}
+	if err := os.Remove(path + contentTypeSuffix); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
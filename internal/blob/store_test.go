@@ -0,0 +1,59 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"project/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store { return NewMemoryStore() },
+		"file":   func(t *testing.T) Store { return NewFileStore(t.TempDir()) },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+
+			_, err := store.Get(ctx, "avatars/user1/small")
+			assert.Equal(t, errors.ErrBlobNotFound, err)
+
+			obj := Object{Key: "avatars/user1/small", ContentType: "image/png", Data: []byte("png")}
+			require.NoError(t, store.Put(ctx, obj))
+			fetched, err := store.Get(ctx, obj.Key)
+			require.NoError(t, err)
+			assert.Equal(t, obj, *fetched)
+
+			require.NoError(t, store.Put(ctx, Object{Key: obj.Key, ContentType: "image/jpeg", Data: []byte("jpeg")}))
+			fetched, err = store.Get(ctx, obj.Key)
+			require.NoError(t, err)
+			assert.Equal(t, "image/jpeg", fetched.ContentType)
+			assert.Equal(t, []byte("jpeg"), fetched.Data)
+
+			require.NoError(t, store.Delete(ctx, obj.Key))
+			assert.Equal(t, errors.ErrBlobNotFound, store.Delete(ctx, obj.Key))
+		})
+	}
+}
+
+func TestFileStoreStaysInsideDir(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewFileStore(filepath.Join(dir, "blobs"))
+
+	require.NoError(t, store.Put(ctx, Object{Key: "../../escape", ContentType: "text/plain", Data: []byte("x")}))
+	_, err := os.Stat(filepath.Join(dir, "blobs", "escape"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Equal(t, errors.ErrBlobNotFound, store.Put(ctx, Object{Key: "avatar.type"}))
+}
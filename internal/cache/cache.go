@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(addr, password string, db int) *RedisCache {
+	return &RedisCache{client: redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})}
+}
+
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, errors.ErrCacheMiss
+	}
+	return value, err
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+	element   *list.Element
+}
+
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	order   *list.List
+	size    int
+	now     func() time.Time
+}
+
+func NewMemoryCache() *MemoryCache {
+	return NewLRUCache(0)
+}
+
+func NewLRUCache(size int) *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryEntry), order: list.New(), size: size, now: time.Now}
+}
+
+func (c *MemoryCache) lookup(key string) (memoryEntry, bool) {
+	entry, exists := c.entries[key]
+	if exists && !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(key)
+		return memoryEntry{}, false
+	}
+	if exists {
+		c.order.MoveToFront(entry.element)
+	}
+	return entry, exists
+}
+
+func (c *MemoryCache) store(key string, entry memoryEntry) {
+	if current, exists := c.entries[key]; exists {
+		entry.element = current.element
+		c.order.MoveToFront(entry.element)
+	} else {
+		entry.element = c.order.PushFront(key)
+	}
+	c.entries[key] = entry
+	for c.size > 0 && c.order.Len() > c.size {
+		c.remove(c.order.Back().Value.(string))
+	}
+}
+
+func (c *MemoryCache) remove(key string) {
+	if entry, exists := c.entries[key]; exists {
+		c.order.Remove(entry.element)
+		delete(c.entries, key)
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.lookup(key)
+	if !exists {
+		return nil, errors.ErrCacheMiss
+	}
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := memoryEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+	c.store(key, entry)
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		c.remove(key)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, _ := c.lookup(key)
+	var current int64
+	if len(entry.value) > 0 {
+		parsed, err := strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		current = parsed
+	}
+	current++
+	entry.value = []byte(strconv.FormatInt(current, 10))
+	c.store(key, entry)
+	return current, nil
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	c := NewMemoryCache()
+	c.now = func() time.Time { return now }
+
+	_, err := c.Get(ctx, "user:1")
+	assert.Equal(t, errors.ErrCacheMiss, err)
+
+	require.NoError(t, c.Set(ctx, "user:1", []byte("alice"), time.Minute))
+	value, err := c.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("alice"), value)
+
+	now = now.Add(time.Minute)
+	_, err = c.Get(ctx, "user:1")
+	assert.Equal(t, errors.ErrCacheMiss, err)
+
+	require.NoError(t, c.Set(ctx, "user:2", []byte("bob"), 0))
+	require.NoError(t, c.Delete(ctx, "user:2", "user:3"))
+	_, err = c.Get(ctx, "user:2")
+	assert.Equal(t, errors.ErrCacheMiss, err)
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := c.Incr(ctx, "taskver:1")
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	value, err = c.Get(ctx, "taskver:1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+}
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRUCache(2)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+	_, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+
+	_, err = c.Get(ctx, "b")
+	assert.Equal(t, errors.ErrCacheMiss, err)
+	value, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	require.NoError(t, c.Set(ctx, "c", []byte("4"), 0))
+	_, err = c.Incr(ctx, "d")
+	require.NoError(t, err)
+	_, err = c.Get(ctx, "a")
+	assert.Equal(t, errors.ErrCacheMiss, err)
+	value, err = c.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("4"), value)
+
+	require.NoError(t, c.Delete(ctx, "c", "d"))
+	assert.Zero(t, c.order.Len())
+}
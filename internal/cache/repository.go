@@ -0,0 +1,380 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"project/repository"
+)
+
+const (
+	userKeyPrefix    = "user:"
+	taskKeyPrefix    = "task:"
+	tasksKeyPrefix   = "tasks:"
+	versionKeyPrefix = "taskver:"
+)
+
+func load(ctx context.Context, c Cache, key string, dst interface{}) bool {
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		if err != errors.ErrCacheMiss {
+			requestid.Println(ctx, "[WARN] Ошибка чтения из кэша:", err)
+		}
+		return false
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		requestid.Println(ctx, "[WARN] Некорректная запись в кэше:", err)
+		return false
+	}
+	return true
+}
+
+func store(ctx context.Context, c Cache, key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		requestid.Println(ctx, "[WARN] Не удалось сериализовать значение для кэша:", err)
+		return
+	}
+	if err := c.Set(ctx, key, data, ttl); err != nil {
+		requestid.Println(ctx, "[WARN] Ошибка записи в кэш:", err)
+	}
+}
+
+type Storage struct {
+	repository.Storage
+	cache Cache
+	ttl   time.Duration
+}
+
+func NewStorage(storage repository.Storage, c Cache, ttl time.Duration) *Storage {
+	return &Storage{Storage: storage, cache: c, ttl: ttl}
+}
+
+func (r *Storage) WithTx(ctx context.Context, fn func(tx repository.Storage) error) error {
+	return r.Storage.WithTx(ctx, func(tx repository.Storage) error {
+		return fn(&Storage{Storage: tx, cache: r.cache, ttl: r.ttl})
+	})
+}
+
+func (r *Storage) invalidate(ctx context.Context, id string) {
+	if err := r.cache.Delete(ctx, userKeyPrefix+id); err != nil {
+		requestid.Println(ctx, "[WARN] Ошибка инвалидации кэша пользователя:", err)
+	}
+}
+
+func (r *Storage) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	key := userKeyPrefix + id
+	var cached models.User
+	if load(ctx, r.cache, key, &cached) {
+		return &cached, nil
+	}
+	user, err := r.Storage.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	store(ctx, r.cache, key, user, r.ttl)
+	return user, nil
+}
+
+func (r *Storage) UpdateUser(ctx context.Context, id string, user *models.User) error {
+	if err := r.Storage.UpdateUser(ctx, id, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *Storage) DeleteUser(ctx context.Context, id string) error {
+	if err := r.Storage.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *Storage) SetUserActive(ctx context.Context, id string, active bool) error {
+	if err := r.Storage.SetUserActive(ctx, id, active); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *Storage) RecordLogin(ctx context.Context, record *models.LoginRecord) error {
+	if err := r.Storage.RecordLogin(ctx, record); err != nil {
+		return err
+	}
+	if record.Success {
+		r.invalidate(ctx, record.UserID)
+	}
+	return nil
+}
+
+type cachedTask struct {
+	Version int64        `json:"version"`
+	Task    *models.Task `json:"task"`
+}
+
+func (r *Storage) version(ctx context.Context, userID string) (int64, bool) {
+	data, err := r.cache.Get(ctx, versionKeyPrefix+userID)
+	if err == errors.ErrCacheMiss {
+		return 0, true
+	}
+	if err != nil {
+		requestid.Println(ctx, "[WARN] Ошибка чтения версии кэша задач:", err)
+		return 0, false
+	}
+	version, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		requestid.Println(ctx, "[WARN] Некорректная версия кэша задач:", err)
+		return 0, false
+	}
+	return version, true
+}
+
+func (r *Storage) bump(ctx context.Context, userIDs ...string) {
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		if _, err := r.cache.Incr(ctx, versionKeyPrefix+userID); err != nil {
+			requestid.Println(ctx, "[WARN] Ошибка инвалидации кэша задач:", err)
+		}
+	}
+}
+
+func (r *Storage) bumpTask(ctx context.Context, task *models.Task, extra ...string) {
+	if task == nil {
+		r.bump(ctx, extra...)
+		return
+	}
+	r.bump(ctx, append([]string{task.UserID, task.AssigneeID}, extra...)...)
+}
+
+func (r *Storage) lookup(ctx context.Context, id string) *models.Task {
+	task, err := r.Storage.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return task
+}
+
+func listKey(userID string, version int64, filter models.TaskFilter) string {
+	data, _ := json.Marshal(filter)
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s%s:%d:%s", tasksKeyPrefix, userID, version, hex.EncodeToString(sum[:16]))
+}
+
+func (r *Storage) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
+	key := taskKeyPrefix + id
+	var cached cachedTask
+	if load(ctx, r.cache, key, &cached) && cached.Task != nil {
+		if version, ok := r.version(ctx, cached.Task.UserID); ok && version == cached.Version {
+			return cached.Task, nil
+		}
+	}
+	task, err := r.Storage.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version, ok := r.version(ctx, task.UserID); ok {
+		store(ctx, r.cache, key, cachedTask{Version: version, Task: task}, r.ttl)
+	}
+	return task, nil
+}
+
+func (r *Storage) GetTasks(ctx context.Context, userID string, filter models.TaskFilter) ([]models.Task, error) {
+	version, ok := r.version(ctx, userID)
+	if !ok {
+		return r.Storage.GetTasks(ctx, userID, filter)
+	}
+	key := listKey(userID, version, filter)
+	var cached []models.Task
+	if load(ctx, r.cache, key, &cached) {
+		return cached, nil
+	}
+	tasks, err := r.Storage.GetTasks(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	store(ctx, r.cache, key, tasks, r.ttl)
+	return tasks, nil
+}
+
+func (r *Storage) CreateTask(ctx context.Context, task *models.Task) error {
+	if err := r.Storage.CreateTask(ctx, task); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, task)
+	return nil
+}
+
+func (r *Storage) CreateTasks(ctx context.Context, tasks []models.Task) error {
+	if err := r.Storage.CreateTasks(ctx, tasks); err != nil {
+		return err
+	}
+	for i := range tasks {
+		r.bumpTask(ctx, &tasks[i])
+	}
+	return nil
+}
+
+func (r *Storage) UpdateTask(ctx context.Context, id string, task *models.Task) error {
+	before := r.lookup(ctx, id)
+	if err := r.Storage.UpdateTask(ctx, id, task); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before, task.UserID, task.AssigneeID)
+	return nil
+}
+
+func (r *Storage) DeleteTask(ctx context.Context, id string) error {
+	before := r.lookup(ctx, id)
+	if err := r.Storage.DeleteTask(ctx, id); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before)
+	return nil
+}
+
+func (r *Storage) RestoreTask(ctx context.Context, id string) error {
+	before := r.lookup(ctx, id)
+	if err := r.Storage.RestoreTask(ctx, id); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before)
+	return nil
+}
+
+func (r *Storage) HardDeleteTask(ctx context.Context, id string) error {
+	before := r.lookup(ctx, id)
+	if err := r.Storage.HardDeleteTask(ctx, id); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before)
+	return nil
+}
+
+func (r *Storage) SetTaskArchived(ctx context.Context, id string, archived bool) error {
+	before := r.lookup(ctx, id)
+	if err := r.Storage.SetTaskArchived(ctx, id, archived); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before)
+	return nil
+}
+
+func (r *Storage) AssignTask(ctx context.Context, id, assigneeID string) error {
+	before := r.lookup(ctx, id)
+	if err := r.Storage.AssignTask(ctx, id, assigneeID); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before, assigneeID)
+	return nil
+}
+
+func (r *Storage) ReorderTasks(ctx context.Context, userID string, taskIDs []string) error {
+	if err := r.Storage.ReorderTasks(ctx, userID, taskIDs); err != nil {
+		return err
+	}
+	r.bump(ctx, userID)
+	return nil
+}
+
+func (r *Storage) ApplyBulk(ctx context.Context, ops []models.BulkOperation) ([]models.BulkResult, error) {
+	affected := make([]*models.Task, 0, len(ops))
+	for _, op := range ops {
+		affected = append(affected, r.lookup(ctx, op.TaskID))
+	}
+	results, err := r.Storage.ApplyBulk(ctx, ops)
+	for _, task := range affected {
+		r.bumpTask(ctx, task)
+	}
+	return results, err
+}
+
+func (r *Storage) AttachTag(ctx context.Context, taskID, tagID string) error {
+	before := r.lookup(ctx, taskID)
+	if err := r.Storage.AttachTag(ctx, taskID, tagID); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before)
+	return nil
+}
+
+func (r *Storage) DetachTag(ctx context.Context, taskID, tagID string) error {
+	before := r.lookup(ctx, taskID)
+	if err := r.Storage.DetachTag(ctx, taskID, tagID); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, before)
+	return nil
+}
+
+func (r *Storage) UpdateTag(ctx context.Context, id string, tag *models.Tag) error {
+	before, _ := r.Storage.GetTagByID(ctx, id)
+	if err := r.Storage.UpdateTag(ctx, id, tag); err != nil {
+		return err
+	}
+	if before != nil {
+		r.bump(ctx, before.UserID)
+	}
+	return nil
+}
+
+func (r *Storage) DeleteTag(ctx context.Context, id string) error {
+	before, _ := r.Storage.GetTagByID(ctx, id)
+	if err := r.Storage.DeleteTag(ctx, id); err != nil {
+		return err
+	}
+	if before != nil {
+		r.bump(ctx, before.UserID)
+	}
+	return nil
+}
+
+func (r *Storage) DeleteProject(ctx context.Context, id string) error {
+	before, _ := r.Storage.GetProjectByID(ctx, id)
+	if err := r.Storage.DeleteProject(ctx, id); err != nil {
+		return err
+	}
+	if before != nil {
+		r.bump(ctx, before.UserID)
+	}
+	return nil
+}
+
+func (r *Storage) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) error {
+	if err := r.Storage.AddChecklistItem(ctx, item); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, r.lookup(ctx, item.TaskID))
+	return nil
+}
+
+func (r *Storage) ToggleChecklistItem(ctx context.Context, taskID, itemID string) (*models.ChecklistItem, error) {
+	item, err := r.Storage.ToggleChecklistItem(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	r.bumpTask(ctx, r.lookup(ctx, taskID))
+	return item, nil
+}
+
+func (r *Storage) DeleteChecklistItem(ctx context.Context, taskID, itemID string) error {
+	if err := r.Storage.DeleteChecklistItem(ctx, taskID, itemID); err != nil {
+		return err
+	}
+	r.bumpTask(ctx, r.lookup(ctx, taskID))
+	return nil
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	inmemory "project/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageCachesGetUserByID(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.NewStorage()
+	repo := NewStorage(storage, NewMemoryCache(), time.Minute)
+
+	user := &models.User{Username: "alice", Email: "alice@example.com", Password: "password123"}
+	require.NoError(t, storage.CreateUser(ctx, user))
+
+	cached, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", cached.Email)
+
+	require.NoError(t, storage.UpdateUser(ctx, user.ID, &models.User{Username: "alice", Email: "direct@example.com", Password: "password123"}))
+	cached, err = repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", cached.Email, "read should be served from cache")
+
+	require.NoError(t, repo.UpdateUser(ctx, user.ID, &models.User{Username: "alice", Email: "new@example.com", Password: "password123"}))
+	cached, err = repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", cached.Email)
+
+	require.NoError(t, repo.SetUserActive(ctx, user.ID, false))
+	cached, err = repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, cached.Active)
+
+	require.NoError(t, repo.DeleteUser(ctx, user.ID))
+	_, err = repo.GetUserByID(ctx, user.ID)
+	assert.Equal(t, errors.ErrUserNotFound, err)
+}
+
+func TestStorageInvalidatesTasksOnWrites(t *testing.T) {
+	ctx := context.Background()
+	owner := "11111111-1111-1111-1111-111111111111"
+	assignee := "22222222-2222-2222-2222-222222222222"
+
+	tests := []struct {
+		name  string
+		write func(repo *Storage, task *models.Task) error
+		check func(t *testing.T, repo *Storage, task *models.Task)
+	}{
+		{
+			name: "update",
+			write: func(repo *Storage, task *models.Task) error {
+				updated := *task
+				updated.Title = "Updated"
+				return repo.UpdateTask(ctx, task.ID, &updated)
+			},
+			check: func(t *testing.T, repo *Storage, task *models.Task) {
+				fetched, err := repo.GetTaskByID(ctx, task.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Updated", fetched.Title)
+				tasks, err := repo.GetTasks(ctx, owner, models.TaskFilter{})
+				require.NoError(t, err)
+				require.Len(t, tasks, 1)
+				assert.Equal(t, "Updated", tasks[0].Title)
+			},
+		},
+		{
+			name: "create",
+			write: func(repo *Storage, task *models.Task) error {
+				return repo.CreateTask(ctx, &models.Task{Title: "Second", Status: models.StatusNew, UserID: owner})
+			},
+			check: func(t *testing.T, repo *Storage, task *models.Task) {
+				tasks, err := repo.GetTasks(ctx, owner, models.TaskFilter{})
+				require.NoError(t, err)
+				assert.Len(t, tasks, 2)
+			},
+		},
+		{
+			name: "delete",
+			write: func(repo *Storage, task *models.Task) error {
+				return repo.DeleteTask(ctx, task.ID)
+			},
+			check: func(t *testing.T, repo *Storage, task *models.Task) {
+				tasks, err := repo.GetTasks(ctx, owner, models.TaskFilter{})
+				require.NoError(t, err)
+				assert.Empty(t, tasks)
+			},
+		},
+		{
+			name: "assign",
+			write: func(repo *Storage, task *models.Task) error {
+				if _, err := repo.GetTasks(ctx, assignee, models.TaskFilter{View: models.TaskViewAssigned}); err != nil {
+					return err
+				}
+				return repo.AssignTask(ctx, task.ID, assignee)
+			},
+			check: func(t *testing.T, repo *Storage, task *models.Task) {
+				fetched, err := repo.GetTaskByID(ctx, task.ID)
+				require.NoError(t, err)
+				assert.Equal(t, assignee, fetched.AssigneeID)
+				tasks, err := repo.GetTasks(ctx, assignee, models.TaskFilter{View: models.TaskViewAssigned})
+				require.NoError(t, err)
+				assert.Len(t, tasks, 1)
+			},
+		},
+		{
+			name: "archive",
+			write: func(repo *Storage, task *models.Task) error {
+				return repo.SetTaskArchived(ctx, task.ID, true)
+			},
+			check: func(t *testing.T, repo *Storage, task *models.Task) {
+				fetched, err := repo.GetTaskByID(ctx, task.ID)
+				require.NoError(t, err)
+				assert.True(t, fetched.Archived)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := inmemory.NewStorage()
+			repo := NewStorage(storage, NewMemoryCache(), time.Minute)
+
+			task := &models.Task{Title: "First", Status: models.StatusNew, UserID: owner}
+			require.NoError(t, storage.CreateTask(ctx, task))
+
+			_, err := repo.GetTaskByID(ctx, task.ID)
+			require.NoError(t, err)
+			_, err = repo.GetTasks(ctx, owner, models.TaskFilter{})
+			require.NoError(t, err)
+
+			require.NoError(t, tt.write(repo, task))
+			tt.check(t, repo, task)
+		})
+	}
+}
+
+func TestStorageServesTaskReadsFromCache(t *testing.T) {
+	ctx := context.Background()
+	owner := "11111111-1111-1111-1111-111111111111"
+	storage := inmemory.NewStorage()
+	repo := NewStorage(storage, NewMemoryCache(), time.Minute)
+
+	task := &models.Task{Title: "First", Status: models.StatusNew, UserID: owner}
+	require.NoError(t, storage.CreateTask(ctx, task))
+
+	_, err := repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	done := models.StatusDone
+	_, err = repo.GetTasks(ctx, owner, models.TaskFilter{Status: done})
+	require.NoError(t, err)
+
+	updated := *task
+	updated.Title = "Changed behind the cache"
+	updated.Status = done
+	require.NoError(t, storage.UpdateTask(ctx, task.ID, &updated))
+
+	fetched, err := repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", fetched.Title)
+
+	tasks, err := repo.GetTasks(ctx, owner, models.TaskFilter{Status: done})
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	tasks, err = repo.GetTasks(ctx, owner, models.TaskFilter{})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1, "different filters use different keys")
+	assert.Equal(t, "Changed behind the cache", tasks[0].Title)
+
+	_, err = repo.GetTaskByID(ctx, "33333333-3333-3333-3333-333333333333")
+	assert.Equal(t, errors.ErrNotFound, err)
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"project/internal/domain/models"
+	"project/repository"
+)
+
+type UserStorage struct {
+	repository.Storage
+	users *MemoryCache
+	ttl   time.Duration
+}
+
+func NewUserStorage(storage repository.Storage, size int, ttl time.Duration) *UserStorage {
+	return &UserStorage{Storage: storage, users: NewLRUCache(size), ttl: ttl}
+}
+
+func (r *UserStorage) WithTx(ctx context.Context, fn func(tx repository.Storage) error) error {
+	return r.Storage.WithTx(ctx, func(tx repository.Storage) error {
+		return fn(&UserStorage{Storage: tx, users: r.users, ttl: r.ttl})
+	})
+}
+
+func (r *UserStorage) invalidate(ctx context.Context, id string) {
+	_ = r.users.Delete(ctx, userKeyPrefix+id)
+}
+
+func (r *UserStorage) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	key := userKeyPrefix + id
+	var cached models.User
+	if load(ctx, r.users, key, &cached) {
+		return &cached, nil
+	}
+	user, err := r.Storage.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	store(ctx, r.users, key, user, r.ttl)
+	return user, nil
+}
+
+func (r *UserStorage) IsUserActive(ctx context.Context, id string) (bool, error) {
+	user, err := r.GetUserByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return user.Active, nil
+}
+
+func (r *UserStorage) UpdateUser(ctx context.Context, id string, user *models.User) error {
+	if err := r.Storage.UpdateUser(ctx, id, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *UserStorage) DeleteUser(ctx context.Context, id string) error {
+	if err := r.Storage.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *UserStorage) SetUserActive(ctx context.Context, id string, active bool) error {
+	if err := r.Storage.SetUserActive(ctx, id, active); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *UserStorage) RecordLogin(ctx context.Context, record *models.LoginRecord) error {
+	if err := r.Storage.RecordLogin(ctx, record); err != nil {
+		return err
+	}
+	if record.Success {
+		r.invalidate(ctx, record.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/repository"
+	inmemory "project/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserStorageInvalidatesOnWrites(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		write func(repo *UserStorage, user *models.User) error
+		check func(t *testing.T, repo *UserStorage, user *models.User)
+	}{
+		{
+			name: "update",
+			write: func(repo *UserStorage, user *models.User) error {
+				return repo.UpdateUser(ctx, user.ID, &models.User{Username: "alice", Email: "new@example.com", Password: "password123", Role: models.RoleAdmin})
+			},
+			check: func(t *testing.T, repo *UserStorage, user *models.User) {
+				cached, err := repo.GetUserByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "new@example.com", cached.Email)
+				assert.Equal(t, models.RoleAdmin, cached.Role)
+			},
+		},
+		{
+			name: "update in transaction",
+			write: func(repo *UserStorage, user *models.User) error {
+				return repo.WithTx(ctx, func(tx repository.Storage) error {
+					return tx.UpdateUser(ctx, user.ID, &models.User{Username: "alice", Email: "tx@example.com", Password: "password123"})
+				})
+			},
+			check: func(t *testing.T, repo *UserStorage, user *models.User) {
+				cached, err := repo.GetUserByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "tx@example.com", cached.Email)
+			},
+		},
+		{
+			name: "suspend",
+			write: func(repo *UserStorage, user *models.User) error {
+				return repo.SetUserActive(ctx, user.ID, false)
+			},
+			check: func(t *testing.T, repo *UserStorage, user *models.User) {
+				cached, err := repo.GetUserByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.False(t, cached.Active)
+			},
+		},
+		{
+			name: "login",
+			write: func(repo *UserStorage, user *models.User) error {
+				return repo.RecordLogin(ctx, &models.LoginRecord{UserID: user.ID, Success: true, CreatedAt: time.Now()})
+			},
+			check: func(t *testing.T, repo *UserStorage, user *models.User) {
+				cached, err := repo.GetUserByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.NotNil(t, cached.LastLoginAt)
+			},
+		},
+		{
+			name: "delete",
+			write: func(repo *UserStorage, user *models.User) error {
+				return repo.DeleteUser(ctx, user.ID)
+			},
+			check: func(t *testing.T, repo *UserStorage, user *models.User) {
+				_, err := repo.GetUserByID(ctx, user.ID)
+				assert.Equal(t, errors.ErrUserNotFound, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := inmemory.NewStorage()
+			repo := NewUserStorage(storage, 10, time.Minute)
+			user := &models.User{Username: "alice", Email: "alice@example.com", Password: "password123", Active: true}
+			require.NoError(t, storage.CreateUser(ctx, user))
+
+			_, err := repo.GetUserByID(ctx, user.ID)
+			require.NoError(t, err)
+			require.NoError(t, tt.write(repo, user))
+			tt.check(t, repo, user)
+		})
+	}
+}
+
+func TestUserStorageServesFromCache(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.NewStorage()
+	repo := NewUserStorage(storage, 1, time.Minute)
+	now := time.Now()
+	repo.users.now = func() time.Time { return now }
+
+	alice := &models.User{Username: "alice", Email: "alice@example.com", Password: "password123"}
+	bob := &models.User{Username: "bob", Email: "bob@example.com", Password: "password123"}
+	require.NoError(t, storage.CreateUser(ctx, alice))
+	require.NoError(t, storage.CreateUser(ctx, bob))
+
+	_, err := repo.GetUserByID(ctx, alice.ID)
+	require.NoError(t, err)
+	require.NoError(t, storage.UpdateUser(ctx, alice.ID, &models.User{Username: "alice", Email: "changed@example.com", Password: "password123"}))
+	cached, err := repo.GetUserByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", cached.Email, "read should be served from cache")
+
+	now = now.Add(time.Minute)
+	cached, err = repo.GetUserByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "changed@example.com", cached.Email, "expired entry should be reloaded")
+
+	require.NoError(t, storage.UpdateUser(ctx, alice.ID, &models.User{Username: "alice", Email: "again@example.com", Password: "password123"}))
+	_, err = repo.GetUserByID(ctx, bob.ID)
+	require.NoError(t, err)
+	cached, err = repo.GetUserByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "again@example.com", cached.Email, "least recently used entry should be evicted")
+}
+
+func TestUserStorageEnforcesChangesFromOtherReplicasWithinTTL(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.NewStorage()
+	repo := NewUserStorage(storage, 10, 30*time.Second)
+	now := time.Now()
+	repo.users.now = func() time.Time { return now }
+	user := &models.User{Username: "alice", Email: "alice@example.com", Password: "password123", Role: models.RoleAdmin}
+	require.NoError(t, storage.CreateUser(ctx, user))
+
+	active, err := repo.IsUserActive(ctx, user.ID)
+	require.NoError(t, err)
+	require.True(t, active)
+
+	require.NoError(t, storage.SetUserActive(ctx, user.ID, false))
+	require.NoError(t, storage.UpdateUser(ctx, user.ID, &models.User{Username: "alice", Email: "alice@example.com", Password: "password123", Role: models.RoleUser}))
+
+	now = now.Add(29 * time.Second)
+	active, err = repo.IsUserActive(ctx, user.ID)
+	require.NoError(t, err)
+	cached, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, active, "active flag is served from cache within the TTL")
+	assert.Equal(t, models.RoleAdmin, cached.Role, "role is served from the same cached entry")
+
+	now = now.Add(time.Second)
+	active, err = repo.IsUserActive(ctx, user.ID)
+	require.NoError(t, err)
+	cached, err = repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, active, "suspension must apply once the TTL expires")
+	assert.Equal(t, models.RoleUser, cached.Role, "role change must apply once the TTL expires")
+}
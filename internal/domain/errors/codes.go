@@ -0,0 +1,123 @@
+package errors
+
+import "errors"
+
+var codes = map[error]string{
+	ErrUserNotFound:       "user_not_found",
+	ErrInvalidCredentials: "invalid_credentials",
+	ErrUserAlreadyExists:  "user_already_exists",
+	ErrInvalidInput:       "invalid_input",
+	ErrDatabaseConnection: "database_connection",
+	ErrValidationFailed:   "validation_failed",
+	ErrUnauthorized:       "unauthorized",
+	ErrForbidden:          "forbidden",
+	ErrInternalServer:     "internal_error",
+	ErrBadRequest:         "bad_request",
+	ErrNotFound:           "not_found",
+	ErrConflict:           "conflict",
+
+	ErrInvalidUsername:    "invalid_username",
+	ErrInvalidEmail:       "invalid_email",
+	ErrInvalidPassword:    "invalid_password",
+	ErrInvalidRole:        "invalid_role",
+	ErrInvalidStatus:      "invalid_status",
+	ErrInvalidTitle:       "invalid_title",
+	ErrInvalidDescription: "invalid_description",
+
+	ErrInvalidRequest: "invalid_request",
+	ErrTaskTransition: "task_transition",
+
+	ErrUnauthorizedAction:    "unauthorized_action",
+	ErrUserUpdateForbidden:   "user_update_forbidden",
+	ErrUserDeleteForbidden:   "user_delete_forbidden",
+	ErrTaskNotFound:          "task_not_found",
+	ErrTasksNotFound:         "tasks_not_found",
+	ErrEmptySearchQuery:      "empty_search_query",
+	ErrParentTaskNotFound:    "parent_task_not_found",
+	ErrTagNotFound:           "tag_not_found",
+	ErrTaskAlreadyExists:     "task_already_exists",
+	ErrTagAlreadyExists:      "tag_already_exists",
+	ErrShareWithSelf:         "share_with_self",
+	ErrProjectNotFound:       "project_not_found",
+	ErrProjectAlreadyExists:  "project_already_exists",
+	ErrTemplateNotFound:      "template_not_found",
+	ErrTemplateAlreadyExists: "template_already_exists",
+	ErrWebhookNotFound:       "webhook_not_found",
+	ErrWebhookDeliveryFailed: "webhook_delivery_failed",
+	ErrBulkUnknownAction:     "bulk_unknown_action",
+	ErrTaskNotInTrash:        "task_not_in_trash",
+	ErrTaskNotDone:           "task_not_done",
+	ErrTaskView:              "task_view",
+	ErrTaskSort:              "task_sort",
+	ErrTaskPage:              "task_page",
+	ErrDueWindow:             "due_window",
+	ErrChecklistItemNotFound: "checklist_item_not_found",
+	ErrChecklistMismatch:     "checklist_mismatch",
+	ErrWorkflowNotFound:      "workflow_not_found",
+	ErrWorkflowInvalid:       "workflow_invalid",
+	ErrWorkflowDoneRequired:  "workflow_done_required",
+	ErrUserSuspended:         "user_suspended",
+	ErrSuspendSelf:           "suspend_self",
+	ErrInvalidTimezone:       "invalid_timezone",
+	ErrUserSearchQuery:       "user_search_query",
+	ErrActivityPage:          "activity_page",
+	ErrBlobNotFound:          "blob_not_found",
+	ErrCacheMiss:             "cache_miss",
+	ErrDatabaseUnavailable:   "database_unavailable",
+	ErrInMemoryStorage:       "in_memory_storage",
+	ErrMigrationDirty:        "migration_dirty",
+	ErrShuttingDown:          "shutting_down",
+	ErrCORSOriginForbidden:   "cors_origin_forbidden",
+	ErrMaintenanceMode:       "maintenance_mode",
+	ErrWorkspaceNotFound:     "workspace_not_found",
+	ErrNotWorkspaceMember:    "not_workspace_member",
+	ErrWorkspaceOwner:        "workspace_owner",
+	ErrWorkspaceMismatch:     "workspace_mismatch",
+	ErrRealtimeUnavailable:   "realtime_unavailable",
+	ErrQueryTimeout:          "query_timeout",
+	ErrAvatarNotFound:        "avatar_not_found",
+	ErrAvatarTooLarge:        "avatar_too_large",
+	ErrAvatarType:            "avatar_type",
+	ErrAvatarVariant:         "avatar_variant",
+	ErrIdempotencyKeyInvalid: "idempotency_key_invalid",
+	ErrIdempotencyKeyReused:  "idempotency_key_reused",
+	ErrIdempotencyInProgress: "idempotency_in_progress",
+	ErrExportFormat:          "export_format",
+	ErrTokenGeneration:       "token_generation",
+	ErrNotAuthorized:         "not_authorized",
+	ErrMethodNotAllowed:      "method_not_allowed",
+	ErrPreconditionFailed:    "precondition_failed",
+	ErrFeatureDisabled:       "feature_disabled",
+
+	ErrInvalidGzipRequest:    "invalid_gzip_request",
+	ErrGzipCompressionFailed: "gzip_compression_failed",
+
+	ErrCaptchaRequired:    "captcha_required",
+	ErrCaptchaFailed:      "captcha_failed",
+	ErrCaptchaUnavailable: "captcha_unavailable",
+
+	ErrGraphQLSyntax:          "graphql_syntax",
+	ErrGraphQLOperation:       "graphql_operation",
+	ErrGraphQLUnknownField:    "graphql_unknown_field",
+	ErrGraphQLUnknownArgument: "graphql_unknown_argument",
+	ErrGraphQLSelection:       "graphql_selection",
+	ErrGraphQLFragment:        "graphql_fragment",
+	ErrGraphQLVariable:        "graphql_variable",
+	ErrGraphQLArgument:        "graphql_argument",
+
+	ErrUserExists:             "user_already_exists",
+	ErrTaskStatus:             "invalid_status",
+	ErrInvalidUserCredentials: "invalid_credentials",
+}
+
+func Code(err error) string {
+	if code, ok := codes[err]; ok {
+		return code
+	}
+	for target, code := range codes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
+	return ""
+}
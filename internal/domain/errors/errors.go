@@ -27,6 +27,7 @@ var (
 	ErrInvalidRequest = errors.New("некорректные данные запроса")
 	ErrUserExists     = errors.New("пользователь уже существует")
 	ErrTaskStatus     = errors.New("недопустимый статус задачи")
+	ErrTaskTransition = errors.New("переход в этот статус запрещен рабочим процессом")
 
 	ErrInvalidRequestData     = errors.New("некорректные данные запроса")
 	ErrInvalidUserCredentials = errors.New("неверные учетные данные")
@@ -35,8 +36,62 @@ var (
 	ErrUserDeleteForbidden    = errors.New("нет прав на удаление этого пользователя")
 	ErrTaskNotFound           = errors.New("задача не найдена")
 	ErrTasksNotFound          = errors.New("задачи не найдены")
+	ErrEmptySearchQuery       = errors.New("пустой поисковый запрос")
+	ErrParentTaskNotFound     = errors.New("родительская задача не найдена")
+	ErrTaskAlreadyExists      = errors.New("задача с таким идентификатором уже существует")
+	ErrTagNotFound            = errors.New("тег не найден")
+	ErrTagAlreadyExists       = errors.New("тег с таким именем уже существует")
+	ErrShareWithSelf          = errors.New("нельзя поделиться задачей с самим собой")
+	ErrProjectNotFound        = errors.New("проект не найден")
+	ErrProjectAlreadyExists   = errors.New("проект с таким именем уже существует")
+	ErrTemplateNotFound       = errors.New("шаблон не найден")
+	ErrTemplateAlreadyExists  = errors.New("шаблон с таким именем уже существует")
+	ErrWebhookNotFound        = errors.New("вебхук не найден")
+	ErrWebhookDeliveryFailed  = errors.New("не удалось доставить вебхук")
+	ErrBulkUnknownAction      = errors.New("неизвестная операция")
+	ErrTaskNotInTrash         = errors.New("задача не найдена в корзине")
+	ErrTaskNotDone            = errors.New("архивировать можно только выполненные задачи")
+	ErrTaskView               = errors.New("недопустимый режим просмотра задач")
+	ErrTaskSort               = errors.New("недопустимое поле сортировки задач")
+	ErrTaskPage               = errors.New("некорректные параметры страницы списка задач")
+	ErrDueWindow              = errors.New("некорректный интервал срока выполнения")
+	ErrChecklistItemNotFound  = errors.New("пункт чек-листа не найден")
+	ErrChecklistMismatch      = errors.New("список пунктов не совпадает с чек-листом задачи")
+	ErrWorkflowNotFound       = errors.New("рабочий процесс не найден")
+	ErrWorkflowInvalid        = errors.New("некорректное описание рабочего процесса")
+	ErrWorkflowDoneRequired   = errors.New("рабочий процесс должен содержать статус done")
+	ErrUserSuspended          = errors.New("учетная запись пользователя заблокирована")
+	ErrSuspendSelf            = errors.New("нельзя заблокировать собственную учетную запись")
+	ErrInvalidTimezone        = errors.New("неизвестный часовой пояс")
+	ErrUserSearchQuery        = errors.New("поисковый запрос должен содержать от 2 до 100 символов")
+	ErrActivityPage           = errors.New("некорректные параметры страницы ленты активности")
+	ErrBlobNotFound           = errors.New("объект не найден в хранилище")
+	ErrCacheMiss              = errors.New("ключ не найден в кэше")
+	ErrDatabaseUnavailable    = errors.New("база данных недоступна")
+	ErrInMemoryStorage        = errors.New("сервис работает на хранилище в памяти")
+	ErrMigrationDirty         = errors.New("последняя миграция завершилась с ошибкой")
+	ErrShuttingDown           = errors.New("сервис завершает работу")
+	ErrCORSOriginForbidden    = errors.New("источник запроса не разрешен политикой CORS")
+	ErrMaintenanceMode        = errors.New("сервис находится на обслуживании, изменения временно недоступны")
+	ErrWorkspaceNotFound      = errors.New("рабочее пространство не найдено")
+	ErrNotWorkspaceMember     = errors.New("пользователь не состоит в рабочем пространстве")
+	ErrWorkspaceOwner         = errors.New("нельзя изменить роль или исключить владельца рабочего пространства")
+	ErrWorkspaceMismatch      = errors.New("подзадача должна находиться в рабочем пространстве родительской задачи")
+	ErrRealtimeUnavailable    = errors.New("обновления в реальном времени недоступны")
+	ErrQueryTimeout           = errors.New("превышено время ожидания ответа базы данных")
+	ErrAvatarNotFound         = errors.New("аватар не найден")
+	ErrAvatarTooLarge         = errors.New("файл аватара слишком большой")
+	ErrAvatarType             = errors.New("неподдерживаемый формат изображения")
+	ErrAvatarVariant          = errors.New("неизвестный размер аватара")
+	ErrIdempotencyKeyInvalid  = errors.New("некорректный ключ идемпотентности")
+	ErrIdempotencyKeyReused   = errors.New("ключ идемпотентности уже использован с другим запросом")
+	ErrIdempotencyInProgress  = errors.New("запрос с этим ключом идемпотентности еще выполняется")
+	ErrExportFormat           = errors.New("неподдерживаемый формат экспорта")
 	ErrTokenGeneration        = errors.New("ошибка генерации токена")
 	ErrNotAuthorized          = errors.New("пользователь не авторизован")
+	ErrMethodNotAllowed       = errors.New("использован некорректный HTTP-метод")
+	ErrPreconditionFailed     = errors.New("задача была изменена другим запросом")
+	ErrFeatureDisabled        = errors.New("функция отключена для этой установки")
 
 	ErrInvalidGzipRequest    = errors.New("некорректный gzip-запрос")
 	ErrGzipCompressionFailed = errors.New("ошибка gzip-сжатия")
@@ -45,4 +100,39 @@ var (
 	ErrConfigFileReadFailed = errors.New("ошибка чтения файла конфигурации")
 	ErrConfigParseFailed    = errors.New("ошибка парсинга конфигурации")
 	ErrConfigInvalidFormat  = errors.New("неверный формат конфигурации")
+	ErrTLSKeyPairIncomplete = errors.New("для TLS необходимо указать и сертификат, и ключ")
+	ErrSocketPathInUse      = errors.New("путь unix-сокета уже занят")
+	ErrConfigInvalidPort    = errors.New("порт должен быть от 1 до 65535")
+	ErrConfigInvalidDSN     = errors.New("некорректная строка подключения к БД")
+	ErrTLSFileNotFound      = errors.New("файл TLS не найден")
+	ErrConfigSecretMissing  = errors.New("не задан обязательный параметр")
+	ErrUserCacheTTLTooLong  = errors.New("TTL кэша пользователей больше допустимой задержки применения блокировки и смены роли")
+
+	ErrCommand         = errors.New("неизвестная команда")
+	ErrCommandArgument = errors.New("некорректный аргумент команды")
+	ErrNotLoggedIn     = errors.New("вход не выполнен, запустите tasks-cli login")
+	ErrOutputFormat    = errors.New("неизвестный формат вывода")
+
+	ErrMigrationCommand  = errors.New("неизвестная команда миграции")
+	ErrMigrationArgument = errors.New("некорректный аргумент команды миграции")
+
+	ErrBackupCommand  = errors.New("неизвестная команда резервного копирования")
+	ErrBackupArgument = errors.New("некорректный аргумент команды резервного копирования")
+	ErrBackupFormat   = errors.New("неподдерживаемый формат резервной копии")
+
+	ErrCaptchaRequired    = errors.New("требуется пройти проверку captcha")
+	ErrCaptchaFailed      = errors.New("проверка captcha не пройдена")
+	ErrCaptchaUnavailable = errors.New("сервис проверки captcha недоступен")
+
+	ErrReminderNoRecipient   = errors.New("не указан адрес получателя напоминания")
+	ErrReminderWebhookFailed = errors.New("webhook напоминания вернул ошибку")
+
+	ErrGraphQLSyntax          = errors.New("синтаксическая ошибка в запросе GraphQL")
+	ErrGraphQLOperation       = errors.New("операция GraphQL не найдена")
+	ErrGraphQLUnknownField    = errors.New("неизвестное поле в запросе GraphQL")
+	ErrGraphQLUnknownArgument = errors.New("неизвестный аргумент в запросе GraphQL")
+	ErrGraphQLSelection       = errors.New("некорректный набор полей в запросе GraphQL")
+	ErrGraphQLFragment        = errors.New("некорректный фрагмент в запросе GraphQL")
+	ErrGraphQLVariable        = errors.New("не задано значение переменной GraphQL")
+	ErrGraphQLArgument        = errors.New("некорректное значение аргумента GraphQL")
 )
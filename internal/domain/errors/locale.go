@@ -0,0 +1,123 @@
+package errors
+
+var english = map[error]string{
+	ErrUserNotFound:       "user not found",
+	ErrInvalidCredentials: "invalid credentials",
+	ErrUserAlreadyExists:  "user already exists",
+	ErrInvalidInput:       "invalid input",
+	ErrDatabaseConnection: "database connection error",
+	ErrValidationFailed:   "validation failed",
+	ErrUnauthorized:       "access denied",
+	ErrForbidden:          "forbidden",
+	ErrInternalServer:     "internal server error",
+	ErrBadRequest:         "bad request",
+	ErrNotFound:           "resource not found",
+	ErrConflict:           "resource conflict",
+
+	ErrInvalidUsername:    "invalid username",
+	ErrInvalidEmail:       "invalid email",
+	ErrInvalidPassword:    "invalid password",
+	ErrInvalidRole:        "invalid user role",
+	ErrInvalidStatus:      "invalid task status",
+	ErrInvalidTitle:       "invalid task title",
+	ErrInvalidDescription: "invalid task description",
+
+	ErrInvalidRequest: "invalid request data",
+	ErrTaskTransition: "this status transition is not allowed by the workflow",
+
+	ErrUnauthorizedAction:    "not allowed to perform this action",
+	ErrUserUpdateForbidden:   "not allowed to update this user",
+	ErrUserDeleteForbidden:   "not allowed to delete this user",
+	ErrTaskNotFound:          "task not found",
+	ErrTasksNotFound:         "tasks not found",
+	ErrEmptySearchQuery:      "empty search query",
+	ErrParentTaskNotFound:    "parent task not found",
+	ErrTagNotFound:           "tag not found",
+	ErrTaskAlreadyExists:     "a task with this id already exists",
+	ErrTagAlreadyExists:      "a tag with this name already exists",
+	ErrShareWithSelf:         "a task cannot be shared with yourself",
+	ErrProjectNotFound:       "project not found",
+	ErrProjectAlreadyExists:  "a project with this name already exists",
+	ErrTemplateNotFound:      "template not found",
+	ErrTemplateAlreadyExists: "a template with this name already exists",
+	ErrWebhookNotFound:       "webhook not found",
+	ErrWebhookDeliveryFailed: "webhook delivery failed",
+	ErrBulkUnknownAction:     "unknown operation",
+	ErrTaskNotInTrash:        "task not found in trash",
+	ErrTaskNotDone:           "only completed tasks can be archived",
+	ErrTaskView:              "invalid task view",
+	ErrTaskSort:              "invalid task sort field",
+	ErrTaskPage:              "invalid task list page parameters",
+	ErrDueWindow:             "invalid due date window",
+	ErrChecklistItemNotFound: "checklist item not found",
+	ErrChecklistMismatch:     "item list does not match the task checklist",
+	ErrWorkflowNotFound:      "workflow not found",
+	ErrWorkflowInvalid:       "invalid workflow definition",
+	ErrWorkflowDoneRequired:  "workflow must contain the done status",
+	ErrUserSuspended:         "user account is suspended",
+	ErrSuspendSelf:           "you cannot suspend your own account",
+	ErrInvalidTimezone:       "unknown timezone",
+	ErrUserSearchQuery:       "search query must be 2 to 100 characters long",
+	ErrActivityPage:          "invalid activity page parameters",
+	ErrBlobNotFound:          "object not found in storage",
+	ErrCacheMiss:             "key not found in cache",
+	ErrDatabaseUnavailable:   "database is unavailable",
+	ErrInMemoryStorage:       "service is running on in-memory storage",
+	ErrMigrationDirty:        "the last migration failed",
+	ErrShuttingDown:          "service is shutting down",
+	ErrCORSOriginForbidden:   "request origin is not allowed by the CORS policy",
+	ErrMaintenanceMode:       "service is under maintenance, changes are temporarily unavailable",
+	ErrWorkspaceNotFound:     "workspace not found",
+	ErrNotWorkspaceMember:    "user is not a member of the workspace",
+	ErrWorkspaceOwner:        "the workspace owner cannot be demoted or removed",
+	ErrWorkspaceMismatch:     "a subtask must belong to its parent task's workspace",
+	ErrRealtimeUnavailable:   "real-time updates are unavailable",
+	ErrQueryTimeout:          "database query timed out",
+	ErrAvatarNotFound:        "avatar not found",
+	ErrAvatarTooLarge:        "avatar file is too large",
+	ErrAvatarType:            "unsupported image format",
+	ErrAvatarVariant:         "unknown avatar size",
+	ErrIdempotencyKeyInvalid: "invalid idempotency key",
+	ErrIdempotencyKeyReused:  "idempotency key was already used with a different request",
+	ErrIdempotencyInProgress: "a request with this idempotency key is still in progress",
+	ErrExportFormat:          "unsupported export format",
+	ErrTokenGeneration:       "token generation failed",
+	ErrNotAuthorized:         "user is not authorized",
+	ErrMethodNotAllowed:      "HTTP method is not allowed",
+	ErrPreconditionFailed:    "task was modified by another request",
+	ErrFeatureDisabled:       "feature is disabled for this deployment",
+
+	ErrInvalidGzipRequest:    "invalid gzip request",
+	ErrGzipCompressionFailed: "gzip compression failed",
+
+	ErrCaptchaRequired:    "captcha verification is required",
+	ErrCaptchaFailed:      "captcha verification failed",
+	ErrCaptchaUnavailable: "captcha verification service is unavailable",
+
+	ErrGraphQLSyntax:          "GraphQL query syntax error",
+	ErrGraphQLOperation:       "GraphQL operation not found",
+	ErrGraphQLUnknownField:    "unknown field in GraphQL query",
+	ErrGraphQLUnknownArgument: "unknown argument in GraphQL query",
+	ErrGraphQLSelection:       "invalid selection set in GraphQL query",
+	ErrGraphQLFragment:        "invalid fragment in GraphQL query",
+	ErrGraphQLVariable:        "GraphQL variable value is missing",
+	ErrGraphQLArgument:        "invalid GraphQL argument value",
+}
+
+var englishByMessage = func() map[string]string {
+	byMessage := make(map[string]string, len(english))
+	for err, message := range english {
+		byMessage[err.Error()] = message
+	}
+	return byMessage
+}()
+
+func Localize(message, locale string) string {
+	if locale != "en" {
+		return message
+	}
+	if translated, ok := englishByMessage[message]; ok {
+		return translated
+	}
+	return message
+}
@@ -1,11 +1,49 @@
 package models
 
+import (
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"golang.org/x/text/unicode/norm"
+)
+
 type User struct {
 	ID       string `json:"id" validate:"uuid"`
 	Username string `json:"username" validate:"required,min=3,max=50,alphanum"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8,max=100,alphanum"`
-	Role     string `json:"role" validate:"omitempty,oneof=user admin moderator"`
+	Role     string `json:"role" validate:"omitempty,role"`
+	Active   bool   `json:"active"`
+
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+type LoginRecord struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NormalizeUsername(username string) string {
+	return strings.ToLower(norm.NFC.String(strings.TrimSpace(username)))
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(norm.NFC.String(strings.TrimSpace(email)))
+}
+
+const (
+	RoleUser      = "user"
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+)
+
+func IsUserRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin || role == RoleModerator
 }
 
 type LoginRequest struct {
@@ -14,35 +52,457 @@ type LoginRequest struct {
 }
 
 type RegisterRequest struct {
-	Username string `json:"username" validate:"required,min=3,max=50,alphanum"`
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6,max=100"`
-	Role     string `json:"role" validate:"omitempty,oneof=user admin moderator"`
+	Username     string `json:"username" validate:"required,min=3,max=50,alphanum"`
+	Email        string `json:"email" validate:"required,email"`
+	Password     string `json:"password" validate:"required,min=6,max=100"`
+	CaptchaToken string `json:"captcha_token"`
 }
 
 type UpdateUserRequest struct {
 	Username string `json:"username" validate:"omitempty,min=3,max=50,alphanum"`
 	Email    string `json:"email" validate:"omitempty,email"`
 	Password string `json:"password" validate:"omitempty,min=6,max=100"`
-	Role     string `json:"role" validate:"omitempty,oneof=user admin moderator"`
+}
+
+func (r *LoginRequest) Normalize() {
+	r.Username = NormalizeUsername(r.Username)
+}
+
+func (r *RegisterRequest) Normalize() {
+	r.Username = NormalizeUsername(r.Username)
+	r.Email = NormalizeEmail(r.Email)
+}
+
+func (r *UpdateUserRequest) Normalize() {
+	r.Username = NormalizeUsername(r.Username)
+	r.Email = NormalizeEmail(r.Email)
+}
+
+const (
+	LocaleRU = "ru"
+	LocaleEN = "en"
+)
+
+type NotificationPreferences struct {
+	Reminders bool     `json:"reminders"`
+	Channels  []string `json:"channels,omitempty" validate:"omitempty,max=3,dive,oneof=log email webhook"`
+}
+
+type UserPreferences struct {
+	Timezone      string                  `json:"timezone" validate:"required,max=64"`
+	Locale        string                  `json:"locale" validate:"required,oneof=ru en"`
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{
+		Timezone:      "UTC",
+		Locale:        LocaleRU,
+		Notifications: NotificationPreferences{Reminders: true},
+	}
+}
+
+func (p UserPreferences) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (p UserPreferences) AllowsChannel(channel string) bool {
+	if !p.Notifications.Reminders {
+		return false
+	}
+	if len(p.Notifications.Channels) == 0 {
+		return true
+	}
+	for _, c := range p.Notifications.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
 }
 
 type Task struct {
-	ID          string `json:"id" validate:"omitempty,uuid"`
-	Title       string `json:"title" validate:"required,min=1,max=100"`
-	Description string `json:"description" validate:"omitempty,max=500"`
-	Status      string `json:"status" validate:"required,oneof=new in_progress done"`
-	UserID      string `json:"user_id" validate:"required,uuid"`
-	Deleted     bool   `json:"deleted"`
+	ID          string   `json:"id" validate:"omitempty,uuid"`
+	Title       string   `json:"title" validate:"required,min=1,max=100"`
+	Description string   `json:"description" validate:"omitempty,max=500"`
+	Status      string   `json:"status" validate:"required,max=20"`
+	UserID      string   `json:"user_id" validate:"required,uuid"`
+	Deleted     bool     `json:"deleted"`
+	Archived    bool     `json:"archived"`
+	Tags        []string `json:"tags,omitempty"`
+	ParentID    string   `json:"parent_id,omitempty"`
+	ProjectID   string   `json:"project_id,omitempty"`
+	WorkspaceID string   `json:"workspace_id,omitempty"`
+	AssigneeID  string   `json:"assignee_id,omitempty"`
+	Position    int      `json:"position"`
+	Version     int64    `json:"version"`
+
+	DueDate               *time.Time `json:"due_date,omitempty"`
+	ReminderOffsetMinutes int        `json:"reminder_offset_minutes,omitempty"`
+	RemindedAt            *time.Time `json:"reminded_at,omitempty"`
+	DeletedAt             *time.Time `json:"deleted_at,omitempty"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+
+	Checklist *ChecklistProgress `json:"checklist,omitempty"`
+}
+
+const (
+	StatusNew        = "new"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+)
+
+type Workflow struct {
+	UserID        string              `json:"user_id"`
+	Statuses      []string            `json:"statuses"`
+	InitialStatus string              `json:"initial_status"`
+	Transitions   map[string][]string `json:"transitions"`
+}
+
+type WorkflowRequest struct {
+	Statuses      []string            `json:"statuses" validate:"required,min=2,max=20,dive,required,status"`
+	InitialStatus string              `json:"initial_status" validate:"required,status"`
+	Transitions   map[string][]string `json:"transitions"`
+}
+
+func DefaultWorkflow(userID string) Workflow {
+	return Workflow{
+		UserID:        userID,
+		Statuses:      []string{StatusNew, StatusInProgress, StatusDone},
+		InitialStatus: StatusNew,
+		Transitions:   map[string][]string{},
+	}
+}
+
+func (w Workflow) HasStatus(status string) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Workflow) CanTransition(from, to string) bool {
+	if !w.HasStatus(to) {
+		return false
+	}
+	if from == to || len(w.Transitions) == 0 || !w.HasStatus(from) {
+		return true
+	}
+	for _, next := range w.Transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type ChecklistItem struct {
+	ID       string `json:"id"`
+	TaskID   string `json:"task_id"`
+	Title    string `json:"title"`
+	Done     bool   `json:"done"`
+	Position int    `json:"position"`
+}
+
+type ChecklistProgress struct {
+	Total int     `json:"total"`
+	Done  int     `json:"done"`
+	Ratio float64 `json:"ratio"`
+}
+
+func NewChecklistProgress(total, done int) *ChecklistProgress {
+	if total == 0 {
+		return nil
+	}
+	return &ChecklistProgress{Total: total, Done: done, Ratio: float64(done) / float64(total)}
+}
+
+type ChecklistItemRequest struct {
+	Title string `json:"title" validate:"required,min=1,max=200"`
+}
+
+type ReorderChecklistRequest struct {
+	ItemIDs []string `json:"item_ids" validate:"required,min=1,max=500,dive,required"`
 }
 
 type CreateTaskRequest struct {
 	Title       string `json:"title" validate:"required,min=1,max=100"`
 	Description string `json:"description" validate:"omitempty,max=500"`
+	ParentID    string `json:"parent_id"`
+	ProjectID   string `json:"project_id"`
+	WorkspaceID string `json:"workspace_id" validate:"omitempty,uuid"`
+
+	DueDate               *time.Time `json:"due_date"`
+	ReminderOffsetMinutes int        `json:"reminder_offset_minutes" validate:"min=0,max=525600"`
+}
+
+type BatchCreateTasksRequest struct {
+	Tasks []CreateTaskRequest `json:"tasks" validate:"required,min=1,max=500,dive"`
 }
 
 type UpdateTaskRequest struct {
 	Title       string `json:"title" validate:"omitempty,min=1,max=100"`
 	Description string `json:"description" validate:"omitempty,max=500"`
-	Status      string `json:"status" validate:"omitempty,oneof=new in_progress done"`
+	Status      string `json:"status" validate:"omitempty,status"`
+
+	DueDate               *time.Time `json:"due_date"`
+	ReminderOffsetMinutes *int       `json:"reminder_offset_minutes" validate:"omitempty,min=0,max=525600"`
+	ProjectID             *string    `json:"project_id"`
+}
+
+type TaskFilter struct {
+	Status        string
+	Deleted       *bool
+	Archived      *bool
+	TitleContains string
+	Tag           string
+	ProjectID     string
+	WorkspaceID   string
+	View          string
+
+	Sort       string
+	Descending bool
+	Limit      int
+	Offset     int
+}
+
+const (
+	TaskViewCreated  = "created"
+	TaskViewAssigned = "assigned"
+)
+
+const (
+	TaskSortPosition = "position"
+	TaskSortTitle    = "title"
+	TaskSortStatus   = "status"
+	TaskSortDueDate  = "due_date"
+)
+
+func IsTaskSort(field string) bool {
+	switch field {
+	case TaskSortPosition, TaskSortTitle, TaskSortStatus, TaskSortDueDate:
+		return true
+	}
+	return false
+}
+
+type ReorderTasksRequest struct {
+	TaskIDs []string `json:"task_ids" validate:"required,min=1,max=500,dive,required"`
+}
+
+type AssignTaskRequest struct {
+	AssigneeID string `json:"assignee_id" validate:"required,uuid"`
+}
+
+type Tag struct {
+	ID     string `json:"id"`
+	Name   string `json:"name" validate:"required,min=1,max=50"`
+	UserID string `json:"user_id"`
+}
+
+type TagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type TaskShare struct {
+	TaskID     string `json:"task_id"`
+	UserID     string `json:"user_id"`
+	Permission string `json:"permission"`
+}
+
+type ShareTaskRequest struct {
+	UserID     string `json:"user_id" validate:"required,uuid"`
+	Permission string `json:"permission" validate:"required,oneof=read write"`
+}
+
+type Project struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+}
+
+type ProjectRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleViewer = "viewer"
+)
+
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+type WorkspaceRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+type WorkspaceMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member viewer"`
+}
+
+type TaskTemplate struct {
+	ID          string   `json:"id"`
+	UserID      string   `json:"user_id"`
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Checklist   []string `json:"checklist"`
+}
+
+type TaskTemplateRequest struct {
+	Name        string   `json:"name" validate:"required,min=1,max=100"`
+	Title       string   `json:"title" validate:"required,min=1,max=100"`
+	Description string   `json:"description" validate:"omitempty,max=500"`
+	Tags        []string `json:"tags" validate:"max=20,dive,min=1,max=50"`
+	Checklist   []string `json:"checklist" validate:"max=50,dive,min=1,max=100"`
+}
+
+const (
+	BulkActionUpdateStatus = "update_status"
+	BulkActionDelete       = "delete"
+	BulkActionMove         = "move"
+)
+
+type BulkOperation struct {
+	TaskID    string `json:"task_id"`
+	Action    string `json:"action"`
+	Status    string `json:"status,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+type BulkRequest struct {
+	Operations []BulkOperation `json:"operations" validate:"required,min=1,max=500"`
+}
+
+type BulkResult struct {
+	TaskID  string `json:"task_id"`
+	Action  string `json:"action"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+const (
+	TaskEventCreate = "create"
+	TaskEventUpdate = "update"
+	TaskEventDelete = "delete"
+)
+
+type TaskEvent struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	UserID    string    `json:"user_id"`
+	Action    string    `json:"action"`
+	OldValue  *Task     `json:"old_value,omitempty"`
+	NewValue  *Task     `json:"new_value,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	ActivityTaskCreated   = "task.created"
+	ActivityTaskUpdated   = "task.updated"
+	ActivityTaskCompleted = "task.completed"
+	ActivityTaskDeleted   = "task.deleted"
+	ActivityLogin         = "login"
+	ActivityLoginFailed   = "login.failed"
+)
+
+type ActivityItem struct {
+	Type      string    `json:"type"`
+	TaskID    string    `json:"task_id,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	WebhookEventTaskCreated   = "task.created"
+	WebhookEventTaskUpdated   = "task.updated"
+	WebhookEventTaskDeleted   = "task.deleted"
+	WebhookEventTaskCompleted = "task.completed"
+)
+
+type Webhook struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2000"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=128"`
+	Events []string `json:"events" validate:"max=4,dive,oneof=task.created task.updated task.deleted task.completed"`
+}
+
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type MaintenanceStatus struct {
+	Enabled           bool       `json:"enabled"`
+	Since             *time.Time `json:"since,omitempty"`
+	RetryAfterSeconds int        `json:"retry_after_seconds"`
+}
+
+type MaintenanceRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+type LoggingStatus struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+type LoggingRequest struct {
+	Level  string `json:"level" validate:"omitempty,oneof=debug info warn error"`
+	Format string `json:"format" validate:"omitempty,oneof=text json"`
+}
+
+type ErrorResponse struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+type ErrorEnvelope struct {
+	Error ErrorResponse `json:"error"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
@@ -0,0 +1,443 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+
+	"project/internal/domain/errors"
+)
+
+const typenameField = "__typename"
+
+type ResolveFunc func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)
+
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDef
+}
+
+type FieldDef struct {
+	Type    *Object
+	Args    []string
+	Resolve ResolveFunc
+}
+
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+}
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+type orderedMap []orderedField
+
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type execution struct {
+	fragments map[string]*Fragment
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	op := doc.Operation(req.OperationName)
+	if op == nil {
+		return &Response{Errors: []*Error{{Message: errors.ErrGraphQLOperation.Error()}}}
+	}
+	root := s.Query
+	if op.Type == OperationMutation {
+		root = s.Mutation
+	}
+	if root == nil {
+		return &Response{Errors: []*Error{{Message: errors.ErrGraphQLOperation.Error(), Locations: []Location{op.Location}}}}
+	}
+
+	e := &execution{fragments: doc.Fragments, variables: make(map[string]interface{})}
+	for _, def := range op.Variables {
+		value, ok := req.Variables[def.Name]
+		if !ok {
+			value, ok = def.Default, def.Default != nil
+		}
+		if (!ok || value == nil) && def.NonNull {
+			e.fail(errors.ErrGraphQLVariable, def.Location, nil)
+			continue
+		}
+		e.variables[def.Name] = value
+	}
+	if len(e.errors) > 0 {
+		return &Response{Errors: e.errors}
+	}
+	e.validate(root, op.Selections, map[string]bool{})
+	if len(e.errors) > 0 {
+		return &Response{Errors: e.errors}
+	}
+
+	data := e.executeSelections(ctx, root, nil, op.Selections, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func (doc *Document) Operation(name string) *Operation {
+	if name == "" {
+		if len(doc.Operations) == 1 {
+			return doc.Operations[0]
+		}
+		return nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op
+		}
+	}
+	return nil
+}
+
+func IsMutation(req Request) bool {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return false
+	}
+	op := doc.Operation(req.OperationName)
+	return op != nil && op.Type == OperationMutation
+}
+
+func asError(err error) *Error {
+	if gqlErr, ok := err.(*Error); ok {
+		return gqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+func (e *execution) fail(err error, loc Location, path []interface{}) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{loc}, Path: path})
+}
+
+func (e *execution) validate(obj *Object, selections []Selection, visiting map[string]bool) {
+	for _, selection := range selections {
+		e.validateDirectives(selection)
+		switch sel := selection.(type) {
+		case *Field:
+			e.validateField(obj, sel, visiting)
+		case *InlineFragment:
+			if sel.TypeCondition != "" && sel.TypeCondition != obj.Name {
+				e.fail(errors.ErrGraphQLFragment, sel.Location, nil)
+				continue
+			}
+			e.validate(obj, sel.Selections, visiting)
+		case *FragmentSpread:
+			fragment, ok := e.fragments[sel.Name]
+			if !ok || visiting[sel.Name] || fragment.TypeCondition != obj.Name {
+				e.fail(errors.ErrGraphQLFragment, sel.Location, nil)
+				continue
+			}
+			visiting[sel.Name] = true
+			e.validate(obj, fragment.Selections, visiting)
+			delete(visiting, sel.Name)
+		}
+	}
+}
+
+func (e *execution) validateField(obj *Object, field *Field, visiting map[string]bool) {
+	if field.Name == typenameField {
+		if len(field.Arguments) > 0 {
+			e.fail(errors.ErrGraphQLUnknownArgument, field.Location, nil)
+		}
+		if len(field.Selections) > 0 {
+			e.fail(errors.ErrGraphQLSelection, field.Location, nil)
+		}
+		return
+	}
+	def, ok := obj.Fields[field.Name]
+	if !ok {
+		e.fail(errors.ErrGraphQLUnknownField, field.Location, nil)
+		return
+	}
+	for name, value := range field.Arguments {
+		if !contains(def.Args, name) {
+			e.fail(errors.ErrGraphQLUnknownArgument, field.Location, nil)
+			continue
+		}
+		e.validateValue(value, field.Location)
+	}
+	switch {
+	case def.Type == nil && len(field.Selections) > 0, def.Type != nil && len(field.Selections) == 0:
+		e.fail(errors.ErrGraphQLSelection, field.Location, nil)
+	case def.Type != nil:
+		e.validate(def.Type, field.Selections, visiting)
+	}
+}
+
+func (e *execution) validateDirectives(selection Selection) {
+	for _, directive := range directivesOf(selection) {
+		if directive.Name != "include" && directive.Name != "skip" {
+			continue
+		}
+		value, ok := directive.Arguments["if"]
+		if !ok || len(directive.Arguments) != 1 {
+			e.fail(errors.ErrGraphQLArgument, selection.location(), nil)
+			continue
+		}
+		e.validateValue(value, selection.location())
+		if _, ok := e.resolveValue(value).(bool); !ok {
+			e.fail(errors.ErrGraphQLArgument, selection.location(), nil)
+		}
+	}
+}
+
+func (e *execution) validateValue(value Value, loc Location) {
+	switch v := value.(type) {
+	case Variable:
+		if _, ok := e.variables[v.Name]; !ok {
+			e.fail(errors.ErrGraphQLVariable, loc, nil)
+		}
+	case []Value:
+		for _, item := range v {
+			e.validateValue(item, loc)
+		}
+	case map[string]Value:
+		for _, item := range v {
+			e.validateValue(item, loc)
+		}
+	}
+}
+
+func directivesOf(selection Selection) []*Directive {
+	switch sel := selection.(type) {
+	case *Field:
+		return sel.Directives
+	case *InlineFragment:
+		return sel.Directives
+	case *FragmentSpread:
+		return sel.Directives
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *execution) included(selection Selection) bool {
+	for _, directive := range directivesOf(selection) {
+		condition, _ := e.resolveValue(directive.Arguments["if"]).(bool)
+		switch directive.Name {
+		case "include":
+			if !condition {
+				return false
+			}
+		case "skip":
+			if condition {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+func (e *execution) collectFields(selections []Selection, groups []*fieldGroup, visited map[string]bool) []*fieldGroup {
+	for _, selection := range selections {
+		if !e.included(selection) {
+			continue
+		}
+		switch sel := selection.(type) {
+		case *Field:
+			key := sel.ResponseKey()
+			found := false
+			for _, group := range groups {
+				if group.key == key {
+					group.fields = append(group.fields, sel)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*Field{sel}})
+			}
+		case *InlineFragment:
+			groups = e.collectFields(sel.Selections, groups, visited)
+		case *FragmentSpread:
+			if visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			groups = e.collectFields(e.fragments[sel.Name].Selections, groups, visited)
+		}
+	}
+	return groups
+}
+
+func (e *execution) executeSelections(ctx context.Context, obj *Object, source interface{}, selections []Selection, path []interface{}) orderedMap {
+	groups := e.collectFields(selections, nil, map[string]bool{})
+	result := make(orderedMap, 0, len(groups))
+	for _, group := range groups {
+		field := group.fields[0]
+		fieldPath := append(append([]interface{}{}, path...), group.key)
+		if field.Name == typenameField {
+			result = append(result, orderedField{key: group.key, value: obj.Name})
+			continue
+		}
+		def := obj.Fields[field.Name]
+		args := make(map[string]interface{}, len(field.Arguments))
+		for name, value := range field.Arguments {
+			args[name] = e.resolveValue(value)
+		}
+		value, err := def.Resolve(ctx, source, args)
+		if err != nil {
+			e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{field.Location}, Path: fieldPath})
+			result = append(result, orderedField{key: group.key})
+			continue
+		}
+		var subSelections []Selection
+		for _, f := range group.fields {
+			subSelections = append(subSelections, f.Selections...)
+		}
+		result = append(result, orderedField{key: group.key, value: e.completeValue(ctx, def.Type, value, subSelections, fieldPath)})
+	}
+	return result
+}
+
+func (e *execution) completeValue(ctx context.Context, obj *Object, value interface{}, selections []Selection, path []interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.IsNil() {
+		return nil
+	}
+	if obj == nil {
+		return value
+	}
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			itemPath := append(append([]interface{}{}, path...), i)
+			list[i] = e.completeValue(ctx, obj, rv.Index(i).Interface(), selections, itemPath)
+		}
+		return list
+	}
+	return e.executeSelections(ctx, obj, value, selections, path)
+}
+
+func (e *execution) resolveValue(value Value) interface{} {
+	switch v := value.(type) {
+	case Variable:
+		return e.variables[v.Name]
+	case Enum:
+		return string(v)
+	case []Value:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = e.resolveValue(item)
+		}
+		return list
+	case map[string]Value:
+		object := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			object[name] = e.resolveValue(item)
+		}
+		return object
+	}
+	return value
+}
+
+func String(args map[string]interface{}, name string) (*string, error) {
+	value, ok := args[name]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.ErrGraphQLArgument
+	}
+	return &s, nil
+}
+
+func Int(args map[string]interface{}, name string) (*int, error) {
+	value, ok := args[name]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	switch n := value.(type) {
+	case int:
+		return &n, nil
+	case float64:
+		if n == math.Trunc(n) && math.Abs(n) <= math.MaxInt32 {
+			i := int(n)
+			return &i, nil
+		}
+	}
+	return nil, errors.ErrGraphQLArgument
+}
+
+func Bool(args map[string]interface{}, name string) (*bool, error) {
+	value, ok := args[name]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return nil, errors.ErrGraphQLArgument
+	}
+	return &b, nil
+}
@@ -0,0 +1,225 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+
+	"project/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID   int
+	Name string
+}
+
+func testSchema() *Schema {
+	item := &Object{Name: "Item", Fields: map[string]*FieldDef{
+		"id": {Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return source.(*testItem).ID, nil
+		}},
+		"name": {Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return source.(*testItem).Name, nil
+		}},
+		"broken": {Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return nil, stderrors.New("broken field")
+		}},
+	}}
+	items := []*testItem{{ID: 1, Name: "first"}, {ID: 2, Name: "second"}, {ID: 3, Name: "third"}}
+
+	query := &Object{Name: "Query", Fields: map[string]*FieldDef{
+		"items": {Type: item, Args: []string{"limit"}, Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			limit, err := Int(args, "limit")
+			if err != nil {
+				return nil, err
+			}
+			if limit != nil && *limit < len(items) {
+				return items[:*limit], nil
+			}
+			return items, nil
+		}},
+		"item": {Type: item, Args: []string{"id"}, Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			id, err := Int(args, "id")
+			if err != nil || id == nil {
+				return nil, errors.ErrGraphQLArgument
+			}
+			for _, it := range items {
+				if it.ID == *id {
+					return it, nil
+				}
+			}
+			return (*testItem)(nil), nil
+		}},
+	}}
+
+	mutation := &Object{Name: "Mutation", Fields: map[string]*FieldDef{
+		"rename": {Type: item, Args: []string{"id", "name"}, Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			name, err := String(args, "name")
+			if err != nil || name == nil {
+				return nil, errors.ErrGraphQLArgument
+			}
+			items[0].Name = *name
+			return items[0], nil
+		}},
+	}}
+
+	return &Schema{Query: query, Mutation: mutation}
+}
+
+func executeJSON(t *testing.T, schema *Schema, req Request) string {
+	body, err := json.Marshal(schema.Execute(context.Background(), req))
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      Request
+		expected string
+	}{
+		{
+			name:     "ordered fields and aliases",
+			req:      Request{Query: `{ items(limit: 2) { name key: id __typename } }`},
+			expected: `{"data":{"items":[{"name":"first","key":1,"__typename":"Item"},{"name":"second","key":2,"__typename":"Item"}]}}`,
+		},
+		{
+			name:     "variables from json",
+			req:      Request{Query: `query ($id: Int!) { item(id: $id) { name } }`, Variables: map[string]interface{}{"id": float64(3)}},
+			expected: `{"data":{"item":{"name":"third"}}}`,
+		},
+		{
+			name:     "variable default",
+			req:      Request{Query: `query ($limit: Int = 1) { items(limit: $limit) { id } }`},
+			expected: `{"data":{"items":[{"id":1}]}}`,
+		},
+		{
+			name:     "nil object",
+			req:      Request{Query: `{ item(id: 9) { id } }`},
+			expected: `{"data":{"item":null}}`,
+		},
+		{
+			name:     "fragments and directives",
+			req:      Request{Query: `query ($skip: Boolean!) { item(id: 1) { ...f ... on Item { name @skip(if: $skip) } } } fragment f on Item { id }`, Variables: map[string]interface{}{"skip": true}},
+			expected: `{"data":{"item":{"id":1}}}`,
+		},
+		{
+			name:     "named operation",
+			req:      Request{Query: `query A { item(id: 1) { id } } query B { item(id: 2) { id } }`, OperationName: "B"},
+			expected: `{"data":{"item":{"id":2}}}`,
+		},
+		{
+			name:     "resolver error keeps other fields",
+			req:      Request{Query: `{ item(id: 1) { id broken } }`},
+			expected: `{"data":{"item":{"id":1,"broken":null}},"errors":[{"message":"broken field","locations":[{"line":1,"column":20}],"path":["item","broken"]}]}`,
+		},
+		{
+			name:     "mutation",
+			req:      Request{Query: `mutation { rename(id: 1, name: "renamed") { id name } }`},
+			expected: `{"data":{"rename":{"id":1,"name":"renamed"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.JSONEq(t, tt.expected, executeJSON(t, testSchema(), tt.req))
+		})
+	}
+}
+
+func TestExecuteFieldOrder(t *testing.T) {
+	body := executeJSON(t, testSchema(), Request{Query: `{ item(id: 2) { name id } }`})
+	assert.Equal(t, `{"data":{"item":{"name":"second","id":2}}}`, body)
+}
+
+func TestExecuteValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      Request
+		expected error
+	}{
+		{name: "syntax error", req: Request{Query: `{ items {`}, expected: errors.ErrGraphQLSyntax},
+		{name: "unknown field", req: Request{Query: `{ items { password } }`}, expected: errors.ErrGraphQLUnknownField},
+		{name: "unknown argument", req: Request{Query: `{ items(owner: 1) { id } }`}, expected: errors.ErrGraphQLUnknownArgument},
+		{name: "object without selection", req: Request{Query: `{ items }`}, expected: errors.ErrGraphQLSelection},
+		{name: "scalar with selection", req: Request{Query: `{ items { id { value } } }`}, expected: errors.ErrGraphQLSelection},
+		{name: "unknown fragment", req: Request{Query: `{ items { ...missing } }`}, expected: errors.ErrGraphQLFragment},
+		{name: "fragment cycle", req: Request{Query: `{ items { ...a } } fragment a on Item { ...b } fragment b on Item { ...a }`}, expected: errors.ErrGraphQLFragment},
+		{name: "wrong type condition", req: Request{Query: `{ items { ... on User { id } } }`}, expected: errors.ErrGraphQLFragment},
+		{name: "undefined variable", req: Request{Query: `{ item(id: $id) { id } }`}, expected: errors.ErrGraphQLVariable},
+		{name: "missing required variable", req: Request{Query: `query ($id: Int!) { item(id: $id) { id } }`}, expected: errors.ErrGraphQLVariable},
+		{name: "ambiguous operation", req: Request{Query: `query A { items { id } } query B { items { id } }`}, expected: errors.ErrGraphQLOperation},
+		{name: "missing mutation root", req: Request{Query: `mutation { rename(id: 1, name: "x") { id } }`}, expected: errors.ErrGraphQLOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := testSchema()
+			if tt.name == "missing mutation root" {
+				schema.Mutation = nil
+			}
+			resp := schema.Execute(context.Background(), tt.req)
+			assert.Nil(t, resp.Data)
+			require.NotEmpty(t, resp.Errors)
+			assert.Equal(t, tt.expected.Error(), resp.Errors[0].Message)
+		})
+	}
+}
+
+func TestArgumentHelpers(t *testing.T) {
+	args := map[string]interface{}{"int": 5, "float": float64(7), "fraction": 1.5, "text": "value", "flag": true, "null": nil}
+
+	n, err := Int(args, "int")
+	require.NoError(t, err)
+	assert.Equal(t, 5, *n)
+
+	n, err = Int(args, "float")
+	require.NoError(t, err)
+	assert.Equal(t, 7, *n)
+
+	_, err = Int(args, "fraction")
+	assert.ErrorIs(t, err, errors.ErrGraphQLArgument)
+
+	n, err = Int(args, "null")
+	require.NoError(t, err)
+	assert.Nil(t, n)
+
+	s, err := String(args, "text")
+	require.NoError(t, err)
+	assert.Equal(t, "value", *s)
+
+	_, err = String(args, "int")
+	assert.ErrorIs(t, err, errors.ErrGraphQLArgument)
+
+	b, err := Bool(args, "flag")
+	require.NoError(t, err)
+	assert.True(t, *b)
+
+	b, err = Bool(args, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, b)
+}
+
+func TestIsMutation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want bool
+	}{
+		{name: "query", req: Request{Query: `{ me { id } }`}, want: false},
+		{name: "mutation", req: Request{Query: `mutation { deleteTask(id: "1") }`}, want: true},
+		{name: "named query among mutations", req: Request{Query: `query A { me { id } } mutation B { deleteTask(id: "1") }`, OperationName: "A"}, want: false},
+		{name: "named mutation", req: Request{Query: `query A { me { id } } mutation B { deleteTask(id: "1") }`, OperationName: "B"}, want: true},
+		{name: "syntax error", req: Request{Query: `mutation {`}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsMutation(tt.req))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"testing"
+
+	"project/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# tasks of the current user
+		query Tasks($status: String = "todo", $limit: Int!) {
+			me { id }
+			list: tasks(status: $status, limit: $limit, sort: TITLE, ids: [1, 2], filter: {deleted: false}) @include(if: true) {
+				...taskFields
+				... on Task { description }
+			}
+		}
+
+		fragment taskFields on Task { id title }
+	`)
+	require.NoError(t, err)
+	require.Len(t, doc.Operations, 1)
+
+	op := doc.Operations[0]
+	assert.Equal(t, OperationQuery, op.Type)
+	assert.Equal(t, "Tasks", op.Name)
+	require.Len(t, op.Variables, 2)
+	assert.Equal(t, "status", op.Variables[0].Name)
+	assert.Equal(t, "todo", op.Variables[0].Default)
+	assert.False(t, op.Variables[0].NonNull)
+	assert.True(t, op.Variables[1].NonNull)
+
+	require.Len(t, op.Selections, 2)
+	list := op.Selections[1].(*Field)
+	assert.Equal(t, "list", list.ResponseKey())
+	assert.Equal(t, "tasks", list.Name)
+	assert.Equal(t, Variable{Name: "status"}, list.Arguments["status"])
+	assert.Equal(t, Enum("TITLE"), list.Arguments["sort"])
+	assert.Equal(t, []Value{1, 2}, list.Arguments["ids"])
+	assert.Equal(t, map[string]Value{"deleted": false}, list.Arguments["filter"])
+	assert.Equal(t, Location{Line: 5, Column: 4}, list.Location)
+	require.Len(t, list.Directives, 1)
+	assert.Equal(t, "include", list.Directives[0].Name)
+
+	require.Len(t, list.Selections, 2)
+	assert.Equal(t, "taskFields", list.Selections[0].(*FragmentSpread).Name)
+	assert.Equal(t, "Task", list.Selections[1].(*InlineFragment).TypeCondition)
+
+	fragment := doc.Fragments["taskFields"]
+	require.NotNil(t, fragment)
+	assert.Equal(t, "Task", fragment.TypeCondition)
+	assert.Len(t, fragment.Selections, 2)
+}
+
+func TestParseValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Value
+	}{
+		{name: "integer", input: `-42`, expected: -42},
+		{name: "float", input: `1.5e2`, expected: 150.0},
+		{name: "string escapes", input: `"a\"b\nж"`, expected: "a\"b\nж"},
+		{name: "unicode string", input: `"задача"`, expected: "задача"},
+		{name: "block string", input: "\"\"\"\n    first\n      second\n\"\"\"", expected: "first\n  second"},
+		{name: "null", input: `null`, expected: nil},
+		{name: "boolean", input: `true`, expected: true},
+		{name: "empty list", input: `[]`, expected: []Value{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(`{ field(value: ` + tt.input + `) }`)
+			require.NoError(t, err)
+			field := doc.Operations[0].Selections[0].(*Field)
+			assert.Equal(t, tt.expected, field.Arguments["value"])
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		location Location
+	}{
+		{name: "empty document", input: ``, location: Location{Line: 1, Column: 1}},
+		{name: "unclosed selection", input: `{ me { id }`, location: Location{Line: 1, Column: 12}},
+		{name: "empty selection", input: `{ }`, location: Location{Line: 1, Column: 3}},
+		{name: "unterminated string", input: `{ task(id: "1) { id } }`, location: Location{Line: 1, Column: 12}},
+		{name: "invalid number", input: `{ task(id: 1x) { id } }`, location: Location{Line: 1, Column: 12}},
+		{name: "duplicate argument", input: `{ task(id: 1, id: 2) { id } }`, location: Location{Line: 1, Column: 15}},
+		{name: "unexpected character", input: "{ me { id } }\n  %", location: Location{Line: 2, Column: 3}},
+		{name: "variable in default value", input: `query ($a: Int = $b) { me { id } }`, location: Location{Line: 1, Column: 18}},
+		{name: "subscription", input: `subscription { me { id } }`, location: Location{Line: 1, Column: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			require.Error(t, err)
+			gqlErr, ok := err.(*Error)
+			require.True(t, ok)
+			assert.Equal(t, errors.ErrGraphQLSyntax.Error(), gqlErr.Message)
+			assert.Equal(t, []Location{tt.location}, gqlErr.Locations)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Job func(ctx context.Context)
+
+type Stats struct {
+	Queued    int   `json:"queued"`
+	Processed int64 `json:"processed"`
+	Rejected  int64 `json:"rejected"`
+}
+
+type Pool struct {
+	name   string
+	queue  chan Job
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.RWMutex
+	closed  bool
+	workers sync.WaitGroup
+	tickers sync.WaitGroup
+	stop    chan struct{}
+	once    sync.Once
+
+	processed atomic.Int64
+	rejected  atomic.Int64
+}
+
+func NewPool(name string, workers, queueSize int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		name:   name,
+		queue:  make(chan Job, queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+	}
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.workers.Done()
+	for job := range p.queue {
+		p.run(job)
+	}
+}
+
+func (p *Pool) run(job Job) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("[ERROR] Паника в фоновой задаче (%s): %v\n%s", p.name, recovered, debug.Stack())
+		}
+		p.processed.Add(1)
+	}()
+	job(p.ctx)
+}
+
+func (p *Pool) Submit(job Job) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		p.rejected.Add(1)
+		return false
+	}
+	select {
+	case p.queue <- job:
+		return true
+	default:
+		p.rejected.Add(1)
+		return false
+	}
+}
+
+func (p *Pool) Every(interval time.Duration, job Job) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+	p.tickers.Add(1)
+	go func() {
+		defer p.tickers.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.Submit(job)
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (p *Pool) Close(ctx context.Context) error {
+	var err error
+	p.once.Do(func() {
+		p.mu.Lock()
+		p.closed = true
+		close(p.stop)
+		p.mu.Unlock()
+		p.tickers.Wait()
+		close(p.queue)
+
+		done := make(chan struct{})
+		go func() {
+			p.workers.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
+			p.cancel()
+			<-done
+		}
+		p.cancel()
+	})
+	return err
+}
+
+func (p *Pool) Stop() {
+	p.cancel()
+	_ = p.Close(context.Background())
+}
+
+func (p *Pool) Stats() Stats {
+	return Stats{Queued: len(p.queue), Processed: p.processed.Load(), Rejected: p.rejected.Load()}
+}
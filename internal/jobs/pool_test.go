@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolSubmit(t *testing.T) {
+	tests := []struct {
+		name      string
+		workers   int
+		queueSize int
+		jobs      int
+		block     bool
+		closed    bool
+		accepted  int
+	}{
+		{
+			name:      "runs every queued job",
+			workers:   2,
+			queueSize: 10,
+			jobs:      10,
+			accepted:  10,
+		},
+		{
+			name:      "rejects when queue is full",
+			workers:   1,
+			queueSize: 2,
+			jobs:      5,
+			block:     true,
+			accepted:  3,
+		},
+		{
+			name:      "rejects after close",
+			workers:   1,
+			queueSize: 2,
+			jobs:      2,
+			closed:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool("test", tt.workers, tt.queueSize)
+			if tt.closed {
+				require.NoError(t, pool.Close(context.Background()))
+			}
+
+			release := make(chan struct{})
+			started := make(chan struct{}, 1)
+			var ran atomic.Int64
+			accepted := 0
+			for i := 0; i < tt.jobs; i++ {
+				job := func(ctx context.Context) { ran.Add(1) }
+				if tt.block && i == 0 {
+					job = func(ctx context.Context) {
+						started <- struct{}{}
+						<-release
+						ran.Add(1)
+					}
+				}
+				if pool.Submit(job) {
+					accepted++
+				}
+				if tt.block && i == 0 {
+					<-started
+				}
+			}
+			close(release)
+
+			require.NoError(t, pool.Close(context.Background()))
+			assert.Equal(t, tt.accepted, accepted)
+			assert.Equal(t, int64(tt.accepted), ran.Load())
+			stats := pool.Stats()
+			assert.Equal(t, 0, stats.Queued)
+			assert.Equal(t, int64(tt.accepted), stats.Processed)
+			assert.Equal(t, int64(tt.jobs-tt.accepted), stats.Rejected)
+		})
+	}
+}
+
+func TestPoolCloseDeadlineCancelsJobs(t *testing.T) {
+	pool := NewPool("test", 1, 1)
+	canceled := make(chan struct{})
+	require.True(t, pool.Submit(func(ctx context.Context) {
+		<-ctx.Done()
+		close(canceled)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pool.Close(ctx), context.DeadlineExceeded)
+	<-canceled
+	assert.NoError(t, pool.Close(context.Background()))
+}
+
+func TestPoolEvery(t *testing.T) {
+	pool := NewPool("test", 1, 1)
+	ticks := make(chan struct{}, 10)
+	pool.Every(time.Millisecond, func(ctx context.Context) {
+		select {
+		case ticks <- struct{}{}:
+		default:
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ticks:
+		case <-time.After(time.Second):
+			t.Fatal("periodic job did not run")
+		}
+	}
+	pool.Stop()
+
+	processed := pool.Stats().Processed
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, processed, pool.Stats().Processed)
+}
+
+func TestPoolRecoversPanics(t *testing.T) {
+	pool := NewPool("test", 1, 2)
+	var ran atomic.Bool
+	require.True(t, pool.Submit(func(ctx context.Context) { panic("boom") }))
+	require.True(t, pool.Submit(func(ctx context.Context) { ran.Store(true) }))
+
+	require.NoError(t, pool.Close(context.Background()))
+	assert.True(t, ran.Load())
+	assert.Equal(t, int64(2), pool.Stats().Processed)
+}
@@ -0,0 +1,12 @@
+//go:build go_json
+
+package jsoncodec
+
+import gojson "github.com/goccy/go-json"
+
+const Name = "go-json"
+
+var (
+	Marshal   = gojson.Marshal
+	Unmarshal = gojson.Unmarshal
+)
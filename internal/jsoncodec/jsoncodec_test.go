@@ -0,0 +1,69 @@
+package jsoncodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"project/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func largeTaskList(n int) []models.Task {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tasks := make([]models.Task, n)
+	for i := range tasks {
+		tasks[i] = models.Task{
+			ID:          fmt.Sprintf("task%d", i),
+			Title:       fmt.Sprintf("Task %d", i),
+			Description: "Описание задачи с <html> & \"кавычками\"",
+			Status:      "in_progress",
+			UserID:      "user123",
+			Tags:        []string{"work", "urgent"},
+			Position:    i,
+			DueDate:     &due,
+		}
+	}
+	return tasks
+}
+
+func TestCodecMatchesEncodingJSON(t *testing.T) {
+	tasks := largeTaskList(3)
+
+	want, err := json.Marshal(tasks)
+	require.NoError(t, err)
+	got, err := Marshal(tasks)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got), Name)
+
+	var decoded []models.Task
+	require.NoError(t, Unmarshal(got, &decoded))
+	assert.Equal(t, tasks, decoded)
+}
+
+func BenchmarkMarshalTasks(b *testing.B) {
+	tasks := largeTaskList(5000)
+
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(tasks); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	if Name == "encoding/json" {
+		return
+	}
+	b.Run(Name, func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := Marshal(tasks); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
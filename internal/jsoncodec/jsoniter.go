@@ -0,0 +1,13 @@
+//go:build jsoniter
+
+package jsoncodec
+
+import jsoniter "github.com/json-iterator/go"
+
+const Name = "jsoniter"
+
+var (
+	codec     = jsoniter.ConfigCompatibleWithStandardLibrary
+	Marshal   = codec.Marshal
+	Unmarshal = codec.Unmarshal
+)
@@ -0,0 +1,12 @@
+//go:build !jsoniter && !go_json
+
+package jsoncodec
+
+import "encoding/json"
+
+const Name = "encoding/json"
+
+var (
+	Marshal   = json.Marshal
+	Unmarshal = json.Unmarshal
+)
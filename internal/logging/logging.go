@@ -0,0 +1,195 @@
+package logging
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+const textTimeLayout = "2006/01/02 15:04:05"
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+var tagLevels = map[string]Level{
+	"DEBUG":   LevelDebug,
+	"INFO":    LevelInfo,
+	"SUCCESS": LevelInfo,
+	"WARN":    LevelWarn,
+	"ERROR":   LevelError,
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+func ParseLevel(name string) (Level, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		name = "warn"
+	}
+	for level, levelName := range levelNames {
+		if levelName == name {
+			return level, true
+		}
+	}
+	return LevelInfo, false
+}
+
+func ValidFormat(format string) bool {
+	return format == FormatText || format == FormatJSON
+}
+
+type Writer struct {
+	mu    sync.Mutex
+	out   io.Writer
+	level atomic.Int32
+	json  atomic.Bool
+	now   func() time.Time
+}
+
+func NewWriter(out io.Writer) *Writer {
+	w := &Writer{out: out, now: time.Now}
+	w.level.Store(int32(LevelInfo))
+	return w
+}
+
+func (w *Writer) SetLevel(level Level) {
+	w.level.Store(int32(level))
+}
+
+func (w *Writer) Level() Level {
+	return Level(w.level.Load())
+}
+
+func (w *Writer) Enabled(level Level) bool {
+	return level >= w.Level()
+}
+
+func (w *Writer) SetFormat(format string) bool {
+	if !ValidFormat(format) {
+		return false
+	}
+	w.json.Store(format == FormatJSON)
+	return true
+}
+
+func (w *Writer) Format() string {
+	if w.json.Load() {
+		return FormatJSON
+	}
+	return FormatText
+}
+
+type entry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Tag       string `json:"tag,omitempty"`
+	Message   string `json:"msg"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func splitTag(line string) (string, Level, string) {
+	if strings.HasPrefix(line, "[") {
+		if end := strings.IndexByte(line, ']'); end > 0 {
+			if level, ok := tagLevels[line[1:end]]; ok {
+				return line[1:end], level, strings.TrimLeft(line[end+1:], " ")
+			}
+		}
+	}
+	return "", LevelInfo, line
+}
+
+func splitRequestID(message string) (string, string) {
+	i := strings.LastIndex(message, " request_id=")
+	if i < 0 || strings.ContainsAny(message[i+len(" request_id="):], " \n") {
+		return message, ""
+	}
+	return message[:i], message[i+len(" request_id="):]
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	tag, level, message := splitTag(line)
+	if !w.Enabled(level) {
+		return len(p), nil
+	}
+
+	now := w.now()
+	var out []byte
+	if w.json.Load() {
+		message, requestID := splitRequestID(message)
+		e := entry{Time: now.Format(time.RFC3339Nano), Level: level.String(), Message: message, RequestID: requestID}
+		if tag == "SUCCESS" {
+			e.Tag = "success"
+		}
+		encoded, err := json.Marshal(e)
+		if err != nil {
+			return 0, err
+		}
+		out = append(encoded, '\n')
+	} else {
+		out = []byte(now.Format(textTimeLayout) + " " + line + "\n")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.out.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+var std = NewWriter(os.Stderr)
+
+func Setup(level, format string) {
+	if l, ok := ParseLevel(level); ok {
+		std.SetLevel(l)
+	}
+	std.SetFormat(format)
+	log.SetFlags(0)
+	log.SetOutput(std)
+}
+
+func SetLevel(level Level) {
+	std.SetLevel(level)
+}
+
+func SetFormat(format string) bool {
+	return std.SetFormat(format)
+}
+
+func CurrentLevel() Level {
+	return std.Level()
+}
+
+func CurrentFormat() string {
+	return std.Format()
+}
+
+func Enabled(level Level) bool {
+	return std.Enabled(level)
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Level
+		ok    bool
+	}{
+		{name: "debug", input: "debug", want: LevelDebug, ok: true},
+		{name: "upper case", input: "ERROR", want: LevelError, ok: true},
+		{name: "warning alias", input: "warning", want: LevelWarn, ok: true},
+		{name: "unknown", input: "verbose", want: LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, ok := ParseLevel(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  Level
+		format string
+		line   string
+		want   string
+	}{
+		{
+			name:   "text keeps line",
+			level:  LevelInfo,
+			format: FormatText,
+			line:   "[SUCCESS] Сервер запущен",
+			want:   "2024/01/02 03:04:05 [SUCCESS] Сервер запущен\n",
+		},
+		{
+			name:   "below level is dropped",
+			level:  LevelWarn,
+			format: FormatText,
+			line:   "[INFO] Кэш прогрет",
+		},
+		{
+			name:   "untagged line is info",
+			level:  LevelError,
+			format: FormatText,
+			line:   "сообщение без уровня",
+		},
+		{
+			name:   "debug passes at debug level",
+			level:  LevelDebug,
+			format: FormatText,
+			line:   "[DEBUG] Запрос к БД выполнен",
+			want:   "2024/01/02 03:04:05 [DEBUG] Запрос к БД выполнен\n",
+		},
+		{
+			name:   "json with request id",
+			level:  LevelInfo,
+			format: FormatJSON,
+			line:   "[ERROR] Ошибка получения задачи: timeout request_id=abc-123",
+			want:   `{"time":"2024-01-02T03:04:05Z","level":"error","msg":"Ошибка получения задачи: timeout","request_id":"abc-123"}` + "\n",
+		},
+		{
+			name:   "json success tag",
+			level:  LevelInfo,
+			format: FormatJSON,
+			line:   "[SUCCESS] Миграции применены",
+			want:   `{"time":"2024-01-02T03:04:05Z","level":"info","tag":"success","msg":"Миграции применены"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewWriter(&out)
+			w.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+			w.SetLevel(tt.level)
+			assert.True(t, w.SetFormat(tt.format))
+
+			logger := log.New(w, "", 0)
+			logger.Println(tt.line)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestWriterRejectsUnknownFormat(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{})
+	assert.False(t, w.SetFormat("xml"))
+	assert.Equal(t, FormatText, w.Format())
+}
@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+var DefaultBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+type CounterVec struct {
+	label  string
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func NewCounterVec(label string) *CounterVec {
+	return &CounterVec{label: label, values: make(map[string]float64)}
+}
+
+func (c *CounterVec) Inc(labelValue string) {
+	c.Add(labelValue, 1)
+}
+
+func (c *CounterVec) Add(labelValue string, delta float64) {
+	c.mu.Lock()
+	c.values[labelValue] += delta
+	c.mu.Unlock()
+}
+
+func (c *CounterVec) snapshot() map[string]float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make(map[string]float64, len(c.values))
+	for k, v := range c.values {
+		values[k] = v
+	}
+	return values
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type HistogramVec struct {
+	label   string
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogram
+}
+
+func NewHistogramVec(label string, buckets []float64) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &HistogramVec{label: label, buckets: sorted, series: make(map[string]*histogram)}
+}
+
+func (h *HistogramVec) Observe(labelValue string, value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	series, ok := h.series[labelValue]
+	if !ok {
+		series = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.series[labelValue] = series
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+type family struct {
+	name  string
+	help  string
+	kind  string
+	write func(w *bufio.Writer, name string)
+}
+
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	r.families[f.name] = f
+	r.mu.Unlock()
+}
+
+func (r *Registry) Counter(name, help string, c *CounterVec) {
+	r.register(family{name: name, help: help, kind: kindCounter, write: func(w *bufio.Writer, name string) {
+		writeValues(w, name, c.label, c.snapshot())
+	}})
+}
+
+func (r *Registry) CounterFunc(name, help, label string, fn func() map[string]float64) {
+	r.register(family{name: name, help: help, kind: kindCounter, write: func(w *bufio.Writer, name string) {
+		writeValues(w, name, label, fn())
+	}})
+}
+
+func (r *Registry) GaugeFunc(name, help, label string, fn func() map[string]float64) {
+	r.register(family{name: name, help: help, kind: kindGauge, write: func(w *bufio.Writer, name string) {
+		writeValues(w, name, label, fn())
+	}})
+}
+
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+func (r *Registry) GaugeVecFunc(name, help string, labelNames []string, fn func() []Sample) {
+	r.register(family{name: name, help: help, kind: kindGauge, write: func(w *bufio.Writer, name string) {
+		samples := fn()
+		sort.Slice(samples, func(i, j int) bool {
+			return strings.Join(samples[i].Labels, "\x00") < strings.Join(samples[j].Labels, "\x00")
+		})
+		for _, sample := range samples {
+			pairs := make([]string, 0, 2*len(labelNames))
+			for i, labelName := range labelNames {
+				if i < len(sample.Labels) {
+					pairs = append(pairs, labelName, sample.Labels[i])
+				}
+			}
+			fmt.Fprintf(w, "%s%s %s\n", name, braced(labels(pairs...)), formatFloat(sample.Value))
+		}
+	}})
+}
+
+func (r *Registry) Histogram(name, help string, h *HistogramVec) {
+	r.register(family{name: name, help: help, kind: kindHistogram, write: func(w *bufio.Writer, name string) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		for _, labelValue := range sortedKeys(h.series) {
+			series := h.series[labelValue]
+			for i, bound := range h.buckets {
+				fmt.Fprintf(w, "%s_bucket{%s} %d\n", name, labels(h.label, labelValue, "le", formatFloat(bound)), series.counts[i])
+			}
+			fmt.Fprintf(w, "%s_bucket{%s} %d\n", name, labels(h.label, labelValue, "le", "+Inf"), series.count)
+			fmt.Fprintf(w, "%s_sum%s %s\n", name, braced(labels(h.label, labelValue)), formatFloat(series.sum))
+			fmt.Fprintf(w, "%s_count%s %d\n", name, braced(labels(h.label, labelValue)), series.count)
+		}
+	}})
+}
+
+func (r *Registry) Write(out io.Writer) error {
+	r.mu.Lock()
+	families := make([]family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	w := bufio.NewWriter(out)
+	for _, f := range families {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+		f.write(w, f.name)
+	}
+	return w.Flush()
+}
+
+func writeValues(w *bufio.Writer, name, label string, values map[string]float64) {
+	for _, labelValue := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s%s %s\n", name, braced(labels(label, labelValue)), formatFloat(values[labelValue]))
+	}
+}
+
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i] == "" {
+			continue
+		}
+		parts = append(parts, pairs[i]+"="+strconv.Quote(pairs[i+1]))
+	}
+	return strings.Join(parts, ",")
+}
+
+func braced(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	registry := NewRegistry()
+	queries := NewCounterVec("statement")
+	queries.Inc("get_task_by_id")
+	queries.Add("get_task_by_id", 2)
+	queries.Inc("create_task")
+	registry.Counter("db_queries_total", "Запросы", queries)
+
+	duration := NewHistogramVec("statement", []float64{0.5, 0.1})
+	duration.Observe("get_task_by_id", 0.05)
+	duration.Observe("get_task_by_id", 0.3)
+	duration.Observe("get_task_by_id", 2)
+	registry.Histogram("db_query_duration_seconds", "Длительность", duration)
+
+	registry.GaugeFunc("db_pool_idle_conns", "Свободные соединения", "pool", func() map[string]float64 {
+		return map[string]float64{"primary": 3}
+	})
+	registry.CounterFunc("purge_runs_total", "Запуски", "", func() map[string]float64 {
+		return map[string]float64{"": 7}
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, registry.Write(&out))
+	assert.Equal(t, `# HELP db_pool_idle_conns Свободные соединения
+# TYPE db_pool_idle_conns gauge
+db_pool_idle_conns{pool="primary"} 3
+# HELP db_queries_total Запросы
+# TYPE db_queries_total counter
+db_queries_total{statement="create_task"} 1
+db_queries_total{statement="get_task_by_id"} 3
+# HELP db_query_duration_seconds Длительность
+# TYPE db_query_duration_seconds histogram
+db_query_duration_seconds_bucket{statement="get_task_by_id",le="0.1"} 1
+db_query_duration_seconds_bucket{statement="get_task_by_id",le="0.5"} 2
+db_query_duration_seconds_bucket{statement="get_task_by_id",le="+Inf"} 3
+db_query_duration_seconds_sum{statement="get_task_by_id"} 2.35
+db_query_duration_seconds_count{statement="get_task_by_id"} 3
+# HELP purge_runs_total Запуски
+# TYPE purge_runs_total counter
+purge_runs_total 7
+`, out.String())
+}
+
+func TestRegistryReplacesFamily(t *testing.T) {
+	registry := NewRegistry()
+	registry.GaugeFunc("up", "Доступность", "", func() map[string]float64 { return map[string]float64{"": 0} })
+	registry.GaugeFunc("up", "Доступность", "", func() map[string]float64 { return map[string]float64{"": 1} })
+
+	var out bytes.Buffer
+	require.NoError(t, registry.Write(&out))
+	assert.Equal(t, "# HELP up Доступность\n# TYPE up gauge\nup 1\n", out.String())
+}
+
+func TestRegistryGaugeVecFunc(t *testing.T) {
+	registry := NewRegistry()
+	registry.GaugeVecFunc("slo_error_ratio", "Доля ошибок", []string{"route", "window"}, func() []Sample {
+		return []Sample{
+			{Labels: []string{"POST /users/login", "5m0s"}, Value: 0.25},
+			{Labels: []string{"GET /tasks", "1h0m0s"}, Value: 0},
+		}
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, registry.Write(&out))
+	assert.Equal(t, `# HELP slo_error_ratio Доля ошибок
+# TYPE slo_error_ratio gauge
+slo_error_ratio{route="GET /tasks",window="1h0m0s"} 0
+slo_error_ratio{route="POST /users/login",window="5m0s"} 0.25
+`, out.String())
+}
@@ -0,0 +1,114 @@
+package purge
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"project/internal/jobs"
+)
+
+type Source interface {
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Stats struct {
+	Runs      int64     `json:"runs"`
+	Purged    int64     `json:"purged"`
+	Failures  int64     `json:"failures"`
+	LastRun   time.Time `json:"last_run"`
+	LastCount int64     `json:"last_count"`
+}
+
+type Worker struct {
+	source    Source
+	interval  time.Duration
+	retention time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	stats Stats
+
+	pool *jobs.Pool
+	once sync.Once
+}
+
+func NewWorker(source Source, interval, retention time.Duration) *Worker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	if retention < 0 {
+		retention = 0
+	}
+	return &Worker{
+		source:    source,
+		interval:  interval,
+		retention: retention,
+		now:       time.Now,
+		pool:      jobs.NewPool("очистка корзины", 1, 1),
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	w.RunOnce(ctx)
+}
+
+func (w *Worker) Start() {
+	w.pool.Submit(w.run)
+	w.pool.Every(w.interval, w.run)
+	log.Printf("[INFO] Очистка корзины запущена, интервал %s, срок хранения %s", w.interval, w.retention)
+}
+
+func (w *Worker) Stop() {
+	w.once.Do(func() {
+		w.pool.Stop()
+		log.Println("[INFO] Очистка корзины остановлена")
+	})
+}
+
+func (w *Worker) Close(ctx context.Context) error {
+	var err error
+	w.once.Do(func() {
+		err = w.pool.Close(ctx)
+		log.Println("[INFO] Очистка корзины остановлена")
+	})
+	return err
+}
+
+func (w *Worker) RunOnce(ctx context.Context) int64 {
+	now := w.now()
+	purged, err := w.source.PurgeDeleted(ctx, now.Add(-w.retention))
+	interrupted := err != nil && ctx.Err() != nil
+
+	w.mu.Lock()
+	w.stats.Runs++
+	w.stats.LastRun = now
+	if err != nil && !interrupted {
+		w.stats.Failures++
+	} else {
+		w.stats.Purged += purged
+		w.stats.LastCount = purged
+	}
+	total := w.stats.Purged
+	w.mu.Unlock()
+
+	if interrupted {
+		log.Printf("[INFO] Очистка корзины прервана, успели удалить задач: %d", purged)
+		return purged
+	}
+	if err != nil {
+		log.Println("[ERROR] Не удалось очистить корзину:", err)
+		return 0
+	}
+	if purged > 0 {
+		log.Printf("[SUCCESS] Из корзины удалено задач: %d (всего %d)", purged, total)
+	}
+	return purged
+}
+
+func (w *Worker) Stats() Stats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stats
+}
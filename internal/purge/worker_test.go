@@ -0,0 +1,132 @@
+package purge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSource struct {
+	purged int64
+	err    error
+	before []time.Time
+}
+
+func (f *fakeSource) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	f.before = append(f.before, before)
+	return f.purged, f.err
+}
+
+type blockingSource struct {
+	started chan struct{}
+}
+
+type slowSource struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *slowSource) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	close(s.started)
+	select {
+	case <-s.release:
+		return 3, nil
+	case <-ctx.Done():
+		return 1, ctx.Err()
+	}
+}
+
+func (b *blockingSource) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	close(b.started)
+	<-ctx.Done()
+	return 2, ctx.Err()
+}
+
+func TestWorkerRunOnce(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		source    *fakeSource
+		want      int64
+		wantStats Stats
+	}{
+		{
+			name:      "purges expired tasks",
+			source:    &fakeSource{purged: 3},
+			want:      3,
+			wantStats: Stats{Runs: 2, Purged: 6, LastRun: now, LastCount: 3},
+		},
+		{
+			name:      "source error",
+			source:    &fakeSource{err: errors.ErrInternalServer},
+			want:      0,
+			wantStats: Stats{Runs: 2, Failures: 2, LastRun: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorker(tt.source, time.Hour, 24*time.Hour)
+			w.now = func() time.Time { return now }
+
+			assert.Equal(t, tt.want, w.RunOnce(context.Background()))
+			w.RunOnce(context.Background())
+
+			assert.Equal(t, now.Add(-24*time.Hour), tt.source.before[0])
+			assert.Equal(t, tt.wantStats, w.Stats())
+		})
+	}
+}
+
+func TestWorkerStartStop(t *testing.T) {
+	source := &fakeSource{purged: 1}
+	w := NewWorker(source, time.Hour, time.Hour)
+	w.Start()
+	w.Stop()
+	w.Stop()
+
+	assert.Equal(t, int64(1), w.Stats().Runs)
+	assert.Equal(t, int64(1), w.Stats().Purged)
+}
+
+func TestWorkerStopInterruptsRun(t *testing.T) {
+	source := &blockingSource{started: make(chan struct{})}
+	w := NewWorker(source, time.Hour, time.Hour)
+	w.Start()
+	<-source.started
+	w.Stop()
+
+	assert.Equal(t, Stats{Runs: 1, Purged: 2, LastRun: w.Stats().LastRun, LastCount: 2}, w.Stats())
+}
+
+func TestWorkerCloseDrainsRun(t *testing.T) {
+	source := &slowSource{started: make(chan struct{}), release: make(chan struct{})}
+	w := NewWorker(source, time.Hour, time.Hour)
+	w.Start()
+	<-source.started
+
+	closed := make(chan error)
+	go func() { closed <- w.Close(context.Background()) }()
+	close(source.release)
+
+	assert.NoError(t, <-closed)
+	assert.Equal(t, int64(3), w.Stats().Purged)
+	assert.NoError(t, w.Close(context.Background()))
+}
+
+func TestWorkerCloseInterruptsAfterDeadline(t *testing.T) {
+	source := &slowSource{started: make(chan struct{}), release: make(chan struct{})}
+	w := NewWorker(source, time.Hour, time.Hour)
+	w.Start()
+	<-source.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, w.Close(ctx), context.DeadlineExceeded)
+	assert.Equal(t, Stats{Runs: 1, Purged: 1, LastRun: w.Stats().LastRun, LastCount: 1}, w.Stats())
+}
@@ -0,0 +1,69 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(ch <-chan Event) (Event, bool) {
+	select {
+	case event, ok := <-ch:
+		return event, ok
+	default:
+		return Event{}, false
+	}
+}
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+	owner, unsubscribeOwner := hub.Subscribe("user1")
+	defer unsubscribeOwner()
+	assignee, unsubscribeAssignee := hub.Subscribe("user2")
+	defer unsubscribeAssignee()
+	other, unsubscribeOther := hub.Subscribe("user3")
+	defer unsubscribeOther()
+
+	event := Event{Name: EventTaskUpdated, TaskID: "task1", UserID: "user1", AssigneeID: "user2"}
+	hub.Publish(event)
+
+	got, ok := receive(owner)
+	assert.True(t, ok)
+	assert.Equal(t, event, got)
+	got, ok = receive(assignee)
+	assert.True(t, ok)
+	assert.Equal(t, event, got)
+	_, ok = receive(other)
+	assert.False(t, ok)
+}
+
+func TestHubDropsEventsForSlowSubscribers(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe("user1")
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		hub.Publish(Event{Name: EventTaskCreated, UserID: "user1"})
+	}
+	assert.Len(t, ch, subscriberBuffer)
+}
+
+func TestHubUnsubscribeAndClose(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe("user1")
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-ch
+	assert.False(t, ok)
+	hub.Publish(Event{Name: EventTaskCreated, UserID: "user1"})
+
+	open, unsubscribeOpen := hub.Subscribe("user1")
+	hub.Close()
+	_, ok = <-open
+	assert.False(t, ok)
+	assert.NotPanics(t, unsubscribeOpen)
+
+	late, _ := hub.Subscribe("user1")
+	_, ok = <-late
+	assert.False(t, ok)
+}
@@ -0,0 +1,120 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+)
+
+const (
+	ChannelLog     = "log"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, r Reminder) error
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) Name() string { return ChannelLog }
+
+func (LogNotifier) Notify(ctx context.Context, r Reminder) error {
+	log.Printf("[INFO] Напоминание: задача %s \"%s\" пользователя %s, срок %s", r.Task.ID, r.Task.Title, r.Task.UserID, r.DueDate().Format(time.RFC3339))
+	return nil
+}
+
+type EmailNotifier struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewEmailNotifier(addr, from, username, password string) *EmailNotifier {
+	return &EmailNotifier{Addr: addr, From: from, Username: username, Password: password, send: smtp.SendMail}
+}
+
+func (n *EmailNotifier) Name() string { return ChannelEmail }
+
+func (n *EmailNotifier) Notify(ctx context.Context, r Reminder) error {
+	if r.Email == "" {
+		return errors.ErrReminderNoRecipient
+	}
+	var auth smtp.Auth
+	if n.Username != "" {
+		host := n.Addr
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+	subject, body := "Напоминание", "Срок задачи \"%s\" истекает %s."
+	if r.Preferences.Locale == models.LocaleEN {
+		subject, body = "Reminder", "Task \"%s\" is due %s."
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n"+body+"\r\n",
+		n.From, r.Email, subject, r.Task.Title, r.Task.Title, r.DueDate().Format("2006-01-02 15:04 MST"))
+	return n.send(n.Addr, auth, n.From, []string{r.Email}, []byte(msg))
+}
+
+type WebhookNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+type webhookPayload struct {
+	TaskID  string    `json:"task_id"`
+	Title   string    `json:"title"`
+	UserID  string    `json:"user_id"`
+	Email   string    `json:"email,omitempty"`
+	DueDate time.Time `json:"due_date"`
+	Locale  string    `json:"locale"`
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *WebhookNotifier) Name() string { return ChannelWebhook }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, r Reminder) error {
+	body, err := json.Marshal(webhookPayload{
+		TaskID:  r.Task.ID,
+		Title:   r.Task.Title,
+		UserID:  r.Task.UserID,
+		Email:   r.Email,
+		DueDate: r.DueDate(),
+		Locale:  r.Preferences.Locale,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", errors.ErrReminderWebhookFailed, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"project/internal/domain/models"
+	"project/internal/jobs"
+)
+
+const defaultWorkers = 4
+
+type Source interface {
+	GetDueReminders(ctx context.Context, now time.Time, defaultOffset time.Duration) ([]models.Task, error)
+	MarkReminded(ctx context.Context, taskID string, at time.Time) error
+}
+
+type UserSource interface {
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+}
+
+type PreferenceSource interface {
+	GetUserPreferences(ctx context.Context, userID string) (*models.UserPreferences, error)
+}
+
+type Reminder struct {
+	Task        models.Task
+	Email       string
+	Preferences models.UserPreferences
+}
+
+func (r Reminder) DueDate() time.Time {
+	return r.Task.DueDate.In(r.Preferences.Location())
+}
+
+type Scheduler struct {
+	source        Source
+	users         UserSource
+	notifiers     []Notifier
+	interval      time.Duration
+	defaultOffset time.Duration
+	now           func() time.Time
+
+	pool *jobs.Pool
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func NewScheduler(source Source, users UserSource, notifiers []Notifier, interval, defaultOffset time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{
+		source:        source,
+		users:         users,
+		notifiers:     notifiers,
+		interval:      interval,
+		defaultOffset: defaultOffset,
+		now:           time.Now,
+		pool:          jobs.NewPool("напоминания", defaultWorkers, defaultWorkers*4),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		s.RunOnce(context.Background())
+		for {
+			select {
+			case <-ticker.C:
+				s.RunOnce(context.Background())
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+	log.Printf("[INFO] Планировщик напоминаний запущен, интервал %s", s.interval)
+}
+
+func (s *Scheduler) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+		_ = s.pool.Close(context.Background())
+		log.Println("[INFO] Планировщик напоминаний остановлен")
+	})
+}
+
+func (s *Scheduler) RunOnce(ctx context.Context) int {
+	now := s.now()
+	tasks, err := s.source.GetDueReminders(ctx, now, s.defaultOffset)
+	if err != nil {
+		log.Println("[ERROR] Не удалось получить задачи для напоминаний:", err)
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	var delivered atomic.Int64
+	for _, task := range tasks {
+		wg.Add(1)
+		job := func(context.Context) {
+			defer wg.Done()
+			if s.remind(ctx, task, now) {
+				delivered.Add(1)
+			}
+		}
+		if !s.pool.Submit(job) {
+			job(ctx)
+		}
+	}
+	wg.Wait()
+
+	sent := int(delivered.Load())
+	if sent > 0 {
+		log.Println("[SUCCESS] Отправлено напоминаний:", sent)
+	}
+	return sent
+}
+
+func (s *Scheduler) remind(ctx context.Context, task models.Task, now time.Time) bool {
+	r := Reminder{Task: task, Preferences: models.DefaultUserPreferences()}
+	if s.users != nil {
+		if user, err := s.users.GetUserByID(ctx, task.UserID); err == nil {
+			r.Email = user.Email
+		}
+		if prefs, ok := s.users.(PreferenceSource); ok {
+			if p, err := prefs.GetUserPreferences(ctx, task.UserID); err == nil {
+				r.Preferences = *p
+			}
+		}
+	}
+
+	notifiers := make([]Notifier, 0, len(s.notifiers))
+	for _, n := range s.notifiers {
+		if r.Preferences.AllowsChannel(n.Name()) {
+			notifiers = append(notifiers, n)
+		}
+	}
+	if len(notifiers) == 0 {
+		log.Println("[INFO] Напоминания отключены в настройках пользователя, задача:", task.ID)
+		if err := s.source.MarkReminded(ctx, task.ID, now); err != nil {
+			log.Println("[ERROR] Не удалось отметить напоминание:", err)
+		}
+		return false
+	}
+
+	delivered := false
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, r); err != nil {
+			log.Printf("[ERROR] Не удалось отправить напоминание через %s для задачи %s: %v", n.Name(), task.ID, err)
+			continue
+		}
+		delivered = true
+	}
+	if !delivered {
+		return false
+	}
+	if err := s.source.MarkReminded(ctx, task.ID, now); err != nil {
+		log.Println("[ERROR] Не удалось отметить напоминание:", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	mu       sync.Mutex
+	tasks    []models.Task
+	err      error
+	marked   []string
+	gotNow   time.Time
+	gotShift time.Duration
+}
+
+func (f *fakeSource) GetDueReminders(ctx context.Context, now time.Time, defaultOffset time.Duration) ([]models.Task, error) {
+	f.gotNow = now
+	f.gotShift = defaultOffset
+	return f.tasks, f.err
+}
+
+func (f *fakeSource) MarkReminded(ctx context.Context, taskID string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.marked = append(f.marked, taskID)
+	return nil
+}
+
+type fakeUsers map[string]*models.User
+
+func (f fakeUsers) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	if u, ok := f[id]; ok {
+		return u, nil
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+type fakePreferenceUsers struct {
+	fakeUsers
+	prefs map[string]models.UserPreferences
+}
+
+func (f fakePreferenceUsers) GetUserPreferences(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	if p, ok := f.prefs[userID]; ok {
+		return &p, nil
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+type fakeNotifier struct {
+	mu   sync.Mutex
+	name string
+	err  error
+	sent []Reminder
+}
+
+func (f *fakeNotifier) Name() string {
+	if f.name != "" {
+		return f.name
+	}
+	return "fake"
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, r Reminder) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, r)
+	return f.err
+}
+
+func (f *fakeNotifier) sorted() []Reminder {
+	sort.Slice(f.sent, func(i, j int) bool { return f.sent[i].Task.ID < f.sent[j].Task.ID })
+	return f.sent
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		sourceErr  error
+		notifyErr  error
+		wantSent   int
+		wantMarked []string
+	}{
+		{
+			name:       "delivered reminders are marked",
+			wantSent:   2,
+			wantMarked: []string{"task1", "task2"},
+		},
+		{
+			name:      "failed delivery is retried later",
+			notifyErr: errors.ErrReminderWebhookFailed,
+			wantSent:  0,
+		},
+		{
+			name:      "source error",
+			sourceErr: errors.ErrInternalServer,
+			wantSent:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeSource{
+				tasks: []models.Task{
+					{ID: "task1", Title: "A", UserID: "user1", DueDate: &due},
+					{ID: "task2", Title: "B", UserID: "user2", DueDate: &due},
+				},
+				err: tt.sourceErr,
+			}
+			notifier := &fakeNotifier{err: tt.notifyErr}
+			users := fakeUsers{"user1": {ID: "user1", Email: "user1@example.com"}}
+			s := NewScheduler(source, users, []Notifier{notifier}, time.Minute, 30*time.Minute)
+			now := due.Add(-10 * time.Minute)
+			s.now = func() time.Time { return now }
+
+			sent := s.RunOnce(context.Background())
+
+			assert.Equal(t, tt.wantSent, sent)
+			assert.ElementsMatch(t, tt.wantMarked, source.marked)
+			assert.Equal(t, now, source.gotNow)
+			assert.Equal(t, 30*time.Minute, source.gotShift)
+			if tt.sourceErr == nil {
+				delivered := notifier.sorted()
+				require.Len(t, delivered, 2)
+				assert.Equal(t, "user1@example.com", delivered[0].Email)
+				assert.Empty(t, delivered[1].Email)
+			}
+		})
+	}
+}
+
+func TestSchedulerRespectsPreferences(t *testing.T) {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := &fakeSource{
+		tasks: []models.Task{
+			{ID: "task1", Title: "A", UserID: "user1", DueDate: &due},
+			{ID: "task2", Title: "B", UserID: "user2", DueDate: &due},
+			{ID: "task3", Title: "C", UserID: "user3", DueDate: &due},
+		},
+	}
+	users := fakePreferenceUsers{
+		fakeUsers: fakeUsers{},
+		prefs: map[string]models.UserPreferences{
+			"user1": {Timezone: "Europe/Moscow", Locale: models.LocaleEN, Notifications: models.NotificationPreferences{Reminders: true, Channels: []string{ChannelWebhook}}},
+			"user2": {Timezone: "UTC", Locale: models.LocaleRU, Notifications: models.NotificationPreferences{Reminders: false}},
+		},
+	}
+	logs := &fakeNotifier{name: ChannelLog}
+	hooks := &fakeNotifier{name: ChannelWebhook}
+	s := NewScheduler(source, users, []Notifier{logs, hooks}, time.Minute, 0)
+
+	sent := s.RunOnce(context.Background())
+
+	assert.Equal(t, 2, sent)
+	assert.ElementsMatch(t, []string{"task1", "task2", "task3"}, source.marked)
+	delivered := hooks.sorted()
+	require.Len(t, delivered, 2)
+	assert.Equal(t, "task1", delivered[0].Task.ID)
+	assert.Equal(t, "Europe/Moscow", delivered[0].DueDate().Location().String())
+	assert.Equal(t, 15, delivered[0].DueDate().Hour())
+	require.Len(t, logs.sent, 1)
+	assert.Equal(t, "task3", logs.sent[0].Task.ID)
+	assert.Equal(t, models.DefaultUserPreferences(), logs.sent[0].Preferences)
+}
+
+func TestSchedulerStartStop(t *testing.T) {
+	source := &fakeSource{}
+	s := NewScheduler(source, nil, []Notifier{LogNotifier{}}, 10*time.Millisecond, 0)
+	s.Start()
+	s.Stop()
+	s.Stop()
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{name: "accepted", statusCode: http.StatusNoContent},
+		{name: "rejected", statusCode: http.StatusBadGateway, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got webhookPayload
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			n := NewWebhookNotifier(srv.URL)
+			err := n.Notify(context.Background(), Reminder{Task: models.Task{ID: "task1", Title: "A", UserID: "user1", DueDate: &due}})
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errors.ErrReminderWebhookFailed)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "task1", got.TaskID)
+			assert.True(t, due.Equal(got.DueDate))
+		})
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	n := NewEmailNotifier("smtp.example.com:587", "noreply@example.com", "", "")
+	var gotTo []string
+	var gotMsg string
+	n.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Equal(t, "smtp.example.com:587", addr)
+		assert.Nil(t, a)
+		gotTo = to
+		gotMsg = string(msg)
+		return nil
+	}
+
+	err := n.Notify(context.Background(), Reminder{Task: models.Task{Title: "Отчет", DueDate: &due}})
+	assert.Equal(t, errors.ErrReminderNoRecipient, err)
+
+	err = n.Notify(context.Background(), Reminder{Task: models.Task{Title: "Отчет", DueDate: &due}, Email: "user@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Subject: Напоминание: Отчет")
+
+	prefs := models.UserPreferences{Timezone: "Asia/Tokyo", Locale: models.LocaleEN}
+	err = n.Notify(context.Background(), Reminder{Task: models.Task{Title: "Report", DueDate: &due}, Email: "user@example.com", Preferences: prefs})
+	assert.NoError(t, err)
+	assert.Contains(t, gotMsg, "Subject: Reminder: Report")
+	assert.Contains(t, gotMsg, "Task \"Report\" is due 2025-01-01 21:00 JST.")
+}
@@ -0,0 +1,62 @@
+package requestid
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+const (
+	Header = "X-Request-ID"
+
+	maxLength = 128
+)
+
+type contextKey struct{}
+
+func New() string {
+	return uuid.NewString()
+}
+
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+func Println(ctx context.Context, v ...interface{}) {
+	if id := FromContext(ctx); id != "" {
+		v = append(v, "request_id="+id)
+	}
+	log.Println(v...)
+}
+
+func Printf(ctx context.Context, format string, v ...interface{}) {
+	if id := FromContext(ctx); id != "" {
+		format += " request_id=%s"
+		v = append(v, id)
+	}
+	log.Printf(format, v...)
+}
@@ -0,0 +1,58 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{name: "uuid", id: "0b8f3a52-5d0e-4c43-9c85-3c1b2f9d8e11", want: true},
+		{name: "trace style", id: "edge-01:req_42.7", want: true},
+		{name: "empty", id: "", want: false},
+		{name: "spaces", id: "req 42", want: false},
+		{name: "newline injection", id: "req\nfake log line", want: false},
+		{name: "too long", id: strings.Repeat("a", maxLength+1), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Valid(tt.id))
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Empty(t, FromContext(context.Background()))
+
+	ctx := NewContext(context.Background(), "req-1")
+	assert.Equal(t, "req-1", FromContext(ctx))
+	assert.True(t, Valid(New()))
+}
+
+func TestLogging(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	ctx := NewContext(context.Background(), "req-1")
+	Println(ctx, "[ERROR] Задача не найдена:", "task1")
+	Printf(ctx, "[INFO] Получено задач: %d", 3)
+	Println(context.Background(), "[INFO] Без запроса")
+
+	assert.Equal(t, "[ERROR] Задача не найдена: task1 request_id=req-1\n[INFO] Получено задач: 3 request_id=req-1\n[INFO] Без запроса\n", out.String())
+}
@@ -0,0 +1,94 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Store interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	CreateTasks(ctx context.Context, tasks []models.Task) error
+}
+
+type Options struct {
+	Users    int
+	Tasks    int
+	Prefix   string
+	Password string
+}
+
+type Result struct {
+	Users   int
+	Skipped int
+	Tasks   int
+}
+
+var statuses = []string{models.StatusNew, models.StatusInProgress, models.StatusDone}
+
+func DemoTasks(userID string, count int, now time.Time) []models.Task {
+	tasks := make([]models.Task, 0, count)
+	for i := 0; i < count; i++ {
+		task := models.Task{
+			Title:       fmt.Sprintf("Демо-задача %d", i+1),
+			Description: "Создано командой seed для локальной разработки",
+			Status:      statuses[i%len(statuses)],
+			UserID:      userID,
+		}
+		if i%4 == 0 {
+			due := now.Add(time.Duration(i-count/2) * 24 * time.Hour).Truncate(time.Hour)
+			task.DueDate = &due
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func Run(ctx context.Context, store Store, opts Options) (Result, error) {
+	var res Result
+	if opts.Users < 0 || opts.Tasks < 0 || opts.Prefix == "" {
+		return res, errors.ErrConfigInvalidFormat
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(opts.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return res, err
+	}
+
+	now := time.Now()
+	for i := 1; i <= opts.Users; i++ {
+		username := fmt.Sprintf("%s%d", opts.Prefix, i)
+		user := &models.User{
+			ID:       uuid.New().String(),
+			Username: username,
+			Email:    username + "@example.com",
+			Password: string(hash),
+			Role:     models.RoleUser,
+		}
+		if err := store.CreateUser(ctx, user); err != nil {
+			if err == errors.ErrUserAlreadyExists {
+				log.Println("[WARN] Пользователь уже существует, пропускаем:", username)
+				res.Skipped++
+				continue
+			}
+			return res, err
+		}
+		res.Users++
+
+		if opts.Tasks == 0 {
+			continue
+		}
+		batch := DemoTasks(user.ID, opts.Tasks, now)
+		if err := store.CreateTasks(ctx, batch); err != nil {
+			return res, err
+		}
+		res.Tasks += len(batch)
+	}
+	return res, nil
+}
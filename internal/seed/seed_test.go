@@ -0,0 +1,69 @@
+package seed
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	inmemory "project/repository/inmemory"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		want    Result
+		wantErr error
+	}{
+		{
+			name: "users with tasks",
+			opts: Options{Users: 3, Tasks: 5, Prefix: "demo", Password: "password123"},
+			want: Result{Users: 3, Tasks: 15},
+		},
+		{
+			name: "users without tasks",
+			opts: Options{Users: 2, Prefix: "demo", Password: "password123"},
+			want: Result{Users: 2},
+		},
+		{
+			name:    "negative count",
+			opts:    Options{Users: -1, Prefix: "demo", Password: "password123"},
+			wantErr: errors.ErrConfigInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := inmemory.NewStorage()
+			got, err := Run(context.Background(), storage, tt.opts)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSeedCreatesUsableAccounts(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.NewStorage()
+	opts := Options{Users: 2, Tasks: 4, Prefix: "demo", Password: "password123"}
+
+	_, err := Run(ctx, storage, opts)
+	require.NoError(t, err)
+
+	user, err := storage.GetUserByUsername(ctx, "demo2")
+	require.NoError(t, err)
+	assert.Equal(t, "demo2@example.com", user.Email)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("password123")))
+
+	tasks, err := storage.GetTasks(ctx, user.ID, models.TaskFilter{})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 4)
+
+	again, err := Run(ctx, storage, opts)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Skipped: 2}, again)
+}
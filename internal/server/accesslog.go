@@ -0,0 +1,169 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"project/internal/jsoncodec"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	accessLogCommon = "common"
+	accessLogJSON   = "json"
+	accessLogOff    = "off"
+
+	commonLogTime = "02/Jan/2006:15:04:05 -0700"
+)
+
+func isAccessLogFormat(format string) bool {
+	return format == accessLogCommon || format == accessLogJSON || format == accessLogOff
+}
+
+type accessLogEntry struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Query     string    `json:"query,omitempty"`
+	Proto     string    `json:"proto"`
+	Status    int       `json:"status"`
+	LatencyMS float64   `json:"latency_ms"`
+	Bytes     int       `json:"bytes"`
+	UserID    string    `json:"user_id,omitempty"`
+	IP        string    `json:"ip"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+type accessLogger struct {
+	format     string
+	sampleRate float64
+	exclude    []string
+	sample     func() float64
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func newAccessLogger(cfg *Config) *accessLogger {
+	format := cfg.AccessLogFormat
+	if !isAccessLogFormat(format) {
+		format = accessLogCommon
+	}
+	sampleRate := cfg.AccessLogSampleRate
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1
+	}
+	var exclude []string
+	for _, path := range strings.Split(cfg.AccessLogExclude, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			exclude = append(exclude, path)
+		}
+	}
+	return &accessLogger{format: format, sampleRate: sampleRate, exclude: exclude, sample: rand.Float64, out: gin.DefaultWriter}
+}
+
+func (l *accessLogger) excluded(path string) bool {
+	for _, pattern := range l.exclude {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *accessLogger) sampled(status int) bool {
+	return status >= 500 || l.sampleRate >= 1 || l.sample() < l.sampleRate
+}
+
+func (l *accessLogger) write(entry accessLogEntry) {
+	var line string
+	if l.format == accessLogJSON {
+		data, err := jsoncodec.Marshal(entry)
+		if err != nil {
+			return
+		}
+		line = string(data) + "\n"
+	} else {
+		line = commonLogLine(entry)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.out, line)
+}
+
+func commonLogLine(entry accessLogEntry) string {
+	uri := entry.Path
+	if entry.Query != "" {
+		uri += "?" + entry.Query
+	}
+	bytes := "-"
+	if entry.Bytes > 0 {
+		bytes = fmt.Sprint(entry.Bytes)
+	}
+	line := fmt.Sprintf("%s - %s [%s] %q %d %s %.3fms",
+		entry.IP,
+		dashIfEmpty(entry.UserID),
+		entry.Time.Format(commonLogTime),
+		entry.Method+" "+uri+" "+entry.Proto,
+		entry.Status,
+		bytes,
+		entry.LatencyMS,
+	)
+	if entry.RequestID != "" {
+		line += " " + requestIDKey + "=" + entry.RequestID
+	}
+	return line + "\n"
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func (api *TaskAPI) accessLogMiddleware() gin.HandlerFunc {
+	logger := api.accessLog
+	return func(ctx *gin.Context) {
+		if logger.format == accessLogOff || logger.excluded(ctx.Request.URL.Path) {
+			ctx.Next()
+			return
+		}
+		start := time.Now()
+		path, query := ctx.Request.URL.Path, ctx.Request.URL.RawQuery
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		if !logger.sampled(status) {
+			return
+		}
+		userID, _ := api.getUserIDFromJWT(ctx)
+		size := ctx.Writer.Size()
+		if size < 0 {
+			size = 0
+		}
+		logger.write(accessLogEntry{
+			Time:      start,
+			Method:    ctx.Request.Method,
+			Path:      path,
+			Query:     query,
+			Proto:     ctx.Request.Proto,
+			Status:    status,
+			LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			Bytes:     size,
+			UserID:    userID,
+			IP:        ctx.ClientIP(),
+			RequestID: ctx.GetString(requestIDKey),
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/requestid"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveLogged(api *TaskAPI, path string, withToken bool) string {
+	var out bytes.Buffer
+	api.accessLog.out = &out
+
+	req, _ := http.NewRequest("GET", path, nil)
+	req.Header.Set(requestid.Header, "req-1")
+	req.RemoteAddr = "192.0.2.10:5000"
+	if withToken {
+		req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	}
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	return out.String()
+}
+
+func TestAccessLogCommonFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{AccessLogFormat: accessLogCommon})
+
+	line := serveLogged(api, "/livez?verbose=1", true)
+
+	assert.True(t, strings.HasPrefix(line, "192.0.2.10 - user123 ["), line)
+	assert.Contains(t, line, `"GET /livez?verbose=1 HTTP/1.1" 200 15 `)
+	assert.True(t, strings.HasSuffix(line, "ms request_id=req-1\n"), line)
+}
+
+func TestAccessLogJSONFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{AccessLogFormat: accessLogJSON})
+
+	line := serveLogged(api, "/tasks/missing/nowhere", false)
+
+	var entry accessLogEntry
+	require.NoError(t, json.Unmarshal([]byte(line), &entry))
+	assert.Equal(t, "GET", entry.Method)
+	assert.Equal(t, "/tasks/missing/nowhere", entry.Path)
+	assert.Equal(t, http.StatusNotFound, entry.Status)
+	assert.Equal(t, "192.0.2.10", entry.IP)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Empty(t, entry.UserID)
+}
+
+func TestAccessLogFiltering(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		sample float64
+		path   string
+		logged bool
+	}{
+		{name: "excluded path", cfg: Config{AccessLogExclude: "/livez, /metrics"}, path: "/livez", logged: false},
+		{name: "excluded prefix", cfg: Config{AccessLogExclude: "/debug/*"}, path: "/debug/runtime", logged: false},
+		{name: "not excluded", cfg: Config{AccessLogExclude: "/metrics"}, path: "/livez", logged: true},
+		{name: "disabled", cfg: Config{AccessLogFormat: accessLogOff}, path: "/livez", logged: false},
+		{name: "sampled out", cfg: Config{AccessLogSampleRate: 0.1}, sample: 0.5, path: "/livez", logged: false},
+		{name: "sampled in", cfg: Config{AccessLogSampleRate: 0.1}, sample: 0.05, path: "/livez", logged: true},
+		{name: "zero rate logs everything", cfg: Config{}, sample: 0.99, path: "/livez", logged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			cfg := tt.cfg
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &cfg)
+			api.accessLog.sample = func() float64 { return tt.sample }
+
+			line := serveLogged(api, tt.path, false)
+
+			if tt.logged {
+				assert.NotEmpty(t, line)
+			} else {
+				assert.Empty(t, line)
+			}
+		})
+	}
+}
+
+func TestAccessLogAlwaysLogsServerErrors(t *testing.T) {
+	logger := newAccessLogger(&Config{AccessLogSampleRate: 0.01})
+	logger.sample = func() float64 { return 0.9 }
+
+	assert.False(t, logger.sampled(http.StatusOK))
+	assert.True(t, logger.sampled(http.StatusInternalServerError))
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+)
+
+func parseActivityPage(ctx *gin.Context) (time.Time, int, error) {
+	limit := defaultActivityLimit
+	if raw := ctx.Query("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 || value > maxActivityLimit {
+			return time.Time{}, 0, errors.ErrActivityPage
+		}
+		limit = value
+	}
+	var before time.Time
+	if raw := ctx.Query("before"); raw != "" {
+		value, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return time.Time{}, 0, errors.ErrActivityPage
+		}
+		before = value
+	}
+	return before, limit, nil
+}
+
+func taskEventActivity(event models.TaskEvent) models.ActivityItem {
+	item := models.ActivityItem{TaskID: event.TaskID, CreatedAt: event.CreatedAt}
+	switch event.Action {
+	case models.TaskEventCreate:
+		item.Type = models.ActivityTaskCreated
+	case models.TaskEventDelete:
+		item.Type = models.ActivityTaskDeleted
+	default:
+		item.Type = models.ActivityTaskUpdated
+		if event.NewValue != nil && event.NewValue.Status == models.StatusDone &&
+			(event.OldValue == nil || event.OldValue.Status != models.StatusDone) {
+			item.Type = models.ActivityTaskCompleted
+		}
+	}
+	if event.NewValue != nil {
+		item.Title = event.NewValue.Title
+	} else if event.OldValue != nil {
+		item.Title = event.OldValue.Title
+	}
+	return item
+}
+
+func loginActivity(record models.LoginRecord) models.ActivityItem {
+	item := models.ActivityItem{
+		Type:      models.ActivityLogin,
+		IP:        record.IP,
+		UserAgent: record.UserAgent,
+		CreatedAt: record.CreatedAt,
+	}
+	if !record.Success {
+		item.Type = models.ActivityLoginFailed
+	}
+	return item
+}
+
+func (api *TaskAPI) getActivity(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	before, limit, err := parseActivityPage(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	events, err := api.storage.GetUserTaskEvents(ctx.Request.Context(), userID, before, limit)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	logins, err := api.storage.GetLoginHistory(ctx.Request.Context(), userID, before, limit)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+
+	items := make([]models.ActivityItem, 0, len(events)+len(logins))
+	for _, event := range events {
+		items = append(items, taskEventActivity(event))
+	}
+	for _, record := range logins {
+		items = append(items, loginActivity(record))
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CreatedAt.After(items[j].CreatedAt) })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	response := gin.H{"activity": items}
+	if len(items) == limit {
+		response["next_before"] = items[len(items)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetActivity(t *testing.T) {
+	base := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	open := &models.Task{ID: "task1", Title: "Write report", Status: models.StatusNew}
+	done := &models.Task{ID: "task1", Title: "Write report", Status: models.StatusDone}
+	events := []models.TaskEvent{
+		{TaskID: "task1", UserID: "user123", Action: models.TaskEventUpdate, OldValue: open, NewValue: done, CreatedAt: base.Add(3 * time.Hour)},
+		{TaskID: "task1", UserID: "user123", Action: models.TaskEventCreate, NewValue: open, CreatedAt: base.Add(time.Hour)},
+	}
+	logins := []models.LoginRecord{
+		{UserID: "user123", IP: "10.0.0.1", UserAgent: "agent", Success: true, CreatedAt: base.Add(2 * time.Hour)},
+		{UserID: "user123", IP: "10.0.0.2", UserAgent: "agent", Success: false, CreatedAt: base},
+	}
+	before := base.Add(4 * time.Hour)
+
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		mockSetup  func(*MockUserStore, *MockTaskStore)
+		wantTypes  []string
+		wantNext   string
+		want       error
+	}{
+		{
+			name:       "merged feed",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore, tm *MockTaskStore) {
+				tm.On("GetUserTaskEvents", mock.Anything, "user123", time.Time{}, defaultActivityLimit).Return(events, nil)
+				m.On("GetLoginHistory", "user123", time.Time{}, defaultActivityLimit).Return(logins, nil)
+			},
+			wantTypes: []string{models.ActivityTaskCompleted, models.ActivityLogin, models.ActivityTaskCreated, models.ActivityLoginFailed},
+		},
+		{
+			name:       "paginated feed",
+			query:      "?limit=2&before=" + before.Format(time.RFC3339Nano),
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore, tm *MockTaskStore) {
+				tm.On("GetUserTaskEvents", mock.Anything, "user123", before, 2).Return(events, nil)
+				m.On("GetLoginHistory", "user123", before, 2).Return(logins, nil)
+			},
+			wantTypes: []string{models.ActivityTaskCompleted, models.ActivityLogin},
+			wantNext:  base.Add(2 * time.Hour).Format(time.RFC3339Nano),
+		},
+		{
+			name:       "invalid limit",
+			query:      "?limit=0",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore, tm *MockTaskStore) {},
+			want:       errors.ErrActivityPage,
+		},
+		{
+			name:       "invalid cursor",
+			query:      "?before=yesterday",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore, tm *MockTaskStore) {},
+			want:       errors.ErrActivityPage,
+		},
+		{
+			name:       "storage error",
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(m *MockUserStore, tm *MockTaskStore) {
+				tm.On("GetUserTaskEvents", mock.Anything, "user123", time.Time{}, defaultActivityLimit).Return(nil, errors.ErrInternalServer)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockRepo, mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/users/me/activity"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				assert.Contains(t, w.Body.String(), tt.want.Error())
+			}
+			if tt.wantTypes != nil {
+				var response struct {
+					Activity   []models.ActivityItem `json:"activity"`
+					NextBefore string                `json:"next_before"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				types := []string{}
+				for _, item := range response.Activity {
+					types = append(types, item.Type)
+				}
+				assert.Equal(t, tt.wantTypes, types)
+				assert.Equal(t, tt.wantNext, response.NextBefore)
+				assert.Equal(t, "Write report", response.Activity[0].Title)
+			}
+			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetActivityUnauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+	req, _ := http.NewRequest("GET", "/users/me/activity", nil)
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+)
+
+func respondError(ctx *gin.Context, status int, err error) {
+	respondErrorDetails(ctx, status, err, nil)
+}
+
+func respondErrorDetails(ctx *gin.Context, status int, err error, details interface{}) {
+	_ = ctx.Error(err)
+	ctx.AbortWithStatusJSON(status, models.ErrorEnvelope{Error: models.ErrorResponse{
+		Code:    errorCode(err, status),
+		Message: err.Error(),
+		Details: details,
+	}})
+}
+
+func respondValidationError(ctx *gin.Context, err, validationErr error) {
+	respondErrorDetails(ctx, http.StatusBadRequest, err, validationDetails(validationErr))
+}
+
+func errorCode(err error, status int) string {
+	if code := errors.Code(err); code != "" {
+		return code
+	}
+	return statusCode(status)
+}
+
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+func validationDetails(err error) []models.FieldError {
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+	details := make([]models.FieldError, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		details = append(details, models.FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe.Tag(), fe.Param()),
+		})
+	}
+	return details
+}
+
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+type errorWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *errorWriter) Write(data []byte) (int, error) {
+	if w.Status() >= http.StatusBadRequest {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorWriter) WriteString(s string) (int, error) {
+	if w.Status() >= http.StatusBadRequest {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *errorWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+func (api *TaskAPI) errorMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		writer := &errorWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+		if last := ctx.Errors.Last(); last != nil && last.Err == errors.ErrDatabaseUnavailable && writer.Header().Get("Retry-After") == "" {
+			writer.Header().Set("Retry-After", strconv.Itoa(int(api.dbRetryAfter.Seconds())))
+		}
+		if writer.body.Len() == 0 {
+			return
+		}
+
+		body := writer.body.Bytes()
+		if response, ok := api.errorResponse(ctx, body); ok {
+			if encoded, err := json.Marshal(models.ErrorEnvelope{Error: response}); err == nil {
+				body = encoded
+			}
+		}
+		_, _ = writer.ResponseWriter.Write(body)
+	}
+}
+
+func (api *TaskAPI) errorResponse(ctx *gin.Context, body []byte) (models.ErrorResponse, bool) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return models.ErrorResponse{}, false
+	}
+	raw, ok := payload["error"]
+	if !ok || len(payload) != 1 {
+		return models.ErrorResponse{}, false
+	}
+
+	var response models.ErrorResponse
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		response.Message = message
+	} else if err := json.Unmarshal(raw, &response); err != nil || response.Message == "" {
+		return models.ErrorResponse{}, false
+	}
+	if response.Code == "" {
+		if last := ctx.Errors.Last(); last != nil {
+			response.Code = errorCode(last.Err, ctx.Writer.Status())
+		} else {
+			response.Code = statusCode(ctx.Writer.Status())
+		}
+	}
+	if locale := api.requestLocale(ctx); locale != models.LocaleRU {
+		response.Message = errors.Localize(response.Message, locale)
+	}
+	if id := ctx.GetString(requestIDKey); id != "" {
+		response.RequestID = id
+	}
+	return response, true
+}
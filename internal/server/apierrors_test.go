@@ -0,0 +1,188 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   string
+	}{
+		{name: "domain error", err: errors.ErrTaskNotFound, status: http.StatusNotFound, want: "task_not_found"},
+		{name: "wrapped domain error", err: fmt.Errorf("load: %w", errors.ErrWorkspaceNotFound), status: http.StatusNotFound, want: "workspace_not_found"},
+		{name: "alias shares code", err: errors.ErrUserExists, status: http.StatusConflict, want: "user_already_exists"},
+		{name: "unknown error falls back to status", err: fmt.Errorf("boom"), status: http.StatusServiceUnavailable, want: "service_unavailable"},
+		{name: "unknown status", err: fmt.Errorf("boom"), status: 599, want: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorCode(tt.err, tt.status))
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":                  "name",
+		"DueDate":               "due_date",
+		"ParentID":              "parent_id",
+		"ReminderOffsetMinutes": "reminder_offset_minutes",
+		"URLPath":               "url_path",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, snakeCase(in), in)
+	}
+}
+
+func TestErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		handler     gin.HandlerFunc
+		header      string
+		statusCode  int
+		wantCode    string
+		wantMessage string
+		wantDetails bool
+		wantRaw     string
+	}{
+		{
+			name:        "domain error",
+			handler:     func(c *gin.Context) { respondError(c, http.StatusNotFound, errors.ErrTaskNotFound) },
+			statusCode:  http.StatusNotFound,
+			wantCode:    "task_not_found",
+			wantMessage: errors.ErrTaskNotFound.Error(),
+		},
+		{
+			name:        "localized message",
+			handler:     func(c *gin.Context) { respondError(c, http.StatusNotFound, errors.ErrTaskNotFound) },
+			header:      "en-US",
+			statusCode:  http.StatusNotFound,
+			wantCode:    "task_not_found",
+			wantMessage: "task not found",
+		},
+		{
+			name: "validation details",
+			handler: func(c *gin.Context) {
+				var req models.WorkspaceRequest
+				respondValidationError(c, errors.ErrInvalidRequest, requestValidator.ValidateStruct(&req))
+			},
+			statusCode:  http.StatusBadRequest,
+			wantCode:    "invalid_request",
+			wantMessage: errors.ErrInvalidRequest.Error(),
+			wantDetails: true,
+		},
+		{
+			name:        "legacy body is wrapped",
+			handler:     func(c *gin.Context) { c.JSON(http.StatusConflict, gin.H{"error": "конфликт"}) },
+			statusCode:  http.StatusConflict,
+			wantCode:    "conflict",
+			wantMessage: "конфликт",
+		},
+		{
+			name:       "other error bodies pass through",
+			handler:    func(c *gin.Context) { c.JSON(http.StatusServiceUnavailable, gin.H{"status": "down", "error": "x"}) },
+			statusCode: http.StatusServiceUnavailable,
+			wantRaw:    `{"error":"x","status":"down"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			router := gin.New()
+			router.Use(requestIDMiddleware(), api.errorMiddleware())
+			router.GET("/", tt.handler)
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.wantRaw != "" {
+				assert.Equal(t, tt.wantRaw, strings.TrimSpace(w.Body.String()))
+				return
+			}
+			var response models.ErrorEnvelope
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantCode, response.Error.Code)
+			assert.Equal(t, tt.wantMessage, response.Error.Message)
+			assert.NotEmpty(t, response.Error.RequestID)
+			if tt.wantDetails {
+				assert.Contains(t, w.Body.String(), `"details":[{"field":"name","message":"обязательное поле","rule":"required"}]`)
+			} else {
+				assert.NotContains(t, w.Body.String(), "details")
+			}
+		})
+	}
+}
+
+func TestDatabaseUnavailableResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *Config
+		err        error
+		statusCode int
+		retryAfter string
+	}{
+		{
+			name:       "open circuit",
+			cfg:        &Config{DBBreakerCooldown: 30 * time.Second},
+			err:        errors.ErrDatabaseUnavailable,
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "30",
+		},
+		{
+			name:       "default cooldown",
+			cfg:        &Config{},
+			err:        errors.ErrDatabaseUnavailable,
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "10",
+		},
+		{
+			name:       "other storage error",
+			cfg:        &Config{},
+			err:        errors.ErrDatabaseConnection,
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTags", mock.Anything, "user123").Return(nil, tt.err)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, tt.cfg)
+
+			req, _ := http.NewRequest("GET", "/tags", nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) archiveTask(ctx *gin.Context) {
+	api.setTaskArchived(ctx, true)
+}
+
+func (api *TaskAPI) unarchiveTask(ctx *gin.Context) {
+	api.setTaskArchived(ctx, false)
+}
+
+func (api *TaskAPI) setTaskArchived(ctx *gin.Context, archived bool) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadAccessibleTask(ctx, userID, ctx.Param("taskID"), true)
+	if !ok {
+		return
+	}
+	if task.Deleted {
+		respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		return
+	}
+	if archived && task.Status != "done" {
+		respondError(ctx, http.StatusBadRequest, errors.ErrTaskNotDone)
+		return
+	}
+	before := *task
+	if err := api.storage.SetTaskArchived(ctx.Request.Context(), task.ID, archived); err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	task.Archived = archived
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, &before, task)
+	ctx.JSON(http.StatusOK, gin.H{"task": task})
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestArchiveTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		task       *models.Task
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "archive done task",
+			path:       "/tasks/task1/archive",
+			task:       &models.Task{ID: "task1", UserID: "user123", Status: "done"},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("SetTaskArchived", mock.Anything, "task1", true).Return(nil)
+			},
+		},
+		{
+			name:       "archive unfinished task",
+			path:       "/tasks/task1/archive",
+			task:       &models.Task{ID: "task1", UserID: "user123", Status: "in_progress"},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "unarchive task",
+			path:       "/tasks/task1/unarchive",
+			task:       &models.Task{ID: "task1", UserID: "user123", Status: "done", Archived: true},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("SetTaskArchived", mock.Anything, "task1", false).Return(nil)
+			},
+		},
+		{
+			name:       "archive deleted task",
+			path:       "/tasks/task1/archive",
+			task:       &models.Task{ID: "task1", UserID: "user123", Status: "done", Deleted: true},
+			statusCode: http.StatusNotFound,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(tt.task, nil)
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) loadUpdatableTask(ctx *gin.Context, userID, taskID string, statusOnly bool) (*models.Task, bool) {
+	task, err := api.updatableTask(ctx.Request.Context(), userID, taskID, statusOnly)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return nil, false
+	}
+	return task, true
+}
+
+func (api *TaskAPI) updatableTask(ctx context.Context, userID, taskID string, statusOnly bool) (*models.Task, error) {
+	task, err := api.accessibleTask(ctx, userID, taskID, !statusOnly)
+	if err != nil {
+		return nil, err
+	}
+	if task.Deleted {
+		return nil, errors.ErrTaskNotFound
+	}
+	if !statusOnly || task.AssigneeID == userID {
+		return task, nil
+	}
+	allowed, err := api.canAccessTask(ctx, task, userID, true)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	if !allowed {
+		return nil, errors.ErrForbidden
+	}
+	return task, nil
+}
+
+func (api *TaskAPI) loadAssignableTask(ctx *gin.Context, userID string) (*models.Task, bool) {
+	task, ok := api.loadAccessibleTask(ctx, userID, ctx.Param("taskID"), true)
+	if !ok {
+		return nil, false
+	}
+	if task.Deleted {
+		respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		return nil, false
+	}
+	return task, true
+}
+
+func (api *TaskAPI) assignTask(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadAssignableTask(ctx, userID)
+	if !ok {
+		return
+	}
+	var req models.AssignTaskRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	if _, err := api.storage.GetUserByID(ctx.Request.Context(), req.AssigneeID); err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	api.setTaskAssignee(ctx, userID, task, req.AssigneeID)
+}
+
+func (api *TaskAPI) unassignTask(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadAssignableTask(ctx, userID)
+	if !ok {
+		return
+	}
+	api.setTaskAssignee(ctx, userID, task, "")
+}
+
+func (api *TaskAPI) setTaskAssignee(ctx *gin.Context, userID string, task *models.Task, assigneeID string) {
+	before := *task
+	if err := api.storage.AssignTask(ctx.Request.Context(), task.ID, assigneeID); err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	task.AssigneeID = assigneeID
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, &before, task)
+	ctx.JSON(http.StatusOK, gin.H{"task": task})
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testAssigneeID = "7b7c5e9a-1f6e-4f38-9a51-2d4b0c3f8e11"
+
+func TestAssignTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		userID     string
+		statusCode int
+		mockSetup  func(*MockUserStore, *MockTaskStore)
+	}{
+		{
+			name:       "owner assigns task",
+			method:     "POST",
+			body:       `{"assignee_id": "` + testAssigneeID + `"}`,
+			userID:     "user123",
+			statusCode: http.StatusOK,
+			mockSetup: func(repo *MockUserStore, m *MockTaskStore) {
+				repo.On("GetUserByID", testAssigneeID).Return(&models.User{ID: testAssigneeID}, nil)
+				m.On("AssignTask", mock.Anything, "task1", testAssigneeID).Return(nil)
+			},
+		},
+		{
+			name:       "unknown assignee",
+			method:     "POST",
+			body:       `{"assignee_id": "` + testAssigneeID + `"}`,
+			userID:     "user123",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(repo *MockUserStore, m *MockTaskStore) {
+				repo.On("GetUserByID", testAssigneeID).Return(nil, errors.ErrUserNotFound)
+			},
+		},
+		{
+			name:       "invalid assignee id",
+			method:     "POST",
+			body:       `{"assignee_id": "not-a-uuid"}`,
+			userID:     "user123",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(repo *MockUserStore, m *MockTaskStore) {},
+		},
+		{
+			name:       "owner unassigns task",
+			method:     "DELETE",
+			userID:     "user123",
+			statusCode: http.StatusOK,
+			mockSetup: func(repo *MockUserStore, m *MockTaskStore) {
+				m.On("AssignTask", mock.Anything, "task1", "").Return(nil)
+			},
+		},
+		{
+			name:       "assignee cannot reassign",
+			method:     "DELETE",
+			userID:     testAssigneeID,
+			statusCode: http.StatusForbidden,
+			mockSetup: func(repo *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskPermission", mock.Anything, "task1", testAssigneeID).Return("", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123"}, nil)
+			tt.mockSetup(mockRepo, mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest(tt.method, "/tasks/task1/assign", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(tt.userID)})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssigneeUpdatesStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "assignee updates status",
+			method:     "PUT",
+			body:       `{"status": "done"}`,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "assignee patches status",
+			method:     "PATCH",
+			body:       `{"status": "in_progress"}`,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "assignee cannot change title",
+			method:     "PUT",
+			body:       `{"title": "Renamed"}`,
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskPermission", mock.Anything, "task1", testAssigneeID).Return("", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", Status: "new", UserID: "user123", AssigneeID: testAssigneeID}, nil)
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest(tt.method, "/tasks/task1", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(testAssigneeID)})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"project/internal/blob"
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	avatarFormField      = "avatar"
+	avatarVariantOrig    = "original"
+	avatarDefaultSize    = "medium"
+	avatarMaxPixels      = 4096 * 4096
+	avatarFormOverhead   = 1 << 20
+	avatarJPEGQuality    = 90
+	avatarContentTypePNG = "image/png"
+)
+
+var avatarVariants = map[string]int{
+	"small":  64,
+	"medium": 256,
+}
+
+var avatarContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+func newBlobStore(cfg *Config) blob.Store {
+	if cfg.BlobDir != "" {
+		return blob.NewFileStore(cfg.BlobDir)
+	}
+	return blob.NewMemoryStore()
+}
+
+func avatarSizeLimit(cfg *Config) int64 {
+	if cfg.AvatarMaxSize <= 0 {
+		return defaultAvatarMaxSize
+	}
+	return cfg.AvatarMaxSize
+}
+
+func avatarKey(userID, variant string) string {
+	return "avatars/" + userID + "/" + variant
+}
+
+func avatarURLs(userID string) gin.H {
+	urls := gin.H{avatarVariantOrig: "/users/" + userID + "/avatar?size=" + avatarVariantOrig}
+	for variant := range avatarVariants {
+		urls[variant] = "/users/" + userID + "/avatar?size=" + variant
+	}
+	return urls
+}
+
+func resizeSquare(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	offsetX := bounds.Min.X + (bounds.Dx()-side)/2
+	offsetY := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0 := offsetY + y*side/size
+		y1 := offsetY + (y+1)*side/size
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < size; x++ {
+			x0 := offsetX + x*side/size
+			x1 := offsetX + (x+1)*side/size
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+func encodeAvatar(img image.Image, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: avatarJPEGQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), contentType, nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), avatarContentTypePNG, nil
+}
+
+func (api *TaskAPI) readAvatar(ctx *gin.Context) ([]byte, bool) {
+	limit := api.avatarMaxSize + avatarFormOverhead
+	if ctx.Request.ContentLength > limit {
+		respondError(ctx, http.StatusRequestEntityTooLarge, errors.ErrAvatarTooLarge)
+		return nil, false
+	}
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+	file, header, err := ctx.Request.FormFile(avatarFormField)
+	if err != nil {
+		if _, tooLarge := err.(*http.MaxBytesError); tooLarge {
+			respondError(ctx, http.StatusRequestEntityTooLarge, errors.ErrAvatarTooLarge)
+			return nil, false
+		}
+		respondError(ctx, http.StatusBadRequest, errors.ErrBadRequest)
+		return nil, false
+	}
+	defer func() { _ = file.Close() }()
+	if header.Size > api.avatarMaxSize {
+		respondError(ctx, http.StatusRequestEntityTooLarge, errors.ErrAvatarTooLarge)
+		return nil, false
+	}
+	data, err := io.ReadAll(io.LimitReader(file, api.avatarMaxSize+1))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.ErrBadRequest)
+		return nil, false
+	}
+	if int64(len(data)) > api.avatarMaxSize {
+		respondError(ctx, http.StatusRequestEntityTooLarge, errors.ErrAvatarTooLarge)
+		return nil, false
+	}
+	return data, true
+}
+
+func (api *TaskAPI) uploadAvatar(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	data, ok := api.readAvatar(ctx)
+	if !ok {
+		return
+	}
+	contentType := http.DetectContentType(data)
+	if !avatarContentTypes[contentType] {
+		respondError(ctx, http.StatusBadRequest, errors.ErrAvatarType)
+		return
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.ErrAvatarType)
+		return
+	}
+	if config.Width*config.Height > avatarMaxPixels {
+		respondError(ctx, http.StatusRequestEntityTooLarge, errors.ErrAvatarTooLarge)
+		return
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.ErrAvatarType)
+		return
+	}
+
+	objects := []blob.Object{}
+	for variant, size := range avatarVariants {
+		resized, resizedType, err := encodeAvatar(resizeSquare(img, size), contentType)
+		if err != nil {
+			respondInternalError(ctx, err)
+			return
+		}
+		objects = append(objects, blob.Object{Key: avatarKey(userID, variant), ContentType: resizedType, Data: resized})
+	}
+	objects = append(objects, blob.Object{Key: avatarKey(userID, avatarVariantOrig), ContentType: contentType, Data: data})
+	for _, obj := range objects {
+		if err := api.blobs.Put(ctx.Request.Context(), obj); err != nil {
+			respondInternalError(ctx, err)
+			return
+		}
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"avatar": avatarURLs(userID)})
+}
+
+func (api *TaskAPI) getAvatar(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+	variant := ctx.DefaultQuery("size", avatarDefaultSize)
+	if _, known := avatarVariants[variant]; !known && variant != avatarVariantOrig {
+		respondError(ctx, http.StatusBadRequest, errors.ErrAvatarVariant)
+		return
+	}
+	obj, err := api.blobs.Get(ctx.Request.Context(), avatarKey(userID, variant))
+	if err != nil {
+		if err == errors.ErrBlobNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrAvatarNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.Data(http.StatusOK, obj.ContentType, obj.Data)
+}
+
+func (api *TaskAPI) deleteAvatar(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	if err := api.blobs.Delete(ctx.Request.Context(), avatarKey(userID, avatarVariantOrig)); err != nil {
+		if err == errors.ErrBlobNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrAvatarNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	for variant := range avatarVariants {
+		if err := api.blobs.Delete(ctx.Request.Context(), avatarKey(userID, variant)); err != nil && err != errors.ErrBlobNotFound {
+			respondInternalError(ctx, err)
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "аватар успешно удален"})
+}
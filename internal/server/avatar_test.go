@@ -0,0 +1,172 @@
+package server
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/blob"
+	"project/internal/domain/errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(t *testing.T, width, height int, format string) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	return buf.Bytes()
+}
+
+func avatarRequest(t *testing.T, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(avatarFormField, "avatar")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest("POST", "/users/me/avatar", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	return req
+}
+
+func TestUploadAvatar(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		maxSize    int64
+		statusCode int
+		want       error
+	}{
+		{name: "png", data: testImage(t, 300, 200, "png"), statusCode: http.StatusCreated},
+		{name: "jpeg", data: testImage(t, 40, 80, "jpeg"), statusCode: http.StatusCreated},
+		{name: "not an image", data: []byte("hello, world"), statusCode: http.StatusBadRequest, want: errors.ErrAvatarType},
+		{name: "truncated image", data: testImage(t, 50, 50, "png")[:64], statusCode: http.StatusBadRequest, want: errors.ErrAvatarType},
+		{name: "too large", data: testImage(t, 300, 300, "png"), maxSize: 512, statusCode: http.StatusRequestEntityTooLarge, want: errors.ErrAvatarTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{AvatarMaxSize: tt.maxSize})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, avatarRequest(t, tt.data))
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				assert.Contains(t, w.Body.String(), tt.want.Error())
+			}
+		})
+	}
+}
+
+func TestUploadAvatarUnauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+	req := avatarRequest(t, testImage(t, 10, 10, "png"))
+	req.Header.Del("Cookie")
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAvatarVariants(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+	api.SetBlobStore(blob.NewMemoryStore())
+	original := testImage(t, 300, 200, "png")
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, avatarRequest(t, original))
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "/users/user123/avatar?size=small")
+
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		side       int
+	}{
+		{name: "default medium", query: "", statusCode: http.StatusOK, side: 256},
+		{name: "small", query: "?size=small", statusCode: http.StatusOK, side: 64},
+		{name: "unknown size", query: "?size=huge", statusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/users/user123/avatar"+tt.query, nil)
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.side == 0 {
+				return
+			}
+			assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+			config, err := png.DecodeConfig(w.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.side, config.Width)
+			assert.Equal(t, tt.side, config.Height)
+		})
+	}
+
+	req, _ := http.NewRequest("GET", "/users/user123/avatar?size=original", nil)
+	w = httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, original, w.Body.Bytes())
+
+	req, _ = http.NewRequest("DELETE", "/users/me/avatar", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	w = httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest("DELETE", "/users/me/avatar", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	w = httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("GET", "/users/user123/avatar?size=small", nil)
+	w = httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestResizeSquare(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		src.Set(x, 0, color.RGBA{R: 255, A: 255})
+		src.Set(x, 1, color.RGBA{B: 255, A: 255})
+	}
+
+	dst := resizeSquare(src, 1)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), dst.Bounds())
+	assert.Equal(t, color.RGBA{R: 127, B: 127, A: 255}, dst.RGBAAt(0, 0))
+
+	dst = resizeSquare(src, 4)
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, dst.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, dst.RGBAAt(3, 3))
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) createTasksBatch(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.BatchCreateTasksRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+
+	status, err := api.initialStatus(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	tasks := make([]models.Task, 0, len(req.Tasks))
+	checkedProjects := map[string]bool{}
+	checkedWorkspaces := map[string]bool{}
+	for _, item := range req.Tasks {
+		task := models.Task{
+			Title:       item.Title,
+			Description: item.Description,
+			Status:      status,
+			UserID:      userID,
+			ParentID:    item.ParentID,
+			ProjectID:   item.ProjectID,
+			WorkspaceID: item.WorkspaceID,
+
+			DueDate:               item.DueDate,
+			ReminderOffsetMinutes: item.ReminderOffsetMinutes,
+		}
+		if !api.validateParent(ctx, userID, &task) {
+			return
+		}
+		if !checkedProjects[task.ProjectID] {
+			if !api.validateProject(ctx, userID, task.ProjectID) {
+				return
+			}
+			checkedProjects[task.ProjectID] = true
+		}
+		if !checkedWorkspaces[task.WorkspaceID] {
+			if !api.validateWorkspace(ctx, userID, task.WorkspaceID) {
+				return
+			}
+			checkedWorkspaces[task.WorkspaceID] = true
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := api.storage.CreateTasks(ctx.Request.Context(), tasks); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ids := make([]string, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+		api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventCreate, nil, &tasks[i])
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"ids": ids})
+}
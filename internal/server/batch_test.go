@@ -0,0 +1,112 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTasksBatch(t *testing.T) {
+	tooMany := make([]models.CreateTaskRequest, 501)
+	for i := range tooMany {
+		tooMany[i] = models.CreateTaskRequest{Title: "Task " + strconv.Itoa(i)}
+	}
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		statusCode int
+		wantIDs    []string
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "creates tasks in order",
+			body:       models.BatchCreateTasksRequest{Tasks: []models.CreateTaskRequest{{Title: "First"}, {Title: "Second", ProjectID: "project1"}, {Title: "Third", ProjectID: "project1"}}},
+			statusCode: http.StatusCreated,
+			wantIDs:    []string{"id-First", "id-Second", "id-Third"},
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "project1").Return(&models.Project{ID: "project1", UserID: "user123"}, nil).Once()
+				m.On("CreateTasks", mock.Anything, mock.MatchedBy(func(tasks []models.Task) bool {
+					return len(tasks) == 3 && tasks[0].Title == "First" && tasks[2].ProjectID == "project1" && tasks[1].UserID == "user123" && tasks[1].Status == "new"
+				})).Run(func(args mock.Arguments) {
+					tasks := args.Get(1).([]models.Task)
+					for i := range tasks {
+						tasks[i].ID = "id-" + tasks[i].Title
+					}
+				}).Return(nil)
+			},
+		},
+		{
+			name:       "empty batch",
+			body:       models.BatchCreateTasksRequest{},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "batch too large",
+			body:       models.BatchCreateTasksRequest{Tasks: tooMany},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "invalid item",
+			body:       models.BatchCreateTasksRequest{Tasks: []models.CreateTaskRequest{{Title: "Ok"}, {Title: ""}}},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "foreign parent",
+			body:       models.BatchCreateTasksRequest{Tasks: []models.CreateTaskRequest{{Title: "Child", ParentID: "parent1"}}},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent1").Return(&models.Task{ID: "parent1", UserID: "user456"}, nil)
+			},
+		},
+		{
+			name:       "storage error",
+			body:       models.BatchCreateTasksRequest{Tasks: []models.CreateTaskRequest{{Title: "First"}}},
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateTasks", mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			jsonData, _ := json.Marshal(tt.body)
+			req, _ := http.NewRequest("POST", "/tasks/batch", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.wantIDs != nil {
+				var resp struct {
+					IDs []string `json:"ids"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.wantIDs, resp.IDs)
+				assert.Len(t, mockTaskRepo.events, len(tt.wantIDs))
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
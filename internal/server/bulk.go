@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) checkBulkOperation(ctx context.Context, userID string, op models.BulkOperation) (*models.Task, error) {
+	switch op.Action {
+	case models.BulkActionUpdateStatus, models.BulkActionDelete, models.BulkActionMove:
+	default:
+		return nil, errors.ErrBulkUnknownAction
+	}
+
+	task, err := api.storage.GetTaskByID(ctx, op.TaskID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrTaskNotFound
+		}
+		return nil, errors.ErrInternalServer
+	}
+	if task.Deleted {
+		return nil, errors.ErrTaskNotFound
+	}
+	allowed := op.Action == models.BulkActionUpdateStatus && task.AssigneeID != "" && task.AssigneeID == userID
+	if !allowed {
+		allowed, err = api.canAccessTask(ctx, task, userID, true)
+	}
+	if err != nil {
+		return nil, errors.ErrInternalServer
+	}
+	if !allowed {
+		return nil, errors.ErrForbidden
+	}
+	if op.Action == models.BulkActionUpdateStatus && op.Status != task.Status {
+		if err := api.checkStatusChange(ctx, task, op.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	if op.Action == models.BulkActionMove && op.ProjectID != "" {
+		project, err := api.storage.GetProjectByID(ctx, op.ProjectID)
+		if err != nil {
+			if err == errors.ErrProjectNotFound {
+				return nil, errors.ErrProjectNotFound
+			}
+			return nil, errors.ErrInternalServer
+		}
+		if project.UserID != task.UserID {
+			return nil, errors.ErrForbidden
+		}
+	}
+	return task, nil
+}
+
+func (api *TaskAPI) bulkTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.BulkRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+
+	results := make([]models.BulkResult, len(req.Operations))
+	tasks := make([]*models.Task, len(req.Operations))
+	var ops []models.BulkOperation
+	var positions []int
+	for i, op := range req.Operations {
+		results[i] = models.BulkResult{TaskID: op.TaskID, Action: op.Action}
+		task, err := api.checkBulkOperation(ctx.Request.Context(), userID, op)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		tasks[i] = task
+		ops = append(ops, op)
+		positions = append(positions, i)
+	}
+
+	if len(ops) > 0 {
+		applied, err := api.storage.ApplyBulk(ctx.Request.Context(), ops)
+		if err != nil {
+			respondInternalError(ctx, err)
+			return
+		}
+		for j, result := range applied {
+			results[positions[j]] = result
+		}
+	}
+
+	succeeded := 0
+	for i, result := range results {
+		if !result.Success {
+			continue
+		}
+		succeeded++
+		op := req.Operations[i]
+		switch result.Action {
+		case models.BulkActionDelete:
+			api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventDelete, tasks[i], nil)
+		case models.BulkActionUpdateStatus:
+			after := *tasks[i]
+			after.Status = op.Status
+			api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, tasks[i], &after)
+			if tasks[i].ParentID != "" {
+				api.rollUpStatus(ctx.Request.Context(), tasks[i].ParentID)
+			}
+		case models.BulkActionMove:
+			after := *tasks[i]
+			after.ProjectID = op.ProjectID
+			api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, tasks[i], &after)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTasks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123", Status: "new"}, nil)
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "task2").Return(&models.Task{ID: "task2", UserID: "user123", Status: "new"}, nil)
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "task3").Return(&models.Task{ID: "task3", UserID: "user123", Status: "new"}, nil)
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "foreign").Return(&models.Task{ID: "foreign", UserID: "user456", Status: "new"}, nil)
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "missing").Return(nil, errors.ErrNotFound)
+	mockTaskRepo.On("GetTaskPermission", mock.Anything, "foreign", "user123").Return("", nil)
+	mockTaskRepo.On("GetProjectByID", mock.Anything, "project1").Return(&models.Project{ID: "project1", UserID: "user123"}, nil)
+
+	validOps := []models.BulkOperation{
+		{TaskID: "task1", Action: models.BulkActionUpdateStatus, Status: "done"},
+		{TaskID: "task2", Action: models.BulkActionDelete},
+		{TaskID: "task3", Action: models.BulkActionMove, ProjectID: "project1"},
+	}
+	mockTaskRepo.On("ApplyBulk", mock.Anything, validOps).Return([]models.BulkResult{
+		{TaskID: "task1", Action: models.BulkActionUpdateStatus, Success: true},
+		{TaskID: "task2", Action: models.BulkActionDelete, Success: true},
+		{TaskID: "task3", Action: models.BulkActionMove, Success: true},
+	}, nil)
+
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+	request := models.BulkRequest{Operations: []models.BulkOperation{
+		validOps[0],
+		{TaskID: "foreign", Action: models.BulkActionDelete},
+		validOps[1],
+		{TaskID: "missing", Action: models.BulkActionDelete},
+		{TaskID: "task1", Action: models.BulkActionUpdateStatus, Status: "archived"},
+		{TaskID: "task1", Action: "archive"},
+		validOps[2],
+	}}
+	jsonData, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/tasks/bulk", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Results   []models.BulkResult `json:"results"`
+		Succeeded int                 `json:"succeeded"`
+		Failed    int                 `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 7)
+	assert.Equal(t, 3, resp.Succeeded)
+	assert.Equal(t, 4, resp.Failed)
+	assert.True(t, resp.Results[0].Success)
+	assert.Equal(t, errors.ErrForbidden.Error(), resp.Results[1].Error)
+	assert.True(t, resp.Results[2].Success)
+	assert.Equal(t, errors.ErrTaskNotFound.Error(), resp.Results[3].Error)
+	assert.Equal(t, errors.ErrTaskStatus.Error(), resp.Results[4].Error)
+	assert.Equal(t, errors.ErrBulkUnknownAction.Error(), resp.Results[5].Error)
+	assert.True(t, resp.Results[6].Success)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestBulkTasksValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+	}{
+		{name: "empty operations", body: `{"operations": []}`, statusCode: http.StatusBadRequest},
+		{name: "malformed body", body: `{"operations": "all"}`, statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("POST", "/tasks/bulk", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
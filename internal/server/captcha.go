@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+)
+
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+const (
+	CaptchaProviderRecaptcha = "recaptcha"
+	CaptchaProviderHCaptcha  = "hcaptcha"
+
+	recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+	hcaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+)
+
+type siteVerifyCaptcha struct {
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func NewRecaptchaVerifier(secret string) CaptchaVerifier {
+	return newSiteVerifyCaptcha(recaptchaVerifyURL, secret)
+}
+
+func NewHCaptchaVerifier(secret string) CaptchaVerifier {
+	return newSiteVerifyCaptcha(hcaptchaVerifyURL, secret)
+}
+
+func newSiteVerifyCaptcha(verifyURL, secret string) *siteVerifyCaptcha {
+	return &siteVerifyCaptcha{
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *siteVerifyCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return errors.ErrCaptchaRequired
+	}
+
+	form := url.Values{}
+	form.Set("secret", c.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.ErrCaptchaUnavailable
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.ErrCaptchaUnavailable
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.ErrCaptchaUnavailable
+	}
+
+	var result siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.ErrCaptchaUnavailable
+	}
+	if !result.Success {
+		return errors.ErrCaptchaFailed
+	}
+	return nil
+}
+
+func newCaptchaVerifier(cfg *Config) CaptchaVerifier {
+	switch strings.ToLower(cfg.CaptchaProvider) {
+	case CaptchaProviderRecaptcha:
+		return NewRecaptchaVerifier(cfg.CaptchaSecret)
+	case CaptchaProviderHCaptcha:
+		return NewHCaptchaVerifier(cfg.CaptchaSecret)
+	default:
+		return nil
+	}
+}
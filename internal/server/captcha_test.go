@@ -0,0 +1,180 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type stubCaptchaVerifier struct {
+	err   error
+	token string
+}
+
+func (s *stubCaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	s.token = token
+	return s.err
+}
+
+func TestSiteVerifyCaptcha(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		statusCode int
+		response   string
+		want       error
+	}{
+		{
+			name:       "successful verification",
+			token:      "valid-token",
+			statusCode: http.StatusOK,
+			response:   `{"success": true}`,
+			want:       nil,
+		},
+		{
+			name:       "rejected token",
+			token:      "bad-token",
+			statusCode: http.StatusOK,
+			response:   `{"success": false, "error-codes": ["invalid-input-response"]}`,
+			want:       errors.ErrCaptchaFailed,
+		},
+		{
+			name:  "missing token",
+			token: "",
+			want:  errors.ErrCaptchaRequired,
+		},
+		{
+			name:       "provider error",
+			token:      "valid-token",
+			statusCode: http.StatusInternalServerError,
+			response:   ``,
+			want:       errors.ErrCaptchaUnavailable,
+		},
+		{
+			name:       "malformed provider response",
+			token:      "valid-token",
+			statusCode: http.StatusOK,
+			response:   `not json`,
+			want:       errors.ErrCaptchaUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, r.ParseForm())
+				assert.Equal(t, "secret", r.PostForm.Get("secret"))
+				assert.Equal(t, tt.token, r.PostForm.Get("response"))
+				assert.Equal(t, "10.0.0.1", r.PostForm.Get("remoteip"))
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			verifier := newSiteVerifyCaptcha(srv.URL, "secret")
+			err := verifier.Verify(context.Background(), tt.token, "10.0.0.1")
+
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestNewCaptchaVerifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		wantNil   bool
+		verifyURL string
+	}{
+		{name: "disabled", provider: "", wantNil: true},
+		{name: "unknown provider", provider: "unknown", wantNil: true},
+		{name: "recaptcha", provider: "recaptcha", verifyURL: recaptchaVerifyURL},
+		{name: "hcaptcha", provider: "HCaptcha", verifyURL: hcaptchaVerifyURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := newCaptchaVerifier(&Config{CaptchaProvider: tt.provider, CaptchaSecret: "secret"})
+			if tt.wantNil {
+				assert.Nil(t, verifier)
+				return
+			}
+			sv, ok := verifier.(*siteVerifyCaptcha)
+			assert.True(t, ok)
+			assert.Equal(t, tt.verifyURL, sv.verifyURL)
+			assert.Equal(t, "secret", sv.secret)
+		})
+	}
+}
+
+func TestRegisterWithCaptcha(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifyErr  error
+		statusCode int
+		mockSetup  func(*MockUserStore)
+	}{
+		{
+			name:       "captcha passed",
+			verifyErr:  nil,
+			statusCode: http.StatusCreated,
+			mockSetup: func(mockRepo *MockUserStore) {
+				mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
+				mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+			},
+		},
+		{
+			name:       "captcha failed",
+			verifyErr:  errors.ErrCaptchaFailed,
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(mockRepo *MockUserStore) {},
+		},
+		{
+			name:       "captcha provider unavailable",
+			verifyErr:  errors.ErrCaptchaUnavailable,
+			statusCode: http.StatusServiceUnavailable,
+			mockSetup:  func(mockRepo *MockUserStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockRepo)
+			if tt.statusCode == http.StatusCreated {
+				mockTaskRepo.On("CreateProject", mock.Anything, mock.AnythingOfType("*models.Project")).Return(nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+			verifier := &stubCaptchaVerifier{err: tt.verifyErr}
+			api.SetCaptchaVerifier(verifier)
+
+			jsonData, _ := json.Marshal(models.RegisterRequest{
+				Username:     "testuser",
+				Email:        "test@example.com",
+				Password:     "password123",
+				CaptchaToken: "captcha-token",
+			})
+			req, _ := http.NewRequest("POST", "/users/register", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, "captcha-token", verifier.token)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
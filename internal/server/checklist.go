@@ -0,0 +1,145 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) loadChecklistTask(ctx *gin.Context, needWrite bool) (*models.Task, bool) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return nil, false
+	}
+	task, ok := api.loadAccessibleTask(ctx, userID, ctx.Param("taskID"), needWrite)
+	if !ok {
+		return nil, false
+	}
+	if task.Deleted {
+		respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		return nil, false
+	}
+	return task, true
+}
+
+func checklistProgress(items []models.ChecklistItem) *models.ChecklistProgress {
+	done := 0
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	return models.NewChecklistProgress(len(items), done)
+}
+
+func (api *TaskAPI) respondChecklist(ctx *gin.Context, taskID string) {
+	items, err := api.storage.GetChecklist(ctx.Request.Context(), taskID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"items": items, "progress": checklistProgress(items)})
+}
+
+func (api *TaskAPI) getChecklist(ctx *gin.Context) {
+	task, ok := api.loadChecklistTask(ctx, false)
+	if !ok {
+		return
+	}
+	api.respondChecklist(ctx, task.ID)
+}
+
+func (api *TaskAPI) addChecklistItem(ctx *gin.Context) {
+	task, ok := api.loadChecklistTask(ctx, true)
+	if !ok {
+		return
+	}
+	var req models.ChecklistItemRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	item := models.ChecklistItem{TaskID: task.ID, Title: req.Title}
+	if err := api.storage.AddChecklistItem(ctx.Request.Context(), &item); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"item": item})
+}
+
+func (api *TaskAPI) toggleChecklistItem(ctx *gin.Context) {
+	task, ok := api.loadChecklistTask(ctx, true)
+	if !ok {
+		return
+	}
+	item, err := api.storage.ToggleChecklistItem(ctx.Request.Context(), task.ID, ctx.Param("itemID"))
+	if err != nil {
+		if err == errors.ErrChecklistItemNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrChecklistItemNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"item": item})
+}
+
+func (api *TaskAPI) reorderChecklist(ctx *gin.Context) {
+	task, ok := api.loadChecklistTask(ctx, true)
+	if !ok {
+		return
+	}
+	var req models.ReorderChecklistRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	items, err := api.storage.GetChecklist(ctx.Request.Context(), task.ID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	existing := make(map[string]bool, len(items))
+	for _, item := range items {
+		existing[item.ID] = true
+	}
+	seen := make(map[string]bool, len(req.ItemIDs))
+	for _, id := range req.ItemIDs {
+		if seen[id] || !existing[id] {
+			respondError(ctx, http.StatusBadRequest, errors.ErrChecklistMismatch)
+			return
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(existing) {
+		respondError(ctx, http.StatusBadRequest, errors.ErrChecklistMismatch)
+		return
+	}
+	if err := api.storage.ReorderChecklist(ctx.Request.Context(), task.ID, req.ItemIDs); err != nil {
+		if err == errors.ErrChecklistItemNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrChecklistItemNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	api.respondChecklist(ctx, task.ID)
+}
+
+func (api *TaskAPI) deleteChecklistItem(ctx *gin.Context) {
+	task, ok := api.loadChecklistTask(ctx, true)
+	if !ok {
+		return
+	}
+	if err := api.storage.DeleteChecklistItem(ctx.Request.Context(), task.ID, ctx.Param("itemID")); err != nil {
+		if err == errors.ErrChecklistItemNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrChecklistItemNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "пункт чек-листа успешно удален"})
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChecklistHandlers(t *testing.T) {
+	ownTask := &models.Task{ID: "task1", UserID: "user123", Status: "new"}
+	sharedTask := &models.Task{ID: "task2", UserID: "user456", Status: "new"}
+	items := []models.ChecklistItem{
+		{ID: "item1", TaskID: "task1", Title: "Buy milk", Done: true, Position: 0},
+		{ID: "item2", TaskID: "task1", Title: "Buy bread", Position: 1},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+		check      func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "get checklist with progress",
+			method:     "GET",
+			path:       "/tasks/task1/checklist",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetChecklist", mock.Anything, "task1").Return(items, nil)
+			},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp struct {
+					Items    []models.ChecklistItem   `json:"items"`
+					Progress models.ChecklistProgress `json:"progress"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Len(t, resp.Items, 2)
+				assert.Equal(t, models.ChecklistProgress{Total: 2, Done: 1, Ratio: 0.5}, resp.Progress)
+			},
+		},
+		{
+			name:       "read-only share can view",
+			method:     "GET",
+			path:       "/tasks/task2/checklist",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task2").Return(sharedTask, nil)
+				m.On("GetTaskPermission", mock.Anything, "task2", "user123").Return(PermissionRead, nil)
+				m.On("GetChecklist", mock.Anything, "task2").Return([]models.ChecklistItem{}, nil)
+			},
+		},
+		{
+			name:       "read-only share cannot add",
+			method:     "POST",
+			path:       "/tasks/task2/checklist",
+			body:       models.ChecklistItemRequest{Title: "Nope"},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task2").Return(sharedTask, nil)
+				m.On("GetTaskPermission", mock.Anything, "task2", "user123").Return(PermissionRead, nil)
+			},
+		},
+		{
+			name:       "add item",
+			method:     "POST",
+			path:       "/tasks/task1/checklist",
+			body:       models.ChecklistItemRequest{Title: "Buy eggs"},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("AddChecklistItem", mock.Anything, &models.ChecklistItem{TaskID: "task1", Title: "Buy eggs"}).Return(nil)
+			},
+		},
+		{
+			name:       "add item without title",
+			method:     "POST",
+			path:       "/tasks/task1/checklist",
+			body:       models.ChecklistItemRequest{},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+			},
+		},
+		{
+			name:       "toggle item",
+			method:     "POST",
+			path:       "/tasks/task1/checklist/item2/toggle",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("ToggleChecklistItem", mock.Anything, "task1", "item2").Return(&models.ChecklistItem{ID: "item2", TaskID: "task1", Done: true}, nil)
+			},
+		},
+		{
+			name:       "toggle missing item",
+			method:     "POST",
+			path:       "/tasks/task1/checklist/missing/toggle",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("ToggleChecklistItem", mock.Anything, "task1", "missing").Return(nil, errors.ErrChecklistItemNotFound)
+			},
+		},
+		{
+			name:       "reorder items",
+			method:     "POST",
+			path:       "/tasks/task1/checklist/reorder",
+			body:       models.ReorderChecklistRequest{ItemIDs: []string{"item2", "item1"}},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetChecklist", mock.Anything, "task1").Return(items, nil)
+				m.On("ReorderChecklist", mock.Anything, "task1", []string{"item2", "item1"}).Return(nil)
+			},
+		},
+		{
+			name:       "reorder with missing item",
+			method:     "POST",
+			path:       "/tasks/task1/checklist/reorder",
+			body:       models.ReorderChecklistRequest{ItemIDs: []string{"item2"}},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetChecklist", mock.Anything, "task1").Return(items, nil)
+			},
+		},
+		{
+			name:       "reorder with duplicates",
+			method:     "POST",
+			path:       "/tasks/task1/checklist/reorder",
+			body:       models.ReorderChecklistRequest{ItemIDs: []string{"item1", "item1"}},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetChecklist", mock.Anything, "task1").Return(items, nil)
+			},
+		},
+		{
+			name:       "delete item",
+			method:     "DELETE",
+			path:       "/tasks/task1/checklist/item1",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("DeleteChecklistItem", mock.Anything, "task1", "item1").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.check != nil {
+				tt.check(t, w)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -1,19 +1,141 @@
 package server
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	stderrors "errors"
 	"flag"
 	"fmt"
 	"os"
 	"project/internal/domain/errors"
+	"project/internal/logging"
+	"project/internal/reminder"
+	"project/internal/tracing"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type Config struct {
-	Addr        string
-	Port        int
-	DBStr       string
-	MigratePath string
+	Addr            string
+	UnixSocketMode  string
+	Port            int
+	DBStr           string
+	MigratePath     string
+	CaptchaProvider string
+	CaptchaSecret   string
+	JWTTTL          time.Duration
+	JWTIssuer       string
+	JWTAudience     string
+	JWTClockSkew    time.Duration
+
+	IntrospectionSecret string
+
+	ReminderInterval      time.Duration
+	ReminderDefaultOffset time.Duration
+	ReminderChannels      string
+	ReminderWebhookURL    string
+	SMTPAddr              string
+	SMTPFrom              string
+	SMTPUsername          string
+	SMTPPassword          string
+
+	PurgeInterval  time.Duration
+	PurgeRetention time.Duration
+
+	IdempotencyTTL time.Duration
+
+	BlobDir       string
+	AvatarMaxSize int64
+
+	DBMinConns          int
+	DBMaxConns          int
+	DBHealthCheckPeriod time.Duration
+	DBReplicaStr        string
+
+	DBRetryAttempts       int
+	DBRetryInitialBackoff time.Duration
+	DBRetryMaxBackoff     time.Duration
+
+	DBBreakerThreshold int
+	DBBreakerCooldown  time.Duration
+
+	DBReadTimeout   time.Duration
+	DBWriteTimeout  time.Duration
+	DBQueryTimeouts map[string]time.Duration
+
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	CacheTTL      time.Duration
+
+	ResponseCache     string
+	ResponseCacheSize int
+	ResponseCacheTTL  time.Duration
+
+	UserCacheSize int
+	UserCacheTTL  time.Duration
+
+	ShutdownDrainDelay time.Duration
+
+	SlowRequestThreshold time.Duration
+	DBSlowQueryThreshold time.Duration
+	DBSQLComments        bool
+
+	MetricsLatencyBuckets string
+	SLORoutes             string
+	SLOWindows            string
+
+	LogLevel  string
+	LogFormat string
+
+	AccessLogFormat     string
+	AccessLogSampleRate float64
+	AccessLogExclude    string
+
+	TracingEndpoint    string
+	TracingInsecure    bool
+	TracingSampleRatio float64
+	TracingService     string
+
+	CompressionLevel   int
+	CompressionMinSize int
+	CompressionTypes   string
+
+	AllowedOrigins string
+
+	UIDir      string
+	UIEmbedded bool
+
+	TLSCertFile         string
+	TLSKeyFile          string
+	TLSAutocertHosts    string
+	TLSAutocertCacheDir string
+	TLSAutocertEmail    string
+	TLSRedirectAddr     string
+
+	HTTP2Disabled   bool
+	H2C             bool
+	HTTP2MaxStreams int
+
+	HTTPReadTimeout        time.Duration
+	HTTPReadHeaderTimeout  time.Duration
+	HTTPWriteTimeout       time.Duration
+	HTTPIdleTimeout        time.Duration
+	HTTPMaxHeaderBytes     int
+	HTTPKeepAlivesDisabled bool
+	HTTPTCPKeepAlive       time.Duration
+
+	DebugEndpoints bool
+
+	MaintenanceMode       bool
+	MaintenanceRetryAfter time.Duration
+
+	FeatureFlags map[string]bool
+
+	problems []error
 }
 
 const (
@@ -21,15 +143,94 @@ const (
 	defaultPort        = 8080
 	defaultDBStr       = "postgresql://shouldbeinVaultuser:shouldbeinVaultpassword@db:5432/tasks?sslmode=disable"
 	defaultMigratePath = "migrations"
+	defaultJWTTTL      = time.Hour
+	defaultJWTSkew     = 30 * time.Second
+
+	defaultReminderInterval = time.Minute
+	defaultReminderOffset   = time.Hour
+	defaultReminderChannels = "log"
+
+	defaultPurgeInterval  = time.Hour
+	defaultPurgeRetention = 30 * 24 * time.Hour
+
+	defaultIdempotencyTTL = 24 * time.Hour
+
+	defaultAvatarMaxSize = 2 << 20
+
+	defaultDBMinConns          = 1
+	defaultDBMaxConns          = 10
+	defaultDBHealthCheckPeriod = time.Minute
+
+	defaultDBRetryAttempts       = 3
+	defaultDBRetryInitialBackoff = 50 * time.Millisecond
+	defaultDBRetryMaxBackoff     = time.Second
+
+	defaultDBBreakerThreshold = 5
+	defaultDBBreakerCooldown  = 10 * time.Second
+
+	defaultDBReadTimeout  = 5 * time.Second
+	defaultDBWriteTimeout = 10 * time.Second
+
+	defaultCacheTTL = time.Minute
+
+	defaultResponseCacheSize = 1000
+	defaultResponseCacheTTL  = 30 * time.Second
+
+	defaultUserCacheSize = 10000
+	defaultUserCacheTTL  = 30 * time.Second
+	maxUserCacheTTL      = time.Minute
+
+	defaultShutdownDrainDelay = 5 * time.Second
+
+	defaultSlowRequestThreshold = time.Second
+	defaultDBSlowQueryThreshold = 500 * time.Millisecond
+
+	defaultAccessLogFormat     = accessLogCommon
+	defaultAccessLogSampleRate = 1
+	defaultAccessLogExclude    = "/health,/livez,/readyz,/metrics"
+
+	defaultTracingSampleRatio = 1
+
+	defaultCompressionMinSize = 1024
+	defaultCompressionTypes   = "application/json,application/x-ndjson,application/xml,application/javascript,text/html,text/css,text/plain,text/xml,text/javascript"
+
+	defaultTLSAutocertCacheDir = "certs"
+
+	defaultUnixSocketMode = "0660"
+
+	defaultHTTP2MaxStreams = 250
+
+	defaultHTTPReadTimeout       = time.Minute
+	defaultHTTPReadHeaderTimeout = 30 * time.Second
+	defaultHTTPIdleTimeout       = 2 * time.Minute
+	defaultHTTPMaxHeaderBytes    = 1 << 20
+	defaultHTTPTCPKeepAlive      = 15 * time.Second
+
+	defaultMaintenanceRetryAfter = 2 * time.Minute
+
+	defaultEnvFile = ".env"
 )
 
 var (
-	addr        = flag.String("addr", defaultAddr, "адрес сервера (по умолчанию 0.0.0.0)")
+	addr        = flag.String("addr", defaultAddr, "адрес сервера или unix:/путь/к/сокету (по умолчанию 0.0.0.0)")
 	port        = flag.Int("port", defaultPort, "порт сервера (по умолчанию 8080)")
 	dbstr       = flag.String("dbstr", defaultDBStr, "строка подключения к БД (по умолчанию стандартная)")
 	dbDsn       = flag.String("dbdsn", "", "DSN для подключения к базе данных (приоритетнее dbstr)")
 	migratePath = flag.String("migratepath", defaultMigratePath, "путь к папке с миграциями")
 	configFile  = flag.String("c", "", "путь к файлу конфигурации JSON")
+	captcha     = flag.String("captcha", "", "провайдер captcha при регистрации: recaptcha или hcaptcha")
+	jwtTTL      = flag.Duration("jwtttl", 0, "время жизни access-токена (по умолчанию 1h)")
+	jwtIssuer   = flag.String("jwtissuer", "", "значение iss для выдаваемых и проверяемых токенов")
+	jwtAudience = flag.String("jwtaudience", "", "значение aud для выдаваемых и проверяемых токенов")
+	jwtSkew     = flag.Duration("jwtskew", -1, "допустимое расхождение часов при проверке токена (по умолчанию 30s)")
+	reminders   = flag.String("reminders", "", "каналы напоминаний через запятую: log, email, webhook")
+	purgeAfter  = flag.Duration("purgeretention", -1, "срок хранения удаленных задач в корзине (по умолчанию 720h)")
+	debugRoutes = flag.Bool("debug", false, "включить /debug/pprof и /debug/runtime для администраторов")
+	maintenance = flag.Bool("maintenance", false, "запустить сервис в режиме обслуживания: изменения отклоняются с 503")
+	uiDir       = flag.String("ui", "", "каталог со сборкой веб-интерфейса; API переносится под /api")
+	plainHTTP2  = flag.Bool("h2c", false, "разрешить HTTP/2 без TLS (h2c) для развертываний за доверенным прокси")
+	autocertFor = flag.String("autocert", "", "домены через запятую для автоматического получения сертификатов Let's Encrypt")
+	envFile     = flag.String("env", "", "путь к .env файлу с переменными окружения (по умолчанию .env)")
 	parsed      = false
 )
 
@@ -39,17 +240,97 @@ func ReadConfig() *Config {
 		parsed = true
 	}
 
+	var problems []error
+	if err := loadDotEnv(); err != nil {
+		problems = append(problems, err)
+	}
+
 	cfg := &Config{
-		Addr:        defaultAddr,
-		Port:        defaultPort,
-		DBStr:       defaultDBStr,
-		MigratePath: defaultMigratePath,
+		Addr:         defaultAddr,
+		Port:         defaultPort,
+		DBStr:        defaultDBStr,
+		MigratePath:  defaultMigratePath,
+		JWTTTL:       defaultJWTTTL,
+		JWTClockSkew: defaultJWTSkew,
+
+		ReminderInterval:      defaultReminderInterval,
+		ReminderDefaultOffset: defaultReminderOffset,
+		ReminderChannels:      defaultReminderChannels,
+
+		PurgeInterval:  defaultPurgeInterval,
+		PurgeRetention: defaultPurgeRetention,
+
+		IdempotencyTTL: defaultIdempotencyTTL,
+
+		AvatarMaxSize: defaultAvatarMaxSize,
+
+		DBMinConns:          defaultDBMinConns,
+		DBMaxConns:          defaultDBMaxConns,
+		DBHealthCheckPeriod: defaultDBHealthCheckPeriod,
+
+		DBRetryAttempts:       defaultDBRetryAttempts,
+		DBRetryInitialBackoff: defaultDBRetryInitialBackoff,
+		DBRetryMaxBackoff:     defaultDBRetryMaxBackoff,
+
+		DBBreakerThreshold: defaultDBBreakerThreshold,
+		DBBreakerCooldown:  defaultDBBreakerCooldown,
+
+		DBReadTimeout:  defaultDBReadTimeout,
+		DBWriteTimeout: defaultDBWriteTimeout,
+
+		CacheTTL: defaultCacheTTL,
+
+		ResponseCacheSize: defaultResponseCacheSize,
+		ResponseCacheTTL:  defaultResponseCacheTTL,
+
+		UserCacheSize: defaultUserCacheSize,
+		UserCacheTTL:  defaultUserCacheTTL,
+
+		ShutdownDrainDelay: defaultShutdownDrainDelay,
+
+		SlowRequestThreshold: defaultSlowRequestThreshold,
+		DBSlowQueryThreshold: defaultDBSlowQueryThreshold,
+
+		SLORoutes:  defaultSLORoutes,
+		SLOWindows: defaultSLOWindows,
+
+		LogLevel:  logging.LevelInfo.String(),
+		LogFormat: logging.FormatText,
+
+		AccessLogFormat:     defaultAccessLogFormat,
+		AccessLogSampleRate: defaultAccessLogSampleRate,
+		AccessLogExclude:    defaultAccessLogExclude,
+
+		TracingSampleRatio: defaultTracingSampleRatio,
+		TracingService:     tracing.DefaultServiceName,
+
+		CompressionLevel:   gzip.DefaultCompression,
+		CompressionMinSize: defaultCompressionMinSize,
+		CompressionTypes:   defaultCompressionTypes,
+
+		TLSAutocertCacheDir: defaultTLSAutocertCacheDir,
+
+		UnixSocketMode: defaultUnixSocketMode,
+
+		HTTP2MaxStreams: defaultHTTP2MaxStreams,
+
+		HTTPReadTimeout:       defaultHTTPReadTimeout,
+		HTTPReadHeaderTimeout: defaultHTTPReadHeaderTimeout,
+		HTTPIdleTimeout:       defaultHTTPIdleTimeout,
+		HTTPMaxHeaderBytes:    defaultHTTPMaxHeaderBytes,
+		HTTPTCPKeepAlive:      defaultHTTPTCPKeepAlive,
+
+		MaintenanceRetryAfter: defaultMaintenanceRetryAfter,
 	}
 
-	jsonConfig := loadJSONConfig()
+	jsonConfig, err := loadJSONConfig(*cfg)
+	if err != nil {
+		problems = append(problems, err)
+	}
 	if jsonConfig != nil {
 		cfg = jsonConfig
 	}
+	cfg.problems = problems
 
 	cfg = applyEnvOverrides(cfg)
 	cfg = applyFlagOverrides(cfg)
@@ -57,7 +338,121 @@ func ReadConfig() *Config {
 	return cfg
 }
 
-func loadJSONConfig() *Config {
+func (c *Config) Validate() error {
+	problems := append([]error(nil), c.problems...)
+	missing := func(name, reason string) {
+		problems = append(problems, fmt.Errorf("%w %s: %s", errors.ErrConfigSecretMissing, name, reason))
+	}
+
+	if !strings.HasPrefix(c.Addr, "unix:") && (c.Port < 1 || c.Port > 65535) {
+		problems = append(problems, fmt.Errorf("%w: %d", errors.ErrConfigInvalidPort, c.Port))
+	}
+	if err := validateDSN(c.DBStr); err != nil {
+		problems = append(problems, fmt.Errorf("%w: %v", errors.ErrConfigInvalidDSN, err))
+	}
+	if c.DBReplicaStr != "" {
+		if err := validateDSN(c.DBReplicaStr); err != nil {
+			problems = append(problems, fmt.Errorf("%w (реплика): %v", errors.ErrConfigInvalidDSN, err))
+		}
+	}
+
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		problems = append(problems, fmt.Errorf("%w: TracingSampleRatio %v", errors.ErrConfigInvalidFormat, c.TracingSampleRatio))
+	}
+	if _, ok := logging.ParseLevel(c.LogLevel); c.LogLevel != "" && !ok {
+		problems = append(problems, fmt.Errorf("%w: LogLevel %s", errors.ErrConfigInvalidFormat, c.LogLevel))
+	}
+	if c.LogFormat != "" && !logging.ValidFormat(c.LogFormat) {
+		problems = append(problems, fmt.Errorf("%w: LogFormat %s", errors.ErrConfigInvalidFormat, c.LogFormat))
+	}
+	if _, err := parseLatencyBuckets(c.MetricsLatencyBuckets); err != nil {
+		problems = append(problems, fmt.Errorf("%w: MetricsLatencyBuckets %s", errors.ErrConfigInvalidFormat, c.MetricsLatencyBuckets))
+	}
+	if _, _, err := parseSLOWindows(c.SLOWindows); err != nil {
+		problems = append(problems, fmt.Errorf("%w: SLOWindows %s", errors.ErrConfigInvalidFormat, c.SLOWindows))
+	}
+	if c.HTTP2MaxStreams < 0 {
+		problems = append(problems, fmt.Errorf("%w: HTTP2MaxStreams %d", errors.ErrConfigInvalidFormat, c.HTTP2MaxStreams))
+	}
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"HTTPReadTimeout", c.HTTPReadTimeout},
+		{"HTTPReadHeaderTimeout", c.HTTPReadHeaderTimeout},
+		{"HTTPWriteTimeout", c.HTTPWriteTimeout},
+		{"HTTPIdleTimeout", c.HTTPIdleTimeout},
+	} {
+		if timeout.value < 0 {
+			problems = append(problems, fmt.Errorf("%w: %s %v", errors.ErrConfigInvalidFormat, timeout.name, timeout.value))
+		}
+	}
+	if c.UserCacheSize < 0 {
+		problems = append(problems, fmt.Errorf("%w: UserCacheSize %d", errors.ErrConfigInvalidFormat, c.UserCacheSize))
+	}
+	if c.UserCacheSize > 0 && c.UserCacheTTL <= 0 {
+		problems = append(problems, fmt.Errorf("%w: UserCacheTTL %v", errors.ErrConfigInvalidFormat, c.UserCacheTTL))
+	}
+	if c.UserCacheSize > 0 && c.UserCacheTTL > maxUserCacheTTL {
+		problems = append(problems, fmt.Errorf("%w: UserCacheTTL %v, максимум %v", errors.ErrUserCacheTTLTooLong, c.UserCacheTTL, maxUserCacheTTL))
+	}
+	if c.HTTPMaxHeaderBytes < 0 {
+		problems = append(problems, fmt.Errorf("%w: HTTPMaxHeaderBytes %d", errors.ErrConfigInvalidFormat, c.HTTPMaxHeaderBytes))
+	}
+	if c.TLSAutocertHosts == "" && (c.TLSCertFile != "" || c.TLSKeyFile != "") {
+		if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+			problems = append(problems, errors.ErrTLSKeyPairIncomplete)
+		}
+		for _, file := range []string{c.TLSCertFile, c.TLSKeyFile} {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(file); err != nil {
+				problems = append(problems, fmt.Errorf("%w: %s", errors.ErrTLSFileNotFound, file))
+			}
+		}
+	}
+
+	switch strings.ToLower(c.CaptchaProvider) {
+	case "":
+	case CaptchaProviderRecaptcha, CaptchaProviderHCaptcha:
+		if c.CaptchaSecret == "" {
+			missing("CAPTCHA_SECRET", "требуется для провайдера captcha "+c.CaptchaProvider)
+		}
+	default:
+		problems = append(problems, fmt.Errorf("%w: неизвестный провайдер captcha %s", errors.ErrConfigInvalidFormat, c.CaptchaProvider))
+	}
+	for _, channel := range strings.Split(c.ReminderChannels, ",") {
+		switch strings.ToLower(strings.TrimSpace(channel)) {
+		case reminder.ChannelEmail:
+			if c.SMTPAddr == "" || c.SMTPFrom == "" {
+				missing("SMTP_ADDR/SMTP_FROM", "требуются для напоминаний по email")
+			}
+		case reminder.ChannelWebhook:
+			if c.ReminderWebhookURL == "" {
+				missing("REMINDER_WEBHOOK_URL", "требуется для напоминаний через webhook")
+			}
+		}
+	}
+	if c.SMTPUsername != "" && c.SMTPPassword == "" {
+		missing("SMTP_PASSWORD", "требуется при заданном SMTP_USERNAME")
+	}
+	if c.ResponseCache == ResponseCacheRedis && c.RedisAddr == "" {
+		missing("REDIS_ADDR", "требуется для кэширования ответов в Redis")
+	}
+
+	return stderrors.Join(problems...)
+}
+
+func validateDSN(dsn string) error {
+	if strings.TrimSpace(dsn) == "" {
+		return errors.ErrConfigInvalidFormat
+	}
+	_, err := pgconn.ParseConfig(dsn)
+	return err
+}
+
+func loadJSONConfig(defaults Config) (*Config, error) {
 	configPath := *configFile
 	if configPath == "" {
 		configPath = os.Getenv("CONFIG")
@@ -65,24 +460,65 @@ func loadJSONConfig() *Config {
 
 	if configPath == "" {
 		fmt.Printf("JSON конфигурация: не указан путь к файлу\n")
-		return nil
+		return nil, nil
 	}
 
 	fmt.Printf("Загрузка JSON конфигурации из: %s\n", configPath)
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		fmt.Printf("Warning: %s %s: %v\n", errors.ErrConfigFileReadFailed.Error(), configPath, err)
-		return nil
+		return nil, fmt.Errorf("%w %s: %v", errors.ErrConfigFileReadFailed, configPath, err)
 	}
 
-	var jsonConfig Config
+	jsonConfig := defaults
 	if err := json.Unmarshal(data, &jsonConfig); err != nil {
 		fmt.Printf("Warning: %s: %v\n", errors.ErrConfigParseFailed.Error(), err)
-		return nil
+		return nil, fmt.Errorf("%w %s: %v", errors.ErrConfigParseFailed, configPath, err)
 	}
 
 	fmt.Printf("JSON конфигурация успешно загружена из: %s\n", configPath)
-	return &jsonConfig
+	return &jsonConfig, nil
+}
+
+func loadDotEnv() error {
+	path := *envFile
+	if path == "" {
+		path = os.Getenv("ENV_FILE")
+	}
+	explicit := path != ""
+	if !explicit {
+		path = defaultEnvFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if explicit || !os.IsNotExist(err) {
+			fmt.Printf("Warning: %s %s: %v\n", errors.ErrConfigFileReadFailed.Error(), path, err)
+			return fmt.Errorf("%w %s: %v", errors.ErrConfigFileReadFailed, path, err)
+		}
+		return nil
+	}
+
+	vars, err := parseDotEnv(string(data))
+	if err != nil {
+		fmt.Printf("Warning: %s %s: %v\n", errors.ErrConfigParseFailed.Error(), path, err)
+		return fmt.Errorf("%w %s: %v", errors.ErrConfigParseFailed, path, err)
+	}
+	loaded := 0
+	for key, value := range vars {
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		os.Setenv(key, value)
+		loaded++
+	}
+	fmt.Printf("Загружено переменных окружения из %s: %d\n", path, loaded)
+	return nil
+}
+
+func (c *Config) invalidEnv(name, value string) {
+	fmt.Printf("Warning: %s в переменной окружения %s: %s\n", errors.ErrConfigInvalidFormat.Error(), name, value)
+	c.problems = append(c.problems, fmt.Errorf("%w в переменной окружения %s: %s", errors.ErrConfigInvalidFormat, name, value))
 }
 
 func applyEnvOverrides(cfg *Config) *Config {
@@ -91,9 +527,9 @@ func applyEnvOverrides(cfg *Config) *Config {
 	}
 	if port := os.Getenv("PORT"); port != "" {
 		if p, err := strconv.Atoi(port); err != nil {
-			fmt.Printf("Warning: %s в переменной окружения PORT: %s\n", errors.ErrConfigInvalidFormat.Error(), port)
+			cfg.invalidEnv("PORT", port)
 		} else if p < 1 || p > 65535 {
-			fmt.Printf("Warning: %s - порт должен быть от 1 до 65535: %d\n", errors.ErrConfigInvalidFormat.Error(), p)
+			cfg.invalidEnv("PORT", port)
 		} else {
 			cfg.Port = p
 		}
@@ -104,6 +540,477 @@ func applyEnvOverrides(cfg *Config) *Config {
 	if migratePath := os.Getenv("MIGRATE_PATH"); migratePath != "" {
 		cfg.MigratePath = migratePath
 	}
+	if provider := os.Getenv("CAPTCHA_PROVIDER"); provider != "" {
+		cfg.CaptchaProvider = provider
+	}
+	if secret := os.Getenv("CAPTCHA_SECRET"); secret != "" {
+		cfg.CaptchaSecret = secret
+	}
+	if ttl := os.Getenv("JWT_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err != nil || d <= 0 {
+			cfg.invalidEnv("JWT_TTL", ttl)
+		} else {
+			cfg.JWTTTL = d
+		}
+	}
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		cfg.JWTIssuer = issuer
+	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		cfg.JWTAudience = audience
+	}
+	if skew := os.Getenv("JWT_CLOCK_SKEW"); skew != "" {
+		if d, err := time.ParseDuration(skew); err != nil || d < 0 {
+			cfg.invalidEnv("JWT_CLOCK_SKEW", skew)
+		} else {
+			cfg.JWTClockSkew = d
+		}
+	}
+	if secret := os.Getenv("INTROSPECTION_SECRET"); secret != "" {
+		cfg.IntrospectionSecret = secret
+	}
+	if interval := os.Getenv("REMINDER_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err != nil || d <= 0 {
+			cfg.invalidEnv("REMINDER_INTERVAL", interval)
+		} else {
+			cfg.ReminderInterval = d
+		}
+	}
+	if offset := os.Getenv("REMINDER_DEFAULT_OFFSET"); offset != "" {
+		if d, err := time.ParseDuration(offset); err != nil || d < 0 {
+			cfg.invalidEnv("REMINDER_DEFAULT_OFFSET", offset)
+		} else {
+			cfg.ReminderDefaultOffset = d
+		}
+	}
+	if channels := os.Getenv("REMINDER_CHANNELS"); channels != "" {
+		cfg.ReminderChannels = channels
+	}
+	if webhook := os.Getenv("REMINDER_WEBHOOK_URL"); webhook != "" {
+		cfg.ReminderWebhookURL = webhook
+	}
+	if smtpAddr := os.Getenv("SMTP_ADDR"); smtpAddr != "" {
+		cfg.SMTPAddr = smtpAddr
+	}
+	if smtpFrom := os.Getenv("SMTP_FROM"); smtpFrom != "" {
+		cfg.SMTPFrom = smtpFrom
+	}
+	if smtpUser := os.Getenv("SMTP_USERNAME"); smtpUser != "" {
+		cfg.SMTPUsername = smtpUser
+	}
+	if smtpPassword := os.Getenv("SMTP_PASSWORD"); smtpPassword != "" {
+		cfg.SMTPPassword = smtpPassword
+	}
+	if interval := os.Getenv("PURGE_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err != nil || d <= 0 {
+			cfg.invalidEnv("PURGE_INTERVAL", interval)
+		} else {
+			cfg.PurgeInterval = d
+		}
+	}
+	if retention := os.Getenv("PURGE_RETENTION"); retention != "" {
+		if d, err := time.ParseDuration(retention); err != nil || d < 0 {
+			cfg.invalidEnv("PURGE_RETENTION", retention)
+		} else {
+			cfg.PurgeRetention = d
+		}
+	}
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err != nil || d <= 0 {
+			cfg.invalidEnv("IDEMPOTENCY_TTL", ttl)
+		} else {
+			cfg.IdempotencyTTL = d
+		}
+	}
+	if dir := os.Getenv("BLOB_DIR"); dir != "" {
+		cfg.BlobDir = dir
+	}
+	if size := os.Getenv("AVATAR_MAX_SIZE"); size != "" {
+		if n, err := strconv.ParseInt(size, 10, 64); err != nil || n <= 0 {
+			cfg.invalidEnv("AVATAR_MAX_SIZE", size)
+		} else {
+			cfg.AvatarMaxSize = n
+		}
+	}
+
+	if conns := os.Getenv("DB_MIN_CONNS"); conns != "" {
+		if n, err := strconv.Atoi(conns); err != nil || n < 0 {
+			cfg.invalidEnv("DB_MIN_CONNS", conns)
+		} else {
+			cfg.DBMinConns = n
+		}
+	}
+	if conns := os.Getenv("DB_MAX_CONNS"); conns != "" {
+		if n, err := strconv.Atoi(conns); err != nil || n <= 0 {
+			cfg.invalidEnv("DB_MAX_CONNS", conns)
+		} else {
+			cfg.DBMaxConns = n
+		}
+	}
+	if period := os.Getenv("DB_HEALTH_CHECK_PERIOD"); period != "" {
+		if d, err := time.ParseDuration(period); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_HEALTH_CHECK_PERIOD", period)
+		} else {
+			cfg.DBHealthCheckPeriod = d
+		}
+	}
+	if replica := os.Getenv("DB_REPLICA_STR"); replica != "" {
+		cfg.DBReplicaStr = replica
+	}
+	if attempts := os.Getenv("DB_RETRY_ATTEMPTS"); attempts != "" {
+		if n, err := strconv.Atoi(attempts); err != nil || n < 1 {
+			cfg.invalidEnv("DB_RETRY_ATTEMPTS", attempts)
+		} else {
+			cfg.DBRetryAttempts = n
+		}
+	}
+	if backoff := os.Getenv("DB_RETRY_INITIAL_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_RETRY_INITIAL_BACKOFF", backoff)
+		} else {
+			cfg.DBRetryInitialBackoff = d
+		}
+	}
+	if backoff := os.Getenv("DB_RETRY_MAX_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_RETRY_MAX_BACKOFF", backoff)
+		} else {
+			cfg.DBRetryMaxBackoff = d
+		}
+	}
+	if threshold := os.Getenv("DB_BREAKER_THRESHOLD"); threshold != "" {
+		if n, err := strconv.Atoi(threshold); err != nil || n < 0 {
+			cfg.invalidEnv("DB_BREAKER_THRESHOLD", threshold)
+		} else {
+			cfg.DBBreakerThreshold = n
+		}
+	}
+	if cooldown := os.Getenv("DB_BREAKER_COOLDOWN"); cooldown != "" {
+		if d, err := time.ParseDuration(cooldown); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_BREAKER_COOLDOWN", cooldown)
+		} else {
+			cfg.DBBreakerCooldown = d
+		}
+	}
+	if timeout := os.Getenv("DB_READ_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_READ_TIMEOUT", timeout)
+		} else {
+			cfg.DBReadTimeout = d
+		}
+	}
+	if timeout := os.Getenv("DB_WRITE_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_WRITE_TIMEOUT", timeout)
+		} else {
+			cfg.DBWriteTimeout = d
+		}
+	}
+	if timeouts := os.Getenv("DB_QUERY_TIMEOUTS"); timeouts != "" {
+		if parsed, err := parseOperationTimeouts(timeouts); err != nil {
+			cfg.invalidEnv("DB_QUERY_TIMEOUTS", timeouts)
+		} else {
+			cfg.DBQueryTimeouts = parsed
+		}
+	}
+	if redisAddr := os.Getenv("REDIS_ADDR"); redisAddr != "" {
+		cfg.RedisAddr = redisAddr
+	}
+	if redisPassword := os.Getenv("REDIS_PASSWORD"); redisPassword != "" {
+		cfg.RedisPassword = redisPassword
+	}
+	if redisDB := os.Getenv("REDIS_DB"); redisDB != "" {
+		if n, err := strconv.Atoi(redisDB); err != nil || n < 0 {
+			cfg.invalidEnv("REDIS_DB", redisDB)
+		} else {
+			cfg.RedisDB = n
+		}
+	}
+	if ttl := os.Getenv("CACHE_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err != nil || d <= 0 {
+			cfg.invalidEnv("CACHE_TTL", ttl)
+		} else {
+			cfg.CacheTTL = d
+		}
+	}
+	if backend, ok := os.LookupEnv("RESPONSE_CACHE"); ok {
+		if !isResponseCacheBackend(backend) {
+			cfg.invalidEnv("RESPONSE_CACHE", backend)
+		} else {
+			cfg.ResponseCache = backend
+		}
+	}
+	if size := os.Getenv("RESPONSE_CACHE_SIZE"); size != "" {
+		if n, err := strconv.Atoi(size); err != nil || n <= 0 {
+			cfg.invalidEnv("RESPONSE_CACHE_SIZE", size)
+		} else {
+			cfg.ResponseCacheSize = n
+		}
+	}
+	if ttl := os.Getenv("RESPONSE_CACHE_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err != nil || d <= 0 {
+			cfg.invalidEnv("RESPONSE_CACHE_TTL", ttl)
+		} else {
+			cfg.ResponseCacheTTL = d
+		}
+	}
+	if size := os.Getenv("USER_CACHE_SIZE"); size != "" {
+		if n, err := strconv.Atoi(size); err != nil || n < 0 {
+			cfg.invalidEnv("USER_CACHE_SIZE", size)
+		} else {
+			cfg.UserCacheSize = n
+		}
+	}
+	if ttl := os.Getenv("USER_CACHE_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err != nil || d <= 0 {
+			cfg.invalidEnv("USER_CACHE_TTL", ttl)
+		} else {
+			cfg.UserCacheTTL = d
+		}
+	}
+	if delay := os.Getenv("SHUTDOWN_DRAIN_DELAY"); delay != "" {
+		if d, err := time.ParseDuration(delay); err != nil || d < 0 {
+			cfg.invalidEnv("SHUTDOWN_DRAIN_DELAY", delay)
+		} else {
+			cfg.ShutdownDrainDelay = d
+		}
+	}
+	if threshold := os.Getenv("SLOW_REQUEST_THRESHOLD"); threshold != "" {
+		if d, err := time.ParseDuration(threshold); err != nil || d < 0 {
+			cfg.invalidEnv("SLOW_REQUEST_THRESHOLD", threshold)
+		} else {
+			cfg.SlowRequestThreshold = d
+		}
+	}
+	if threshold := os.Getenv("DB_SLOW_QUERY_THRESHOLD"); threshold != "" {
+		if d, err := time.ParseDuration(threshold); err != nil || d <= 0 {
+			cfg.invalidEnv("DB_SLOW_QUERY_THRESHOLD", threshold)
+		} else {
+			cfg.DBSlowQueryThreshold = d
+		}
+	}
+	if comments := os.Getenv("DB_SQL_COMMENTS"); comments != "" {
+		if b, err := strconv.ParseBool(comments); err != nil {
+			cfg.invalidEnv("DB_SQL_COMMENTS", comments)
+		} else {
+			cfg.DBSQLComments = b
+		}
+	}
+	if buckets, ok := os.LookupEnv("METRICS_LATENCY_BUCKETS"); ok {
+		if _, err := parseLatencyBuckets(buckets); err != nil {
+			cfg.invalidEnv("METRICS_LATENCY_BUCKETS", buckets)
+		} else {
+			cfg.MetricsLatencyBuckets = buckets
+		}
+	}
+	if routes, ok := os.LookupEnv("SLO_ROUTES"); ok {
+		cfg.SLORoutes = routes
+	}
+	if windows := os.Getenv("SLO_WINDOWS"); windows != "" {
+		if _, _, err := parseSLOWindows(windows); err != nil {
+			cfg.invalidEnv("SLO_WINDOWS", windows)
+		} else {
+			cfg.SLOWindows = windows
+		}
+	}
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		if _, ok := logging.ParseLevel(level); !ok {
+			cfg.invalidEnv("LOG_LEVEL", level)
+		} else {
+			cfg.LogLevel = level
+		}
+	}
+	if format := os.Getenv("LOG_FORMAT"); format != "" {
+		if !logging.ValidFormat(format) {
+			cfg.invalidEnv("LOG_FORMAT", format)
+		} else {
+			cfg.LogFormat = format
+		}
+	}
+	if format := os.Getenv("ACCESS_LOG_FORMAT"); format != "" {
+		if !isAccessLogFormat(format) {
+			cfg.invalidEnv("ACCESS_LOG_FORMAT", format)
+		} else {
+			cfg.AccessLogFormat = format
+		}
+	}
+	if rate := os.Getenv("ACCESS_LOG_SAMPLE_RATE"); rate != "" {
+		if f, err := strconv.ParseFloat(rate, 64); err != nil || f <= 0 || f > 1 {
+			cfg.invalidEnv("ACCESS_LOG_SAMPLE_RATE", rate)
+		} else {
+			cfg.AccessLogSampleRate = f
+		}
+	}
+	if exclude, ok := os.LookupEnv("ACCESS_LOG_EXCLUDE"); ok {
+		cfg.AccessLogExclude = exclude
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		cfg.TracingEndpoint = endpoint
+	}
+	if insecure := os.Getenv("TRACING_INSECURE"); insecure != "" {
+		if b, err := strconv.ParseBool(insecure); err != nil {
+			cfg.invalidEnv("TRACING_INSECURE", insecure)
+		} else {
+			cfg.TracingInsecure = b
+		}
+	}
+	if ratio := os.Getenv("TRACING_SAMPLE_RATIO"); ratio != "" {
+		if f, err := strconv.ParseFloat(ratio, 64); err != nil || f < 0 || f > 1 {
+			cfg.invalidEnv("TRACING_SAMPLE_RATIO", ratio)
+		} else {
+			cfg.TracingSampleRatio = f
+		}
+	}
+	if service := os.Getenv("OTEL_SERVICE_NAME"); service != "" {
+		cfg.TracingService = service
+	}
+	if level := os.Getenv("COMPRESSION_LEVEL"); level != "" {
+		if n, err := strconv.Atoi(level); err != nil || n < gzip.HuffmanOnly || n > gzip.BestCompression {
+			cfg.invalidEnv("COMPRESSION_LEVEL", level)
+		} else {
+			cfg.CompressionLevel = n
+		}
+	}
+	if size := os.Getenv("COMPRESSION_MIN_SIZE"); size != "" {
+		if n, err := strconv.Atoi(size); err != nil || n < 0 {
+			cfg.invalidEnv("COMPRESSION_MIN_SIZE", size)
+		} else {
+			cfg.CompressionMinSize = n
+		}
+	}
+	if types, ok := os.LookupEnv("COMPRESSION_TYPES"); ok {
+		cfg.CompressionTypes = types
+	}
+	if origins, ok := os.LookupEnv("ALLOWED_ORIGINS"); ok {
+		cfg.AllowedOrigins = origins
+	}
+	if enabled := os.Getenv("MAINTENANCE_MODE"); enabled != "" {
+		if b, err := strconv.ParseBool(enabled); err != nil {
+			cfg.invalidEnv("MAINTENANCE_MODE", enabled)
+		} else {
+			cfg.MaintenanceMode = b
+		}
+	}
+	if retry := os.Getenv("MAINTENANCE_RETRY_AFTER"); retry != "" {
+		if d, err := time.ParseDuration(retry); err != nil || d <= 0 {
+			cfg.invalidEnv("MAINTENANCE_RETRY_AFTER", retry)
+		} else {
+			cfg.MaintenanceRetryAfter = d
+		}
+	}
+	if dir := os.Getenv("UI_DIR"); dir != "" {
+		cfg.UIDir = dir
+	}
+	if embedded := os.Getenv("UI_EMBEDDED"); embedded != "" {
+		if b, err := strconv.ParseBool(embedded); err != nil {
+			cfg.invalidEnv("UI_EMBEDDED", embedded)
+		} else {
+			cfg.UIEmbedded = b
+		}
+	}
+	if mode := os.Getenv("UNIX_SOCKET_MODE"); mode != "" {
+		if _, err := parseSocketMode(mode); err != nil {
+			cfg.invalidEnv("UNIX_SOCKET_MODE", mode)
+		} else {
+			cfg.UnixSocketMode = mode
+		}
+	}
+	if cert := os.Getenv("TLS_CERT_FILE"); cert != "" {
+		cfg.TLSCertFile = cert
+	}
+	if key := os.Getenv("TLS_KEY_FILE"); key != "" {
+		cfg.TLSKeyFile = key
+	}
+	if hosts := os.Getenv("TLS_AUTOCERT_HOSTS"); hosts != "" {
+		cfg.TLSAutocertHosts = hosts
+	}
+	if dir := os.Getenv("TLS_AUTOCERT_CACHE_DIR"); dir != "" {
+		cfg.TLSAutocertCacheDir = dir
+	}
+	if email := os.Getenv("TLS_AUTOCERT_EMAIL"); email != "" {
+		cfg.TLSAutocertEmail = email
+	}
+	if redirect, ok := os.LookupEnv("TLS_REDIRECT_ADDR"); ok {
+		cfg.TLSRedirectAddr = redirect
+	}
+	if disabled := os.Getenv("HTTP2_DISABLED"); disabled != "" {
+		if b, err := strconv.ParseBool(disabled); err != nil {
+			cfg.invalidEnv("HTTP2_DISABLED", disabled)
+		} else {
+			cfg.HTTP2Disabled = b
+		}
+	}
+	if enabled := os.Getenv("H2C"); enabled != "" {
+		if b, err := strconv.ParseBool(enabled); err != nil {
+			cfg.invalidEnv("H2C", enabled)
+		} else {
+			cfg.H2C = b
+		}
+	}
+	if streams := os.Getenv("HTTP2_MAX_STREAMS"); streams != "" {
+		if n, err := strconv.Atoi(streams); err != nil || n < 0 {
+			cfg.invalidEnv("HTTP2_MAX_STREAMS", streams)
+		} else {
+			cfg.HTTP2MaxStreams = n
+		}
+	}
+	for _, timeout := range []struct {
+		env    string
+		target *time.Duration
+	}{
+		{"HTTP_READ_TIMEOUT", &cfg.HTTPReadTimeout},
+		{"HTTP_READ_HEADER_TIMEOUT", &cfg.HTTPReadHeaderTimeout},
+		{"HTTP_WRITE_TIMEOUT", &cfg.HTTPWriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", &cfg.HTTPIdleTimeout},
+	} {
+		if value := os.Getenv(timeout.env); value != "" {
+			if d, err := time.ParseDuration(value); err != nil || d < 0 {
+				cfg.invalidEnv(timeout.env, value)
+			} else {
+				*timeout.target = d
+			}
+		}
+	}
+	if size := os.Getenv("HTTP_MAX_HEADER_BYTES"); size != "" {
+		if n, err := strconv.Atoi(size); err != nil || n < 0 {
+			cfg.invalidEnv("HTTP_MAX_HEADER_BYTES", size)
+		} else {
+			cfg.HTTPMaxHeaderBytes = n
+		}
+	}
+	if disabled := os.Getenv("HTTP_KEEP_ALIVES_DISABLED"); disabled != "" {
+		if b, err := strconv.ParseBool(disabled); err != nil {
+			cfg.invalidEnv("HTTP_KEEP_ALIVES_DISABLED", disabled)
+		} else {
+			cfg.HTTPKeepAlivesDisabled = b
+		}
+	}
+	if period := os.Getenv("HTTP_TCP_KEEP_ALIVE"); period != "" {
+		if d, err := time.ParseDuration(period); err != nil {
+			cfg.invalidEnv("HTTP_TCP_KEEP_ALIVE", period)
+		} else {
+			cfg.HTTPTCPKeepAlive = d
+		}
+	}
+	if enabled := os.Getenv("DEBUG_ENDPOINTS"); enabled != "" {
+		if b, err := strconv.ParseBool(enabled); err != nil {
+			cfg.invalidEnv("DEBUG_ENDPOINTS", enabled)
+		} else {
+			cfg.DebugEndpoints = b
+		}
+	}
+	if raw := os.Getenv("FEATURE_FLAGS"); raw != "" {
+		if flags, ok := parseFeatureFlags(raw); !ok {
+			cfg.invalidEnv("FEATURE_FLAGS", raw)
+		} else {
+			if cfg.FeatureFlags == nil {
+				cfg.FeatureFlags = make(map[string]bool, len(flags))
+			}
+			for name, enabled := range flags {
+				cfg.FeatureFlags[name] = enabled
+			}
+		}
+	}
 
 	if cfg.DBStr == defaultDBStr {
 		dbUser := os.Getenv("DB_USER")
@@ -130,5 +1037,251 @@ func applyFlagOverrides(cfg *Config) *Config {
 		cfg.DBStr = *dbstr
 	}
 
+	if *captcha != "" {
+		cfg.CaptchaProvider = *captcha
+	}
+	if *jwtTTL > 0 {
+		cfg.JWTTTL = *jwtTTL
+	}
+	if *jwtIssuer != "" {
+		cfg.JWTIssuer = *jwtIssuer
+	}
+	if *jwtAudience != "" {
+		cfg.JWTAudience = *jwtAudience
+	}
+	if *jwtSkew >= 0 {
+		cfg.JWTClockSkew = *jwtSkew
+	}
+	if *reminders != "" {
+		cfg.ReminderChannels = *reminders
+	}
+	if *purgeAfter >= 0 {
+		cfg.PurgeRetention = *purgeAfter
+	}
+	if *plainHTTP2 {
+		cfg.H2C = true
+	}
+	if *debugRoutes {
+		cfg.DebugEndpoints = true
+	}
+	if *maintenance {
+		cfg.MaintenanceMode = true
+	}
+	if *uiDir != "" {
+		cfg.UIDir = *uiDir
+	}
+	if *autocertFor != "" {
+		cfg.TLSAutocertHosts = *autocertFor
+	}
+
 	return cfg
 }
+
+func parseDotEnv(data string) (map[string]string, error) {
+	vars := map[string]string{}
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%w: строка %d", errors.ErrConfigInvalidFormat, i+1)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: строка %d", errors.ErrConfigInvalidFormat, i+1)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		case strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'"):
+			return nil, fmt.Errorf("%w: строка %d", errors.ErrConfigInvalidFormat, i+1)
+		default:
+			if idx := strings.Index(value, " #"); idx >= 0 {
+				value = strings.TrimSpace(value[:idx])
+			}
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+func parseOperationTimeouts(value string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		operation, duration, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(operation) == "" {
+			return nil, errors.ErrConfigInvalidFormat
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil || d <= 0 {
+			return nil, errors.ErrConfigInvalidFormat
+		}
+		timeouts[strings.TrimSpace(operation)] = d
+	}
+	return timeouts, nil
+}
+
+type jsonDuration time.Duration
+
+func (d *jsonDuration) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch v := raw.(type) {
+	case float64:
+		*d = jsonDuration(time.Duration(v) * time.Second)
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = jsonDuration(parsed)
+	default:
+		return errors.ErrConfigInvalidFormat
+	}
+	return nil
+}
+
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plainConfig Config
+	aux := struct {
+		*plainConfig
+		JWTTTL       *jsonDuration
+		JWTClockSkew *jsonDuration
+
+		ReminderInterval      *jsonDuration
+		ReminderDefaultOffset *jsonDuration
+
+		PurgeInterval  *jsonDuration
+		PurgeRetention *jsonDuration
+
+		IdempotencyTTL *jsonDuration
+
+		DBHealthCheckPeriod *jsonDuration
+
+		DBRetryInitialBackoff *jsonDuration
+		DBRetryMaxBackoff     *jsonDuration
+
+		DBBreakerCooldown *jsonDuration
+
+		CacheTTL *jsonDuration
+
+		ResponseCacheTTL *jsonDuration
+
+		UserCacheTTL *jsonDuration
+
+		DBReadTimeout   *jsonDuration
+		DBWriteTimeout  *jsonDuration
+		DBQueryTimeouts map[string]jsonDuration
+
+		ShutdownDrainDelay *jsonDuration
+
+		SlowRequestThreshold *jsonDuration
+		DBSlowQueryThreshold *jsonDuration
+
+		HTTPReadTimeout       *jsonDuration
+		HTTPReadHeaderTimeout *jsonDuration
+		HTTPWriteTimeout      *jsonDuration
+		HTTPIdleTimeout       *jsonDuration
+		HTTPTCPKeepAlive      *jsonDuration
+
+		MaintenanceRetryAfter *jsonDuration
+	}{plainConfig: (*plainConfig)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.JWTTTL != nil {
+		c.JWTTTL = time.Duration(*aux.JWTTTL)
+	}
+	if aux.JWTClockSkew != nil {
+		c.JWTClockSkew = time.Duration(*aux.JWTClockSkew)
+	}
+	if aux.ReminderInterval != nil {
+		c.ReminderInterval = time.Duration(*aux.ReminderInterval)
+	}
+	if aux.ReminderDefaultOffset != nil {
+		c.ReminderDefaultOffset = time.Duration(*aux.ReminderDefaultOffset)
+	}
+	if aux.PurgeInterval != nil {
+		c.PurgeInterval = time.Duration(*aux.PurgeInterval)
+	}
+	if aux.PurgeRetention != nil {
+		c.PurgeRetention = time.Duration(*aux.PurgeRetention)
+	}
+	if aux.IdempotencyTTL != nil {
+		c.IdempotencyTTL = time.Duration(*aux.IdempotencyTTL)
+	}
+	if aux.DBHealthCheckPeriod != nil {
+		c.DBHealthCheckPeriod = time.Duration(*aux.DBHealthCheckPeriod)
+	}
+	if aux.DBRetryInitialBackoff != nil {
+		c.DBRetryInitialBackoff = time.Duration(*aux.DBRetryInitialBackoff)
+	}
+	if aux.DBRetryMaxBackoff != nil {
+		c.DBRetryMaxBackoff = time.Duration(*aux.DBRetryMaxBackoff)
+	}
+	if aux.DBBreakerCooldown != nil {
+		c.DBBreakerCooldown = time.Duration(*aux.DBBreakerCooldown)
+	}
+	if aux.CacheTTL != nil {
+		c.CacheTTL = time.Duration(*aux.CacheTTL)
+	}
+	if aux.ResponseCacheTTL != nil {
+		c.ResponseCacheTTL = time.Duration(*aux.ResponseCacheTTL)
+	}
+	if aux.UserCacheTTL != nil {
+		c.UserCacheTTL = time.Duration(*aux.UserCacheTTL)
+	}
+	if aux.DBReadTimeout != nil {
+		c.DBReadTimeout = time.Duration(*aux.DBReadTimeout)
+	}
+	if aux.DBWriteTimeout != nil {
+		c.DBWriteTimeout = time.Duration(*aux.DBWriteTimeout)
+	}
+	if aux.DBQueryTimeouts != nil {
+		c.DBQueryTimeouts = make(map[string]time.Duration, len(aux.DBQueryTimeouts))
+		for operation, d := range aux.DBQueryTimeouts {
+			c.DBQueryTimeouts[operation] = time.Duration(d)
+		}
+	}
+	if aux.ShutdownDrainDelay != nil {
+		c.ShutdownDrainDelay = time.Duration(*aux.ShutdownDrainDelay)
+	}
+	if aux.SlowRequestThreshold != nil {
+		c.SlowRequestThreshold = time.Duration(*aux.SlowRequestThreshold)
+	}
+	if aux.DBSlowQueryThreshold != nil {
+		c.DBSlowQueryThreshold = time.Duration(*aux.DBSlowQueryThreshold)
+	}
+	if aux.HTTPReadTimeout != nil {
+		c.HTTPReadTimeout = time.Duration(*aux.HTTPReadTimeout)
+	}
+	if aux.HTTPReadHeaderTimeout != nil {
+		c.HTTPReadHeaderTimeout = time.Duration(*aux.HTTPReadHeaderTimeout)
+	}
+	if aux.HTTPWriteTimeout != nil {
+		c.HTTPWriteTimeout = time.Duration(*aux.HTTPWriteTimeout)
+	}
+	if aux.HTTPIdleTimeout != nil {
+		c.HTTPIdleTimeout = time.Duration(*aux.HTTPIdleTimeout)
+	}
+	if aux.HTTPTCPKeepAlive != nil {
+		c.HTTPTCPKeepAlive = time.Duration(*aux.HTTPTCPKeepAlive)
+	}
+	if aux.MaintenanceRetryAfter != nil {
+		c.MaintenanceRetryAfter = time.Duration(*aux.MaintenanceRetryAfter)
+	}
+	return nil
+}
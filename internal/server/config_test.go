@@ -0,0 +1,341 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "duration strings",
+			data: `{"addr": "127.0.0.1", "jwtttl": "15m", "jwtclockskew": "10s"}`,
+			want: Config{Addr: "127.0.0.1", JWTTTL: 15 * time.Minute, JWTClockSkew: 10 * time.Second},
+		},
+		{
+			name: "duration numbers are seconds",
+			data: `{"jwtttl": 60}`,
+			want: Config{JWTTTL: time.Minute},
+		},
+		{
+			name: "purge settings",
+			data: `{"purgeinterval": "30m", "purgeretention": "168h"}`,
+			want: Config{PurgeInterval: 30 * time.Minute, PurgeRetention: 7 * 24 * time.Hour},
+		},
+		{
+			name: "idempotency ttl",
+			data: `{"idempotencyttl": "2h"}`,
+			want: Config{IdempotencyTTL: 2 * time.Hour},
+		},
+		{
+			name: "avatar storage",
+			data: `{"blobdir": "/var/lib/tasks/blobs", "avatarmaxsize": 1048576}`,
+			want: Config{BlobDir: "/var/lib/tasks/blobs", AvatarMaxSize: 1 << 20},
+		},
+		{
+			name: "database pool",
+			data: `{"dbminconns": 2, "dbmaxconns": 20, "dbhealthcheckperiod": "30s", "dbreplicastr": "postgresql://replica:5432/tasks"}`,
+			want: Config{DBMinConns: 2, DBMaxConns: 20, DBHealthCheckPeriod: 30 * time.Second, DBReplicaStr: "postgresql://replica:5432/tasks"},
+		},
+		{
+			name: "database retry policy",
+			data: `{"dbretryattempts": 5, "dbretryinitialbackoff": "100ms", "dbretrymaxbackoff": "2s"}`,
+			want: Config{DBRetryAttempts: 5, DBRetryInitialBackoff: 100 * time.Millisecond, DBRetryMaxBackoff: 2 * time.Second},
+		},
+		{
+			name: "database circuit breaker",
+			data: `{"dbbreakerthreshold": 3, "dbbreakercooldown": "30s"}`,
+			want: Config{DBBreakerThreshold: 3, DBBreakerCooldown: 30 * time.Second},
+		},
+		{
+			name: "redis cache",
+			data: `{"redisaddr": "redis:6379", "redispassword": "secret", "redisdb": 2, "cachettl": "30s"}`,
+			want: Config{RedisAddr: "redis:6379", RedisPassword: "secret", RedisDB: 2, CacheTTL: 30 * time.Second},
+		},
+		{
+			name: "query timeouts",
+			data: `{"dbreadtimeout": "2s", "dbwritetimeout": "4s", "dbquerytimeouts": {"ExportTasks": "10m", "GetTasks": 3}}`,
+			want: Config{DBReadTimeout: 2 * time.Second, DBWriteTimeout: 4 * time.Second, DBQueryTimeouts: map[string]time.Duration{"ExportTasks": 10 * time.Minute, "GetTasks": 3 * time.Second}},
+		},
+		{
+			name: "response cache",
+			data: `{"responsecache": "memory", "responsecachesize": 500, "responsecachettl": "10s"}`,
+			want: Config{ResponseCache: "memory", ResponseCacheSize: 500, ResponseCacheTTL: 10 * time.Second},
+		},
+		{
+			name: "user cache",
+			data: `{"usercachesize": 200, "usercachettl": "5s"}`,
+			want: Config{UserCacheSize: 200, UserCacheTTL: 5 * time.Second},
+		},
+		{
+			name: "shutdown drain delay",
+			data: `{"shutdowndraindelay": "15s"}`,
+			want: Config{ShutdownDrainDelay: 15 * time.Second},
+		},
+		{
+			name: "slow thresholds",
+			data: `{"slowrequestthreshold": "2s", "dbslowquerythreshold": "250ms", "dbsqlcomments": true}`,
+			want: Config{SlowRequestThreshold: 2 * time.Second, DBSlowQueryThreshold: 250 * time.Millisecond, DBSQLComments: true},
+		},
+		{
+			name: "slo metrics",
+			data: `{"metricslatencybuckets": "0.05,0.1,0.5", "sloroutes": "GET /tasks", "slowindows": "10m"}`,
+			want: Config{MetricsLatencyBuckets: "0.05,0.1,0.5", SLORoutes: "GET /tasks", SLOWindows: "10m"},
+		},
+		{
+			name: "log level and format",
+			data: `{"loglevel": "debug", "logformat": "json"}`,
+			want: Config{LogLevel: "debug", LogFormat: "json"},
+		},
+		{
+			name: "access log",
+			data: `{"accesslogformat": "json", "accesslogsamplerate": 0.25, "accesslogexclude": "/health,/metrics"}`,
+			want: Config{AccessLogFormat: "json", AccessLogSampleRate: 0.25, AccessLogExclude: "/health,/metrics"},
+		},
+		{
+			name: "tracing",
+			data: `{"tracingendpoint": "http://otel-collector:4318", "tracinginsecure": true, "tracingsampleratio": 0.1, "tracingservice": "tasks-api"}`,
+			want: Config{TracingEndpoint: "http://otel-collector:4318", TracingInsecure: true, TracingSampleRatio: 0.1, TracingService: "tasks-api"},
+		},
+		{
+			name: "compression",
+			data: `{"compressionlevel": 9, "compressionminsize": 256, "compressiontypes": "application/json,text/csv"}`,
+			want: Config{CompressionLevel: 9, CompressionMinSize: 256, CompressionTypes: "application/json,text/csv"},
+		},
+		{
+			name: "allowed origins",
+			data: `{"allowedorigins": "https://app.example.com,https://*.example.org"}`,
+			want: Config{AllowedOrigins: "https://app.example.com,https://*.example.org"},
+		},
+		{
+			name: "tls autocert",
+			data: `{"tlsautocerthosts": "tasks.example.com", "tlsautocertcachedir": "/var/lib/certs", "tlsredirectaddr": ":80"}`,
+			want: Config{TLSAutocertHosts: "tasks.example.com", TLSAutocertCacheDir: "/var/lib/certs", TLSRedirectAddr: ":80"},
+		},
+		{
+			name: "http2",
+			data: `{"h2c": true, "http2maxstreams": 100}`,
+			want: Config{H2C: true, HTTP2MaxStreams: 100},
+		},
+		{
+			name: "http transport",
+			data: `{"httpreadtimeout": "20s", "httpreadheadertimeout": "5s", "httpwritetimeout": "1m", "httpidletimeout": 90, "httpmaxheaderbytes": 65536, "httpkeepalivesdisabled": true, "httptcpkeepalive": "-1s"}`,
+			want: Config{
+				HTTPReadTimeout:        20 * time.Second,
+				HTTPReadHeaderTimeout:  5 * time.Second,
+				HTTPWriteTimeout:       time.Minute,
+				HTTPIdleTimeout:        90 * time.Second,
+				HTTPMaxHeaderBytes:     65536,
+				HTTPKeepAlivesDisabled: true,
+				HTTPTCPKeepAlive:       -time.Second,
+			},
+		},
+		{
+			name: "unix socket",
+			data: `{"addr": "unix:/run/tasks/api.sock", "unixsocketmode": "0600"}`,
+			want: Config{Addr: "unix:/run/tasks/api.sock", UnixSocketMode: "0600"},
+		},
+		{
+			name: "ui",
+			data: `{"uidir": "/srv/ui", "uiembedded": true}`,
+			want: Config{UIDir: "/srv/ui", UIEmbedded: true},
+		},
+		{
+			name: "maintenance",
+			data: `{"maintenancemode": true, "maintenanceretryafter": "30s"}`,
+			want: Config{MaintenanceMode: true, MaintenanceRetryAfter: 30 * time.Second},
+		},
+		{
+			name: "feature flags",
+			data: `{"featureflags": {"webhooks": false, "graphql": true}}`,
+			want: Config{FeatureFlags: map[string]bool{"webhooks": false, "graphql": true}},
+		},
+		{
+			name:    "invalid duration",
+			data:    `{"jwtttl": "soon"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			err := json.Unmarshal([]byte(tt.data), &cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestParseOperationTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			name:  "several operations",
+			value: "ExportTasks=10m, GetTasks=2s,",
+			want:  map[string]time.Duration{"ExportTasks": 10 * time.Minute, "GetTasks": 2 * time.Second},
+		},
+		{
+			name:    "missing duration",
+			value:   "ExportTasks",
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			value:   "ExportTasks=soon",
+			wantErr: true,
+		},
+		{
+			name:    "missing operation",
+			value:   "=5s",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOperationTimeouts(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseDotEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "plain values and comments",
+			data: "# local settings\nPORT=9090\n\nDB_HOST = localhost # docker\r\nexport JWT_TTL=15m\n",
+			want: map[string]string{"PORT": "9090", "DB_HOST": "localhost", "JWT_TTL": "15m"},
+		},
+		{
+			name: "quoted values",
+			data: "SMTP_FROM=\"Tasks <noreply@example.com>\"\nDB_PASSWORD='p#ss word'\nGREETING=\"line\\nbreak\"\nEMPTY=\n",
+			want: map[string]string{"SMTP_FROM": "Tasks <noreply@example.com>", "DB_PASSWORD": "p#ss word", "GREETING": "line\nbreak", "EMPTY": ""},
+		},
+		{name: "missing separator", data: "PORT\n", wantErr: true},
+		{name: "unterminated quote", data: "SECRET=\"abc\n", wantErr: true},
+		{name: "key with spaces", data: "DB HOST=localhost\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotEnv(tt.data)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errors.ErrConfigInvalidFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadDotEnvKeepsExistingVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.env")
+	require.NoError(t, os.WriteFile(path, []byte("DOTENV_TEST_NEW=from-file\nDOTENV_TEST_SET=from-file\n"), 0o600))
+	t.Setenv("ENV_FILE", path)
+	t.Setenv("DOTENV_TEST_SET", "from-shell")
+	t.Cleanup(func() { os.Unsetenv("DOTENV_TEST_NEW") })
+
+	require.NoError(t, loadDotEnv())
+
+	assert.Equal(t, "from-file", os.Getenv("DOTENV_TEST_NEW"))
+	assert.Equal(t, "from-shell", os.Getenv("DOTENV_TEST_SET"))
+}
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, []byte("cert"), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0o600))
+
+	valid := func() Config {
+		return Config{Addr: defaultAddr, Port: defaultPort, DBStr: defaultDBStr}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr []error
+	}{
+		{name: "defaults", modify: func(c *Config) {}},
+		{name: "key value dsn", modify: func(c *Config) { c.DBStr = "host=localhost user=tasks dbname=tasks" }},
+		{name: "unix socket ignores port", modify: func(c *Config) { c.Addr = "unix:/run/tasks.sock"; c.Port = 0 }},
+		{name: "tls pair present", modify: func(c *Config) { c.TLSCertFile = certFile; c.TLSKeyFile = keyFile }},
+		{name: "captcha with secret", modify: func(c *Config) { c.CaptchaProvider = "hcaptcha"; c.CaptchaSecret = "secret" }},
+		{name: "port out of range", modify: func(c *Config) { c.Port = 70000 }, wantErr: []error{errors.ErrConfigInvalidPort}},
+		{name: "invalid dsn", modify: func(c *Config) { c.DBStr = "postgres://[::1" }, wantErr: []error{errors.ErrConfigInvalidDSN}},
+		{name: "empty dsn", modify: func(c *Config) { c.DBStr = "" }, wantErr: []error{errors.ErrConfigInvalidDSN}},
+		{name: "invalid replica dsn", modify: func(c *Config) { c.DBReplicaStr = "postgres://[::1" }, wantErr: []error{errors.ErrConfigInvalidDSN}},
+		{name: "tls key missing", modify: func(c *Config) { c.TLSCertFile = certFile }, wantErr: []error{errors.ErrTLSKeyPairIncomplete}},
+		{name: "tls file not found", modify: func(c *Config) { c.TLSCertFile = certFile; c.TLSKeyFile = filepath.Join(dir, "missing.pem") }, wantErr: []error{errors.ErrTLSFileNotFound}},
+		{name: "captcha without secret", modify: func(c *Config) { c.CaptchaProvider = "recaptcha" }, wantErr: []error{errors.ErrConfigSecretMissing}},
+		{name: "unknown captcha provider", modify: func(c *Config) { c.CaptchaProvider = "turnstile" }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "email reminders without smtp", modify: func(c *Config) { c.ReminderChannels = "log,email" }, wantErr: []error{errors.ErrConfigSecretMissing}},
+		{name: "redis response cache without address", modify: func(c *Config) { c.ResponseCache = ResponseCacheRedis }, wantErr: []error{errors.ErrConfigSecretMissing}},
+		{name: "invalid latency buckets", modify: func(c *Config) { c.MetricsLatencyBuckets = "0.1,fast" }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "unknown log level", modify: func(c *Config) { c.LogLevel = "verbose" }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "unknown log format", modify: func(c *Config) { c.LogFormat = "xml" }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "invalid slo window", modify: func(c *Config) { c.SLOWindows = "5m,-1h" }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "negative write timeout", modify: func(c *Config) { c.HTTPWriteTimeout = -time.Second }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "negative user cache size", modify: func(c *Config) { c.UserCacheSize = -1 }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "user cache without ttl", modify: func(c *Config) { c.UserCacheSize = 100 }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{name: "user cache ttl above staleness bound", modify: func(c *Config) { c.UserCacheSize = 100; c.UserCacheTTL = 2 * time.Minute }, wantErr: []error{errors.ErrUserCacheTTLTooLong}},
+		{name: "negative max header bytes", modify: func(c *Config) { c.HTTPMaxHeaderBytes = -1 }, wantErr: []error{errors.ErrConfigInvalidFormat}},
+		{
+			name: "invalid environment and port together",
+			modify: func(c *Config) {
+				c.invalidEnv("JWT_TTL", "soon")
+				c.Port = -1
+			},
+			wantErr: []error{errors.ErrConfigInvalidFormat, errors.ErrConfigInvalidPort},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, want := range tt.wantErr {
+				assert.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	corsAllowMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsMaxAge       = 10 * time.Minute
+)
+
+var (
+	corsAllowHeaders  = strings.Join([]string{"Authorization", "Content-Type", "Content-Encoding", "Accept-Language", "If-Match", "If-None-Match", IdempotencyKeyHeader, requestid.Header}, ", ")
+	corsExposeHeaders = strings.Join([]string{requestid.Header, IdempotencyReplayedHeader, "Content-Disposition", "ETag"}, ", ")
+)
+
+type wildcardOrigin struct {
+	scheme string
+	suffix string
+}
+
+type corsPolicy struct {
+	anyOrigin bool
+	exact     map[string]bool
+	wildcards []wildcardOrigin
+}
+
+func newCORSPolicy(origins []string) *corsPolicy {
+	policy := &corsPolicy{exact: make(map[string]bool)}
+	for _, origin := range origins {
+		origin = strings.TrimRight(strings.ToLower(strings.TrimSpace(origin)), "/")
+		switch {
+		case origin == "":
+		case origin == "*":
+			policy.anyOrigin = true
+		case strings.Contains(origin, "://*."):
+			scheme, host, _ := strings.Cut(origin, "://*")
+			policy.wildcards = append(policy.wildcards, wildcardOrigin{scheme: scheme, suffix: host})
+		default:
+			policy.exact[origin] = true
+		}
+	}
+	return policy
+}
+
+func (p *corsPolicy) enabled() bool {
+	return p.anyOrigin || len(p.exact) > 0 || len(p.wildcards) > 0
+}
+
+func (p *corsPolicy) allows(origin string) bool {
+	origin = strings.ToLower(origin)
+	if p.exact[origin] {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Host == "" || parsed.Path != "" {
+		return false
+	}
+	for _, wildcard := range p.wildcards {
+		host := parsed.Host
+		if parsed.Scheme == wildcard.scheme && strings.HasSuffix(host, wildcard.suffix) && len(host) > len(wildcard.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *TaskAPI) corsMiddleware() gin.HandlerFunc {
+	policy := api.cors
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		if origin == "" || !policy.enabled() {
+			ctx.Next()
+			return
+		}
+		ctx.Writer.Header().Add("Vary", "Origin")
+		preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader("Access-Control-Request-Method") != ""
+
+		switch {
+		case policy.allows(origin):
+			ctx.Header("Access-Control-Allow-Origin", origin)
+			ctx.Header("Access-Control-Allow-Credentials", "true")
+		case policy.anyOrigin:
+			ctx.Header("Access-Control-Allow-Origin", "*")
+		case preflight:
+			respondError(ctx, http.StatusForbidden, errors.ErrCORSOriginForbidden)
+			return
+		default:
+			ctx.Next()
+			return
+		}
+
+		if !preflight {
+			ctx.Header("Access-Control-Expose-Headers", corsExposeHeaders)
+			ctx.Next()
+			return
+		}
+		ctx.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		ctx.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		ctx.Header("Access-Control-Allow-Methods", corsAllowMethods)
+		ctx.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+		ctx.Header("Access-Control-Max-Age", strconv.Itoa(int(corsMaxAge.Seconds())))
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
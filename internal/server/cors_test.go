@@ -0,0 +1,106 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSPolicyAllows(t *testing.T) {
+	policy := newCORSPolicy([]string{" https://app.example.com/ ", "https://*.example.org", ""})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "https://app.example.com", allowed: true},
+		{origin: "HTTPS://APP.EXAMPLE.COM", allowed: true},
+		{origin: "http://app.example.com", allowed: false},
+		{origin: "https://evil.example.com", allowed: false},
+		{origin: "https://team.example.org", allowed: true},
+		{origin: "https://a.b.example.org", allowed: true},
+		{origin: "https://team.example.org:8443", allowed: false},
+		{origin: "https://example.org", allowed: false},
+		{origin: "https://evilexample.org", allowed: false},
+		{origin: "http://team.example.org", allowed: false},
+		{origin: "null", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, policy.allows(tt.origin))
+		})
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       string
+		method        string
+		origin        string
+		preflight     bool
+		expectedCode  int
+		expectedAllow string
+		credentials   bool
+	}{
+		{name: "default sends no headers", method: "OPTIONS", origin: "https://app.example.com", preflight: true, expectedCode: http.StatusNotFound},
+		{name: "allowed preflight", allowed: "https://app.example.com", method: "OPTIONS", origin: "https://app.example.com", preflight: true, expectedCode: http.StatusNoContent, expectedAllow: "https://app.example.com", credentials: true},
+		{name: "forbidden preflight", allowed: "https://app.example.com", method: "OPTIONS", origin: "https://evil.example.com", preflight: true, expectedCode: http.StatusForbidden},
+		{name: "allowed simple request", allowed: "https://app.example.com", method: "GET", origin: "https://app.example.com", expectedCode: http.StatusOK, expectedAllow: "https://app.example.com", credentials: true},
+		{name: "foreign simple request", allowed: "https://app.example.com", method: "GET", origin: "https://evil.example.com", expectedCode: http.StatusOK},
+		{name: "wildcard without credentials", allowed: "*", method: "GET", origin: "https://any.example.net", expectedCode: http.StatusOK, expectedAllow: "*"},
+		{name: "no origin", allowed: "*", method: "GET", expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{AllowedOrigins: tt.allowed})
+
+			req, _ := http.NewRequest(tt.method, "/livez", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+				req.Header.Set("Access-Control-Request-Headers", "Content-Type, Idempotency-Key")
+			}
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Access-Control-Allow-Origin"))
+			if tt.credentials {
+				assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+			} else {
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+			}
+
+			switch tt.expectedCode {
+			case http.StatusNoContent:
+				assert.Equal(t, corsAllowMethods, w.Header().Get("Access-Control-Allow-Methods"))
+				assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), IdempotencyKeyHeader)
+				assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+			case http.StatusForbidden:
+				var response models.ErrorEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "cors_origin_forbidden", response.Error.Code)
+				assert.Equal(t, errors.ErrCORSOriginForbidden.Error(), response.Error.Message)
+			}
+			if tt.expectedAllow != "" && tt.method != "OPTIONS" {
+				assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), IdempotencyReplayedHeader)
+			}
+			if tt.allowed != "" && tt.origin != "" {
+				assert.Contains(t, w.Header().Values("Vary"), "Origin")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) adminMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := api.getUserIDFromJWT(ctx)
+		if err != nil {
+			respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+			return
+		}
+		user, err := api.storage.GetUserByID(ctx.Request.Context(), userID)
+		if err != nil || user.Role != models.RoleAdmin {
+			respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func (api *TaskAPI) configDebugRoutes(router *gin.Engine) {
+	debugGroup := router.Group("/debug", api.adminMiddleware())
+	{
+		debugGroup.GET("/runtime", api.getRuntimeStats)
+		debugGroup.GET("/pprof/", gin.WrapF(pprof.Index))
+		debugGroup.GET("/pprof/cmdline", gin.WrapF(pprof.Cmdline))
+		debugGroup.GET("/pprof/profile", gin.WrapF(pprof.Profile))
+		debugGroup.GET("/pprof/symbol", gin.WrapF(pprof.Symbol))
+		debugGroup.POST("/pprof/symbol", gin.WrapF(pprof.Symbol))
+		debugGroup.GET("/pprof/trace", gin.WrapF(pprof.Trace))
+		debugGroup.GET("/pprof/:profile", func(ctx *gin.Context) {
+			pprof.Handler(ctx.Param("profile")).ServeHTTP(ctx.Writer, ctx.Request)
+		})
+	}
+}
+
+func (api *TaskAPI) getRuntimeStats(ctx *gin.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var lastGC *time.Time
+	if mem.LastGC > 0 {
+		t := time.Unix(0, int64(mem.LastGC)).UTC()
+		lastGC = &t
+	}
+
+	build := gin.H{"go_version": runtime.Version()}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		build["path"] = info.Main.Path
+		build["version"] = info.Main.Version
+		settings := make(map[string]string, len(info.Settings))
+		for _, setting := range info.Settings {
+			settings[setting.Key] = setting.Value
+		}
+		build["settings"] = settings
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"goroutines": runtime.NumGoroutine(),
+		"gomaxprocs": runtime.GOMAXPROCS(0),
+		"num_cpu":    runtime.NumCPU(),
+		"memory": gin.H{
+			"alloc_bytes":       mem.Alloc,
+			"total_alloc_bytes": mem.TotalAlloc,
+			"sys_bytes":         mem.Sys,
+			"heap_inuse_bytes":  mem.HeapInuse,
+			"heap_objects":      mem.HeapObjects,
+		},
+		"gc": gin.H{
+			"num_gc":          mem.NumGC,
+			"pause_total_ns":  mem.PauseTotalNs,
+			"last_gc":         lastGC,
+			"gc_cpu_fraction": mem.GCCPUFraction,
+			"next_gc_bytes":   mem.NextGC,
+		},
+		"build":          build,
+		"uptime_seconds": int64(time.Since(api.startedAt).Seconds()),
+	})
+}
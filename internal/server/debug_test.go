@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugEndpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		path       string
+		userID     string
+		statusCode int
+	}{
+		{
+			name:       "disabled by default",
+			path:       "/debug/runtime",
+			userID:     "admin1",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "runtime stats for admin",
+			enabled:    true,
+			path:       "/debug/runtime",
+			userID:     "admin1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "pprof index for admin",
+			enabled:    true,
+			path:       "/debug/pprof/",
+			userID:     "admin1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "named profile for admin",
+			enabled:    true,
+			path:       "/debug/pprof/goroutine?debug=1",
+			userID:     "admin1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "regular user is forbidden",
+			enabled:    true,
+			path:       "/debug/pprof/heap",
+			userID:     "user123",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "anonymous request",
+			enabled:    true,
+			path:       "/debug/runtime",
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockRepo.On("GetUserByID", "admin1").Return(&models.User{ID: "admin1", Role: "admin"}, nil).Maybe()
+			mockRepo.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "user"}, nil).Maybe()
+
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{DebugEndpoints: tt.enabled})
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			if tt.userID != "" {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(tt.userID)})
+			}
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func TestRuntimeStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := &MockUserStore{}
+	mockRepo.On("GetUserByID", "admin1").Return(&models.User{ID: "admin1", Role: "admin"}, nil)
+
+	api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{DebugEndpoints: true})
+
+	req, _ := http.NewRequest("GET", "/debug/runtime", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("admin1")})
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Goroutines int                    `json:"goroutines"`
+		Memory     map[string]interface{} `json:"memory"`
+		GC         map[string]interface{} `json:"gc"`
+		Build      map[string]interface{} `json:"build"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Positive(t, response.Goroutines)
+	assert.Contains(t, response.Memory, "alloc_bytes")
+	assert.Contains(t, response.GC, "num_gc")
+	assert.NotEmpty(t, response.Build["go_version"])
+}
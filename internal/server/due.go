@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDueWindow = 24 * time.Hour
+	maxDueWindow     = 366 * 24 * time.Hour
+
+	dueWindowToday = "today"
+)
+
+func endOfDay(now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+}
+
+func (api *TaskAPI) getOverdueTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	tasks, err := api.storage.GetOverdueTasks(ctx.Request.Context(), userID, time.Now())
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	loc := api.userPreferences(ctx.Request.Context(), userID).Location()
+	localizeDueDates(tasks, loc)
+	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks, "timezone": loc.String()})
+}
+
+func (api *TaskAPI) getDueTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	loc := api.userPreferences(ctx.Request.Context(), userID).Location()
+	now := time.Now()
+	within := defaultDueWindow
+	label := ""
+	if raw := ctx.Query("within"); raw == dueWindowToday {
+		within = endOfDay(now, loc).Sub(now)
+		label = dueWindowToday
+	} else if raw != "" {
+		within, err = time.ParseDuration(raw)
+		if err != nil || within <= 0 || within > maxDueWindow {
+			respondError(ctx, http.StatusBadRequest, errors.ErrDueWindow)
+			return
+		}
+	}
+	if label == "" {
+		label = within.String()
+	}
+	tasks, err := api.storage.GetDueTasks(ctx.Request.Context(), userID, now, now.Add(within))
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	localizeDueDates(tasks, loc)
+	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks, "within": label, "timezone": loc.String()})
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDueTaskHandlers(t *testing.T) {
+	window := func(d time.Duration) interface{} {
+		return mock.MatchedBy(func(to time.Time) bool { return time.Until(to) > d-time.Minute && time.Until(to) <= d })
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "overdue tasks",
+			path:       "/tasks/overdue",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetOverdueTasks", mock.Anything, "user123", mock.AnythingOfType("time.Time")).Return([]models.Task{{ID: "task1", UserID: "user123"}}, nil)
+			},
+		},
+		{
+			name:       "overdue storage error",
+			path:       "/tasks/overdue",
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetOverdueTasks", mock.Anything, "user123", mock.AnythingOfType("time.Time")).Return(nil, errors.ErrInternalServer)
+			},
+		},
+		{
+			name:       "due with default window",
+			path:       "/tasks/due",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetDueTasks", mock.Anything, "user123", mock.AnythingOfType("time.Time"), window(24*time.Hour)).Return([]models.Task{}, nil)
+			},
+		},
+		{
+			name:       "due within 48h",
+			path:       "/tasks/due?within=48h",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetDueTasks", mock.Anything, "user123", mock.AnythingOfType("time.Time"), window(48*time.Hour)).Return([]models.Task{}, nil)
+			},
+		},
+		{
+			name:       "invalid window",
+			path:       "/tasks/due?within=soon",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "negative window",
+			path:       "/tasks/due?within=-1h",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
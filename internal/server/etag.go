@@ -0,0 +1,63 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func taskETag(tasks ...models.Task) string {
+	h := sha256.New()
+	for _, task := range tasks {
+		fmt.Fprintf(h, "%s:%d:%d:%s", task.ID, task.Version, task.UpdatedAt.UnixNano(), strings.Join(task.Tags, ","))
+		if task.Checklist != nil {
+			fmt.Fprintf(h, ":%d/%d", task.Checklist.Total, task.Checklist.Done)
+		}
+		h.Write([]byte{'\n'})
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func notModified(ctx *gin.Context, etag string) bool {
+	ctx.Header("ETag", etag)
+	match := ctx.GetHeader("If-None-Match")
+	if match == "" || !etagMatches(match, etag, true) {
+		return false
+	}
+	ctx.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+func checkIfMatch(ctx *gin.Context, task *models.Task) bool {
+	match := ctx.GetHeader("If-Match")
+	if match == "" {
+		return true
+	}
+	etag := taskETag(*task)
+	if etagMatches(match, etag, false) {
+		return true
+	}
+	ctx.Header("ETag", etag)
+	respondError(ctx, http.StatusPreconditionFailed, errors.ErrPreconditionFailed)
+	return false
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTaskETag(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	task := models.Task{ID: "task1", Version: 3, UpdatedAt: updatedAt}
+
+	etag := taskETag(task)
+	assert.Equal(t, etag, taskETag(task))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	bumped := task
+	bumped.Version++
+	assert.NotEqual(t, etag, taskETag(bumped))
+
+	tagged := task
+	tagged.Tags = []string{"work"}
+	assert.NotEqual(t, etag, taskETag(tagged))
+
+	assert.NotEqual(t, taskETag(task, bumped), taskETag(bumped, task))
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		weak   bool
+		want   bool
+	}{
+		{name: "exact", header: `"abc"`, want: true},
+		{name: "list", header: `"x", "abc"`, want: true},
+		{name: "wildcard", header: "*", want: true},
+		{name: "mismatch", header: `"x"`, want: false},
+		{name: "weak rejected by strong comparison", header: `W/"abc"`, want: false},
+		{name: "weak accepted by weak comparison", header: `W/"abc"`, weak: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, etagMatches(tt.header, `"abc"`, tt.weak))
+		})
+	}
+}
+
+func TestConditionalTaskRequests(t *testing.T) {
+	newTask := func() *models.Task {
+		return &models.Task{ID: "task1", Title: "Task", Status: "new", UserID: "user123", Version: 2, UpdatedAt: time.Unix(1700000000, 0)}
+	}
+	current := taskETag(*newTask())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		header     string
+		value      string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "get task returns etag",
+			method:     "GET",
+			path:       "/tasks/task1",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(newTask(), nil)
+			},
+		},
+		{
+			name:       "get task not modified",
+			method:     "GET",
+			path:       "/tasks/task1",
+			header:     "If-None-Match",
+			value:      current,
+			statusCode: http.StatusNotModified,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(newTask(), nil)
+			},
+		},
+		{
+			name:       "list not modified",
+			method:     "GET",
+			path:       "/tasks",
+			header:     "If-None-Match",
+			value:      current,
+			statusCode: http.StatusNotModified,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTasks", mock.Anything, "user123", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{*newTask()}, nil)
+			},
+		},
+		{
+			name:       "update with matching etag",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Title: "Renamed"},
+			header:     "If-Match",
+			value:      current,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(newTask(), nil)
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "update with stale etag",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Title: "Renamed"},
+			header:     "If-Match",
+			value:      `"stale"`,
+			statusCode: http.StatusPreconditionFailed,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(newTask(), nil)
+			},
+		},
+		{
+			name:       "patch with stale etag",
+			method:     "PATCH",
+			path:       "/tasks/task1",
+			body:       map[string]string{"title": "Renamed"},
+			header:     "If-Match",
+			value:      `"stale"`,
+			statusCode: http.StatusPreconditionFailed,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(newTask(), nil)
+			},
+		},
+		{
+			name:       "delete with stale etag",
+			method:     "DELETE",
+			path:       "/tasks/task1",
+			header:     "If-Match",
+			value:      `"stale"`,
+			statusCode: http.StatusPreconditionFailed,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(newTask(), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+			if tt.statusCode != http.StatusOK || tt.method == "GET" {
+				assert.Equal(t, current, w.Header().Get("ETag"))
+			} else {
+				assert.NotEmpty(t, w.Header().Get("ETag"))
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
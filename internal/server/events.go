@@ -0,0 +1,66 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/realtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+const eventHeartbeatInterval = 25 * time.Second
+
+type EventHub interface {
+	Subscribe(userID string) (<-chan realtime.Event, func())
+	Close()
+}
+
+func (api *TaskAPI) SetEventHub(hub EventHub) {
+	api.events = hub
+	api.httpSrv.RegisterOnShutdown(hub.Close)
+}
+
+func (api *TaskAPI) streamEvents(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	if api.events == nil {
+		respondError(ctx, http.StatusServiceUnavailable, errors.ErrRealtimeUnavailable)
+		return
+	}
+
+	events, unsubscribe := api.events.Subscribe(userID)
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	clearDeadlines(ctx)
+	_, _ = io.WriteString(ctx.Writer, ": connected\n\n")
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent(event.Name, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-ctx.Request.Context().Done():
+			return false
+		}
+	})
+}
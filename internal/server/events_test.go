@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"project/internal/realtime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEventsRequiresHubAndAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		withHub    bool
+		withToken  bool
+		statusCode int
+	}{
+		{name: "unauthorized", withHub: true, statusCode: http.StatusUnauthorized},
+		{name: "storage without events", withToken: true, statusCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			if tt.withHub {
+				api.SetEventHub(realtime.NewHub())
+			}
+
+			req, _ := http.NewRequest("GET", "/events", nil)
+			if tt.withToken {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			}
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+	hub := realtime.NewHub()
+	api.SetEventHub(hub)
+	srv := httptest.NewServer(api.httpSrv.Handler)
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"/events", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	hub.Publish(realtime.Event{Name: realtime.EventTaskUpdated, TaskID: "other", UserID: "user456"})
+	hub.Publish(realtime.Event{Name: realtime.EventTaskUpdated, TaskID: "task1", UserID: "user123"})
+	var frame []string
+	for len(frame) < 2 {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if line = strings.TrimSpace(line); line != "" {
+			frame = append(frame, line)
+		}
+	}
+	assert.Equal(t, "event:"+realtime.EventTaskUpdated, frame[0])
+	assert.Contains(t, frame[1], `"task_id":"task1"`)
+
+	hub.Close()
+	rest, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Empty(t, strings.TrimSpace(string(rest)))
+}
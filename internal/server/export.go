@@ -0,0 +1,134 @@
+package server
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/jsoncodec"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatJSON   = "json"
+	exportFormatNDJSON = "ndjson"
+)
+
+var exportCSVHeader = []string{"id", "title", "description", "status", "parent_id", "project_id", "assignee_id", "tags", "due_date", "archived"}
+
+func taskCSVRecord(task models.Task) []string {
+	dueDate := ""
+	if task.DueDate != nil {
+		dueDate = task.DueDate.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		task.ID,
+		task.Title,
+		task.Description,
+		task.Status,
+		task.ParentID,
+		task.ProjectID,
+		task.AssigneeID,
+		strings.Join(task.Tags, ";"),
+		dueDate,
+		strconv.FormatBool(task.Archived),
+	}
+}
+
+func (api *TaskAPI) exportTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	defaultFormat := exportFormatJSON
+	if wantsNDJSON(ctx) {
+		defaultFormat = exportFormatNDJSON
+	}
+	format := strings.ToLower(ctx.DefaultQuery("format", defaultFormat))
+	if format != exportFormatCSV && format != exportFormatJSON && format != exportFormatNDJSON {
+		respondError(ctx, http.StatusBadRequest, errors.ErrExportFormat)
+		return
+	}
+
+	filename := "tasks-" + time.Now().UTC().Format("20060102") + "." + format
+	ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	switch format {
+	case exportFormatCSV:
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	case exportFormatNDJSON:
+		ctx.Header("Content-Type", mimeNDJSON)
+	default:
+		ctx.Header("Content-Type", "application/json; charset=utf-8")
+	}
+	ctx.Status(http.StatusOK)
+	clearDeadlines(ctx)
+
+	switch format {
+	case exportFormatCSV:
+		err = api.exportTasksCSV(ctx, userID)
+	case exportFormatNDJSON:
+		err = api.exportTasksNDJSON(ctx, userID)
+	default:
+		err = api.exportTasksJSON(ctx, userID)
+	}
+	if err != nil {
+		requestid.Println(ctx.Request.Context(), "[ERROR] Экспорт задач прерван:", userID, err)
+	}
+}
+
+func (api *TaskAPI) exportTasksCSV(ctx *gin.Context, userID string) error {
+	w := csv.NewWriter(ctx.Writer)
+	if err := w.Write(exportCSVHeader); err != nil {
+		return err
+	}
+	err := api.storage.ExportTasks(ctx.Request.Context(), userID, func(task models.Task) error {
+		return w.Write(taskCSVRecord(task))
+	})
+	w.Flush()
+	if err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func (api *TaskAPI) exportTasksJSON(ctx *gin.Context, userID string) error {
+	if _, err := ctx.Writer.WriteString("["); err != nil {
+		return err
+	}
+	first := true
+	err := api.storage.ExportTasks(ctx.Request.Context(), userID, func(task models.Task) error {
+		data, err := jsoncodec.Marshal(task)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := ctx.Writer.WriteString(","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = ctx.Writer.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = ctx.Writer.WriteString("]")
+	return err
+}
+
+func (api *TaskAPI) exportTasksNDJSON(ctx *gin.Context, userID string) error {
+	written := 0
+	return api.storage.ExportTasks(ctx.Request.Context(), userID, func(task models.Task) error {
+		written++
+		return writeNDJSON(ctx.Writer, task, written)
+	})
+}
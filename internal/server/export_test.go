@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportTasks(t *testing.T) {
+	due := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	exported := []models.Task{
+		{ID: "task1", Title: "First, with comma", Status: "new", UserID: "user123", Tags: []string{"home", "urgent"}, DueDate: &due},
+		{ID: "task2", Title: "Second", Status: "done", UserID: "user123", Archived: true},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		accept      string
+		statusCode  int
+		contentType string
+		check       func(*testing.T, string)
+	}{
+		{
+			name:        "json export",
+			query:       "?format=json",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			check: func(t *testing.T, body string) {
+				var tasks []models.Task
+				assert.NoError(t, json.Unmarshal([]byte(body), &tasks))
+				assert.Equal(t, exported, tasks)
+			},
+		},
+		{
+			name:        "json is default",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			check: func(t *testing.T, body string) {
+				assert.True(t, strings.HasPrefix(body, "["))
+			},
+		},
+		{
+			name:        "csv export",
+			query:       "?format=csv",
+			statusCode:  http.StatusOK,
+			contentType: "text/csv",
+			check: func(t *testing.T, body string) {
+				records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+				assert.NoError(t, err)
+				assert.Len(t, records, 3)
+				assert.Equal(t, exportCSVHeader, records[0])
+				assert.Equal(t, "First, with comma", records[1][1])
+				assert.Equal(t, "home;urgent", records[1][7])
+				assert.Equal(t, "2025-03-01T12:00:00Z", records[1][8])
+				assert.Equal(t, "true", records[2][9])
+			},
+		},
+		{
+			name:        "ndjson export",
+			query:       "?format=ndjson",
+			statusCode:  http.StatusOK,
+			contentType: mimeNDJSON,
+			check: func(t *testing.T, body string) {
+				lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+				assert.Len(t, lines, 2)
+				var task models.Task
+				assert.NoError(t, json.Unmarshal([]byte(lines[1]), &task))
+				assert.Equal(t, exported[1], task)
+			},
+		},
+		{
+			name:        "ndjson from accept header",
+			accept:      mimeNDJSON,
+			statusCode:  http.StatusOK,
+			contentType: mimeNDJSON,
+			check: func(t *testing.T, body string) {
+				assert.True(t, strings.HasPrefix(body, `{"id":"task1"`))
+				assert.Equal(t, 2, strings.Count(body, "\n"))
+			},
+		},
+		{
+			name:       "unsupported format",
+			query:      "?format=xml",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			if tt.statusCode == http.StatusOK {
+				mockTaskRepo.On("ExportTasks", mock.Anything, "user123").Return(exported, nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks/export"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.check != nil {
+				assert.Contains(t, w.Header().Get("Content-Type"), tt.contentType)
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=")
+				tt.check(t, w.Body.String())
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	FeaturePurgeWorker = "purge_worker"
+	FeatureWebhooks    = "webhooks"
+	FeatureRealtime    = "realtime"
+	FeatureGraphQL     = "graphql"
+)
+
+var defaultFeatures = map[string]bool{
+	FeaturePurgeWorker: true,
+	FeatureWebhooks:    true,
+	FeatureRealtime:    true,
+	FeatureGraphQL:     true,
+}
+
+type FeatureFlags interface {
+	Enabled(name string) bool
+}
+
+type StaticFeatureFlags map[string]bool
+
+func NewStaticFeatureFlags(flags map[string]bool) StaticFeatureFlags {
+	merged := make(map[string]bool, len(defaultFeatures)+len(flags))
+	for name, enabled := range defaultFeatures {
+		merged[name] = enabled
+	}
+	for name, enabled := range flags {
+		merged[strings.ToLower(name)] = enabled
+	}
+	return StaticFeatureFlags(merged)
+}
+
+func (f StaticFeatureFlags) Enabled(name string) bool {
+	return f[name]
+}
+
+func parseFeatureFlags(raw string) (map[string]bool, bool) {
+	flags := map[string]bool{}
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, found := strings.Cut(item, "=")
+		enabled := true
+		if found {
+			b, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, false
+			}
+			enabled = b
+		}
+		flags[strings.ToLower(strings.TrimSpace(name))] = enabled
+	}
+	return flags, true
+}
+
+func (api *TaskAPI) SetFeatureFlags(flags FeatureFlags) {
+	api.features = flags
+}
+
+func (api *TaskAPI) FeatureEnabled(name string) bool {
+	return api.features.Enabled(name)
+}
+
+func (api *TaskAPI) featureMiddleware(name string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !api.FeatureEnabled(name) {
+			respondError(ctx, http.StatusNotFound, errors.ErrFeatureDisabled)
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"project/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  map[string]bool
+		ok    bool
+	}{
+		{name: "explicit values", value: "webhooks=false, graphql=true", want: map[string]bool{"webhooks": false, "graphql": true}, ok: true},
+		{name: "bare name enables", value: "Realtime", want: map[string]bool{"realtime": true}, ok: true},
+		{name: "empty items skipped", value: "purge_worker=0,,", want: map[string]bool{"purge_worker": false}, ok: true},
+		{name: "invalid value", value: "webhooks=maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, ok := parseFeatureFlags(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, flags)
+		})
+	}
+}
+
+func TestStaticFeatureFlags(t *testing.T) {
+	flags := NewStaticFeatureFlags(map[string]bool{"Webhooks": false, "beta_search": true})
+
+	assert.True(t, flags.Enabled(FeaturePurgeWorker))
+	assert.True(t, flags.Enabled(FeatureGraphQL))
+	assert.False(t, flags.Enabled(FeatureWebhooks))
+	assert.True(t, flags.Enabled("beta_search"))
+	assert.False(t, flags.Enabled("unknown"))
+}
+
+func TestFeatureMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		flags      map[string]bool
+		method     string
+		path       string
+		statusCode int
+	}{
+		{name: "graphql disabled", flags: map[string]bool{FeatureGraphQL: false}, method: "POST", path: "/graphql", statusCode: http.StatusNotFound},
+		{name: "webhooks disabled", flags: map[string]bool{FeatureWebhooks: false}, method: "GET", path: "/webhooks", statusCode: http.StatusNotFound},
+		{name: "realtime disabled", flags: map[string]bool{FeatureRealtime: false}, method: "GET", path: "/events", statusCode: http.StatusNotFound},
+		{name: "other features unaffected", flags: map[string]bool{FeatureRealtime: false}, method: "POST", path: "/graphql", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{FeatureFlags: tt.flags})
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode == http.StatusNotFound {
+				var response models.ErrorEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "feature_disabled", response.Error.Code)
+			}
+		})
+	}
+}
+
+func TestDispatchWebhooksFeatureDisabled(t *testing.T) {
+	task := models.Task{ID: "task1", UserID: "owner"}
+	dispatcher := &recordingDispatcher{}
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{FeatureFlags: map[string]bool{FeatureWebhooks: false}})
+	api.SetWebhookDispatcher(dispatcher)
+
+	api.recordTaskEvent(t.Context(), "actor", models.TaskEventCreate, nil, &task)
+
+	assert.Empty(t, dispatcher.events)
+}
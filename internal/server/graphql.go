@@ -0,0 +1,448 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/graphql"
+
+	"github.com/gin-gonic/gin"
+)
+
+type graphqlUserKey struct{}
+
+var taskFilterArgs = []string{"status", "title_contains", "tag", "project", "workspace", "view", "deleted", "archived", "sort", "limit", "offset"}
+
+func graphqlUserID(ctx context.Context) string {
+	userID, _ := ctx.Value(graphqlUserKey{}).(string)
+	return userID
+}
+
+func (api *TaskAPI) graphqlQuery(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req graphql.Request
+	if err := bindRequest(ctx, &req); err != nil || strings.TrimSpace(req.Query) == "" {
+		respondError(ctx, http.StatusBadRequest, errors.ErrBadRequest)
+		return
+	}
+	if api.maintenance.active() && graphql.IsMutation(req) {
+		api.rejectMaintenance(ctx)
+		return
+	}
+
+	resp := api.schema.Execute(context.WithValue(ctx.Request.Context(), graphqlUserKey{}, userID), req)
+	if len(resp.Errors) > 0 {
+		locale := api.requestLocale(ctx)
+		for _, e := range resp.Errors {
+			e.Message = errors.Localize(e.Message, locale)
+		}
+	}
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	ctx.JSON(status, resp)
+}
+
+func sourceOf[T any](source interface{}) *T {
+	if value, ok := source.(*T); ok {
+		return value
+	}
+	value := source.(T)
+	return &value
+}
+
+func scalarField[T any](get func(*T) interface{}) *graphql.FieldDef {
+	return &graphql.FieldDef{Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+		return get(sourceOf[T](source)), nil
+	}}
+}
+
+func optionalID(id string) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func (api *TaskAPI) newGraphQLSchema() *graphql.Schema {
+	checklist := &graphql.Object{Name: "ChecklistProgress", Fields: map[string]*graphql.FieldDef{
+		"total": scalarField(func(p *models.ChecklistProgress) interface{} { return p.Total }),
+		"done":  scalarField(func(p *models.ChecklistProgress) interface{} { return p.Done }),
+		"ratio": scalarField(func(p *models.ChecklistProgress) interface{} { return p.Ratio }),
+	}}
+
+	task := &graphql.Object{Name: "Task", Fields: map[string]*graphql.FieldDef{
+		"id":                      scalarField(func(t *models.Task) interface{} { return t.ID }),
+		"title":                   scalarField(func(t *models.Task) interface{} { return t.Title }),
+		"description":             scalarField(func(t *models.Task) interface{} { return t.Description }),
+		"status":                  scalarField(func(t *models.Task) interface{} { return t.Status }),
+		"user_id":                 scalarField(func(t *models.Task) interface{} { return t.UserID }),
+		"parent_id":               scalarField(func(t *models.Task) interface{} { return optionalID(t.ParentID) }),
+		"project_id":              scalarField(func(t *models.Task) interface{} { return optionalID(t.ProjectID) }),
+		"workspace_id":            scalarField(func(t *models.Task) interface{} { return optionalID(t.WorkspaceID) }),
+		"assignee_id":             scalarField(func(t *models.Task) interface{} { return optionalID(t.AssigneeID) }),
+		"position":                scalarField(func(t *models.Task) interface{} { return t.Position }),
+		"deleted":                 scalarField(func(t *models.Task) interface{} { return t.Deleted }),
+		"archived":                scalarField(func(t *models.Task) interface{} { return t.Archived }),
+		"tags":                    scalarField(func(t *models.Task) interface{} { return t.Tags }),
+		"due_date":                scalarField(func(t *models.Task) interface{} { return t.DueDate }),
+		"reminder_offset_minutes": scalarField(func(t *models.Task) interface{} { return t.ReminderOffsetMinutes }),
+	}}
+	task.Fields["checklist"] = &graphql.FieldDef{Type: checklist, Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+		return sourceOf[models.Task](source).Checklist, nil
+	}}
+	task.Fields["subtasks"] = &graphql.FieldDef{Type: task, Resolve: api.resolveSubtasks}
+
+	user := &graphql.Object{Name: "User", Fields: map[string]*graphql.FieldDef{
+		"id":            scalarField(func(u *models.User) interface{} { return u.ID }),
+		"username":      scalarField(func(u *models.User) interface{} { return u.Username }),
+		"email":         scalarField(func(u *models.User) interface{} { return u.Email }),
+		"role":          scalarField(func(u *models.User) interface{} { return u.Role }),
+		"active":        scalarField(func(u *models.User) interface{} { return u.Active }),
+		"last_login_at": scalarField(func(u *models.User) interface{} { return u.LastLoginAt }),
+		"tasks":         {Type: task, Args: taskFilterArgs, Resolve: api.resolveTasks},
+	}}
+
+	project := &graphql.Object{Name: "Project", Fields: map[string]*graphql.FieldDef{
+		"id":   scalarField(func(p *models.Project) interface{} { return p.ID }),
+		"name": scalarField(func(p *models.Project) interface{} { return p.Name }),
+	}}
+
+	tag := &graphql.Object{Name: "Tag", Fields: map[string]*graphql.FieldDef{
+		"id":   scalarField(func(t *models.Tag) interface{} { return t.ID }),
+		"name": scalarField(func(t *models.Tag) interface{} { return t.Name }),
+	}}
+
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.FieldDef{
+		"me":       {Type: user, Resolve: api.resolveMe},
+		"task":     {Type: task, Args: []string{"id"}, Resolve: api.resolveTask},
+		"tasks":    {Type: task, Args: taskFilterArgs, Resolve: api.resolveTasks},
+		"search":   {Type: task, Args: []string{"q"}, Resolve: api.resolveSearch},
+		"projects": {Type: project, Resolve: api.resolveProjects},
+		"tags":     {Type: tag, Resolve: api.resolveTags},
+	}}
+
+	mutation := &graphql.Object{Name: "Mutation", Fields: map[string]*graphql.FieldDef{
+		"createTask": {
+			Type:    task,
+			Args:    []string{"title", "description", "parent_id", "project_id", "workspace_id", "due_date", "reminder_offset_minutes"},
+			Resolve: api.resolveCreateTask,
+		},
+		"updateTask": {
+			Type:    task,
+			Args:    []string{"id", "title", "description", "status", "project_id", "due_date", "reminder_offset_minutes"},
+			Resolve: api.resolveUpdateTask,
+		},
+		"deleteTask": {Args: []string{"id"}, Resolve: api.resolveDeleteTask},
+	}}
+
+	return &graphql.Schema{Query: query, Mutation: mutation}
+}
+
+func stringArg(args map[string]interface{}, name string) (string, error) {
+	value, err := graphql.String(args, name)
+	if err != nil || value == nil {
+		return "", err
+	}
+	return *value, nil
+}
+
+func timeArg(args map[string]interface{}, name string) (*time.Time, error) {
+	value, err := graphql.String(args, name)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, errors.ErrGraphQLArgument
+	}
+	return &parsed, nil
+}
+
+func (api *TaskAPI) resolveMe(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	user, err := api.storage.GetUserByID(ctx, graphqlUserID(ctx))
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, internalError(err)
+	}
+	return user, nil
+}
+
+func (api *TaskAPI) resolveTask(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	id, err := stringArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	return api.accessibleTask(ctx, graphqlUserID(ctx), id, false)
+}
+
+func (api *TaskAPI) resolveTasks(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	userID := graphqlUserID(ctx)
+	filter := models.TaskFilter{}
+	var err error
+	for name, target := range map[string]*string{
+		"status":         &filter.Status,
+		"title_contains": &filter.TitleContains,
+		"tag":            &filter.Tag,
+		"project":        &filter.ProjectID,
+		"workspace":      &filter.WorkspaceID,
+		"view":           &filter.View,
+	} {
+		if *target, err = stringArg(args, name); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Deleted, err = graphql.Bool(args, "deleted"); err != nil {
+		return nil, err
+	}
+	if filter.Archived, err = graphql.Bool(args, "archived"); err != nil {
+		return nil, err
+	}
+	sort, err := stringArg(args, "sort")
+	if err != nil {
+		return nil, err
+	}
+	filter.Sort = strings.TrimPrefix(sort, "-")
+	filter.Descending = strings.HasPrefix(sort, "-")
+	limit, err := graphql.Int(args, "limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit != nil {
+		if *limit < 1 {
+			return nil, errors.ErrTaskPage
+		}
+		filter.Limit = *limit
+	}
+	offset, err := graphql.Int(args, "offset")
+	if err != nil {
+		return nil, err
+	}
+	if offset != nil {
+		filter.Offset = *offset
+	}
+	if err := api.checkTaskFilter(ctx, userID, filter); err != nil {
+		return nil, err
+	}
+
+	tasks, err := api.storage.GetTasks(ctx, userID, filter)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return tasks, nil
+}
+
+func (api *TaskAPI) resolveSearch(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	query, err := stringArg(args, "q")
+	if err != nil {
+		return nil, err
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.ErrEmptySearchQuery
+	}
+	tasks, err := api.storage.SearchTasks(ctx, graphqlUserID(ctx), query)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return tasks, nil
+}
+
+func (api *TaskAPI) resolveSubtasks(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	subtasks, err := api.storage.GetSubtasks(ctx, sourceOf[models.Task](source).ID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return subtasks, nil
+}
+
+func (api *TaskAPI) resolveProjects(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	projects, err := api.storage.GetProjects(ctx, graphqlUserID(ctx))
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return projects, nil
+}
+
+func (api *TaskAPI) resolveTags(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	tags, err := api.storage.GetTags(ctx, graphqlUserID(ctx))
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return tags, nil
+}
+
+func (api *TaskAPI) resolveCreateTask(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	userID := graphqlUserID(ctx)
+	var req models.CreateTaskRequest
+	var err error
+	if req.Title, err = stringArg(args, "title"); err != nil {
+		return nil, err
+	}
+	if req.Description, err = stringArg(args, "description"); err != nil {
+		return nil, err
+	}
+	if req.ParentID, err = stringArg(args, "parent_id"); err != nil {
+		return nil, err
+	}
+	if req.ProjectID, err = stringArg(args, "project_id"); err != nil {
+		return nil, err
+	}
+	if req.WorkspaceID, err = stringArg(args, "workspace_id"); err != nil {
+		return nil, err
+	}
+	if req.DueDate, err = timeArg(args, "due_date"); err != nil {
+		return nil, err
+	}
+	offset, err := graphql.Int(args, "reminder_offset_minutes")
+	if err != nil {
+		return nil, err
+	}
+	if offset != nil {
+		req.ReminderOffsetMinutes = *offset
+	}
+	valid := requestValidator.validate
+	if err := valid.Struct(req); err != nil {
+		return nil, errors.ErrInvalidRequest
+	}
+
+	status, err := api.initialStatus(ctx, userID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	task := models.Task{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      status,
+		UserID:      userID,
+		ParentID:    req.ParentID,
+		ProjectID:   req.ProjectID,
+		WorkspaceID: req.WorkspaceID,
+
+		DueDate:               req.DueDate,
+		ReminderOffsetMinutes: req.ReminderOffsetMinutes,
+	}
+	if err := api.checkParent(ctx, userID, &task); err != nil {
+		return nil, err
+	}
+	if err := api.checkProject(ctx, userID, task.ProjectID); err != nil {
+		return nil, err
+	}
+	if err := api.checkTaskWorkspace(ctx, userID, task.WorkspaceID); err != nil {
+		return nil, err
+	}
+	if err := api.storage.CreateTask(ctx, &task); err != nil {
+		if err == errors.ErrConflict {
+			return nil, errors.ErrConflict
+		}
+		return nil, internalError(err)
+	}
+	api.recordTaskEvent(ctx, userID, models.TaskEventCreate, nil, &task)
+	return &task, nil
+}
+
+func (api *TaskAPI) resolveUpdateTask(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	userID := graphqlUserID(ctx)
+	id, err := stringArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	var req models.UpdateTaskRequest
+	if req.Title, err = stringArg(args, "title"); err != nil {
+		return nil, err
+	}
+	if req.Description, err = stringArg(args, "description"); err != nil {
+		return nil, err
+	}
+	if req.Status, err = stringArg(args, "status"); err != nil {
+		return nil, err
+	}
+	if req.DueDate, err = timeArg(args, "due_date"); err != nil {
+		return nil, err
+	}
+	if req.ReminderOffsetMinutes, err = graphql.Int(args, "reminder_offset_minutes"); err != nil {
+		return nil, err
+	}
+	if req.ProjectID, err = graphql.String(args, "project_id"); err != nil {
+		return nil, err
+	}
+	valid := requestValidator.validate
+	if err := valid.Struct(req); err != nil {
+		return nil, errors.ErrInvalidRequest
+	}
+
+	statusOnly := req.Title == "" && req.Description == "" && req.DueDate == nil && req.ReminderOffsetMinutes == nil && req.ProjectID == nil
+	task, err := api.updatableTask(ctx, userID, id, statusOnly)
+	if err != nil {
+		return nil, err
+	}
+	before := *task
+	if req.Status != "" && req.Status != task.Status {
+		if err := api.checkStatusChange(ctx, task, req.Status); err != nil {
+			return nil, err
+		}
+	}
+	if req.Title != "" {
+		task.Title = req.Title
+	}
+	if req.Description != "" {
+		task.Description = req.Description
+	}
+	if req.Status != "" {
+		task.Status = req.Status
+	}
+	if req.DueDate != nil {
+		task.DueDate = req.DueDate
+	}
+	if req.ReminderOffsetMinutes != nil {
+		task.ReminderOffsetMinutes = *req.ReminderOffsetMinutes
+	}
+	if req.ProjectID != nil {
+		if err := api.checkProject(ctx, task.UserID, *req.ProjectID); err != nil {
+			return nil, err
+		}
+		task.ProjectID = *req.ProjectID
+	}
+	if err := api.storage.UpdateTask(ctx, id, task); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrTaskNotFound
+		}
+		return nil, internalError(err)
+	}
+	api.recordTaskEvent(ctx, userID, models.TaskEventUpdate, &before, task)
+	if req.Status != "" && task.ParentID != "" {
+		api.rollUpStatus(ctx, task.ParentID)
+	}
+	return task, nil
+}
+
+func (api *TaskAPI) resolveDeleteTask(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	userID := graphqlUserID(ctx)
+	id, err := stringArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	task, err := api.accessibleTask(ctx, userID, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.storage.DeleteTask(ctx, id); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrTaskNotFound
+		}
+		return nil, internalError(err)
+	}
+	api.recordTaskEvent(ctx, userID, models.TaskEventDelete, task, nil)
+	return true, nil
+}
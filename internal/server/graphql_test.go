@@ -0,0 +1,177 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/graphql"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLHandler(t *testing.T) {
+	ownTask := &models.Task{ID: "task1", Title: "Own", Status: models.StatusNew, UserID: "user123", Tags: []string{"home"}}
+	foreignTask := &models.Task{ID: "task2", Title: "Foreign", Status: models.StatusNew, UserID: "user456"}
+
+	tests := []struct {
+		name       string
+		request    graphql.Request
+		language   string
+		statusCode int
+		expected   string
+		mockSetup  func(*MockUserStore, *MockTaskStore)
+	}{
+		{
+			name:       "current user with filtered tasks",
+			request:    graphql.Request{Query: `{ me { username tasks(status: "new", limit: 10) { id title tags parent_id } } }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"me":{"username":"alice","tasks":[{"id":"task1","title":"Own","tags":["home"],"parent_id":null}]}}}`,
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				u.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Username: "alice"}, nil)
+				m.On("GetTasks", mock.Anything, "user123", models.TaskFilter{Status: models.StatusNew, Limit: 10}).Return([]models.Task{*ownTask}, nil)
+			},
+		},
+		{
+			name:       "task by id with variables",
+			request:    graphql.Request{Query: `query ($id: String!) { task(id: $id) { id status subtasks { id } } }`, Variables: map[string]interface{}{"id": "task1"}},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"task":{"id":"task1","status":"new","subtasks":[]}}}`,
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetSubtasks", mock.Anything, "task1").Return([]models.Task{}, nil)
+			},
+		},
+		{
+			name:       "foreign task is forbidden",
+			request:    graphql.Request{Query: `{ task(id: "task2") { id } }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"task":null},"errors":[{"message":"` + errors.ErrForbidden.Error() + `","locations":[{"line":1,"column":3}],"path":["task"]}]}`,
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task2").Return(foreignTask, nil)
+				m.On("GetTaskPermission", mock.Anything, "task2", "user123").Return("", nil)
+			},
+		},
+		{
+			name:       "invalid page",
+			request:    graphql.Request{Query: `{ tasks(limit: 500) { id } }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"tasks":null},"errors":[{"message":"` + errors.ErrTaskPage.Error() + `","locations":[{"line":1,"column":3}],"path":["tasks"]}]}`,
+			mockSetup:  func(u *MockUserStore, m *MockTaskStore) {},
+		},
+		{
+			name:       "unknown field is localized",
+			request:    graphql.Request{Query: `{ me { password } }`},
+			language:   "en",
+			statusCode: http.StatusBadRequest,
+			expected:   `{"errors":[{"message":"unknown field in GraphQL query","locations":[{"line":1,"column":8}]}]}`,
+			mockSetup:  func(u *MockUserStore, m *MockTaskStore) {},
+		},
+		{
+			name:       "create task",
+			request:    graphql.Request{Query: `mutation { createTask(title: "New task", reminder_offset_minutes: 30) { title status user_id reminder_offset_minutes } }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"createTask":{"title":"New task","status":"new","user_id":"user123","reminder_offset_minutes":30}}}`,
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("CreateTask", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "create task without title",
+			request:    graphql.Request{Query: `mutation { createTask(description: "no title") { id } }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"createTask":null},"errors":[{"message":"` + errors.ErrInvalidRequest.Error() + `","locations":[{"line":1,"column":12}],"path":["createTask"]}]}`,
+			mockSetup:  func(u *MockUserStore, m *MockTaskStore) {},
+		},
+		{
+			name:       "update task",
+			request:    graphql.Request{Query: `mutation { updateTask(id: "task1", title: "Renamed") { id title } }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"updateTask":{"id":"task1","title":"Renamed"}}}`,
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				task := *ownTask
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&task, nil)
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "delete missing task",
+			request:    graphql.Request{Query: `mutation { deleteTask(id: "missing") }`},
+			statusCode: http.StatusOK,
+			expected:   `{"data":{"deleteTask":null},"errors":[{"message":"` + errors.ErrTaskNotFound.Error() + `","locations":[{"line":1,"column":12}],"path":["deleteTask"]}]}`,
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "missing").Return(nil, errors.ErrNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockRepo, mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			_ = json.NewEncoder(&body).Encode(tt.request)
+			req, _ := http.NewRequest("POST", "/graphql", &body)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.language != "" {
+				req.Header.Set("Accept-Language", tt.language)
+			}
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.JSONEq(t, tt.expected, w.Body.String())
+			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGraphQLHandlerRecordsMutations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", Title: "Own", Status: models.StatusNew, UserID: "user123"}, nil)
+	mockTaskRepo.On("DeleteTask", mock.Anything, "task1").Return(nil)
+
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+	body, _ := json.Marshal(graphql.Request{Query: `mutation { deleteTask(id: "task1") }`})
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"deleteTask":true}}`, w.Body.String())
+	require.Len(t, mockTaskRepo.events, 1)
+	assert.Equal(t, models.TaskEventDelete, mockTaskRepo.events[0].Action)
+}
+
+func TestGraphQLHandlerUnauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+	body, _ := json.Marshal(graphql.Request{Query: `{ me { id } }`})
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/purge"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthDown     = "down"
+)
+
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (uint, bool, error)
+}
+
+type PurgeStatsSource interface {
+	PurgeStats() purge.Stats
+}
+
+func (api *TaskAPI) SetHealthChecker(checker HealthChecker) {
+	api.health = checker
+}
+
+func (api *TaskAPI) SetPurgeStatsSource(source PurgeStatsSource) {
+	api.purgeStats = source
+}
+
+func (api *TaskAPI) databaseHealth(ctx context.Context) (gin.H, gin.H) {
+	if api.health == nil {
+		database := gin.H{"status": healthDown, "storage": "memory", "error": errors.ErrInMemoryStorage.Error()}
+		migrations := gin.H{"status": healthDown, "error": errors.ErrInMemoryStorage.Error()}
+		return database, migrations
+	}
+
+	start := time.Now()
+	if err := api.health.Ping(ctx); err != nil {
+		requestid.Println(ctx, "[ERROR] Проверка соединения с базой данных не прошла:", err)
+		database := gin.H{"status": healthDown, "storage": "postgres", "error": errors.ErrDatabaseUnavailable.Error()}
+		migrations := gin.H{"status": healthDown, "error": errors.ErrDatabaseUnavailable.Error()}
+		return database, migrations
+	}
+	database := gin.H{"status": healthOK, "storage": "postgres", "latency_ms": time.Since(start).Milliseconds()}
+
+	version, dirty, err := api.health.MigrationVersion(ctx)
+	if err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось получить версию миграций:", err)
+		return database, gin.H{"status": healthDown, "error": errors.ErrDatabaseUnavailable.Error()}
+	}
+	migrations := gin.H{"status": healthOK, "version": version, "dirty": dirty}
+	if dirty {
+		migrations["status"] = healthDown
+		migrations["error"] = errors.ErrMigrationDirty.Error()
+	}
+	return database, migrations
+}
+
+func (api *TaskAPI) healthCheck(ctx *gin.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx.Request.Context(), healthCheckTimeout)
+	defer cancel()
+
+	database, migrations := api.databaseHealth(checkCtx)
+	components := gin.H{"database": database, "migrations": migrations}
+	if api.purgeStats != nil {
+		components["purge"] = gin.H{"status": healthOK, "stats": api.purgeStats.PurgeStats()}
+	}
+
+	status, code := healthOK, http.StatusOK
+	if database["status"] != healthOK || migrations["status"] != healthOK {
+		status, code = healthDegraded, http.StatusServiceUnavailable
+	}
+
+	uptime := time.Since(api.startedAt)
+	ctx.JSON(code, gin.H{
+		"status":         status,
+		"uptime":         uptime.Truncate(time.Second).String(),
+		"uptime_seconds": int64(uptime.Seconds()),
+		"components":     components,
+	})
+}
+
+type readiness struct {
+	shuttingDown atomic.Bool
+	drainDelay   time.Duration
+}
+
+func (api *TaskAPI) liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": healthOK})
+}
+
+func (api *TaskAPI) readinessCheck(ctx *gin.Context) {
+	if api.readiness.shuttingDown.Load() {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": healthDown, "error": errors.ErrShuttingDown.Error()})
+		return
+	}
+	if api.health == nil {
+		ctx.JSON(http.StatusOK, gin.H{"status": healthOK, "components": gin.H{"database": gin.H{"status": healthOK, "storage": "memory"}}})
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx.Request.Context(), healthCheckTimeout)
+	defer cancel()
+
+	database, migrations := api.databaseHealth(checkCtx)
+	status, code := healthOK, http.StatusOK
+	if database["status"] != healthOK || migrations["status"] != healthOK {
+		status, code = healthDown, http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, gin.H{
+		"status":     status,
+		"components": gin.H{"database": database, "migrations": migrations},
+	})
+}
+
+func (api *TaskAPI) drain(ctx context.Context) {
+	if api.readiness.shuttingDown.Swap(true) || api.readiness.drainDelay <= 0 {
+		return
+	}
+	log.Printf("[INFO] Readiness переведен в состояние down, ожидание %v для вывода из балансировки", api.readiness.drainDelay)
+	timer := time.NewTimer(api.readiness.drainDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/purge"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubHealthChecker struct {
+	pingErr    error
+	version    uint
+	dirty      bool
+	versionErr error
+}
+
+func (s *stubHealthChecker) Ping(ctx context.Context) error {
+	return s.pingErr
+}
+
+func (s *stubHealthChecker) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	return s.version, s.dirty, s.versionErr
+}
+
+type stubPurgeStats struct {
+	stats purge.Stats
+}
+
+func (s *stubPurgeStats) PurgeStats() purge.Stats {
+	return s.stats
+}
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		checker        HealthChecker
+		statusCode     int
+		wantStatus     string
+		wantDatabase   string
+		wantMigrations string
+		wantVersion    float64
+		wantError      error
+	}{
+		{
+			name:           "healthy database",
+			checker:        &stubHealthChecker{version: 22},
+			statusCode:     http.StatusOK,
+			wantStatus:     healthOK,
+			wantDatabase:   healthOK,
+			wantMigrations: healthOK,
+			wantVersion:    22,
+		},
+		{
+			name:           "in-memory fallback",
+			statusCode:     http.StatusServiceUnavailable,
+			wantStatus:     healthDegraded,
+			wantDatabase:   healthDown,
+			wantMigrations: healthDown,
+			wantError:      errors.ErrInMemoryStorage,
+		},
+		{
+			name:           "database unreachable",
+			checker:        &stubHealthChecker{pingErr: context.DeadlineExceeded},
+			statusCode:     http.StatusServiceUnavailable,
+			wantStatus:     healthDegraded,
+			wantDatabase:   healthDown,
+			wantMigrations: healthDown,
+			wantError:      errors.ErrDatabaseUnavailable,
+		},
+		{
+			name:           "dirty migration",
+			checker:        &stubHealthChecker{version: 21, dirty: true},
+			statusCode:     http.StatusServiceUnavailable,
+			wantStatus:     healthDegraded,
+			wantDatabase:   healthOK,
+			wantMigrations: healthDown,
+			wantVersion:    21,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			if tt.checker != nil {
+				api.SetHealthChecker(tt.checker)
+			}
+			api.SetPurgeStatsSource(&stubPurgeStats{stats: purge.Stats{Runs: 3, Purged: 7}})
+
+			req, _ := http.NewRequest("GET", "/health", nil)
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			var response struct {
+				Status     string `json:"status"`
+				Uptime     string `json:"uptime"`
+				Components map[string]map[string]interface{}
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantStatus, response.Status)
+			assert.NotEmpty(t, response.Uptime)
+			assert.Equal(t, tt.wantDatabase, response.Components["database"]["status"])
+			assert.Equal(t, tt.wantMigrations, response.Components["migrations"]["status"])
+			if tt.wantVersion != 0 {
+				assert.Equal(t, tt.wantVersion, response.Components["migrations"]["version"])
+			}
+			if tt.wantError != nil {
+				assert.Equal(t, tt.wantError.Error(), response.Components["database"]["error"])
+			}
+			assert.Equal(t, map[string]interface{}{"runs": float64(3), "purged": float64(7), "failures": float64(0), "last_run": "0001-01-01T00:00:00Z", "last_count": float64(0)}, response.Components["purge"]["stats"])
+		})
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+	api.SetHealthChecker(&stubHealthChecker{pingErr: context.DeadlineExceeded})
+
+	req, _ := http.NewRequest("GET", "/livez", nil)
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestReadinessCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		checker      HealthChecker
+		shuttingDown bool
+		statusCode   int
+		wantStatus   string
+		wantError    error
+	}{
+		{
+			name:       "ready",
+			checker:    &stubHealthChecker{version: 22},
+			statusCode: http.StatusOK,
+			wantStatus: healthOK,
+		},
+		{
+			name:       "in-memory storage is ready",
+			statusCode: http.StatusOK,
+			wantStatus: healthOK,
+		},
+		{
+			name:       "database unreachable",
+			checker:    &stubHealthChecker{pingErr: context.DeadlineExceeded},
+			statusCode: http.StatusServiceUnavailable,
+			wantStatus: healthDown,
+		},
+		{
+			name:       "dirty migration",
+			checker:    &stubHealthChecker{version: 21, dirty: true},
+			statusCode: http.StatusServiceUnavailable,
+			wantStatus: healthDown,
+		},
+		{
+			name:         "shutting down",
+			checker:      &stubHealthChecker{version: 22},
+			shuttingDown: true,
+			statusCode:   http.StatusServiceUnavailable,
+			wantStatus:   healthDown,
+			wantError:    errors.ErrShuttingDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			if tt.checker != nil {
+				api.SetHealthChecker(tt.checker)
+			}
+			api.readiness.shuttingDown.Store(tt.shuttingDown)
+
+			req, _ := http.NewRequest("GET", "/readyz", nil)
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			var response struct {
+				Status string `json:"status"`
+				Error  string `json:"error"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantStatus, response.Status)
+			if tt.wantError != nil {
+				assert.Equal(t, tt.wantError.Error(), response.Error)
+			}
+		})
+	}
+}
+
+func TestShutdownFailsReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{ShutdownDrainDelay: time.Hour})
+	api.SetHealthChecker(&stubHealthChecker{version: 22})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- api.Shutdown(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		req, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+		api.httpSrv.Handler.ServeHTTP(w, req)
+		return w.Code == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not stop waiting after the context was cancelled")
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) recordTaskEvent(ctx context.Context, userID, action string, before, after *models.Task) {
+	event := models.TaskEvent{UserID: userID, Action: action, OldValue: before, NewValue: after}
+	if after != nil {
+		event.TaskID = after.ID
+	} else if before != nil {
+		event.TaskID = before.ID
+	}
+	if err := api.storage.AddTaskEvent(ctx, &event); err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось записать событие истории задачи:", event.TaskID, err)
+	}
+	api.dispatchWebhooks(action, before, after)
+	api.invalidateTaskResponses(ctx, before, after)
+}
+
+func (api *TaskAPI) getTaskHistory(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadAccessibleTask(ctx, userID, ctx.Param("taskID"), false)
+	if !ok {
+		return
+	}
+	events, err := api.storage.GetTaskEvents(ctx.Request.Context(), task.ID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"events": events})
+}
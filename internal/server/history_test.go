@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTaskHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "owner reads history",
+			userID:     "user123",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskEvents", mock.Anything, "task1").Return([]models.TaskEvent{
+					{TaskID: "task1", UserID: "user123", Action: models.TaskEventCreate},
+				}, nil)
+			},
+		},
+		{
+			name:       "shared reader reads history",
+			userID:     "reader",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskPermission", mock.Anything, "task1", "reader").Return(PermissionRead, nil)
+				m.On("GetTaskEvents", mock.Anything, "task1").Return([]models.TaskEvent{}, nil)
+			},
+		},
+		{
+			name:       "stranger forbidden",
+			userID:     "stranger",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskPermission", mock.Anything, "task1", "stranger").Return("", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123"}, nil)
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks/task1/history", nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(tt.userID)})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskEventsRecorded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", Title: "Old", Status: "new", UserID: "user123"}, nil)
+	mockTaskRepo.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+	mockTaskRepo.On("DeleteTask", mock.Anything, "task1").Return(nil)
+
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+	token := generateTestToken("user123")
+
+	body, _ := json.Marshal(models.UpdateTaskRequest{Title: "New"})
+	req, _ := http.NewRequest("PUT", "/tasks/task1", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest("DELETE", "/tasks/task1", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+	w = httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	if assert.Len(t, mockTaskRepo.events, 2) {
+		update := mockTaskRepo.events[0]
+		assert.Equal(t, models.TaskEventUpdate, update.Action)
+		assert.Equal(t, "user123", update.UserID)
+		assert.Equal(t, "Old", update.OldValue.Title)
+		assert.Equal(t, "New", update.NewValue.Title)
+
+		deletion := mockTaskRepo.events[1]
+		assert.Equal(t, models.TaskEventDelete, deletion.Action)
+		assert.Equal(t, "task1", deletion.TaskID)
+		assert.Nil(t, deletion.NewValue)
+	}
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"crypto/tls"
+	"log"
+	"net/http"
+	"slices"
+)
+
+func configureHTTP2(srv *http.Server, cfg *Config) {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if !cfg.HTTP2Disabled {
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(cfg.H2C)
+	} else if cfg.H2C {
+		log.Println("[WARN] h2c запрошен, но HTTP/2 отключен; используется только HTTP/1.1")
+	}
+	srv.Protocols = protocols
+	srv.HTTP2 = &http.HTTP2Config{MaxConcurrentStreams: cfg.HTTP2MaxStreams}
+}
+
+func (api *TaskAPI) http2Enabled() bool {
+	return api.httpSrv.Protocols == nil || api.httpSrv.Protocols.HTTP2()
+}
+
+func (api *TaskAPI) alignNextProtos(config *tls.Config) {
+	if config == nil || api.http2Enabled() {
+		return
+	}
+	config.NextProtos = slices.DeleteFunc(config.NextProtos, func(proto string) bool { return proto == "h2" })
+}
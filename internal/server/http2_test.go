@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestH2C(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "h2c enabled", cfg: Config{H2C: true}},
+		{name: "h2c disabled by default", cfg: Config{}, wantErr: true},
+		{name: "http2 disabled overrides h2c", cfg: Config{H2C: true, HTTP2Disabled: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &tt.cfg)
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			go api.httpSrv.Serve(listener)
+			defer api.httpSrv.Close()
+
+			protocols := new(http.Protocols)
+			protocols.SetUnencryptedHTTP2(true)
+			client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+
+			resp, err := client.Get("http://" + listener.Addr().String() + "/livez")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, 2, resp.ProtoMajor)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestConfigureHTTP2(t *testing.T) {
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{HTTP2MaxStreams: 64})
+	assert.True(t, api.httpSrv.Protocols.HTTP1())
+	assert.True(t, api.httpSrv.Protocols.HTTP2())
+	assert.False(t, api.httpSrv.Protocols.UnencryptedHTTP2())
+	assert.Equal(t, 64, api.httpSrv.HTTP2.MaxConcurrentStreams)
+
+	config := &tls.Config{NextProtos: []string{"h2", "http/1.1", "acme-tls/1"}}
+	api.alignNextProtos(config)
+	assert.Equal(t, []string{"h2", "http/1.1", "acme-tls/1"}, config.NextProtos)
+
+	disabled := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{HTTP2Disabled: true})
+	assert.False(t, disabled.httpSrv.Protocols.HTTP2())
+	disabled.alignNextProtos(config)
+	assert.Equal(t, []string{"http/1.1", "acme-tls/1"}, config.NextProtos)
+}
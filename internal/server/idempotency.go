@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	idempotencySweepEvery   = time.Minute
+)
+
+type idempotencyState int
+
+const (
+	idempotencyFresh idempotencyState = iota
+	idempotencyReplay
+	idempotencyInProgress
+	idempotencyMismatch
+)
+
+type idempotentResponse struct {
+	fingerprint string
+	done        bool
+	expiresAt   time.Time
+	status      int
+	header      http.Header
+	body        []byte
+}
+
+type idempotencyCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	entries   map[string]*idempotentResponse
+	lastSweep time.Time
+}
+
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	return &idempotencyCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*idempotentResponse),
+	}
+}
+
+func (c *idempotencyCache) begin(key, fingerprint string) (*idempotentResponse, idempotencyState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.sweep(now)
+
+	entry, exists := c.entries[key]
+	if exists && now.After(entry.expiresAt) {
+		delete(c.entries, key)
+		exists = false
+	}
+	if !exists {
+		c.entries[key] = &idempotentResponse{fingerprint: fingerprint, expiresAt: now.Add(c.ttl)}
+		return nil, idempotencyFresh
+	}
+	if entry.fingerprint != fingerprint {
+		return nil, idempotencyMismatch
+	}
+	if !entry.done {
+		return nil, idempotencyInProgress
+	}
+	return entry, idempotencyReplay
+}
+
+func (c *idempotencyCache) finish(key string, status int, header http.Header, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.entries[key]
+	if !exists {
+		return
+	}
+	if status >= http.StatusInternalServerError {
+		delete(c.entries, key)
+		return
+	}
+	entry.done = true
+	entry.status = status
+	entry.header = header
+	entry.body = body
+	entry.expiresAt = c.now().Add(c.ttl)
+}
+
+func (c *idempotencyCache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < idempotencySweepEvery {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *capturingWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+func (api *TaskAPI) idempotencyMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if ctx.Request.Method != http.MethodPost || key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			respondError(ctx, http.StatusBadRequest, errors.ErrIdempotencyKeyInvalid)
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, errors.ErrBadRequest)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+
+		userID, _ := api.getUserIDFromJWT(ctx)
+		scoped := userID + "|" + ctx.Request.URL.Path + "|" + key
+		entry, state := api.idempotency.begin(scoped, hex.EncodeToString(sum[:]))
+		switch state {
+		case idempotencyMismatch:
+			respondError(ctx, http.StatusUnprocessableEntity, errors.ErrIdempotencyKeyReused)
+			return
+		case idempotencyInProgress:
+			respondError(ctx, http.StatusConflict, errors.ErrIdempotencyInProgress)
+			return
+		case idempotencyReplay:
+			for name, values := range entry.header {
+				ctx.Writer.Header()[name] = values
+			}
+			ctx.Header(IdempotencyReplayedHeader, "true")
+			ctx.Status(entry.status)
+			_, _ = ctx.Writer.Write(entry.body)
+			ctx.Abort()
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				api.idempotency.finish(scoped, http.StatusInternalServerError, nil, nil)
+				panic(r)
+			}
+		}()
+		writer := &capturingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		api.idempotency.finish(scoped, writer.Status(), writer.Header().Clone(), writer.body.Bytes())
+	}
+}
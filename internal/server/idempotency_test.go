@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotentCreateTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("*models.Task")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Task).ID = "task1"
+	}).Return(nil).Once()
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+	send := func(userID, key, title string) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(models.CreateTaskRequest{Title: title})
+		req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, key)
+		req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(userID)})
+		w := httptest.NewRecorder()
+		api.httpSrv.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	first := send("user123", "key-1", "Buy milk")
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotencyReplayedHeader))
+
+	replay := send("user123", "key-1", "Buy milk")
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, "true", replay.Header().Get(IdempotencyReplayedHeader))
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+
+	mismatch := send("user123", "key-1", "Buy bread")
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+	assert.Contains(t, mismatch.Body.String(), errors.ErrIdempotencyKeyReused.Error())
+
+	tooLong := send("user123", strings.Repeat("k", maxIdempotencyKeyLength+1), "Buy milk")
+	assert.Equal(t, http.StatusBadRequest, tooLong.Code)
+
+	mockTaskRepo.AssertExpectations(t)
+	assert.Len(t, mockTaskRepo.events, 1)
+}
+
+func TestIdempotencyKeyScopedPerUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil).Twice()
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+	for _, userID := range []string{"user123", "user456"} {
+		jsonData, _ := json.Marshal(models.CreateTaskRequest{Title: "Buy milk"})
+		req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, "shared-key")
+		req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(userID)})
+		w := httptest.NewRecorder()
+		api.httpSrv.Handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get(IdempotencyReplayedHeader))
+	}
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestIdempotencyCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newIdempotencyCache(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	_, state := cache.begin("a", "fp")
+	assert.Equal(t, idempotencyFresh, state)
+	_, state = cache.begin("a", "fp")
+	assert.Equal(t, idempotencyInProgress, state)
+
+	cache.finish("a", http.StatusCreated, http.Header{}, []byte(`{}`))
+	entry, state := cache.begin("a", "fp")
+	assert.Equal(t, idempotencyReplay, state)
+	assert.Equal(t, http.StatusCreated, entry.status)
+
+	_, state = cache.begin("b", "fp")
+	assert.Equal(t, idempotencyFresh, state)
+	cache.finish("b", http.StatusInternalServerError, nil, nil)
+	_, state = cache.begin("b", "fp")
+	assert.Equal(t, idempotencyFresh, state)
+
+	now = now.Add(2 * time.Hour)
+	_, state = cache.begin("a", "fp")
+	assert.Equal(t, idempotencyFresh, state)
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type introspectRequest struct {
+	Token string `json:"token" form:"token"`
+}
+
+func (api *TaskAPI) introspectionAuthorized(ctx *gin.Context) bool {
+	if api.introspectionSecret == "" {
+		return false
+	}
+	provided := ""
+	if user, pass, ok := ctx.Request.BasicAuth(); ok {
+		provided = pass
+		if pass == "" {
+			provided = user
+		}
+	} else if auth := ctx.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		provided = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(api.introspectionSecret)) == 1
+}
+
+func (api *TaskAPI) introspect(ctx *gin.Context) {
+	if !api.introspectionAuthorized(ctx) {
+		ctx.Header("WWW-Authenticate", `Basic realm="introspection"`)
+		respondError(ctx, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	var req introspectRequest
+	if err := ctx.ShouldBind(&req); err != nil || req.Token == "" {
+		respondError(ctx, http.StatusBadRequest, errors.ErrInvalidRequest)
+		return
+	}
+
+	claims, err := api.parseJWT(req.Token)
+	if err != nil {
+		ctx.JSON(http.StatusOK, gin.H{"active": false})
+		return
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		ctx.JSON(http.StatusOK, gin.H{"active": false})
+		return
+	}
+	if active, err := api.storage.IsUserActive(ctx.Request.Context(), userID); err == nil && !active {
+		ctx.JSON(http.StatusOK, gin.H{"active": false})
+		return
+	}
+
+	resp := gin.H{
+		"active":     true,
+		"sub":        userID,
+		"token_type": "access_token",
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		resp["exp"] = exp.Unix()
+	}
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		resp["iat"] = iat.Unix()
+	}
+	if iss, err := claims.GetIssuer(); err == nil && iss != "" {
+		resp["iss"] = iss
+	}
+	if aud, err := claims.GetAudience(); err == nil && len(aud) > 0 {
+		resp["aud"] = strings.Join(aud, " ")
+	}
+	if scope, ok := claims["scope"].(string); ok && scope != "" {
+		resp["scope"] = scope
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntrospect(t *testing.T) {
+	cfg := &Config{IntrospectionSecret: "service-secret", JWTIssuer: "tasks"}
+	issuer := &TaskAPI{jwt: newJWTOptions(cfg)}
+	activeToken, err := issuer.generateJWT("user123")
+	require.NoError(t, err)
+	suspendedToken, err := issuer.generateJWT("user456")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		secret     string
+		token      string
+		statusCode int
+		active     bool
+	}{
+		{
+			name:       "active token",
+			secret:     "service-secret",
+			token:      activeToken,
+			statusCode: http.StatusOK,
+			active:     true,
+		},
+		{
+			name:       "expired token",
+			secret:     "service-secret",
+			token:      signTestClaims(jwt.MapClaims{"user_id": "user123", "iss": "tasks", "exp": time.Now().Add(-time.Hour).Unix()}),
+			statusCode: http.StatusOK,
+			active:     false,
+		},
+		{
+			name:       "suspended user token",
+			secret:     "service-secret",
+			token:      suspendedToken,
+			statusCode: http.StatusOK,
+			active:     false,
+		},
+		{
+			name:       "garbage token",
+			secret:     "service-secret",
+			token:      "not-a-jwt",
+			statusCode: http.StatusOK,
+			active:     false,
+		},
+		{
+			name:       "missing token",
+			secret:     "service-secret",
+			token:      "",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong client secret",
+			secret:     "wrong",
+			token:      activeToken,
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{suspended: map[string]bool{"user456": true}}, &MockTaskStore{}}, cfg)
+
+			form := url.Values{}
+			form.Set("token", tt.token)
+			req, _ := http.NewRequest("POST", "/auth/introspect", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth("resource-server", tt.secret)
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.active, body["active"])
+			if tt.active {
+				assert.Equal(t, "user123", body["sub"])
+				assert.Equal(t, "tasks", body["iss"])
+				assert.NotNil(t, body["exp"])
+			}
+		})
+	}
+}
+
+func TestIntrospectDisabledWithoutSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+	req, _ := http.NewRequest("POST", "/auth/introspect", strings.NewReader("token=abc"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
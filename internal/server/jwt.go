@@ -0,0 +1,98 @@
+package server
+
+import (
+	"time"
+
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var jwtSecret = []byte("shouldbeinVaultsecret")
+
+type jwtOptions struct {
+	ttl       time.Duration
+	issuer    string
+	audience  string
+	clockSkew time.Duration
+}
+
+func newJWTOptions(cfg *Config) jwtOptions {
+	opts := jwtOptions{
+		ttl:       cfg.JWTTTL,
+		issuer:    cfg.JWTIssuer,
+		audience:  cfg.JWTAudience,
+		clockSkew: cfg.JWTClockSkew,
+	}
+	if opts.ttl <= 0 {
+		opts.ttl = defaultJWTTTL
+	}
+	if opts.clockSkew < 0 {
+		opts.clockSkew = 0
+	}
+	return opts
+}
+
+func (o jwtOptions) parserOptions() []jwt.ParserOption {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithLeeway(o.clockSkew),
+	}
+	if o.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(o.issuer))
+	}
+	if o.audience != "" {
+		opts = append(opts, jwt.WithAudience(o.audience))
+	}
+	return opts
+}
+
+func (api *TaskAPI) generateJWT(userID string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"sub":     userID,
+		"iat":     now.Unix(),
+		"nbf":     now.Unix(),
+		"exp":     now.Add(api.jwt.ttl).Unix(),
+	}
+	if api.jwt.issuer != "" {
+		claims["iss"] = api.jwt.issuer
+	}
+	if api.jwt.audience != "" {
+		claims["aud"] = api.jwt.audience
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret)
+}
+
+func (api *TaskAPI) parseJWT(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	}, api.jwt.parserOptions()...)
+	if err != nil || !token.Valid {
+		return nil, errors.ErrUnauthorized
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.ErrUnauthorized
+	}
+	return claims, nil
+}
+
+func (api *TaskAPI) getUserIDFromJWT(ctx *gin.Context) (string, error) {
+	cookie, err := ctx.Cookie("jwt_token")
+	if err != nil {
+		return "", errors.ErrUnauthorized
+	}
+	claims, err := api.parseJWT(cookie)
+	if err != nil {
+		return "", err
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return "", errors.ErrUnauthorized
+	}
+	return userID, nil
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestClaims(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, _ := token.SignedString(jwtSecret)
+	return tokenString
+}
+
+func TestNewJWTOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		want jwtOptions
+	}{
+		{
+			name: "empty config falls back to defaults",
+			cfg:  &Config{},
+			want: jwtOptions{ttl: defaultJWTTTL},
+		},
+		{
+			name: "configured values are used",
+			cfg: &Config{
+				JWTTTL:       15 * time.Minute,
+				JWTIssuer:    "tasks",
+				JWTAudience:  "web",
+				JWTClockSkew: time.Minute,
+			},
+			want: jwtOptions{ttl: 15 * time.Minute, issuer: "tasks", audience: "web", clockSkew: time.Minute},
+		},
+		{
+			name: "negative skew is ignored",
+			cfg:  &Config{JWTClockSkew: -time.Second},
+			want: jwtOptions{ttl: defaultJWTTTL},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newJWTOptions(tt.cfg))
+		})
+	}
+}
+
+func TestGenerateJWT(t *testing.T) {
+	api := &TaskAPI{jwt: newJWTOptions(&Config{JWTTTL: 10 * time.Minute, JWTIssuer: "tasks", JWTAudience: "web"})}
+
+	tokenString, err := api.generateJWT("user123")
+	require.NoError(t, err)
+
+	claims, err := api.parseJWT(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user123", claims["user_id"])
+	assert.Equal(t, "tasks", claims["iss"])
+	assert.Equal(t, "web", claims["aud"])
+
+	exp, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), exp.Time, 5*time.Second)
+}
+
+func TestParseJWT(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		cfg     *Config
+		claims  jwt.MapClaims
+		wantErr bool
+	}{
+		{
+			name:   "valid token without iss and aud checks",
+			cfg:    &Config{},
+			claims: jwt.MapClaims{"user_id": "user123", "exp": now.Add(time.Hour).Unix()},
+		},
+		{
+			name:    "expired token",
+			cfg:     &Config{},
+			claims:  jwt.MapClaims{"user_id": "user123", "exp": now.Add(-time.Minute).Unix()},
+			wantErr: true,
+		},
+		{
+			name:   "expired token within clock skew",
+			cfg:    &Config{JWTClockSkew: 2 * time.Minute},
+			claims: jwt.MapClaims{"user_id": "user123", "exp": now.Add(-time.Minute).Unix()},
+		},
+		{
+			name:    "issuer mismatch",
+			cfg:     &Config{JWTIssuer: "tasks"},
+			claims:  jwt.MapClaims{"user_id": "user123", "iss": "other", "exp": now.Add(time.Hour).Unix()},
+			wantErr: true,
+		},
+		{
+			name:    "missing audience",
+			cfg:     &Config{JWTAudience: "web"},
+			claims:  jwt.MapClaims{"user_id": "user123", "exp": now.Add(time.Hour).Unix()},
+			wantErr: true,
+		},
+		{
+			name:   "matching issuer and audience",
+			cfg:    &Config{JWTIssuer: "tasks", JWTAudience: "web"},
+			claims: jwt.MapClaims{"user_id": "user123", "iss": "tasks", "aud": "web", "exp": now.Add(time.Hour).Unix()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &TaskAPI{jwt: newJWTOptions(tt.cfg)}
+			_, err := api.parseJWT(signTestClaims(tt.claims))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetUserIDFromJWT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := &TaskAPI{jwt: newJWTOptions(&Config{JWTTTL: 5 * time.Minute})}
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	tokenString, err := api.generateJWT("user123")
+	require.NoError(t, err)
+	ctx.Request.AddCookie(&http.Cookie{Name: "jwt_token", Value: tokenString})
+
+	userID, err := api.getUserIDFromJWT(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user123", userID)
+}
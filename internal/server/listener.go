@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+)
+
+const (
+	unixAddrPrefix     = "unix:"
+	systemdListenFDs   = 3
+	defaultSocketProbe = 200 * time.Millisecond
+)
+
+func isUnixAddr(addr string) bool {
+	return strings.HasPrefix(addr, unixAddrPrefix)
+}
+
+func parseSocketMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		mode = defaultUnixSocketMode
+	}
+	value, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || value > 0o777 {
+		return 0, errors.ErrConfigInvalidFormat
+	}
+	return os.FileMode(value), nil
+}
+
+func activatedListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, nil
+	}
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+	if fds > 1 {
+		log.Printf("[WARN] systemd передал сокетов: %d, используется только первый", fds)
+	}
+
+	file := os.NewFile(uintptr(systemdListenFDs), "LISTEN_FD_3")
+	defer func() { _ = file.Close() }()
+	return net.FileListener(file)
+}
+
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, errors.ErrSocketPathInUse
+		}
+		if conn, err := net.DialTimeout("unix", path, defaultSocketProbe); err == nil {
+			_ = conn.Close()
+			return nil, errors.ErrSocketPathInUse
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+		log.Println("[INFO] Удален устаревший unix-сокет:", path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+func (api *TaskAPI) listen() (net.Listener, error) {
+	listener, err := activatedListener()
+	if err != nil || listener != nil {
+		if listener != nil {
+			log.Println("[INFO] Используется сокет, переданный systemd:", listener.Addr())
+		}
+		return listener, err
+	}
+
+	addr := api.httpSrv.Addr
+	if !isUnixAddr(addr) {
+		lc := net.ListenConfig{KeepAlive: api.keepAlive}
+		return lc.Listen(context.Background(), "tcp", addr)
+	}
+	mode, err := parseSocketMode(api.socketMode)
+	if err != nil {
+		return nil, err
+	}
+	return listenUnix(strings.TrimPrefix(addr, unixAddrPrefix), mode)
+}
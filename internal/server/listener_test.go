@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"project/internal/domain/errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSocketMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{mode: "", want: 0o660},
+		{mode: "0600", want: 0o600},
+		{mode: "777", want: 0o777},
+		{mode: "0999", wantErr: true},
+		{mode: "01777", wantErr: true},
+		{mode: "rw", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			mode, err := parseSocketMode(tt.mode)
+			if tt.wantErr {
+				assert.Equal(t, errors.ErrConfigInvalidFormat, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mode)
+		})
+	}
+}
+
+func shortSocketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "sock")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "api.sock")
+}
+
+func TestListenUnix(t *testing.T) {
+	t.Run("sets permissions and unlinks on close", func(t *testing.T) {
+		path := shortSocketPath(t)
+		listener, err := listenUnix(path, 0o600)
+		require.NoError(t, err)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		require.NoError(t, listener.Close())
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("replaces stale socket", func(t *testing.T) {
+		path := shortSocketPath(t)
+		stale, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, stale.Close())
+
+		listener, err := listenUnix(path, 0o660)
+		require.NoError(t, err)
+		assert.NoError(t, listener.Close())
+	})
+
+	t.Run("refuses live socket", func(t *testing.T) {
+		path := shortSocketPath(t)
+		live, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		defer live.Close()
+
+		_, err = listenUnix(path, 0o660)
+		assert.Equal(t, errors.ErrSocketPathInUse, err)
+	})
+
+	t.Run("refuses regular file", func(t *testing.T) {
+		path := shortSocketPath(t)
+		require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+		_, err := listenUnix(path, 0o660)
+		assert.Equal(t, errors.ErrSocketPathInUse, err)
+	})
+}
+
+func TestActivatedListenerIgnoresForeignPID(t *testing.T) {
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+
+	listener, err := activatedListener()
+	assert.NoError(t, err)
+	assert.Nil(t, listener)
+	assert.Equal(t, "1", os.Getenv("LISTEN_FDS"))
+}
+
+func TestServeOverUnixSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	path := shortSocketPath(t)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{Addr: unixAddrPrefix + path, Port: 8080})
+	require.Equal(t, unixAddrPrefix+path, api.httpSrv.Addr)
+
+	served := make(chan error, 1)
+	go func() { served <- api.Start() }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get("http://unix/livez")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, api.httpSrv.Shutdown(context.Background()))
+	assert.Equal(t, http.ErrServerClosed, <-served)
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
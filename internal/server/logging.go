@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/models"
+	"project/internal/logging"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func loggingStatus() models.LoggingStatus {
+	return models.LoggingStatus{Level: logging.CurrentLevel().String(), Format: logging.CurrentFormat()}
+}
+
+func (api *TaskAPI) getLogging(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, loggingStatus())
+}
+
+func (api *TaskAPI) updateLogging(ctx *gin.Context) {
+	var req models.LoggingRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	if level, ok := logging.ParseLevel(req.Level); ok {
+		logging.SetLevel(level)
+	}
+	if req.Format != "" {
+		logging.SetFormat(req.Format)
+	}
+	userID, _ := api.getUserIDFromJWT(ctx)
+	status := loggingStatus()
+	requestid.Printf(ctx.Request.Context(), "[WARN] Настройки журнала изменены администратором %s: уровень %s, формат %s", userID, status.Level, status.Format)
+	ctx.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"project/internal/domain/models"
+	"project/internal/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateLogging(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		expectedCode int
+		want         models.LoggingStatus
+	}{
+		{name: "admin switches level and format", userID: "admin1", body: `{"level":"debug","format":"json"}`, expectedCode: http.StatusOK, want: models.LoggingStatus{Level: "debug", Format: "json"}},
+		{name: "admin switches level only", userID: "admin1", body: `{"level":"error"}`, expectedCode: http.StatusOK, want: models.LoggingStatus{Level: "error", Format: "text"}},
+		{name: "unknown level", userID: "admin1", body: `{"level":"verbose"}`, expectedCode: http.StatusBadRequest},
+		{name: "unknown format", userID: "admin1", body: `{"format":"xml"}`, expectedCode: http.StatusBadRequest},
+		{name: "regular user is forbidden", userID: "user123", body: `{"level":"debug"}`, expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logging.SetLevel(logging.LevelInfo)
+			logging.SetFormat(logging.FormatText)
+			t.Cleanup(func() {
+				logging.SetLevel(logging.LevelInfo)
+				logging.SetFormat(logging.FormatText)
+			})
+
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockRepo.On("GetUserByID", "admin1").Return(&models.User{ID: "admin1", Role: "admin"}, nil).Maybe()
+			mockRepo.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "user"}, nil).Maybe()
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest("PUT", "/admin/logging", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(tt.userID)})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				assert.Equal(t, logging.LevelInfo, logging.CurrentLevel())
+				assert.Equal(t, logging.FormatText, logging.CurrentFormat())
+				return
+			}
+			var status models.LoggingStatus
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			assert.Equal(t, tt.want, status)
+			assert.Equal(t, tt.want.Level, logging.CurrentLevel().String())
+			assert.Equal(t, tt.want.Format, logging.CurrentFormat())
+		})
+	}
+}
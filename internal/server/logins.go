@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxLoginUserAgentLength = 512
+	loginHistoryLimit       = 50
+)
+
+func (api *TaskAPI) recordLogin(ctx *gin.Context, userID string, success bool) {
+	userAgent := []rune(ctx.Request.UserAgent())
+	if len(userAgent) > maxLoginUserAgentLength {
+		userAgent = userAgent[:maxLoginUserAgentLength]
+	}
+	record := &models.LoginRecord{
+		UserID:    userID,
+		IP:        ctx.ClientIP(),
+		UserAgent: string(userAgent),
+		Success:   success,
+	}
+	if err := api.storage.RecordLogin(ctx.Request.Context(), record); err != nil {
+		requestid.Println(ctx.Request.Context(), "[ERROR] Не удалось записать вход пользователя:", err)
+	}
+}
+
+func (api *TaskAPI) getLoginHistory(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	records, err := api.storage.GetLoginHistory(ctx.Request.Context(), userID, time.Time{}, loginHistoryLimit)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"logins": records})
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoginIsRecorded(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &models.User{ID: "user1", Username: "testuser", Password: string(hash)}
+
+	tests := []struct {
+		name       string
+		username   string
+		password   string
+		suspended  bool
+		statusCode int
+		recorded   []bool
+	}{
+		{
+			name:       "successful login",
+			username:   "testuser",
+			password:   "password123",
+			statusCode: http.StatusOK,
+			recorded:   []bool{true},
+		},
+		{
+			name:       "wrong password",
+			username:   "testuser",
+			password:   "wrongpassword",
+			statusCode: http.StatusUnauthorized,
+			recorded:   []bool{false},
+		},
+		{
+			name:       "suspended user",
+			username:   "testuser",
+			password:   "password123",
+			suspended:  true,
+			statusCode: http.StatusForbidden,
+			recorded:   []bool{false},
+		},
+		{
+			name:       "unknown user",
+			username:   "nobody",
+			password:   "password123",
+			statusCode: http.StatusUnauthorized,
+			recorded:   []bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{suspended: map[string]bool{"user1": tt.suspended}}
+			mockRepo.On("GetUserByUsername", "testuser").Return(user, nil)
+			mockRepo.On("GetUserByUsername", "nobody").Return(nil, errors.ErrUserNotFound)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			body, _ := json.Marshal(models.LoginRequest{Username: tt.username, Password: tt.password})
+			req, _ := http.NewRequest("POST", "/users/login", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "test-agent/1.0")
+			req.RemoteAddr = "10.0.0.1:5555"
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			success := []bool{}
+			for _, record := range mockRepo.logins {
+				assert.Equal(t, "user1", record.UserID)
+				assert.Equal(t, "10.0.0.1", record.IP)
+				assert.Equal(t, "test-agent/1.0", record.UserAgent)
+				success = append(success, record.Success)
+			}
+			assert.Equal(t, tt.recorded, success)
+		})
+	}
+}
+
+func TestGetLoginHistory(t *testing.T) {
+	at := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	history := []models.LoginRecord{
+		{ID: "login2", UserID: "user123", IP: "10.0.0.1", UserAgent: "agent", Success: true, CreatedAt: at},
+		{ID: "login1", UserID: "user123", IP: "10.0.0.2", UserAgent: "agent", Success: false, CreatedAt: at.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name       string
+		token      bool
+		statusCode int
+		mockSetup  func(*MockUserStore)
+		want       []models.LoginRecord
+	}{
+		{
+			name:       "returns history",
+			token:      true,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetLoginHistory", "user123", time.Time{}, loginHistoryLimit).Return(history, nil)
+			},
+			want: history,
+		},
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			mockSetup:  func(m *MockUserStore) {},
+		},
+		{
+			name:       "storage error",
+			token:      true,
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetLoginHistory", "user123", time.Time{}, loginHistoryLimit).Return(nil, errors.ErrInternalServer)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			tt.mockSetup(mockRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/users/me/logins", nil)
+			if tt.token {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			}
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				var response struct {
+					Logins []models.LoginRecord `json:"logins"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.want, response.Logins)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+var maintenanceExempt = map[string]bool{
+	"/users/login":        true,
+	"/auth/introspect":    true,
+	"/graphql":            true,
+	"/admin/maintenance":  true,
+	"/admin/logging":      true,
+	"/debug/pprof/symbol": true,
+}
+
+type maintenanceState struct {
+	mu         sync.RWMutex
+	since      time.Time
+	retryAfter time.Duration
+}
+
+func newMaintenance(cfg *Config) *maintenanceState {
+	m := &maintenanceState{retryAfter: cfg.MaintenanceRetryAfter}
+	if m.retryAfter <= 0 {
+		m.retryAfter = defaultMaintenanceRetryAfter
+	}
+	if cfg.MaintenanceMode {
+		m.since = time.Now()
+		log.Println("[WARN] Сервис запущен в режиме обслуживания: изменения будут отклоняться")
+	}
+	return m
+}
+
+func (m *maintenanceState) active() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return !m.since.IsZero()
+}
+
+func (m *maintenanceState) set(enabled bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled == !m.since.IsZero() {
+		return false
+	}
+	if enabled {
+		m.since = time.Now()
+	} else {
+		m.since = time.Time{}
+	}
+	return true
+}
+
+func (m *maintenanceState) status() models.MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status := models.MaintenanceStatus{RetryAfterSeconds: int(m.retryAfter.Seconds())}
+	if !m.since.IsZero() {
+		since := m.since
+		status.Enabled = true
+		status.Since = &since
+	}
+	return status
+}
+
+func isWriteMethod(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+func (api *TaskAPI) rejectMaintenance(ctx *gin.Context) {
+	ctx.Header("Retry-After", strconv.Itoa(int(api.maintenance.retryAfter.Seconds())))
+	respondError(ctx, http.StatusServiceUnavailable, errors.ErrMaintenanceMode)
+}
+
+func (api *TaskAPI) maintenanceMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		path := strings.TrimSuffix(ctx.Request.URL.Path, "/")
+		if !isWriteMethod(ctx.Request.Method) || maintenanceExempt[path] || !api.maintenance.active() {
+			ctx.Next()
+			return
+		}
+		api.rejectMaintenance(ctx)
+	}
+}
+
+func (api *TaskAPI) getMaintenance(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, api.maintenance.status())
+}
+
+func (api *TaskAPI) updateMaintenance(ctx *gin.Context) {
+	var req models.MaintenanceRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	if api.maintenance.set(*req.Enabled) {
+		userID, _ := api.getUserIDFromJWT(ctx)
+		if *req.Enabled {
+			requestid.Println(ctx.Request.Context(), "[WARN] Режим обслуживания включен администратором", userID)
+		} else {
+			requestid.Println(ctx.Request.Context(), "[INFO] Режим обслуживания выключен администратором", userID)
+		}
+	}
+	ctx.JSON(http.StatusOK, api.maintenance.status())
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		setup        func(*MockTaskStore)
+		expectedCode int
+	}{
+		{
+			name:         "write is rejected",
+			method:       "POST",
+			path:         "/tasks",
+			body:         `{"title":"Task"}`,
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "delete is rejected",
+			method:       "DELETE",
+			path:         "/tasks/task1",
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "read keeps working",
+			method: "GET",
+			path:   "/tags",
+			setup: func(m *MockTaskStore) {
+				m.On("GetTags", mock.Anything, "user123").Return([]models.Tag{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "probe keeps working",
+			method:       "GET",
+			path:         "/readyz",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "graphql query keeps working",
+			method:       "POST",
+			path:         "/graphql",
+			body:         `{"query":"{ tags { id } }"}`,
+			setup:        func(m *MockTaskStore) { m.On("GetTags", mock.Anything, "user123").Return([]models.Tag{}, nil) },
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "graphql mutation is rejected",
+			method:       "POST",
+			path:         "/graphql",
+			body:         `{"query":"mutation { deleteTask(id: \"task1\") }"}`,
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			if tt.setup != nil {
+				tt.setup(mockTaskRepo)
+			}
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{MaintenanceMode: true, MaintenanceRetryAfter: 90 * time.Second})
+
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusServiceUnavailable {
+				assert.Equal(t, "90", w.Header().Get("Retry-After"))
+				var response models.ErrorEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "maintenance_mode", response.Error.Code)
+				assert.Equal(t, errors.ErrMaintenanceMode.Error(), response.Error.Message)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMaintenanceToggle(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		expectedCode int
+		enabled      bool
+	}{
+		{name: "admin enables", userID: "admin1", body: `{"enabled":true}`, expectedCode: http.StatusOK, enabled: true},
+		{name: "admin disables", userID: "admin1", body: `{"enabled":false}`, expectedCode: http.StatusOK},
+		{name: "missing flag", userID: "admin1", body: `{}`, expectedCode: http.StatusBadRequest},
+		{name: "regular user is forbidden", userID: "user123", body: `{"enabled":true}`, expectedCode: http.StatusForbidden},
+		{name: "anonymous request", body: `{"enabled":true}`, expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockRepo.On("GetUserByID", "admin1").Return(&models.User{ID: "admin1", Role: "admin"}, nil).Maybe()
+			mockRepo.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "user"}, nil).Maybe()
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest("PUT", "/admin/maintenance", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.userID != "" {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(tt.userID)})
+			}
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.enabled, api.maintenance.active())
+			if tt.expectedCode == http.StatusOK {
+				var status models.MaintenanceStatus
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+				assert.Equal(t, tt.enabled, status.Enabled)
+				assert.Equal(t, tt.enabled, status.Since != nil)
+				assert.Equal(t, 120, status.RetryAfterSeconds)
+			}
+		})
+	}
+}
+
+func TestMaintenanceAdminCanLeaveMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := &MockUserStore{}
+	mockRepo.On("GetUserByID", "admin1").Return(&models.User{ID: "admin1", Role: "admin"}, nil)
+	api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{MaintenanceMode: true})
+
+	req, _ := http.NewRequest("PUT", "/admin/maintenance", bytes.NewBufferString(`{"enabled":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("admin1")})
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, api.maintenance.active())
+}
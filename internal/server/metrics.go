@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/metrics"
+	"project/internal/purge"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MetricsSource interface {
+	RegisterMetrics(registry *metrics.Registry)
+}
+
+func (api *TaskAPI) RegisterMetrics(source MetricsSource) {
+	source.RegisterMetrics(api.metrics)
+}
+
+func (api *TaskAPI) purgeStat(fn func(purge.Stats) float64) func() map[string]float64 {
+	return func() map[string]float64 {
+		if api.purgeStats == nil {
+			return nil
+		}
+		return map[string]float64{"": fn(api.purgeStats.PurgeStats())}
+	}
+}
+
+func (api *TaskAPI) registerPurgeMetrics() {
+	api.metrics.CounterFunc("purge_runs_total", "Количество запусков очистки корзины", "", api.purgeStat(func(stats purge.Stats) float64 {
+		return float64(stats.Runs)
+	}))
+	api.metrics.CounterFunc("purge_deleted_total", "Количество задач, удаленных очисткой корзины", "", api.purgeStat(func(stats purge.Stats) float64 {
+		return float64(stats.Purged)
+	}))
+	api.metrics.CounterFunc("purge_failures_total", "Количество неудачных запусков очистки корзины", "", api.purgeStat(func(stats purge.Stats) float64 {
+		return float64(stats.Failures)
+	}))
+	api.metrics.GaugeFunc("purge_last_run_timestamp_seconds", "Время последнего запуска очистки корзины", "", api.purgeStat(func(stats purge.Stats) float64 {
+		if stats.LastRun.IsZero() {
+			return 0
+		}
+		return float64(stats.LastRun.Unix())
+	}))
+}
+
+func (api *TaskAPI) getMetrics(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	if err := api.metrics.Write(ctx.Writer); err != nil {
+		requestid.Println(ctx.Request.Context(), "[ERROR] Не удалось отдать метрики:", err)
+	}
+}
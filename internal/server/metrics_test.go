@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"project/internal/metrics"
+	"project/internal/purge"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubMetricsSource struct{}
+
+func (stubMetricsSource) RegisterMetrics(registry *metrics.Registry) {
+	queries := metrics.NewCounterVec("statement")
+	queries.Inc("get_task_by_id")
+	registry.Counter("db_queries_total", "Запросы", queries)
+}
+
+func TestGetMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		configure   func(api *TaskAPI)
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "no sources",
+			configure:   func(api *TaskAPI) {},
+			contains:    []string{"# TYPE purge_runs_total counter"},
+			notContains: []string{"purge_runs_total 0", "db_queries_total"},
+		},
+		{
+			name: "purge stats and storage metrics",
+			configure: func(api *TaskAPI) {
+				api.SetPurgeStatsSource(&stubPurgeStats{stats: purge.Stats{Runs: 3, Purged: 7, Failures: 1}})
+				api.RegisterMetrics(stubMetricsSource{})
+			},
+			contains: []string{
+				"purge_runs_total 3\n",
+				"purge_deleted_total 7\n",
+				"purge_failures_total 1\n",
+				"purge_last_run_timestamp_seconds 0\n",
+				`db_queries_total{statement="get_task_by_id"} 1`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			tt.configure(api)
+
+			req, _ := http.NewRequest("GET", "/metrics", nil)
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+			for _, want := range tt.contains {
+				assert.Contains(t, w.Body.String(), want)
+			}
+			for _, unwanted := range tt.notContains {
+				assert.NotContains(t, w.Body.String(), unwanted)
+			}
+		})
+	}
+}
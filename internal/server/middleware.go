@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 
 	"project/internal/domain/errors"
 
@@ -40,7 +41,7 @@ func GzipRequestDecompress() gin.HandlerFunc {
 		if strings.Contains(encoding, "gzip") {
 			gr, err := gzip.NewReader(ctx.Request.Body)
 			if err != nil {
-				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidGzipRequest.Error()})
+				respondError(ctx, http.StatusBadRequest, errors.ErrInvalidGzipRequest)
 				return
 			}
 
@@ -57,8 +58,55 @@ func GzipRequestDecompress() gin.HandlerFunc {
 	}
 }
 
+type compressor struct {
+	level   int
+	minSize int
+	types   []string
+	writers sync.Pool
+}
+
+func newCompressor(cfg *Config) *compressor {
+	c := &compressor{level: cfg.CompressionLevel, minSize: cfg.CompressionMinSize}
+	if c.level < gzip.HuffmanOnly || c.level > gzip.BestCompression {
+		c.level = gzip.DefaultCompression
+	}
+	if c.minSize < 0 {
+		c.minSize = defaultCompressionMinSize
+	}
+	for _, ct := range strings.Split(cfg.CompressionTypes, ",") {
+		if ct = strings.ToLower(strings.TrimSpace(ct)); ct != "" {
+			c.types = append(c.types, ct)
+		}
+	}
+	c.writers.New = func() interface{} {
+		gw, _ := gzip.NewWriterLevel(io.Discard, c.level)
+		return gw
+	}
+	return c
+}
+
+func (c *compressor) compressible(ct string) bool {
+	if ct == "" {
+		return false
+	}
+
+	lower := strings.ToLower(ct)
+	if strings.HasPrefix(lower, "text/event-stream") {
+		return false
+	}
+
+	for _, prefix := range c.types {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type gzipResponseWriter struct {
 	writer      gin.ResponseWriter
+	compressor  *compressor
 	gw          *gzip.Writer
 	gzipEnabled bool
 	statusCode  int
@@ -66,8 +114,6 @@ type gzipResponseWriter struct {
 	preBuf      bytes.Buffer
 }
 
-const minCompressSize = 1024
-
 var nonCompressibleStatuses = map[int]bool{
 	http.StatusNoContent:         true,
 	http.StatusNotModified:       true,
@@ -96,7 +142,7 @@ func (w *gzipResponseWriter) Write(data []byte) (int, error) {
 	}
 	w.totalSize += len(data)
 
-	if w.preBuf.Len() >= minCompressSize && w.mayCompress() {
+	if w.preBuf.Len() >= w.compressor.minSize && w.mayCompress() {
 		w.enableGzip()
 		if w.gw != nil {
 			if _, err := w.gw.Write(w.preBuf.Bytes()); err != nil {
@@ -119,7 +165,7 @@ func (w *gzipResponseWriter) mayCompress() bool {
 		return false
 	}
 	ct := w.writer.Header().Get("Content-Type")
-	return isCompressibleContentType(ct)
+	return w.compressor.compressible(ct)
 }
 
 func (w *gzipResponseWriter) enableGzip() {
@@ -131,7 +177,8 @@ func (w *gzipResponseWriter) enableGzip() {
 	} else if !strings.Contains(vary, "Accept-Encoding") {
 		w.writer.Header().Set("Vary", vary+", Accept-Encoding")
 	}
-	w.gw = gzip.NewWriter(w.writer)
+	w.gw = w.compressor.writers.Get().(*gzip.Writer)
+	w.gw.Reset(w.writer)
 	w.gzipEnabled = true
 }
 
@@ -162,6 +209,8 @@ func (w *gzipResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { ret
 
 func (w *gzipResponseWriter) Size() int { return w.writer.Size() }
 
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter { return w.writer }
+
 func (w *gzipResponseWriter) Status() int { return w.writer.Status() }
 
 func (w *gzipResponseWriter) WriteHeaderNow() { w.writer.WriteHeaderNow() }
@@ -169,8 +218,16 @@ func (w *gzipResponseWriter) WriteHeaderNow() { w.writer.WriteHeaderNow() }
 func (w *gzipResponseWriter) Written() bool { return w.writer.Written() }
 
 func GzipResponseCompress() gin.HandlerFunc {
+	return newCompressor(&Config{
+		CompressionLevel:   gzip.DefaultCompression,
+		CompressionMinSize: defaultCompressionMinSize,
+		CompressionTypes:   defaultCompressionTypes,
+	}).middleware()
+}
+
+func (c *compressor) middleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if ctx.Request.Method == http.MethodHead {
+		if ctx.Request.Method == http.MethodHead || len(c.types) == 0 {
 			ctx.Next()
 			return
 		}
@@ -188,7 +245,7 @@ func GzipResponseCompress() gin.HandlerFunc {
 			ctx.Writer.Header().Set("Vary", vary+", Accept-Encoding")
 		}
 
-		gw := &gzipResponseWriter{writer: ctx.Writer}
+		gw := &gzipResponseWriter{writer: ctx.Writer, compressor: c}
 		ctx.Writer = gw
 
 		ctx.Next()
@@ -197,6 +254,9 @@ func GzipResponseCompress() gin.HandlerFunc {
 			if err := gw.gw.Close(); err != nil {
 				_ = ctx.Error(errors.ErrGzipCompressionFailed)
 			}
+			gw.gw.Reset(io.Discard)
+			c.writers.Put(gw.gw)
+			gw.gw = nil
 		} else if gw.preBuf.Len() > 0 {
 			if _, err := gw.writer.Write(gw.preBuf.Bytes()); err != nil {
 				_ = ctx.Error(err)
@@ -205,33 +265,3 @@ func GzipResponseCompress() gin.HandlerFunc {
 		}
 	}
 }
-
-func isCompressibleContentType(ct string) bool {
-	if ct == "" {
-		return false
-	}
-
-	lower := strings.ToLower(ct)
-	if strings.HasPrefix(lower, "text/event-stream") {
-		return false
-	}
-
-	compressiblePrefixes := []string{
-		"application/json",
-		"application/xml",
-		"application/javascript",
-		"text/html",
-		"text/css",
-		"text/plain",
-		"text/xml",
-		"text/javascript",
-	}
-
-	for _, prefix := range compressiblePrefixes {
-		if strings.HasPrefix(lower, prefix) {
-			return true
-		}
-	}
-
-	return false
-}
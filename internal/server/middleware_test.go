@@ -308,3 +308,74 @@ func TestMiddlewareAdditionalScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestCompressor(t *testing.T) {
+	payload := strings.Repeat("compressible payload ", 20)
+
+	tests := []struct {
+		name        string
+		cfg         Config
+		contentType string
+		compressed  bool
+	}{
+		{
+			name:        "above threshold",
+			cfg:         Config{CompressionLevel: gzip.BestSpeed, CompressionMinSize: 64, CompressionTypes: "text/plain"},
+			contentType: "text/plain",
+			compressed:  true,
+		},
+		{
+			name:        "below threshold",
+			cfg:         Config{CompressionLevel: gzip.BestSpeed, CompressionMinSize: 4096, CompressionTypes: "text/plain"},
+			contentType: "text/plain",
+		},
+		{
+			name:        "type not in allow-list",
+			cfg:         Config{CompressionLevel: gzip.BestSpeed, CompressionMinSize: 64, CompressionTypes: "application/json"},
+			contentType: "text/plain",
+		},
+		{
+			name:        "empty allow-list disables compression",
+			cfg:         Config{CompressionMinSize: 64},
+			contentType: "text/plain",
+		},
+		{
+			name:        "invalid level falls back to default",
+			cfg:         Config{CompressionLevel: 42, CompressionMinSize: 64, CompressionTypes: " Text/Plain "},
+			contentType: "text/plain",
+			compressed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(newCompressor(&tt.cfg).middleware())
+			router.GET("/test", func(c *gin.Context) {
+				c.Data(http.StatusOK, tt.contentType, []byte(payload))
+			})
+
+			for i := 0; i < 3; i++ {
+				req, _ := http.NewRequest("GET", "/test", nil)
+				req.Header.Set("Accept-Encoding", "gzip")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusOK, w.Code)
+				if !tt.compressed {
+					assert.Empty(t, w.Header().Get("Content-Encoding"))
+					assert.Equal(t, payload, w.Body.String())
+					continue
+				}
+				assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+				gr, err := gzip.NewReader(w.Body)
+				if assert.NoError(t, err) {
+					body, err := io.ReadAll(gr)
+					assert.NoError(t, err)
+					assert.Equal(t, payload, string(body))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/jsoncodec"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mimeNDJSON = "application/x-ndjson"
+
+	ndjsonFlushEvery = 500
+)
+
+func wantsNDJSON(ctx *gin.Context) bool {
+	for _, part := range strings.Split(ctx.GetHeader("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == mimeNDJSON {
+			return true
+		}
+	}
+	return false
+}
+
+func writeNDJSON(w gin.ResponseWriter, task models.Task, written int) error {
+	data, err := jsoncodec.Marshal(task)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if written%ndjsonFlushEvery == 0 {
+		w.Flush()
+	}
+	return nil
+}
+
+func (api *TaskAPI) streamTasks(ctx *gin.Context, userID string, filter models.TaskFilter) {
+	count := 0
+	err := api.storage.StreamTasks(ctx.Request.Context(), userID, filter, func(task models.Task) error {
+		if count == 0 {
+			ctx.Header("Content-Type", mimeNDJSON)
+			ctx.Status(http.StatusOK)
+			clearDeadlines(ctx)
+		}
+		count++
+		return writeNDJSON(ctx.Writer, task, count)
+	})
+	if err != nil {
+		if count == 0 {
+			respondInternalError(ctx, err)
+			return
+		}
+		requestid.Println(ctx.Request.Context(), "[ERROR] Потоковая выдача задач прервана:", userID, err)
+		return
+	}
+	if count == 0 {
+		respondError(ctx, http.StatusNotFound, errors.ErrTasksNotFound)
+	}
+}
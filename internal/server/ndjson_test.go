@@ -0,0 +1,119 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsNDJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{name: "ndjson", accept: "application/x-ndjson", want: true},
+		{name: "ndjson among others", accept: "application/json;q=0.5, application/x-ndjson", want: true},
+		{name: "ndjson with parameters", accept: "application/x-ndjson; charset=utf-8", want: true},
+		{name: "json", accept: "application/json"},
+		{name: "wildcard", accept: "*/*"},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			ctx.Request.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.want, wantsNDJSON(ctx))
+		})
+	}
+}
+
+func TestGetTasksNDJSON(t *testing.T) {
+	streamed := []models.Task{
+		{ID: "task1", Title: "First", Status: "new", UserID: "user123"},
+		{ID: "task2", Title: "Second", Status: "done", UserID: "user123"},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		tasks      []models.Task
+		err        error
+		filter     models.TaskFilter
+		statusCode int
+		lines      int
+		errorCode  string
+	}{
+		{
+			name:       "streams one task per line",
+			tasks:      streamed,
+			statusCode: http.StatusOK,
+			lines:      2,
+		},
+		{
+			name:       "passes filter",
+			query:      "?status=done",
+			tasks:      streamed[1:],
+			filter:     models.TaskFilter{Status: "done"},
+			statusCode: http.StatusOK,
+			lines:      1,
+		},
+		{
+			name:       "no tasks",
+			tasks:      []models.Task{},
+			statusCode: http.StatusNotFound,
+			errorCode:  "tasks_not_found",
+		},
+		{
+			name:       "storage error before first row",
+			err:        errors.ErrDatabaseUnavailable,
+			statusCode: http.StatusServiceUnavailable,
+			errorCode:  "database_unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("StreamTasks", mock.Anything, "user123", tt.filter).Return(tt.tasks, tt.err)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks"+tt.query, nil)
+			req.Header.Set("Accept", mimeNDJSON)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.errorCode != "" {
+				var response models.ErrorEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.errorCode, response.Error.Code)
+			} else {
+				assert.Equal(t, mimeNDJSON, w.Header().Get("Content-Type"))
+				lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+				require.Len(t, lines, tt.lines)
+				for i, line := range lines {
+					var task models.Task
+					require.NoError(t, json.Unmarshal([]byte(line), &task))
+					assert.Equal(t, tt.tasks[i], task)
+				}
+			}
+			mockTaskRepo.AssertNotCalled(t, "GetTasks", mock.Anything, mock.Anything, mock.Anything)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
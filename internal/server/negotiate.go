@@ -0,0 +1,376 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/ugorji/go/codec"
+)
+
+const xmlRootElement = "response"
+
+var negotiatedFormats = []string{binding.MIMEJSON, binding.MIMEXML, binding.MIMEXML2, binding.MIMEMSGPACK, binding.MIMEMSGPACK2}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+func bindRequest(ctx *gin.Context, obj interface{}) error {
+	switch ctx.ContentType() {
+	case binding.MIMEXML, binding.MIMEXML2:
+		if err := decodeXML(ctx.Request.Body, obj); err != nil {
+			return err
+		}
+		if binding.Validator == nil {
+			return nil
+		}
+		return binding.Validator.ValidateStruct(obj)
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		return ctx.ShouldBindWith(obj, binding.MsgPack)
+	default:
+		return ctx.ShouldBindJSON(obj)
+	}
+}
+
+type negotiatingWriter struct {
+	gin.ResponseWriter
+	decided  bool
+	buffered bool
+	body     bytes.Buffer
+}
+
+func (w *negotiatingWriter) buffering() bool {
+	if !w.decided {
+		w.decided = true
+		header := w.Header()
+		w.buffered = strings.HasPrefix(header.Get("Content-Type"), binding.MIMEJSON) && header.Get("Content-Disposition") == ""
+	}
+	return w.buffered
+}
+
+func (w *negotiatingWriter) Write(data []byte) (int, error) {
+	if w.buffering() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *negotiatingWriter) WriteString(s string) (int, error) {
+	if w.buffering() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *negotiatingWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+func negotiateMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", "Accept")
+		format := ctx.NegotiateFormat(negotiatedFormats...)
+		if format == "" || format == binding.MIMEJSON {
+			ctx.Next()
+			return
+		}
+
+		writer := &negotiatingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+		if !writer.buffered {
+			return
+		}
+
+		body, err := transcodeJSON(writer.body.Bytes(), format)
+		if err != nil {
+			requestid.Println(ctx.Request.Context(), "[ERROR] Не удалось преобразовать ответ в формат", format, err)
+			_, _ = ctx.Writer.Write(writer.body.Bytes())
+			return
+		}
+		if format == binding.MIMEXML || format == binding.MIMEXML2 {
+			ctx.Writer.Header().Set("Content-Type", format+"; charset=utf-8")
+		} else {
+			ctx.Writer.Header().Set("Content-Type", format)
+		}
+		_, _ = ctx.Writer.Write(body)
+	}
+}
+
+func transcodeJSON(body []byte, format string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var out bytes.Buffer
+	if format == binding.MIMEXML || format == binding.MIMEXML2 {
+		out.WriteString(xml.Header)
+		enc := xml.NewEncoder(&out)
+		if err := writeXMLValue(dec, enc, xmlRootElement); err != nil {
+			return nil, err
+		}
+		if err := enc.Flush(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	if err := codec.NewEncoder(&out, handle).Encode(msgpackValue(value)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func msgpackValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = msgpackValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = msgpackValue(item)
+		}
+	}
+	return value
+}
+
+func xmlElement(name string) xml.StartElement {
+	if isXMLName(name) {
+		return xml.StartElement{Name: xml.Name{Local: name}}
+	}
+	return xml.StartElement{Name: xml.Name{Local: "entry"}, Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}}}
+}
+
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		if unicode.IsLetter(r) || r == '_' {
+			continue
+		}
+		if i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func writeXMLValue(dec *json.Decoder, enc *xml.Encoder, name string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	start := xmlElement(name)
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		for dec.More() {
+			child := "item"
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child, _ = key.(string)
+			}
+			if err := writeXMLValue(dec, enc, child); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	case string:
+		err = enc.EncodeToken(xml.CharData(t))
+	case json.Number:
+		err = enc.EncodeToken(xml.CharData(t.String()))
+	case bool:
+		err = enc.EncodeToken(xml.CharData(strconv.FormatBool(t)))
+	}
+	if err != nil {
+		return err
+	}
+	return enc.EncodeToken(start.End())
+}
+
+type xmlNode struct {
+	key      string
+	text     strings.Builder
+	children []*xmlNode
+}
+
+func (n *xmlNode) empty() bool {
+	return len(n.children) == 0 && strings.TrimSpace(n.text.String()) == ""
+}
+
+func parseXML(r io.Reader) (*xmlNode, error) {
+	dec := xml.NewDecoder(r)
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{key: t.Name.Local}
+			for _, attr := range t.Attr {
+				if t.Name.Local == "entry" && attr.Name.Local == "key" {
+					node.key = attr.Value
+				}
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return root, nil
+}
+
+func decodeXML(r io.Reader, obj interface{}) error {
+	root, err := parseXML(r)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(xmlJSONValue(root, reflect.TypeOf(obj).Elem()))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) map[string]reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				jsonFields(fieldType, fields)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = fieldType
+	}
+	return fields
+}
+
+func xmlJSONValue(n *xmlNode, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		if n.empty() {
+			return nil
+		}
+		t = t.Elem()
+	}
+	text := strings.TrimSpace(n.text.String())
+	if n.empty() && t.Kind() != reflect.String && t.Kind() != reflect.Struct && t.Kind() != reflect.Slice && t.Kind() != reflect.Map {
+		return nil
+	}
+	if t == timeType || (reflect.PointerTo(t).Implements(jsonUnmarshalerType) && t.Kind() == reflect.String) {
+		return text
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t, map[string]reflect.Type{})
+		object := map[string]interface{}{}
+		for _, child := range n.children {
+			if fieldType, ok := fields[child.key]; ok {
+				object[child.key] = xmlJSONValue(child, fieldType)
+			}
+		}
+		return object
+	case reflect.Map:
+		object := map[string]interface{}{}
+		for _, child := range n.children {
+			object[child.key] = xmlJSONValue(child, t.Elem())
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return text
+		}
+		items := make([]interface{}, 0, len(n.children))
+		for _, child := range n.children {
+			items = append(items, xmlJSONValue(child, t.Elem()))
+		}
+		return items
+	case reflect.Interface:
+		if len(n.children) == 0 {
+			return text
+		}
+		if n.children[0].key == "item" {
+			return xmlJSONValue(n, reflect.TypeOf([]interface{}{}))
+		}
+		return xmlJSONValue(n, reflect.TypeOf(map[string]interface{}{}))
+	case reflect.Bool:
+		if value, err := strconv.ParseBool(text); err == nil {
+			return value
+		}
+		return text
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return json.Number(text)
+	default:
+		return text
+	}
+}
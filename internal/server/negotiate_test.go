@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ugorji/go/codec"
+)
+
+func TestNegotiatedResponses(t *testing.T) {
+	tags := []models.Tag{{ID: "tag1", Name: "work", UserID: "user123"}}
+
+	tests := []struct {
+		name        string
+		accept      string
+		auth        bool
+		statusCode  int
+		contentType string
+		check       func(*testing.T, []byte)
+	}{
+		{
+			name:        "json by default",
+			auth:        true,
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			check: func(t *testing.T, body []byte) {
+				assert.Contains(t, string(body), `"name":"work"`)
+			},
+		},
+		{
+			name:        "xml response",
+			accept:      "application/xml",
+			auth:        true,
+			statusCode:  http.StatusOK,
+			contentType: "application/xml",
+			check: func(t *testing.T, body []byte) {
+				var resp struct {
+					XMLName xml.Name `xml:"response"`
+					Tags    []struct {
+						ID   string `xml:"id"`
+						Name string `xml:"name"`
+					} `xml:"tags>item"`
+				}
+				assert.NoError(t, xml.Unmarshal(body, &resp))
+				assert.Len(t, resp.Tags, 1)
+				assert.Equal(t, "work", resp.Tags[0].Name)
+			},
+		},
+		{
+			name:        "msgpack response",
+			accept:      "application/msgpack",
+			auth:        true,
+			statusCode:  http.StatusOK,
+			contentType: "application/msgpack",
+			check: func(t *testing.T, body []byte) {
+				var resp struct {
+					Tags []models.Tag `codec:"tags"`
+				}
+				assert.NoError(t, codec.NewDecoderBytes(body, &codec.MsgpackHandle{}).Decode(&resp))
+				assert.Len(t, resp.Tags, 1)
+				assert.Equal(t, "tag1", resp.Tags[0].ID)
+			},
+		},
+		{
+			name:        "xml error envelope",
+			accept:      "application/xml",
+			statusCode:  http.StatusUnauthorized,
+			contentType: "application/xml",
+			check: func(t *testing.T, body []byte) {
+				var resp struct {
+					Code    string `xml:"error>code"`
+					Message string `xml:"error>message"`
+				}
+				assert.NoError(t, xml.Unmarshal(body, &resp))
+				assert.Equal(t, "not_authorized", resp.Code)
+				assert.NotEmpty(t, resp.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			if tt.auth {
+				mockTaskRepo.On("GetTags", mock.Anything, "user123").Return(tags, nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tags", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if tt.auth {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			}
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), tt.contentType), w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Values("Vary"), "Accept")
+			tt.check(t, w.Body.Bytes())
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNegotiatedRequests(t *testing.T) {
+	var msgpackBody bytes.Buffer
+	_ = codec.NewEncoder(&msgpackBody, &codec.MsgpackHandle{}).Encode(map[string]interface{}{"name": "home"})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		statusCode  int
+		tagName     string
+	}{
+		{
+			name:        "xml body",
+			contentType: "application/xml",
+			body:        []byte(`<request><name>work</name></request>`),
+			statusCode:  http.StatusCreated,
+			tagName:     "work",
+		},
+		{
+			name:        "msgpack body",
+			contentType: "application/msgpack",
+			body:        msgpackBody.Bytes(),
+			statusCode:  http.StatusCreated,
+			tagName:     "home",
+		},
+		{
+			name:        "malformed xml",
+			contentType: "application/xml",
+			body:        []byte(`<request><name>work</request>`),
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "xml validation failure",
+			contentType: "application/xml",
+			body:        []byte(`<request><name></name></request>`),
+			statusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			if tt.tagName != "" {
+				mockTaskRepo.On("CreateTag", mock.Anything, mock.MatchedBy(func(tag *models.Tag) bool {
+					return tag.Name == tt.tagName
+				})).Return(nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("POST", "/tags", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDecodeXML(t *testing.T) {
+	body := `<request>
+		<title>Task</title>
+		<due_date>2026-01-02T15:04:05Z</due_date>
+		<reminder_offset_minutes>30</reminder_offset_minutes>
+		<unknown>ignored</unknown>
+	</request>`
+
+	var req models.CreateTaskRequest
+	assert.NoError(t, decodeXML(strings.NewReader(body), &req))
+	assert.Equal(t, "Task", req.Title)
+	assert.Equal(t, 30, req.ReminderOffsetMinutes)
+	if assert.NotNil(t, req.DueDate) {
+		assert.True(t, req.DueDate.Equal(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)))
+	}
+
+	var batch models.BatchCreateTasksRequest
+	assert.NoError(t, decodeXML(strings.NewReader(`<request><tasks><item><title>A</title></item><item><title>B</title></item></tasks></request>`), &batch))
+	assert.Len(t, batch.Tasks, 2)
+	assert.Equal(t, "B", batch.Tasks[1].Title)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var jsonNull = []byte("null")
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), jsonNull)
+}
+
+func applyTaskPatch(task *models.Task, patch map[string]json.RawMessage) error {
+	valid := requestValidator.validate
+	for field, raw := range patch {
+		switch field {
+		case "title":
+			var title string
+			if err := json.Unmarshal(raw, &title); err != nil || valid.Var(title, "required,min=1,max=100") != nil {
+				return errors.ErrInvalidRequest
+			}
+			task.Title = title
+		case "description":
+			var description string
+			if !isJSONNull(raw) {
+				if err := json.Unmarshal(raw, &description); err != nil || valid.Var(description, "max=500") != nil {
+					return errors.ErrInvalidRequest
+				}
+			}
+			task.Description = description
+		case "status":
+			var status string
+			if err := json.Unmarshal(raw, &status); err != nil || valid.Var(status, "required,status") != nil {
+				return errors.ErrInvalidRequest
+			}
+			task.Status = status
+		case "due_date":
+			if isJSONNull(raw) {
+				task.DueDate = nil
+				continue
+			}
+			var dueDate time.Time
+			if err := json.Unmarshal(raw, &dueDate); err != nil {
+				return errors.ErrInvalidRequest
+			}
+			task.DueDate = &dueDate
+		case "reminder_offset_minutes":
+			var offset int
+			if !isJSONNull(raw) {
+				if err := json.Unmarshal(raw, &offset); err != nil || valid.Var(offset, "min=0,max=525600") != nil {
+					return errors.ErrInvalidRequest
+				}
+			}
+			task.ReminderOffsetMinutes = offset
+		case "project_id":
+			var projectID string
+			if !isJSONNull(raw) {
+				if err := json.Unmarshal(raw, &projectID); err != nil {
+					return errors.ErrInvalidRequest
+				}
+			}
+			task.ProjectID = projectID
+		default:
+			return errors.ErrInvalidRequest
+		}
+	}
+	return nil
+}
+
+func (api *TaskAPI) patchTask(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var patch map[string]json.RawMessage
+	data, err := ctx.GetRawData()
+	if err != nil || json.Unmarshal(data, &patch) != nil || patch == nil {
+		respondError(ctx, http.StatusBadRequest, errors.ErrBadRequest)
+		return
+	}
+	_, statusOnly := patch["status"]
+	statusOnly = statusOnly && len(patch) == 1
+	task, ok := api.loadUpdatableTask(ctx, userID, ctx.Param("taskID"), statusOnly)
+	if !ok || !checkIfMatch(ctx, task) {
+		return
+	}
+	before := *task
+	previousStatus := task.Status
+	previousProject := task.ProjectID
+	if err := applyTaskPatch(task, patch); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if task.Status != previousStatus {
+		if err := api.checkStatusChange(ctx.Request.Context(), &before, task.Status); err != nil {
+			respondStatusError(ctx, err)
+			return
+		}
+	}
+	if task.ProjectID != previousProject && !api.validateProject(ctx, task.UserID, task.ProjectID) {
+		return
+	}
+	if err := api.storage.UpdateTask(ctx.Request.Context(), task.ID, task); err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, &before, task)
+	if task.Status != previousStatus && task.ParentID != "" {
+		api.rollUpStatus(ctx.Request.Context(), task.ParentID)
+	}
+	ctx.Header("ETag", taskETag(*task))
+	ctx.JSON(http.StatusOK, gin.H{"task": task})
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPatchTask(t *testing.T) {
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newTask := func() *models.Task {
+		return &models.Task{
+			ID:                    "task123",
+			Title:                 "Original",
+			Description:           "Original description",
+			Status:                "new",
+			UserID:                "user123",
+			ProjectID:             "project1",
+			DueDate:               &due,
+			ReminderOffsetMinutes: 30,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       func(*testing.T, *models.Task)
+	}{
+		{
+			name:       "clear description with null",
+			body:       `{"description": null}`,
+			statusCode: http.StatusOK,
+			want: func(t *testing.T, task *models.Task) {
+				assert.Empty(t, task.Description)
+				assert.Equal(t, "Original", task.Title)
+			},
+		},
+		{
+			name:       "clear description with empty string",
+			body:       `{"description": ""}`,
+			statusCode: http.StatusOK,
+			want: func(t *testing.T, task *models.Task) {
+				assert.Empty(t, task.Description)
+			},
+		},
+		{
+			name:       "clear due date and project",
+			body:       `{"due_date": null, "project_id": null, "reminder_offset_minutes": null}`,
+			statusCode: http.StatusOK,
+			want: func(t *testing.T, task *models.Task) {
+				assert.Nil(t, task.DueDate)
+				assert.Empty(t, task.ProjectID)
+				assert.Zero(t, task.ReminderOffsetMinutes)
+				assert.Equal(t, "Original description", task.Description)
+			},
+		},
+		{
+			name:       "update title and status",
+			body:       `{"title": "Patched", "status": "in_progress"}`,
+			statusCode: http.StatusOK,
+			want: func(t *testing.T, task *models.Task) {
+				assert.Equal(t, "Patched", task.Title)
+				assert.Equal(t, "in_progress", task.Status)
+			},
+		},
+		{name: "title cannot be cleared", body: `{"title": null}`, statusCode: http.StatusBadRequest},
+		{name: "invalid status", body: `{"status": "archived"}`, statusCode: http.StatusBadRequest},
+		{name: "unknown field", body: `{"owner": "user456"}`, statusCode: http.StatusBadRequest},
+		{name: "not an object", body: `["title"]`, statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(newTask(), nil).Maybe()
+			var updated *models.Task
+			if tt.statusCode == http.StatusOK {
+				mockTaskRepo.On("UpdateTask", mock.Anything, "task123", mock.AnythingOfType("*models.Task")).
+					Run(func(args mock.Arguments) { updated = args.Get(2).(*models.Task) }).
+					Return(nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("PATCH", "/tasks/task123", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				tt.want(t, updated)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPatchTrashedTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(&models.Task{ID: "task123", Title: "Original", Status: "new", UserID: "user123", Deleted: true}, nil)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+	req, _ := http.NewRequest("PATCH", "/tasks/task123", bytes.NewBufferString(`{"title": "Patched"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockTaskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything)
+	mockTaskRepo.AssertExpectations(t)
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) userPreferences(ctx context.Context, userID string) models.UserPreferences {
+	prefs, err := api.storage.GetUserPreferences(ctx, userID)
+	if err != nil {
+		return models.DefaultUserPreferences()
+	}
+	return *prefs
+}
+
+func (api *TaskAPI) getPreferences(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	prefs, err := api.storage.GetUserPreferences(ctx.Request.Context(), userID)
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+func (api *TaskAPI) updatePreferences(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.UserPreferences
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.ErrInvalidTimezone)
+		return
+	}
+	if err := api.storage.SaveUserPreferences(ctx.Request.Context(), userID, &req); err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"preferences": req})
+}
+
+func (api *TaskAPI) requestLocale(ctx *gin.Context) string {
+	if userID, err := api.getUserIDFromJWT(ctx); err == nil {
+		if prefs, err := api.storage.GetUserPreferences(ctx.Request.Context(), userID); err == nil {
+			return prefs.Locale
+		}
+	}
+	language := strings.TrimSpace(strings.Split(ctx.GetHeader("Accept-Language"), ",")[0])
+	if strings.HasPrefix(strings.ToLower(language), models.LocaleEN) {
+		return models.LocaleEN
+	}
+	return models.LocaleRU
+}
+
+func localizeDueDates(tasks []models.Task, loc *time.Location) {
+	for i := range tasks {
+		if tasks[i].DueDate != nil {
+			local := tasks[i].DueDate.In(loc)
+			tasks[i].DueDate = &local
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferencesHandlers(t *testing.T) {
+	saved := models.UserPreferences{
+		Timezone:      "Europe/Moscow",
+		Locale:        models.LocaleEN,
+		Notifications: models.NotificationPreferences{Reminders: true, Channels: []string{"email"}},
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		body        interface{}
+		preferences map[string]models.UserPreferences
+		statusCode  int
+		mockSetup   func(*MockUserStore)
+		want        error
+	}{
+		{
+			name:        "get preferences",
+			method:      "GET",
+			preferences: map[string]models.UserPreferences{"user123": saved},
+			statusCode:  http.StatusOK,
+			mockSetup:   func(m *MockUserStore) {},
+		},
+		{
+			name:       "get preferences of missing user",
+			method:     "GET",
+			statusCode: http.StatusNotFound,
+			mockSetup:  func(m *MockUserStore) {},
+		},
+		{
+			name:       "save preferences",
+			method:     "PUT",
+			body:       saved,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore) {
+				m.On("SaveUserPreferences", "user123", &saved).Return(nil)
+			},
+		},
+		{
+			name:       "unknown timezone",
+			method:     "PUT",
+			body:       models.UserPreferences{Timezone: "Mars/Olympus", Locale: models.LocaleRU},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore) {},
+			want:       errors.ErrInvalidTimezone,
+		},
+		{
+			name:       "unsupported locale",
+			method:     "PUT",
+			body:       models.UserPreferences{Timezone: "UTC", Locale: "de"},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore) {},
+		},
+		{
+			name:   "unknown notification channel",
+			method: "PUT",
+			body: models.UserPreferences{
+				Timezone:      "UTC",
+				Locale:        models.LocaleRU,
+				Notifications: models.NotificationPreferences{Reminders: true, Channels: []string{"sms"}},
+			},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{preferences: tt.preferences}
+			tt.mockSetup(mockRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, "/users/me/preferences", &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				assert.Contains(t, w.Body.String(), tt.want.Error())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		locale         string
+		acceptLanguage string
+		authenticated  bool
+		want           string
+	}{
+		{name: "russian by default", authenticated: true, want: errors.ErrTaskNotFound.Error()},
+		{name: "english preference", locale: models.LocaleEN, authenticated: true, want: "task not found"},
+		{name: "preference wins over header", locale: models.LocaleRU, acceptLanguage: "en-US", authenticated: true, want: errors.ErrTaskNotFound.Error()},
+		{name: "accept-language for anonymous", acceptLanguage: "en-GB,en;q=0.9", want: "user is not authorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{preferences: map[string]models.UserPreferences{}}
+			if tt.locale != "" {
+				mockRepo.preferences["user123"] = models.UserPreferences{Timezone: "UTC", Locale: tt.locale}
+			}
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "missing").Return(nil, errors.ErrNotFound).Maybe()
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks/missing", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if tt.authenticated {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			}
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			var resp models.ErrorEnvelope
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.want, resp.Error.Message)
+		})
+	}
+}
+
+func TestDueTasksInUserTimezone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	due := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	mockRepo := &MockUserStore{preferences: map[string]models.UserPreferences{
+		"user123": {Timezone: "Asia/Tokyo", Locale: models.LocaleRU},
+	}}
+	mockTaskRepo := &MockTaskStore{}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	endOfTokyoDay := endOfDay(time.Now(), tokyo)
+	mockTaskRepo.On("GetDueTasks", mock.Anything, "user123", mock.AnythingOfType("time.Time"), mock.MatchedBy(func(to time.Time) bool {
+		return to.Sub(endOfTokyoDay).Abs() < time.Minute
+	})).Return([]models.Task{{ID: "task1", UserID: "user123", DueDate: &due}}, nil)
+
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+	req, _ := http.NewRequest("GET", "/tasks/due?within=today", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Tasks    []models.Task `json:"tasks"`
+		Within   string        `json:"within"`
+		Timezone string        `json:"timezone"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "today", resp.Within)
+	assert.Equal(t, "Asia/Tokyo", resp.Timezone)
+	require.Len(t, resp.Tasks, 1)
+	assert.Contains(t, w.Body.String(), due.In(tokyo).Format(time.RFC3339))
+	assert.True(t, due.Equal(*resp.Tasks[0].DueDate))
+	mockTaskRepo.AssertExpectations(t)
+}
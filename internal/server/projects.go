@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"project/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultProjectName = "Inbox"
+
+func createDefaultProject(ctx context.Context, store repository.Storage, userID string) error {
+	project := models.Project{Name: defaultProjectName, UserID: userID}
+	if err := store.CreateProject(ctx, &project); err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось создать проект по умолчанию:", err)
+		return err
+	}
+	return nil
+}
+
+func (api *TaskAPI) loadOwnProject(ctx *gin.Context, userID, projectID string) (*models.Project, bool) {
+	project, err := api.storage.GetProjectByID(ctx.Request.Context(), projectID)
+	if err != nil {
+		if err == errors.ErrProjectNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrProjectNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return nil, false
+	}
+	if project.UserID != userID {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return nil, false
+	}
+	return project, true
+}
+
+func (api *TaskAPI) validateProject(ctx *gin.Context, userID, projectID string) bool {
+	if err := api.checkProject(ctx.Request.Context(), userID, projectID); err != nil {
+		respondTaskError(ctx, err)
+		return false
+	}
+	return true
+}
+
+func (api *TaskAPI) checkProject(ctx context.Context, userID, projectID string) error {
+	if projectID == "" {
+		return nil
+	}
+	project, err := api.storage.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if err == errors.ErrProjectNotFound {
+			return errors.ErrProjectNotFound
+		}
+		return internalError(err)
+	}
+	if project.UserID != userID {
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+func bindProjectRequest(ctx *gin.Context) (*models.ProjectRequest, bool) {
+	var req models.ProjectRequest
+	if !bindAndValidate(ctx, &req) {
+		return nil, false
+	}
+	return &req, true
+}
+
+func (api *TaskAPI) getProjects(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	projects, err := api.storage.GetProjects(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+func (api *TaskAPI) getProjectByID(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	project, ok := api.loadOwnProject(ctx, userID, ctx.Param("projectID"))
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"project": project})
+}
+
+func (api *TaskAPI) createProject(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindProjectRequest(ctx)
+	if !ok {
+		return
+	}
+	project := models.Project{Name: req.Name, UserID: userID}
+	if err := api.storage.CreateProject(ctx.Request.Context(), &project); err != nil {
+		if err == errors.ErrProjectAlreadyExists {
+			respondError(ctx, http.StatusConflict, errors.ErrProjectAlreadyExists)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"project": project})
+}
+
+func (api *TaskAPI) updateProject(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindProjectRequest(ctx)
+	if !ok {
+		return
+	}
+	project, ok := api.loadOwnProject(ctx, userID, ctx.Param("projectID"))
+	if !ok {
+		return
+	}
+	project.Name = req.Name
+	if err := api.storage.UpdateProject(ctx.Request.Context(), project.ID, project); err != nil {
+		switch err {
+		case errors.ErrProjectAlreadyExists:
+			respondError(ctx, http.StatusConflict, errors.ErrProjectAlreadyExists)
+		case errors.ErrProjectNotFound:
+			respondError(ctx, http.StatusNotFound, errors.ErrProjectNotFound)
+		default:
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"project": project})
+}
+
+func (api *TaskAPI) deleteProject(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	project, ok := api.loadOwnProject(ctx, userID, ctx.Param("projectID"))
+	if !ok {
+		return
+	}
+	if err := api.storage.DeleteProject(ctx.Request.Context(), project.ID); err != nil {
+		if err == errors.ErrProjectNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrProjectNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "проект успешно удален"})
+}
+
+func (api *TaskAPI) getProjectTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	project, ok := api.loadOwnProject(ctx, userID, ctx.Param("projectID"))
+	if !ok {
+		return
+	}
+	filter, err := api.parseTaskFilter(ctx, userID)
+	if err != nil {
+		respondStatusError(ctx, err)
+		return
+	}
+	filter.ProjectID = project.ID
+	tasks, err := api.storage.GetTasks(ctx.Request.Context(), userID, filter)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"project": project, "tasks": tasks})
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProjectHandlers(t *testing.T) {
+	ownProject := &models.Project{ID: "project1", Name: "Work", UserID: "user123"}
+	foreignProject := &models.Project{ID: "project2", Name: "Home", UserID: "user456"}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "list projects",
+			method:     "GET",
+			path:       "/projects",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjects", mock.Anything, "user123").Return([]models.Project{*ownProject}, nil)
+			},
+		},
+		{
+			name:       "create project",
+			method:     "POST",
+			path:       "/projects",
+			body:       models.ProjectRequest{Name: "Work"},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateProject", mock.Anything, &models.Project{Name: "Work", UserID: "user123"}).Return(nil)
+			},
+		},
+		{
+			name:       "create duplicate project",
+			method:     "POST",
+			path:       "/projects",
+			body:       models.ProjectRequest{Name: "Work"},
+			statusCode: http.StatusConflict,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateProject", mock.Anything, mock.AnythingOfType("*models.Project")).Return(errors.ErrProjectAlreadyExists)
+			},
+		},
+		{
+			name:       "get foreign project",
+			method:     "GET",
+			path:       "/projects/project2",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "project2").Return(foreignProject, nil)
+			},
+		},
+		{
+			name:       "rename project",
+			method:     "PUT",
+			path:       "/projects/project1",
+			body:       models.ProjectRequest{Name: "Office"},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "project1").Return(&models.Project{ID: "project1", Name: "Work", UserID: "user123"}, nil)
+				m.On("UpdateProject", mock.Anything, "project1", mock.AnythingOfType("*models.Project")).Return(nil)
+			},
+		},
+		{
+			name:       "delete missing project",
+			method:     "DELETE",
+			path:       "/projects/missing",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "missing").Return(nil, errors.ErrProjectNotFound)
+			},
+		},
+		{
+			name:       "list project tasks",
+			method:     "GET",
+			path:       "/projects/project1/tasks?status=new",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "project1").Return(ownProject, nil)
+				m.On("GetTasks", mock.Anything, "user123", models.TaskFilter{Status: "new", ProjectID: "project1"}).Return([]models.Task{}, nil)
+			},
+		},
+		{
+			name:       "create task in foreign project",
+			method:     "POST",
+			path:       "/tasks",
+			body:       models.CreateTaskRequest{Title: "Task", ProjectID: "project2"},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "project2").Return(foreignProject, nil)
+			},
+		},
+		{
+			name:       "create task in missing project",
+			method:     "POST",
+			path:       "/tasks",
+			body:       models.CreateTaskRequest{Title: "Task", ProjectID: "missing"},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetProjectByID", mock.Anything, "missing").Return(nil, errors.ErrProjectNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	stderrors "errors"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"project/internal/domain/errors"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func isBrokenConnection(recovered interface{}) bool {
+	err, ok := recovered.(error)
+	return ok && (stderrors.Is(err, syscall.EPIPE) || stderrors.Is(err, syscall.ECONNRESET))
+}
+
+func (api *TaskAPI) recoveryMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			if isBrokenConnection(recovered) {
+				requestid.Println(ctx.Request.Context(), "[WARN] Соединение с клиентом разорвано:", recovered)
+				ctx.Abort()
+				return
+			}
+
+			route := ctx.FullPath()
+			if route == "" {
+				route = "unmatched"
+			}
+			api.panics.Inc(route)
+			requestid.Printf(ctx.Request.Context(), "[ERROR] Паника при обработке %s %s: %v\n%s", ctx.Request.Method, ctx.Request.URL.Path, recovered, debug.Stack())
+
+			if ctx.Writer.Written() {
+				ctx.Abort()
+				return
+			}
+			respondError(ctx, http.StatusInternalServerError, errors.ErrInternalServer)
+		}()
+		ctx.Next()
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		statusCode int
+		envelope   bool
+		panics     bool
+	}{
+		{
+			name:       "panic returns error envelope",
+			handler:    func(c *gin.Context) { panic("boom") },
+			statusCode: http.StatusInternalServerError,
+			envelope:   true,
+			panics:     true,
+		},
+		{
+			name: "panic after response started",
+			handler: func(c *gin.Context) {
+				c.String(http.StatusOK, "partial")
+				panic(fmt.Errorf("boom"))
+			},
+			statusCode: http.StatusOK,
+			panics:     true,
+		},
+		{
+			name:       "broken connection is not counted",
+			handler:    func(c *gin.Context) { panic(fmt.Errorf("write: %w", syscall.EPIPE)) },
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "no panic",
+			handler:    func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			statusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			router := gin.New()
+			router.Use(requestIDMiddleware(), api.errorMiddleware(), api.recoveryMiddleware())
+			router.GET("/panic", tt.handler)
+
+			req, _ := http.NewRequest("GET", "/panic", nil)
+			w := httptest.NewRecorder()
+			require.NotPanics(t, func() { router.ServeHTTP(w, req) })
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.envelope {
+				var response models.ErrorEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "internal_error", response.Error.Code)
+				assert.Equal(t, errors.ErrInternalServer.Error(), response.Error.Message)
+				assert.NotEmpty(t, response.Error.RequestID)
+			}
+
+			var out strings.Builder
+			require.NoError(t, api.metrics.Write(&out))
+			if tt.panics {
+				assert.Contains(t, out.String(), `http_panics_total{route="/panic"} 1`)
+			} else {
+				assert.NotContains(t, out.String(), `route="/panic"`)
+			}
+		})
+	}
+}
+
+func TestRecoveryAbortHandler(t *testing.T) {
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+	router := gin.New()
+	router.Use(api.recoveryMiddleware())
+	router.GET("/abort", func(c *gin.Context) { panic(http.ErrAbortHandler) })
+
+	req, _ := http.NewRequest("GET", "/abort", nil)
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { router.ServeHTTP(httptest.NewRecorder(), req) })
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) reorderTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.ReorderTasksRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	seen := make(map[string]bool, len(req.TaskIDs))
+	for _, id := range req.TaskIDs {
+		if seen[id] {
+			respondError(ctx, http.StatusBadRequest, errors.ErrInvalidRequest)
+			return
+		}
+		seen[id] = true
+		task, ok := api.loadOwnTask(ctx, userID, id)
+		if !ok {
+			return
+		}
+		if task.Deleted {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+			return
+		}
+	}
+	if err := api.storage.ReorderTasks(ctx.Request.Context(), userID, req.TaskIDs); err != nil {
+		if err == errors.ErrTaskNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"task_ids": req.TaskIDs})
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReorderTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "reorder own tasks",
+			body:       `{"task_ids": ["task2", "task1"]}`,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123"}, nil)
+				m.On("GetTaskByID", mock.Anything, "task2").Return(&models.Task{ID: "task2", UserID: "user123"}, nil)
+				m.On("ReorderTasks", mock.Anything, "user123", []string{"task2", "task1"}).Return(nil)
+			},
+		},
+		{
+			name:       "foreign task",
+			body:       `{"task_ids": ["task1", "task3"]}`,
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123"}, nil)
+				m.On("GetTaskByID", mock.Anything, "task3").Return(&models.Task{ID: "task3", UserID: "other"}, nil)
+			},
+		},
+		{
+			name:       "duplicate ids",
+			body:       `{"task_ids": ["task1", "task1"]}`,
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123"}, nil)
+			},
+		},
+		{
+			name:       "empty list",
+			body:       `{"task_ids": []}`,
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("POST", "/tasks/reorder", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
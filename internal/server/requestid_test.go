@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		inbound   string
+		generated bool
+	}{
+		{name: "honors inbound id", inbound: "edge-42"},
+		{name: "generates missing id", generated: true},
+		{name: "replaces invalid id", inbound: "bad id\r\nX-Injected: 1", generated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			var seen string
+			mockTaskRepo.On("GetTaskByID", mock.MatchedBy(func(ctx context.Context) bool {
+				seen = requestid.FromContext(ctx)
+				return true
+			}), "missing").Return(nil, errors.ErrNotFound)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks/missing", nil)
+			if tt.inbound != "" {
+				req.Header.Set(requestid.Header, tt.inbound)
+			}
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			id := w.Header().Get(requestid.Header)
+			if tt.generated {
+				assert.True(t, requestid.Valid(id))
+				assert.NotEqual(t, tt.inbound, id)
+			} else {
+				assert.Equal(t, tt.inbound, id)
+			}
+			assert.Equal(t, id, seen)
+
+			var response models.ErrorEnvelope
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "task_not_found", response.Error.Code)
+			assert.Equal(t, errors.ErrTaskNotFound.Error(), response.Error.Message)
+			assert.Equal(t, id, response.Error.RequestID)
+		})
+	}
+}
+
+func TestRequestIDNotAddedToSuccessBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+	req, _ := http.NewRequest("GET", "/livez", nil)
+	req.Header.Set(requestid.Header, "probe-1")
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "probe-1", w.Header().Get(requestid.Header))
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
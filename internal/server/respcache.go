@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/jsoncodec"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ResponseCacheMemory = "memory"
+	ResponseCacheRedis  = "redis"
+
+	ResponseCacheHeader = "X-Cache"
+
+	responseKeyPrefix     = "resp:"
+	responseVersionPrefix = "respver:"
+	writtenTaskKey        = "written_task"
+)
+
+var cachedRoutes = map[string]bool{
+	"/tasks":         true,
+	"/users/:userID": true,
+}
+
+type ResponseCache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+type cachedResponse struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+func isResponseCacheBackend(backend string) bool {
+	return backend == "" || backend == ResponseCacheMemory || backend == ResponseCacheRedis
+}
+
+func (api *TaskAPI) SetResponseCache(store ResponseCache, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultResponseCacheTTL
+	}
+	api.responseCache = store
+	api.responseCacheTTL = ttl
+}
+
+func (api *TaskAPI) responseVersion(ctx context.Context, scope string) (int64, bool) {
+	data, err := api.responseCache.Get(ctx, responseVersionPrefix+scope)
+	if err == errors.ErrCacheMiss {
+		return 0, true
+	}
+	if err != nil {
+		requestid.Println(ctx, "[WARN] Ошибка чтения версии кэша ответов:", err)
+		return 0, false
+	}
+	version, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		requestid.Println(ctx, "[WARN] Некорректная версия кэша ответов:", err)
+		return 0, false
+	}
+	return version, true
+}
+
+func (api *TaskAPI) invalidateResponses(ctx context.Context, userIDs ...string) {
+	if api.responseCache == nil {
+		return
+	}
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		if _, err := api.responseCache.Incr(ctx, responseVersionPrefix+userID); err != nil {
+			requestid.Println(ctx, "[WARN] Ошибка инвалидации кэша ответов:", err)
+		}
+	}
+}
+
+func (api *TaskAPI) invalidateTaskResponses(ctx context.Context, tasks ...*models.Task) {
+	var userIDs []string
+	for _, task := range tasks {
+		if task != nil {
+			userIDs = append(userIDs, task.UserID, task.AssigneeID)
+		}
+	}
+	api.invalidateResponses(ctx, userIDs...)
+}
+
+func (api *TaskAPI) responseCacheKey(ctx *gin.Context, userID string) (string, bool) {
+	scope := userID
+	if target := ctx.Param("userID"); target != "" {
+		scope = target
+	}
+	if scope == "" {
+		return "", false
+	}
+	version, ok := api.responseVersion(ctx.Request.Context(), scope)
+	if !ok {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(userID + "|" + scope + "|" + strconv.FormatInt(version, 10) + "|" + ctx.Request.URL.RequestURI()))
+	return responseKeyPrefix + hex.EncodeToString(sum[:16]), true
+}
+
+func (api *TaskAPI) responseCacheMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if api.responseCache == nil {
+			ctx.Next()
+			return
+		}
+		if isWriteMethod(ctx.Request.Method) {
+			ctx.Next()
+			if ctx.Writer.Status() < http.StatusBadRequest {
+				userID, _ := api.getUserIDFromJWT(ctx)
+				api.invalidateResponses(ctx.Request.Context(), userID, ctx.Param("userID"))
+				if task, ok := ctx.Get(writtenTaskKey); ok {
+					api.invalidateTaskResponses(ctx.Request.Context(), task.(*models.Task))
+				}
+			}
+			return
+		}
+		if ctx.Request.Method != http.MethodGet || !cachedRoutes[ctx.FullPath()] || wantsNDJSON(ctx) || ctx.Query("workspace") != "" {
+			ctx.Next()
+			return
+		}
+
+		userID, _ := api.getUserIDFromJWT(ctx)
+		key, ok := api.responseCacheKey(ctx, userID)
+		if !ok {
+			ctx.Next()
+			return
+		}
+		if data, err := api.responseCache.Get(ctx.Request.Context(), key); err == nil {
+			var cached cachedResponse
+			if jsoncodec.Unmarshal(data, &cached) == nil {
+				ctx.Header(ResponseCacheHeader, "HIT")
+				if cached.ETag != "" && notModified(ctx, cached.ETag) {
+					return
+				}
+				ctx.Data(http.StatusOK, cached.ContentType, cached.Body)
+				ctx.Abort()
+				return
+			}
+		} else if err != errors.ErrCacheMiss {
+			requestid.Println(ctx.Request.Context(), "[WARN] Ошибка чтения из кэша ответов:", err)
+		}
+
+		ctx.Header(ResponseCacheHeader, "MISS")
+		writer := &capturingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+		if writer.Status() != http.StatusOK {
+			return
+		}
+		data, err := jsoncodec.Marshal(cachedResponse{
+			ContentType: writer.Header().Get("Content-Type"),
+			ETag:        writer.Header().Get("ETag"),
+			Body:        writer.body.Bytes(),
+		})
+		if err != nil {
+			return
+		}
+		if err := api.responseCache.Set(ctx.Request.Context(), key, data, api.responseCacheTTL); err != nil {
+			requestid.Println(ctx.Request.Context(), "[WARN] Ошибка записи в кэш ответов:", err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mapResponseCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func (c *mapResponseCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.items[key]
+	if !ok {
+		return nil, errors.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (c *mapResponseCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	return nil
+}
+
+func (c *mapResponseCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, _ := strconv.ParseInt(string(c.items[key]), 10, 64)
+	value++
+	c.items[key] = []byte(strconv.FormatInt(value, 10))
+	return value, nil
+}
+
+func TestResponseCache(t *testing.T) {
+	type step struct {
+		method string
+		path   string
+		body   string
+		cache  string
+		user   string
+	}
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		steps     []step
+		mockSetup func(*MockUserStore, *MockTaskStore)
+	}{
+		{
+			name:    "repeated task list is served from cache",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/tasks", cache: "MISS"},
+				{method: "GET", path: "/tasks", cache: "HIT"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTasks", mock.Anything, "user123", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{{ID: "task1", UserID: "user123"}}, nil).Once()
+			},
+		},
+		{
+			name:    "different query is cached separately",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/tasks", cache: "MISS"},
+				{method: "GET", path: "/tasks?status=done", cache: "MISS"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTasks", mock.Anything, "user123", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{}, nil).Twice()
+			},
+		},
+		{
+			name:    "write invalidates cached list",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/tasks", cache: "MISS"},
+				{method: "POST", path: "/tags", body: `{"name":"work"}`},
+				{method: "GET", path: "/tasks", cache: "MISS"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTasks", mock.Anything, "user123", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{}, nil).Twice()
+				m.On("CreateTag", mock.Anything, mock.AnythingOfType("*models.Tag")).Return(nil)
+			},
+		},
+		{
+			name:    "collaborator checklist write invalidates owner and assignee lists",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/tasks", cache: "MISS", user: "user456"},
+				{method: "GET", path: "/tasks?view=assigned", cache: "MISS", user: "user789"},
+				{method: "POST", path: "/tasks/task1/checklist", body: `{"title":"step"}`},
+				{method: "GET", path: "/tasks", cache: "MISS", user: "user456"},
+				{method: "GET", path: "/tasks?view=assigned", cache: "MISS", user: "user789"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				task := &models.Task{ID: "task1", UserID: "user456", AssigneeID: "user789"}
+				m.On("GetTasks", mock.Anything, "user456", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{*task}, nil).Twice()
+				m.On("GetTasks", mock.Anything, "user789", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{*task}, nil).Twice()
+				m.On("GetTaskByID", mock.Anything, "task1").Return(task, nil)
+				m.On("GetTaskPermission", mock.Anything, "task1", "user123").Return(PermissionWrite, nil)
+				m.On("AddChecklistItem", mock.Anything, mock.AnythingOfType("*models.ChecklistItem")).Return(nil)
+			},
+		},
+		{
+			name:    "workspace task list is not cached",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/tasks?workspace=ws1"},
+				{method: "GET", path: "/tasks?workspace=ws1"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, "ws1", "user123").Return(models.WorkspaceRoleMember, nil).Twice()
+				m.On("GetTasks", mock.Anything, "user123", models.TaskFilter{WorkspaceID: "ws1"}).Return([]models.Task{{ID: "task1", UserID: "user456", WorkspaceID: "ws1"}}, nil).Twice()
+			},
+		},
+		{
+			name:    "user profile is served from cache",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/users/user456", cache: "MISS"},
+				{method: "GET", path: "/users/user456", cache: "HIT"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				u.On("GetUserByID", "user456").Return(&models.User{ID: "user456", Username: "bob"}, nil).Once()
+				u.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: models.RoleUser}, nil).Once()
+			},
+		},
+		{
+			name:    "errors are not cached",
+			enabled: true,
+			steps: []step{
+				{method: "GET", path: "/users/missing", cache: "MISS"},
+				{method: "GET", path: "/users/missing", cache: "MISS"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				u.On("GetUserByID", "missing").Return(nil, errors.ErrUserNotFound).Twice()
+			},
+		},
+		{
+			name: "disabled by default",
+			steps: []step{
+				{method: "GET", path: "/tasks"},
+				{method: "GET", path: "/tasks"},
+			},
+			mockSetup: func(u *MockUserStore, m *MockTaskStore) {
+				m.On("GetTasks", mock.Anything, "user123", mock.AnythingOfType("models.TaskFilter")).Return([]models.Task{}, nil).Twice()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockUserRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockUserRepo, mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockUserRepo, mockTaskRepo}, &Config{})
+			if tt.enabled {
+				api.SetResponseCache(&mapResponseCache{items: map[string][]byte{}}, time.Minute)
+			}
+
+			var first string
+			for i, s := range tt.steps {
+				req, _ := http.NewRequest(s.method, s.path, strings.NewReader(s.body))
+				req.Header.Set("Content-Type", "application/json")
+				user := s.user
+				if user == "" {
+					user = "user123"
+				}
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(user)})
+
+				w := httptest.NewRecorder()
+				api.httpSrv.Handler.ServeHTTP(w, req)
+
+				assert.Equal(t, s.cache, w.Header().Get(ResponseCacheHeader), "step %d", i)
+				if i == 0 {
+					first = w.Body.String()
+				} else if s.cache == "HIT" {
+					assert.Equal(t, first, w.Body.String())
+				}
+			}
+			mockUserRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
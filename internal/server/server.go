@@ -2,96 +2,157 @@ package server
 
 import (
 	"context"
+	stderrors "errors"
+	"io/fs"
 	"net/http"
+	"project/internal/blob"
 	"project/internal/domain/errors"
 	"project/internal/domain/models"
+	"project/internal/graphql"
+	"project/internal/metrics"
+	"project/repository"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var jwtSecret = []byte("shouldbeinVaultsecret")
+const maxTaskPageLimit = 100
 
-func generateJWT(userID string) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour).Unix(),
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
-}
-
-func getUserIDFromJWT(ctx *gin.Context) (string, error) {
-	cookie, err := ctx.Cookie("jwt_token")
-	if err != nil {
-		return "", errors.ErrUnauthorized
-	}
-	token, err := jwt.Parse(cookie, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
-	})
-	if err != nil || !token.Valid {
-		return "", errors.ErrUnauthorized
-	}
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", errors.ErrUnauthorized
-	}
-	userID, ok := claims["user_id"].(string)
-	if !ok || userID == "" {
-		return "", errors.ErrUnauthorized
-	}
-	return userID, nil
-}
-
-type TaskRepository interface {
-	CreateTask(ctx context.Context, task *models.Task) error
-	GetTaskByID(ctx context.Context, id string) (*models.Task, error)
-	GetTasks(ctx context.Context, userID string) ([]models.Task, error)
-	UpdateTask(ctx context.Context, id string, task *models.Task) error
-	DeleteTask(ctx context.Context, id string) error
-}
-
-type Repository interface {
-	GetUserByID(id string) (*models.User, error)
-	GetUserByUsername(username string) (*models.User, error)
-	UpdateUser(id string, user *models.User) error
-	DeleteUser(id string) error
-	CreateUser(user *models.User) error
+type WebhookDispatcher interface {
+	Dispatch(userID, event string, task models.Task)
 }
 
 type TaskAPI struct {
 	httpSrv  *http.Server
-	repo     Repository
-	taskRepo TaskRepository
+	storage  repository.Storage
+	captcha  CaptchaVerifier
+	jwt      jwtOptions
+	webhooks WebhookDispatcher
+
+	idempotency  *idempotencyCache
+	dbRetryAfter time.Duration
+
+	responseCache    ResponseCache
+	responseCacheTTL time.Duration
+
+	blobs         blob.Store
+	avatarMaxSize int64
+
+	introspectionSecret string
+	debugEndpoints      bool
+	features            FeatureFlags
+
+	health     HealthChecker
+	purgeStats PurgeStatsSource
+	startedAt  time.Time
+	readiness  readiness
+	accessLog  *accessLogger
+	cors       *corsPolicy
+
+	tls         *tlsOptions
+	redirectSrv *http.Server
+	socketMode  string
+	keepAlive   time.Duration
+	ui          fs.FS
+	maintenance *maintenanceState
+	compression *compressor
+
+	events  EventHub
+	metrics *metrics.Registry
+	panics  *metrics.CounterVec
+	schema  *graphql.Schema
+
+	slowRequestThreshold time.Duration
+	slowRequests         *metrics.CounterVec
+
+	latency      *metrics.HistogramVec
+	serverErrors *metrics.CounterVec
+	slo          *sloTracker
 }
 
-func NewTaskAPI(repo Repository, taskRepo TaskRepository, cfg *Config) *TaskAPI {
-	if repo == nil || taskRepo == nil {
+func NewTaskAPI(storage repository.Storage, cfg *Config) *TaskAPI {
+	if storage == nil {
 		return nil
 	}
 
 	httpSrv := http.Server{
-		Addr:              cfg.Addr + ":" + strconv.Itoa(cfg.Port),
-		ReadHeaderTimeout: 30 * time.Second,
+		Addr: cfg.Addr + ":" + strconv.Itoa(cfg.Port),
 	}
-
-	api := TaskAPI{
-		httpSrv:  &httpSrv,
-		repo:     repo,
-		taskRepo: taskRepo,
+	if isUnixAddr(cfg.Addr) {
+		httpSrv.Addr = cfg.Addr
 	}
+	configureTransport(&httpSrv, cfg)
+	configureHTTP2(&httpSrv, cfg)
 
+	api := TaskAPI{
+		httpSrv: &httpSrv,
+		storage: storage,
+		captcha: newCaptchaVerifier(cfg),
+		jwt:     newJWTOptions(cfg),
+
+		idempotency:  newIdempotencyCache(cfg.IdempotencyTTL),
+		dbRetryAfter: cfg.DBBreakerCooldown,
+
+		blobs:         newBlobStore(cfg),
+		avatarMaxSize: avatarSizeLimit(cfg),
+
+		introspectionSecret: cfg.IntrospectionSecret,
+		debugEndpoints:      cfg.DebugEndpoints,
+		features:            NewStaticFeatureFlags(cfg.FeatureFlags),
+
+		startedAt: time.Now(),
+		accessLog: newAccessLogger(cfg),
+		cors:      newCORSPolicy(strings.Split(cfg.AllowedOrigins, ",")),
+		tls:       newTLSOptions(cfg),
+		metrics:   metrics.NewRegistry(),
+		panics:    metrics.NewCounterVec("route"),
+
+		socketMode:  cfg.UnixSocketMode,
+		keepAlive:   cfg.HTTPTCPKeepAlive,
+		ui:          uiFiles(cfg),
+		maintenance: newMaintenance(cfg),
+		compression: newCompressor(cfg),
+
+		slowRequestThreshold: cfg.SlowRequestThreshold,
+		slowRequests:         metrics.NewCounterVec("route"),
+
+		latency:      newLatencyHistogram(cfg),
+		serverErrors: metrics.NewCounterVec("route"),
+		slo:          newSLOTracker(cfg),
+	}
+	api.readiness.drainDelay = cfg.ShutdownDrainDelay
+	if api.dbRetryAfter <= 0 {
+		api.dbRetryAfter = defaultDBBreakerCooldown
+	}
+
+	api.registerPurgeMetrics()
+	api.metrics.Counter("http_panics_total", "Количество паник при обработке запросов", api.panics)
+	api.metrics.Counter("http_slow_requests_total", "Количество запросов дольше порога медленных запросов", api.slowRequests)
+	api.registerRequestMetrics()
+	api.schema = api.newGraphQLSchema()
 	api.configRoutes()
 
 	return &api
 }
 
+func (api *TaskAPI) SetCaptchaVerifier(verifier CaptchaVerifier) {
+	api.captcha = verifier
+}
+
+func (api *TaskAPI) SetBlobStore(store blob.Store) {
+	api.blobs = store
+}
+
+func (api *TaskAPI) SetWebhookDispatcher(dispatcher WebhookDispatcher) {
+	api.webhooks = dispatcher
+}
+
 func (api *TaskAPI) Start() error {
 	if api.httpSrv == nil {
 		return errors.ErrInternalServer
@@ -101,83 +162,233 @@ func (api *TaskAPI) Start() error {
 		api.httpSrv.Addr = ":8080"
 	}
 
-	return api.httpSrv.ListenAndServe()
+	listener, err := api.listen()
+	if err != nil {
+		return err
+	}
+	if api.tls != nil && api.tls.enabled() {
+		return api.serveTLS(listener)
+	}
+	return api.httpSrv.Serve(listener)
 }
 
 func (api *TaskAPI) Shutdown(ctx context.Context) error {
 	if api.httpSrv == nil {
 		return nil
 	}
+	api.drain(ctx)
+	api.shutdownRedirectServer(ctx)
 	return api.httpSrv.Shutdown(ctx)
 }
 
 func (api *TaskAPI) configRoutes() {
-	router := gin.Default()
+	binding.Validator = requestValidator
+
+	router := gin.New()
+	router.Use(requestIDMiddleware())
+	router.Use(tracingMiddleware())
+	router.Use(api.slowRequestMiddleware())
+	router.Use(api.requestMetricsMiddleware())
+	router.Use(api.accessLogMiddleware())
+	router.Use(api.compression.middleware())
+	router.Use(negotiateMiddleware())
+	router.Use(api.errorMiddleware())
+	router.Use(api.recoveryMiddleware())
+	router.Use(api.corsMiddleware())
+	router.Use(api.activeUserMiddleware())
+	router.Use(api.maintenanceMiddleware())
+	router.Use(api.idempotencyMiddleware())
+	router.Use(api.responseCacheMiddleware())
 
 	router.NoMethod(func(ctx *gin.Context) {
-		ctx.JSON(http.StatusMethodNotAllowed, gin.H{"error": "использован некорректный HTTP-метод"})
+		respondError(ctx, http.StatusMethodNotAllowed, errors.ErrMethodNotAllowed)
 	})
 
+	router.GET("/health", api.healthCheck)
+	router.GET("/livez", api.liveness)
+	router.GET("/readyz", api.readinessCheck)
+	router.GET("/events", api.featureMiddleware(FeatureRealtime), api.streamEvents)
+	router.GET("/metrics", api.getMetrics)
+	router.POST("/graphql", api.featureMiddleware(FeatureGraphQL), api.graphqlQuery)
+
 	user := router.Group("/users")
 	{
 		user.POST("/login", api.login)
 		user.POST("/register", api.register)
 		user.PUT("/update/:userID", api.updateUser)
 		user.DELETE("/delete/:userID", api.deleteUser)
+		user.GET("/search", api.searchUsers)
 		user.GET("/:userID", api.getUser)
+		user.GET("/me/preferences", api.getPreferences)
+		user.PUT("/me/preferences", api.updatePreferences)
+		user.POST("/me/avatar", api.uploadAvatar)
+		user.DELETE("/me/avatar", api.deleteAvatar)
+		user.GET("/me/logins", api.getLoginHistory)
+		user.GET("/me/activity", api.getActivity)
+		user.GET("/:userID/avatar", api.getAvatar)
+		user.POST("/:userID/suspend", api.suspendUser)
+		user.POST("/:userID/reactivate", api.reactivateUser)
+	}
+
+	if api.introspectionSecret != "" {
+		auth := router.Group("/auth")
+		{
+			auth.POST("/introspect", api.introspect)
+		}
+	}
+
+	admin := router.Group("/admin", api.adminMiddleware())
+	{
+		admin.GET("/maintenance", api.getMaintenance)
+		admin.PUT("/maintenance", api.updateMaintenance)
+		admin.GET("/logging", api.getLogging)
+		admin.PUT("/logging", api.updateLogging)
+	}
+
+	if api.debugEndpoints {
+		api.configDebugRoutes(router)
 	}
 
 	tasks := router.Group("/tasks")
 	{
 		tasks.GET("", api.getTasks)
+		tasks.GET("/search", api.searchTasks)
+		tasks.POST("/bulk", api.bulkTasks)
+		tasks.POST("/batch", api.createTasksBatch)
+		tasks.POST("/reorder", api.reorderTasks)
+		tasks.POST("/from-template/:templateID", api.createTaskFromTemplate)
+		tasks.GET("/trash", api.getTrash)
+		tasks.GET("/overdue", api.getOverdueTasks)
+		tasks.GET("/due", api.getDueTasks)
+		tasks.GET("/export", api.exportTasks)
 		tasks.GET("/:taskID", api.getTaskByID)
 		tasks.POST("", api.createTask)
 		tasks.PUT("/:taskID", api.updateTask)
+		tasks.PATCH("/:taskID", api.patchTask)
 		tasks.DELETE("/:taskID", api.deleteTask)
+		tasks.GET("/:taskID/subtasks", api.getSubtasks)
+		tasks.GET("/:taskID/history", api.getTaskHistory)
+		tasks.POST("/:taskID/tags/:tagID", api.attachTag)
+		tasks.DELETE("/:taskID/tags/:tagID", api.detachTag)
+		tasks.POST("/:taskID/share", api.shareTask)
+		tasks.POST("/:taskID/restore", api.restoreTask)
+		tasks.POST("/:taskID/archive", api.archiveTask)
+		tasks.POST("/:taskID/unarchive", api.unarchiveTask)
+		tasks.POST("/:taskID/assign", api.assignTask)
+		tasks.DELETE("/:taskID/assign", api.unassignTask)
+		tasks.GET("/:taskID/checklist", api.getChecklist)
+		tasks.POST("/:taskID/checklist", api.addChecklistItem)
+		tasks.POST("/:taskID/checklist/reorder", api.reorderChecklist)
+		tasks.POST("/:taskID/checklist/:itemID/toggle", api.toggleChecklistItem)
+		tasks.DELETE("/:taskID/checklist/:itemID", api.deleteChecklistItem)
+	}
+
+	tags := router.Group("/tags")
+	{
+		tags.GET("", api.getTags)
+		tags.POST("", api.createTag)
+		tags.PUT("/:tagID", api.updateTag)
+		tags.DELETE("/:tagID", api.deleteTag)
+	}
+
+	workspaces := router.Group("/workspaces", uuidParams("workspaceID"))
+	{
+		workspaces.GET("", api.getWorkspaces)
+		workspaces.POST("", api.createWorkspace)
+		workspaces.GET("/:workspaceID", api.getWorkspace)
+		workspaces.PUT("/:workspaceID", api.updateWorkspace)
+		workspaces.DELETE("/:workspaceID", api.deleteWorkspace)
+		workspaces.GET("/:workspaceID/members", api.getWorkspaceMembers)
+		workspaces.PUT("/:workspaceID/members/:userID", api.setWorkspaceMember)
+		workspaces.DELETE("/:workspaceID/members/:userID", api.removeWorkspaceMember)
+		workspaces.GET("/:workspaceID/tasks", api.getWorkspaceTasks)
+	}
+
+	projects := router.Group("/projects")
+	{
+		projects.GET("", api.getProjects)
+		projects.POST("", api.createProject)
+		projects.GET("/:projectID", api.getProjectByID)
+		projects.PUT("/:projectID", api.updateProject)
+		projects.DELETE("/:projectID", api.deleteProject)
+		projects.GET("/:projectID/tasks", api.getProjectTasks)
+	}
+
+	templates := router.Group("/templates")
+	{
+		templates.GET("", api.getTemplates)
+		templates.POST("", api.createTemplate)
+		templates.GET("/:templateID", api.getTemplateByID)
+		templates.PUT("/:templateID", api.updateTemplate)
+		templates.DELETE("/:templateID", api.deleteTemplate)
+	}
+
+	workflow := router.Group("/workflow")
+	{
+		workflow.GET("", api.getWorkflow)
+		workflow.PUT("", api.updateWorkflow)
+		workflow.DELETE("", api.resetWorkflow)
+	}
+
+	webhooks := router.Group("/webhooks", api.featureMiddleware(FeatureWebhooks))
+	{
+		webhooks.GET("", api.getWebhooks)
+		webhooks.POST("", api.createWebhook)
+		webhooks.DELETE("/:webhookID", api.deleteWebhook)
+		webhooks.GET("/:webhookID/deliveries", api.getWebhookDeliveries)
 	}
 
 	api.httpSrv.Handler = router
+	if api.ui != nil {
+		api.httpSrv.Handler = withUI(router, newSPAHandler(api.ui))
+	}
 }
 
 func (api *TaskAPI) login(ctx *gin.Context) {
 	var req models.LoginRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRequest.Error()})
+	if !bindAndValidate(ctx, &req) {
 		return
 	}
 
-	valid := validator.New()
-	if err := valid.Struct(req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrValidationFailed.Error()})
+	user, err := api.storage.GetUserByUsername(ctx.Request.Context(), req.Username)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrInvalidUserCredentials)
 		return
 	}
 
-	user, err := api.repo.GetUserByUsername(req.Username)
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrInvalidUserCredentials.Error()})
+		api.recordLogin(ctx, user.ID, false)
+		respondError(ctx, http.StatusUnauthorized, errors.ErrInvalidUserCredentials)
 		return
 	}
-
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	active, err := api.storage.IsUserActive(ctx.Request.Context(), user.ID)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrInvalidUserCredentials.Error()})
+		respondInternalError(ctx, err)
+		return
+	}
+	if !active {
+		api.recordLogin(ctx, user.ID, false)
+		respondError(ctx, http.StatusForbidden, errors.ErrUserSuspended)
 		return
 	}
 
-	token, err := generateJWT(user.ID)
+	token, err := api.generateJWT(user.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrTokenGeneration.Error()})
+		respondError(ctx, http.StatusInternalServerError, errors.ErrTokenGeneration)
 		return
 	}
 	http.SetCookie(ctx.Writer, &http.Cookie{
 		Name:     "jwt_token",
 		Value:    token,
 		Path:     "/",
-		MaxAge:   3600,
+		MaxAge:   int(api.jwt.ttl.Seconds()),
 		HttpOnly: true,
 		Secure:   false,
 		SameSite: http.SameSiteStrictMode,
 	})
+	api.recordLogin(ctx, user.ID, true)
+	api.invalidateResponses(ctx.Request.Context(), user.ID)
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "вход выполнен успешно",
@@ -192,46 +403,53 @@ func (api *TaskAPI) login(ctx *gin.Context) {
 
 func (api *TaskAPI) register(ctx *gin.Context) {
 	var req models.RegisterRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRequest.Error()})
-		return
-	}
-	if req.Role != "" && !allowedUserRoles[req.Role] {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRole.Error()})
+	if !bindAndValidate(ctx, &req) {
 		return
 	}
-	valid := validator.New()
 
-	if err := valid.Struct(req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRequest.Error()})
-		return
+	if api.captcha != nil {
+		if err := api.captcha.Verify(ctx.Request.Context(), req.CaptchaToken, ctx.ClientIP()); err != nil {
+			switch err {
+			case errors.ErrCaptchaRequired, errors.ErrCaptchaFailed:
+				respondError(ctx, http.StatusBadRequest, err)
+			default:
+				respondError(ctx, http.StatusServiceUnavailable, errors.ErrCaptchaUnavailable)
+			}
+			return
+		}
 	}
 
-	existingUser, _ := api.repo.GetUserByUsername(req.Username)
+	existingUser, _ := api.storage.GetUserByUsername(ctx.Request.Context(), req.Username)
 	if existingUser != nil {
-		ctx.JSON(http.StatusConflict, gin.H{"error": errors.ErrUserExists.Error()})
+		respondError(ctx, http.StatusConflict, errors.ErrUserExists)
 		return
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+		respondInternalError(ctx, err)
 		return
 	}
-	role := req.Role
-	if role == "" {
-		role = "user"
-	}
 	user := models.User{
 		ID:       uuid.New().String(),
 		Username: req.Username,
 		Email:    req.Email,
 		Password: string(hash),
-		Role:     role,
+		Role:     models.RoleUser,
 	}
 
-	if err := api.repo.CreateUser(&user); err != nil {
-		ctx.JSON(http.StatusConflict, gin.H{"error": errors.ErrUserAlreadyExists.Error()})
+	err = api.storage.WithTx(ctx.Request.Context(), func(tx repository.Storage) error {
+		if err := tx.CreateUser(ctx.Request.Context(), &user); err != nil {
+			return err
+		}
+		return createDefaultProject(ctx.Request.Context(), tx, user.ID)
+	})
+	if err != nil {
+		if err == errors.ErrUserAlreadyExists {
+			respondError(ctx, http.StatusConflict, errors.ErrUserAlreadyExists)
+			return
+		}
+		respondInternalError(ctx, err)
 		return
 	}
 
@@ -249,44 +467,63 @@ func (api *TaskAPI) register(ctx *gin.Context) {
 func (api *TaskAPI) getUser(ctx *gin.Context) {
 	userID := ctx.Param("userID")
 
-	user, err := api.repo.GetUserByID(userID)
+	user, err := api.storage.GetUserByID(ctx.Request.Context(), userID)
 	if err != nil {
 		if err == errors.ErrUserNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrUserNotFound.Error()})
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+		respondInternalError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"user": gin.H{
-			"id":       user.ID,
-			"username": user.Username,
-			"email":    user.Email,
-			"role":     user.Role,
-		},
-	})
+	response := gin.H{
+		"id":       user.ID,
+		"username": user.Username,
+		"email":    user.Email,
+		"role":     user.Role,
+	}
+	if api.canViewAccountStatus(ctx, user.ID) {
+		response["active"] = user.Active
+		response["last_login_at"] = user.LastLoginAt
+	}
+	ctx.JSON(http.StatusOK, gin.H{"user": response})
+}
+
+func (api *TaskAPI) canViewAccountStatus(ctx *gin.Context, userID string) bool {
+	requesterID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		return false
+	}
+	if requesterID == userID {
+		return true
+	}
+	requester, err := api.storage.GetUserByID(ctx.Request.Context(), requesterID)
+	return err == nil && requester.Role == models.RoleAdmin
 }
 
 func (api *TaskAPI) updateUser(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+	userID, err := api.getUserIDFromJWT(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
 		return
 	}
 	userIDParam := ctx.Param("userID")
 	if userID != userIDParam {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": errors.ErrUserUpdateForbidden.Error()})
+		respondError(ctx, http.StatusForbidden, errors.ErrUserUpdateForbidden)
 		return
 	}
 	var req models.UpdateUserRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRequest.Error()})
+	if !bindAndValidate(ctx, &req) {
 		return
 	}
-	if req.Role != "" && !allowedUserRoles[req.Role] {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRole.Error()})
+	current, err := api.storage.GetUserByID(ctx.Request.Context(), userID)
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
 		return
 	}
 
@@ -294,15 +531,19 @@ func (api *TaskAPI) updateUser(ctx *gin.Context) {
 		Username: req.Username,
 		Email:    req.Email,
 		Password: req.Password,
-		Role:     req.Role,
+		Role:     current.Role,
 	}
 
-	if err := api.repo.UpdateUser(userID, user); err != nil {
+	if err := api.storage.UpdateUser(ctx.Request.Context(), userID, user); err != nil {
 		if err == errors.ErrUserNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrUserNotFound.Error()})
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+		if err == errors.ErrUserAlreadyExists {
+			respondError(ctx, http.StatusConflict, errors.ErrUserAlreadyExists)
+			return
+		}
+		respondInternalError(ctx, err)
 		return
 	}
 
@@ -310,22 +551,22 @@ func (api *TaskAPI) updateUser(ctx *gin.Context) {
 }
 
 func (api *TaskAPI) deleteUser(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+	userID, err := api.getUserIDFromJWT(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
 		return
 	}
 	userIDParam := ctx.Param("userID")
 	if userID != userIDParam {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": errors.ErrUserDeleteForbidden.Error()})
+		respondError(ctx, http.StatusForbidden, errors.ErrUserDeleteForbidden)
 		return
 	}
-	if err := api.repo.DeleteUser(userID); err != nil {
+	if err := api.storage.DeleteUser(ctx.Request.Context(), userID); err != nil {
 		if err == errors.ErrUserNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrUserNotFound.Error()})
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+		respondInternalError(ctx, err)
 		return
 	}
 
@@ -333,124 +574,217 @@ func (api *TaskAPI) deleteUser(ctx *gin.Context) {
 }
 
 func (api *TaskAPI) getTasks(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	filter, err := api.parseTaskFilter(ctx, userID)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondWorkspaceError(ctx, err)
+		return
+	}
+	if wantsNDJSON(ctx) {
+		api.streamTasks(ctx, userID, filter)
 		return
 	}
-	tasks, err := api.taskRepo.GetTasks(ctx.Request.Context(), userID)
+	tasks, err := api.storage.GetTasks(ctx.Request.Context(), userID, filter)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+		respondInternalError(ctx, err)
 		return
 	}
 	if len(tasks) == 0 {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrTasksNotFound.Error()})
+		respondError(ctx, http.StatusNotFound, errors.ErrTasksNotFound)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks})
+	if notModified(ctx, taskETag(tasks...)) {
+		return
+	}
+	response := gin.H{"tasks": tasks}
+	if filter.Limit > 0 && len(tasks) == filter.Limit {
+		response["next_offset"] = filter.Offset + len(tasks)
+	}
+	ctx.JSON(http.StatusOK, response)
 }
 
-func (api *TaskAPI) getTaskByID(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+func (api *TaskAPI) searchTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
 		return
 	}
-	id := ctx.Param("taskID")
-	task, err := api.taskRepo.GetTaskByID(ctx.Request.Context(), id)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrTaskNotFound.Error()})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
-		}
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
+		respondError(ctx, http.StatusBadRequest, errors.ErrEmptySearchQuery)
 		return
 	}
-	if task.UserID != userID {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
+	tasks, err := api.storage.SearchTasks(ctx.Request.Context(), userID, query)
+	if err != nil {
+		respondInternalError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"task": task})
+	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks})
 }
 
-var allowedTaskStatuses = map[string]bool{
-	"new":         true,
-	"in_progress": true,
-	"done":        true,
+func (api *TaskAPI) parseTaskFilter(ctx *gin.Context, userID string) (models.TaskFilter, error) {
+	filter := models.TaskFilter{
+		Status:        ctx.Query("status"),
+		TitleContains: ctx.Query("title_contains"),
+		Tag:           ctx.Query("tag"),
+		ProjectID:     ctx.Query("project"),
+		WorkspaceID:   ctx.Query("workspace"),
+		View:          ctx.Query("view"),
+	}
+	if raw := ctx.Query("deleted"); raw != "" {
+		deleted, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.ErrInvalidRequest
+		}
+		filter.Deleted = &deleted
+	}
+	if raw := ctx.Query("archived"); raw != "" {
+		archived, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.ErrInvalidRequest
+		}
+		filter.Archived = &archived
+	}
+	if raw := ctx.Query("sort"); raw != "" {
+		filter.Sort = strings.TrimPrefix(raw, "-")
+		filter.Descending = strings.HasPrefix(raw, "-")
+	}
+	if raw := ctx.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return filter, errors.ErrTaskPage
+		}
+		filter.Limit = limit
+	}
+	if raw := ctx.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil {
+			return filter, errors.ErrTaskPage
+		}
+		filter.Offset = offset
+	}
+	return filter, api.checkTaskFilter(ctx.Request.Context(), userID, filter)
 }
 
-var allowedUserRoles = map[string]bool{
-	"user":      true,
-	"admin":     true,
-	"moderator": true,
+func (api *TaskAPI) checkTaskFilter(ctx context.Context, userID string, filter models.TaskFilter) error {
+	if filter.Status != "" {
+		workflow, err := api.loadWorkflow(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !workflow.HasStatus(filter.Status) {
+			return errors.ErrTaskStatus
+		}
+	}
+	if filter.View != "" && filter.View != models.TaskViewCreated && filter.View != models.TaskViewAssigned {
+		return errors.ErrTaskView
+	}
+	if filter.Sort != "" && !models.IsTaskSort(filter.Sort) {
+		return errors.ErrTaskSort
+	}
+	if filter.Limit < 0 || filter.Limit > maxTaskPageLimit || filter.Offset < 0 {
+		return errors.ErrTaskPage
+	}
+	if filter.WorkspaceID != "" {
+		if _, err := api.checkWorkspace(ctx, userID, filter.WorkspaceID, models.WorkspaceRoleViewer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (api *TaskAPI) getTaskByID(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	id := ctx.Param("taskID")
+	task, ok := api.loadAccessibleTask(ctx, userID, id, false)
+	if !ok {
+		return
+	}
+	if notModified(ctx, taskETag(*task)) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"task": task})
 }
 
 func (api *TaskAPI) createTask(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+	userID, err := api.getUserIDFromJWT(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
 		return
 	}
 	var req models.CreateTaskRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrBadRequest.Error()})
+	if !bindAndValidate(ctx, &req) {
 		return
 	}
-	valid := validator.New()
-	if err := valid.Struct(req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRequest.Error()})
+	status, err := api.initialStatus(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
 		return
 	}
 	task := models.Task{
 		Title:       req.Title,
 		Description: req.Description,
-		Status:      "new",
+		Status:      status,
 		UserID:      userID,
+		ParentID:    req.ParentID,
+		ProjectID:   req.ProjectID,
+		WorkspaceID: req.WorkspaceID,
+
+		DueDate:               req.DueDate,
+		ReminderOffsetMinutes: req.ReminderOffsetMinutes,
+	}
+	if !api.validateParent(ctx, userID, &task) {
+		return
+	}
+	if !api.validateProject(ctx, userID, task.ProjectID) {
+		return
+	}
+	if !api.validateWorkspace(ctx, userID, task.WorkspaceID) {
+		return
 	}
-	if err := api.taskRepo.CreateTask(ctx.Request.Context(), &task); err != nil {
+	if err := api.storage.CreateTask(ctx.Request.Context(), &task); err != nil {
 		if err == errors.ErrConflict {
-			ctx.JSON(http.StatusConflict, gin.H{"error": errors.ErrConflict.Error()})
+			respondError(ctx, http.StatusConflict, errors.ErrConflict)
 		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+			respondInternalError(ctx, err)
 		}
 		return
 	}
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventCreate, nil, &task)
+	ctx.Header("ETag", taskETag(task))
 	ctx.JSON(http.StatusCreated, gin.H{"task": task})
 }
 
 func (api *TaskAPI) updateTask(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+	userID, err := api.getUserIDFromJWT(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
 		return
 	}
 	id := ctx.Param("taskID")
 	var req models.UpdateTaskRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrBadRequest.Error()})
+	if !bindAndValidate(ctx, &req) {
 		return
 	}
-	valid := validator.New()
-	if err := valid.Struct(req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidRequest.Error()})
+	statusOnly := req.Title == "" && req.Description == "" && req.DueDate == nil && req.ReminderOffsetMinutes == nil && req.ProjectID == nil
+	task, ok := api.loadUpdatableTask(ctx, userID, id, statusOnly)
+	if !ok || !checkIfMatch(ctx, task) {
 		return
 	}
-	task, err := api.taskRepo.GetTaskByID(ctx.Request.Context(), id)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrTaskNotFound.Error()})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+	before := *task
+	if req.Status != "" && req.Status != task.Status {
+		if err := api.checkStatusChange(ctx.Request.Context(), task, req.Status); err != nil {
+			respondStatusError(ctx, err)
+			return
 		}
-		return
-	}
-	if task.UserID != userID {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
-		return
-	}
-	if req.Status != "" && !allowedTaskStatuses[req.Status] {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrTaskStatus.Error()})
-		return
 	}
 	if req.Title != "" {
 		task.Title = req.Title
@@ -461,44 +795,78 @@ func (api *TaskAPI) updateTask(ctx *gin.Context) {
 	if req.Status != "" {
 		task.Status = req.Status
 	}
-	if err := api.taskRepo.UpdateTask(ctx.Request.Context(), id, task); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+	if req.DueDate != nil {
+		task.DueDate = req.DueDate
+	}
+	if req.ReminderOffsetMinutes != nil {
+		task.ReminderOffsetMinutes = *req.ReminderOffsetMinutes
+	}
+	if req.ProjectID != nil {
+		if !api.validateProject(ctx, task.UserID, *req.ProjectID) {
+			return
+		}
+		task.ProjectID = *req.ProjectID
+	}
+	if err := api.storage.UpdateTask(ctx.Request.Context(), id, task); err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
 		return
 	}
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, &before, task)
+	if req.Status != "" && task.ParentID != "" {
+		api.rollUpStatus(ctx.Request.Context(), task.ParentID)
+	}
+	ctx.Header("ETag", taskETag(*task))
 	ctx.JSON(http.StatusOK, gin.H{"task": task})
 }
 
 func (api *TaskAPI) deleteTask(ctx *gin.Context) {
-	userID, err := getUserIDFromJWT(ctx)
+	userID, err := api.getUserIDFromJWT(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrNotAuthorized.Error()})
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
 		return
 	}
 	id := ctx.Param("taskID")
-	task, err := api.taskRepo.GetTaskByID(ctx.Request.Context(), id)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrTaskNotFound.Error()})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+	if raw := ctx.Query("force"); raw != "" {
+		force, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, errors.ErrInvalidRequest)
+			return
+		}
+		if force {
+			api.purgeTask(ctx, userID, id)
+			return
 		}
-		return
 	}
-	if task.UserID != userID {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
+	task, ok := api.loadAccessibleTask(ctx, userID, id, true)
+	if !ok || !checkIfMatch(ctx, task) {
 		return
 	}
-	if err := api.taskRepo.DeleteTask(ctx.Request.Context(), id); err != nil {
+	if err := api.storage.DeleteTask(ctx.Request.Context(), id); err != nil {
 		if err == errors.ErrNotFound {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errors.ErrTaskNotFound.Error()})
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
 		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInternalServer.Error()})
+			respondInternalError(ctx, err)
 		}
 		return
 	}
-	type hardDeleteEnqueuer interface{ EnqueueHardDelete(string) }
-	if enq, ok := any(api.taskRepo).(hardDeleteEnqueuer); ok {
-		enq.EnqueueHardDelete(id)
-	}
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventDelete, task, nil)
 	ctx.JSON(http.StatusOK, gin.H{"message": "задача успешно удалена"})
 }
+
+func internalError(err error) error {
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return errors.ErrQueryTimeout
+	}
+	if stderrors.Is(err, errors.ErrDatabaseUnavailable) {
+		return errors.ErrDatabaseUnavailable
+	}
+	return errors.ErrInternalServer
+}
+
+func respondInternalError(ctx *gin.Context, err error) {
+	respondStatusError(ctx, internalError(err))
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"project/internal/domain/errors"
 	"project/internal/domain/models"
+	"project/repository"
 	"strings"
 	"testing"
 	"time"
@@ -19,11 +21,15 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-type MockRepository struct {
+type MockUserStore struct {
 	mock.Mock
+	preferences map[string]models.UserPreferences
+	suspended   map[string]bool
+	activeErr   error
+	logins      []models.LoginRecord
 }
 
-func (m *MockRepository) GetUserByID(id string) (*models.User, error) {
+func (m *MockUserStore) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -31,7 +37,7 @@ func (m *MockRepository) GetUserByID(id string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockRepository) GetUserByUsername(username string) (*models.User, error) {
+func (m *MockUserStore) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	args := m.Called(username)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -39,31 +45,109 @@ func (m *MockRepository) GetUserByUsername(username string) (*models.User, error
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockRepository) UpdateUser(id string, user *models.User) error {
+func (m *MockUserStore) UpdateUser(ctx context.Context, id string, user *models.User) error {
 	args := m.Called(id, user)
 	return args.Error(0)
 }
 
-func (m *MockRepository) DeleteUser(id string) error {
+func (m *MockUserStore) DeleteUser(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockRepository) CreateUser(user *models.User) error {
+func (m *MockUserStore) CreateUser(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-type MockTaskRepository struct {
+func (m *MockUserStore) SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error) {
+	args := m.Called(query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserStore) GetUserPreferences(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	prefs, exists := m.preferences[userID]
+	if !exists {
+		return nil, errors.ErrUserNotFound
+	}
+	return &prefs, nil
+}
+
+func (m *MockUserStore) IsUserActive(ctx context.Context, id string) (bool, error) {
+	if m.activeErr != nil {
+		return false, m.activeErr
+	}
+	return !m.suspended[id], nil
+}
+
+func (m *MockUserStore) RecordLogin(ctx context.Context, record *models.LoginRecord) error {
+	m.logins = append(m.logins, *record)
+	return nil
+}
+
+func (m *MockUserStore) GetLoginHistory(ctx context.Context, userID string, before time.Time, limit int) ([]models.LoginRecord, error) {
+	args := m.Called(userID, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LoginRecord), args.Error(1)
+}
+
+func (m *MockUserStore) SetUserActive(ctx context.Context, id string, active bool) error {
+	args := m.Called(id, active)
+	return args.Error(0)
+}
+
+func (m *MockUserStore) SaveUserPreferences(ctx context.Context, userID string, prefs *models.UserPreferences) error {
+	args := m.Called(userID, prefs)
+	return args.Error(0)
+}
+
+type MockStorage struct {
+	*MockUserStore
+	*MockTaskStore
+}
+
+func (m *MockStorage) WithTx(ctx context.Context, fn func(tx repository.Storage) error) error {
+	return fn(m)
+}
+
+type MockTaskStore struct {
 	mock.Mock
+	events    []models.TaskEvent
+	workflows map[string]models.Workflow
 }
 
-func (m *MockTaskRepository) CreateTask(ctx context.Context, task *models.Task) error {
+func (m *MockTaskStore) CreateTask(ctx context.Context, task *models.Task) error {
 	args := m.Called(ctx, task)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
+func (m *MockTaskStore) GetOverdueTasks(ctx context.Context, userID string, now time.Time) ([]models.Task, error) {
+	args := m.Called(ctx, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskStore) GetDueTasks(ctx context.Context, userID string, from, to time.Time) ([]models.Task, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskStore) CreateTasks(ctx context.Context, tasks []models.Task) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -71,23 +155,371 @@ func (m *MockTaskRepository) GetTaskByID(ctx context.Context, id string) (*model
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetTasks(ctx context.Context, userID string) ([]models.Task, error) {
-	args := m.Called(ctx, userID)
+func (m *MockTaskStore) GetTasks(ctx context.Context, userID string, filter models.TaskFilter) ([]models.Task, error) {
+	args := m.Called(ctx, userID, filter)
 	return args.Get(0).([]models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) UpdateTask(ctx context.Context, id string, task *models.Task) error {
+func (m *MockTaskStore) UpdateTask(ctx context.Context, id string, task *models.Task) error {
 	args := m.Called(ctx, id, task)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) DeleteTask(ctx context.Context, id string) error {
+func (m *MockTaskStore) DeleteTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) SearchTasks(ctx context.Context, userID, query string) ([]models.Task, error) {
+	args := m.Called(ctx, userID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskStore) GetSubtasks(ctx context.Context, parentID string) ([]models.Task, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetTags(ctx context.Context, userID string) ([]models.Tag, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTaskStore) GetTagByID(ctx context.Context, id string) (*models.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTaskStore) UpdateTag(ctx context.Context, id string, tag *models.Tag) error {
+	args := m.Called(ctx, id, tag)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DeleteTag(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) AttachTag(ctx context.Context, taskID, tagID string) error {
+	args := m.Called(ctx, taskID, tagID)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DetachTag(ctx context.Context, taskID, tagID string) error {
+	args := m.Called(ctx, taskID, tagID)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) ShareTask(ctx context.Context, share *models.TaskShare) error {
+	args := m.Called(ctx, share)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetTaskPermission(ctx context.Context, taskID, userID string) (string, error) {
+	args := m.Called(ctx, taskID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskStore) CreateProject(ctx context.Context, project *models.Project) error {
+	args := m.Called(ctx, project)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetProjects(ctx context.Context, userID string) ([]models.Project, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Project), args.Error(1)
+}
+
+func (m *MockTaskStore) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockTaskStore) UpdateProject(ctx context.Context, id string, project *models.Project) error {
+	args := m.Called(ctx, id, project)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DeleteProject(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) ApplyBulk(ctx context.Context, ops []models.BulkOperation) ([]models.BulkResult, error) {
+	args := m.Called(ctx, ops)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BulkResult), args.Error(1)
+}
+
+func (m *MockTaskStore) GetTrash(ctx context.Context, userID string) ([]models.Task, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskStore) RestoreTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) HardDeleteTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) SetTaskArchived(ctx context.Context, id string, archived bool) error {
+	args := m.Called(ctx, id, archived)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) AssignTask(ctx context.Context, id, assigneeID string) error {
+	args := m.Called(ctx, id, assigneeID)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) ExportTasks(ctx context.Context, userID string, fn func(models.Task) error) error {
+	args := m.Called(ctx, userID)
+	if tasks, ok := args.Get(0).([]models.Task); ok {
+		for _, task := range tasks {
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockTaskStore) StreamTasks(ctx context.Context, userID string, filter models.TaskFilter, fn func(models.Task) error) error {
+	args := m.Called(ctx, userID, filter)
+	if tasks, ok := args.Get(0).([]models.Task); ok {
+		for _, task := range tasks {
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockTaskStore) ReorderTasks(ctx context.Context, userID string, taskIDs []string) error {
+	args := m.Called(ctx, userID, taskIDs)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) CreateTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetTemplates(ctx context.Context, userID string) ([]models.TaskTemplate, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskStore) GetTemplateByID(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskStore) UpdateTemplate(ctx context.Context, id string, template *models.TaskTemplate) error {
+	args := m.Called(ctx, id, template)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DeleteTemplate(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) EnqueueHardDelete(taskID string) {
-	m.Called(taskID)
+func (m *MockTaskStore) AddTaskEvent(ctx context.Context, event *models.TaskEvent) error {
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *MockTaskStore) GetTaskEvents(ctx context.Context, taskID string) ([]models.TaskEvent, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskEvent), args.Error(1)
+}
+
+func (m *MockTaskStore) GetUserTaskEvents(ctx context.Context, userID string, before time.Time, limit int) ([]models.TaskEvent, error) {
+	args := m.Called(ctx, userID, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskEvent), args.Error(1)
+}
+
+func (m *MockTaskStore) CreateWebhook(ctx context.Context, hook *models.Webhook) error {
+	args := m.Called(ctx, hook)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Webhook), args.Error(1)
+}
+
+func (m *MockTaskStore) GetWebhookByID(ctx context.Context, id string) (*models.Webhook, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockTaskStore) DeleteWebhook(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]models.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockTaskStore) GetChecklist(ctx context.Context, taskID string) ([]models.ChecklistItem, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskStore) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) ToggleChecklistItem(ctx context.Context, taskID, itemID string) (*models.ChecklistItem, error) {
+	args := m.Called(ctx, taskID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskStore) ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) error {
+	args := m.Called(ctx, taskID, itemIDs)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DeleteChecklistItem(ctx context.Context, taskID, itemID string) error {
+	args := m.Called(ctx, taskID, itemID)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetWorkflow(ctx context.Context, userID string) (*models.Workflow, error) {
+	workflow, exists := m.workflows[userID]
+	if !exists {
+		return nil, errors.ErrWorkflowNotFound
+	}
+	return &workflow, nil
+}
+
+func (m *MockTaskStore) SaveWorkflow(ctx context.Context, workflow *models.Workflow) error {
+	args := m.Called(ctx, workflow)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DeleteWorkflow(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) CreateWorkspace(ctx context.Context, workspace *models.Workspace) error {
+	args := m.Called(ctx, workspace)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetWorkspaces(ctx context.Context, userID string) ([]models.Workspace, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Workspace), args.Error(1)
+}
+
+func (m *MockTaskStore) GetWorkspaceByID(ctx context.Context, id string) (*models.Workspace, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workspace), args.Error(1)
+}
+
+func (m *MockTaskStore) UpdateWorkspace(ctx context.Context, id string, workspace *models.Workspace) error {
+	args := m.Called(ctx, id, workspace)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) DeleteWorkspace(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WorkspaceMember), args.Error(1)
+}
+
+func (m *MockTaskStore) GetWorkspaceRole(ctx context.Context, workspaceID, userID string) (string, error) {
+	args := m.Called(ctx, workspaceID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskStore) SaveWorkspaceMember(ctx context.Context, member *models.WorkspaceMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockTaskStore) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	args := m.Called(ctx, workspaceID, userID)
+	return args.Error(0)
 }
 
 func TestRegister(t *testing.T) {
@@ -98,52 +530,127 @@ func TestRegister(t *testing.T) {
 			statusCode int
 			success    bool
 		}
-		mockSetup func(*MockRepository)
+		mockSetup func(*MockUserStore)
 	}{
 		{
-			name: "successful registration",
+			name: "successful registration",
+			request: models.RegisterRequest{
+				Username: "testuser",
+				Email:    "test@example.com",
+				Password: "password123",
+			},
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: 201,
+				success:    true,
+			},
+			mockSetup: func(mockRepo *MockUserStore) {
+				mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
+				mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+			},
+		},
+		{
+			name: "user already exists",
+			request: models.RegisterRequest{
+				Username: "existinguser",
+				Email:    "existing@example.com",
+				Password: "password123",
+			},
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: 409,
+				success:    false,
+			},
+			mockSetup: func(mockRepo *MockUserStore) {
+				existingUser := &models.User{
+					ID:       "user1",
+					Username: "existinguser",
+					Email:    "existing@example.com",
+					Password: "password123",
+					Role:     "user",
+				}
+				mockRepo.On("GetUserByUsername", "existinguser").Return(existingUser, nil)
+			},
+		},
+		{
+			name: "username and email are normalized",
+			request: models.RegisterRequest{
+				Username: "TestUser",
+				Email:    "Test@Example.com",
+				Password: "password123",
+			},
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: 201,
+				success:    true,
+			},
+			mockSetup: func(mockRepo *MockUserStore) {
+				mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
+				mockRepo.On("CreateUser", mock.MatchedBy(func(user *models.User) bool {
+					return user.Username == "testuser" && user.Email == "test@example.com"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "existing user with different case",
+			request: models.RegisterRequest{
+				Username: "ExistingUser",
+				Email:    "other@example.com",
+				Password: "password123",
+			},
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: 409,
+				success:    false,
+			},
+			mockSetup: func(mockRepo *MockUserStore) {
+				mockRepo.On("GetUserByUsername", "existinguser").Return(&models.User{ID: "user1", Username: "existinguser"}, nil)
+			},
+		},
+		{
+			name: "create conflicts with concurrent registration",
 			request: models.RegisterRequest{
 				Username: "testuser",
 				Email:    "test@example.com",
 				Password: "password123",
-				Role:     "user",
 			},
 			want: struct {
 				statusCode int
 				success    bool
 			}{
-				statusCode: 201,
-				success:    true,
+				statusCode: 409,
+				success:    false,
 			},
-			mockSetup: func(mockRepo *MockRepository) {
+			mockSetup: func(mockRepo *MockUserStore) {
 				mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
-				mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+				mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(errors.ErrUserAlreadyExists)
 			},
 		},
 		{
-			name: "user already exists",
+			name: "create times out",
 			request: models.RegisterRequest{
-				Username: "existinguser",
-				Email:    "existing@example.com",
+				Username: "testuser",
+				Email:    "test@example.com",
 				Password: "password123",
-				Role:     "user",
 			},
 			want: struct {
 				statusCode int
 				success    bool
 			}{
-				statusCode: 409,
+				statusCode: 504,
 				success:    false,
 			},
-			mockSetup: func(mockRepo *MockRepository) {
-				existingUser := &models.User{
-					ID:       "user1",
-					Username: "existinguser",
-					Email:    "existing@example.com",
-					Password: "password123",
-					Role:     "user",
-				}
-				mockRepo.On("GetUserByUsername", "existinguser").Return(existingUser, nil)
+			mockSetup: func(mockRepo *MockUserStore) {
+				mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
+				mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(fmt.Errorf("create user: %w", context.DeadlineExceeded))
 			},
 		},
 		{
@@ -152,7 +659,6 @@ func TestRegister(t *testing.T) {
 				Username: "",
 				Email:    "invalid-email",
 				Password: "123",
-				Role:     "invalid",
 			},
 			want: struct {
 				statusCode int
@@ -161,7 +667,7 @@ func TestRegister(t *testing.T) {
 				statusCode: 400,
 				success:    false,
 			},
-			mockSetup: func(mockRepo *MockRepository) {
+			mockSetup: func(mockRepo *MockUserStore) {
 			},
 		},
 	}
@@ -169,11 +675,16 @@ func TestRegister(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockRepo)
+			if tt.want.success {
+				mockTaskRepo.On("CreateProject", mock.Anything, mock.MatchedBy(func(p *models.Project) bool {
+					return p.Name == defaultProjectName && p.UserID != ""
+				})).Return(nil)
+			}
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			jsonData, _ := json.Marshal(tt.request)
 			req, _ := http.NewRequest("POST", "/users/register", bytes.NewBuffer(jsonData))
@@ -188,6 +699,147 @@ func TestRegister(t *testing.T) {
 			}
 
 			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegisterIgnoresRequestedRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
+	mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
+	mockRepo.On("CreateUser", mock.MatchedBy(func(user *models.User) bool {
+		return user.Role == models.RoleUser
+	})).Return(nil)
+	mockTaskRepo.On("CreateProject", mock.Anything, mock.AnythingOfType("*models.Project")).Return(nil)
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+	body := `{"username":"testuser","email":"test@example.com","password":"password123","role":"admin"}`
+	req, _ := http.NewRequest("POST", "/users/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"role":"user"`)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRegisterFailsWithoutDefaultProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
+	mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
+	mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+	mockTaskRepo.On("CreateProject", mock.Anything, mock.AnythingOfType("*models.Project")).Return(errors.ErrDatabaseUnavailable)
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+	jsonData, _ := json.Marshal(models.RegisterRequest{Username: "testuser", Email: "test@example.com", Password: "password123"})
+	req, _ := http.NewRequest("POST", "/users/register", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotContains(t, w.Body.String(), "пользователь успешно создан")
+	mockRepo.AssertExpectations(t)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestGetUserAccountStatus(t *testing.T) {
+	lastLogin := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	target := &models.User{ID: "user456", Username: "bob", Role: models.RoleUser, Active: true, LastLoginAt: &lastLogin}
+
+	tests := []struct {
+		name      string
+		requester string
+		role      string
+		visible   bool
+	}{
+		{name: "anonymous caller"},
+		{name: "other user", requester: "user123", role: models.RoleUser},
+		{name: "same user", requester: "user456", visible: true},
+		{name: "admin", requester: "user123", role: models.RoleAdmin, visible: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockRepo.On("GetUserByID", "user456").Return(target, nil)
+			if tt.role != "" {
+				mockRepo.On("GetUserByID", tt.requester).Return(&models.User{ID: tt.requester, Role: tt.role}, nil)
+			}
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/users/user456", nil)
+			if tt.requester != "" {
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken(tt.requester)})
+			}
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response struct {
+				User map[string]interface{} `json:"user"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "bob", response.User["username"])
+			_, hasActive := response.User["active"]
+			_, hasLastLogin := response.User["last_login_at"]
+			assert.Equal(t, tt.visible, hasActive)
+			assert.Equal(t, tt.visible, hasLastLogin)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateUserKeepsRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    *models.User
+		err        error
+		statusCode int
+	}{
+		{
+			name:       "regular user cannot promote themselves",
+			current:    &models.User{ID: "user123", Role: models.RoleUser},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "admin keeps admin role",
+			current:    &models.User{ID: "user123", Role: models.RoleAdmin},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "user not found",
+			err:        errors.ErrUserNotFound,
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockRepo.On("GetUserByID", "user123").Return(tt.current, tt.err)
+			if tt.current != nil {
+				mockRepo.On("UpdateUser", "user123", mock.MatchedBy(func(user *models.User) bool {
+					return user.Role == tt.current.Role && user.Email == "new@example.com"
+				})).Return(nil)
+			}
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			body := `{"email":"new@example.com","role":"admin"}`
+			req, _ := http.NewRequest("PUT", "/users/update/user123", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -200,7 +852,7 @@ func TestLogin(t *testing.T) {
 			statusCode int
 			success    bool
 		}
-		mockSetup func(*MockRepository)
+		mockSetup func(*MockUserStore)
 	}{
 		{
 			name: "successful login",
@@ -215,7 +867,7 @@ func TestLogin(t *testing.T) {
 				statusCode: 200,
 				success:    true,
 			},
-			mockSetup: func(mockRepo *MockRepository) {
+			mockSetup: func(mockRepo *MockUserStore) {
 				hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 				user := &models.User{
 					ID:       "user123",
@@ -240,7 +892,7 @@ func TestLogin(t *testing.T) {
 				statusCode: 401,
 				success:    false,
 			},
-			mockSetup: func(mockRepo *MockRepository) {
+			mockSetup: func(mockRepo *MockUserStore) {
 				mockRepo.On("GetUserByUsername", "nonexistent").Return(nil, errors.ErrUserNotFound)
 			},
 		},
@@ -257,7 +909,7 @@ func TestLogin(t *testing.T) {
 				statusCode: 401,
 				success:    false,
 			},
-			mockSetup: func(mockRepo *MockRepository) {
+			mockSetup: func(mockRepo *MockUserStore) {
 				hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 				user := &models.User{
 					ID:       "user123",
@@ -274,11 +926,11 @@ func TestLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockRepo)
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			jsonData, _ := json.Marshal(tt.request)
 			req, _ := http.NewRequest("POST", "/users/login", bytes.NewBuffer(jsonData))
@@ -306,7 +958,7 @@ func TestCreateTask(t *testing.T) {
 			statusCode int
 			success    bool
 		}
-		mockSetup func(*MockTaskRepository)
+		mockSetup func(*MockTaskStore)
 	}{
 		{
 			name: "successful task creation",
@@ -322,7 +974,7 @@ func TestCreateTask(t *testing.T) {
 				statusCode: 201,
 				success:    true,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
 			},
 		},
@@ -340,7 +992,7 @@ func TestCreateTask(t *testing.T) {
 				statusCode: 400,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 			},
 		},
 		{
@@ -357,7 +1009,7 @@ func TestCreateTask(t *testing.T) {
 				statusCode: 500,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("*models.Task")).Return(errors.ErrInternalServer)
 			},
 		},
@@ -366,11 +1018,11 @@ func TestCreateTask(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockTaskRepo)
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			jsonData, _ := json.Marshal(tt.request)
 			req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonData))
@@ -401,7 +1053,7 @@ func TestGetTasks(t *testing.T) {
 			statusCode int
 			success    bool
 		}
-		mockSetup func(*MockTaskRepository)
+		mockSetup func(*MockTaskStore)
 	}{
 		{
 			name:   "successful tasks retrieval",
@@ -413,7 +1065,7 @@ func TestGetTasks(t *testing.T) {
 				statusCode: 200,
 				success:    true,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				tasks := []models.Task{
 					{
 						ID:          "task1",
@@ -423,7 +1075,7 @@ func TestGetTasks(t *testing.T) {
 						UserID:      "user123",
 					},
 				}
-				mockTaskRepo.On("GetTasks", mock.Anything, "user123").Return(tasks, nil)
+				mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{}).Return(tasks, nil)
 			},
 		},
 		{
@@ -436,8 +1088,22 @@ func TestGetTasks(t *testing.T) {
 				statusCode: 500,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
-				mockTaskRepo.On("GetTasks", mock.Anything, "user123").Return([]models.Task{}, errors.ErrInternalServer)
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{}).Return([]models.Task{}, errors.ErrInternalServer)
+			},
+		},
+		{
+			name:   "database timeout",
+			userID: "user123",
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: http.StatusGatewayTimeout,
+				success:    false,
+			},
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{}).Return([]models.Task{}, fmt.Errorf("timeout: %w", context.DeadlineExceeded))
 			},
 		},
 	}
@@ -445,11 +1111,11 @@ func TestGetTasks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockTaskRepo)
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			req, _ := http.NewRequest("GET", "/tasks", nil)
 			req.AddCookie(&http.Cookie{
@@ -470,6 +1136,219 @@ func TestGetTasks(t *testing.T) {
 	}
 }
 
+func TestInternalError(t *testing.T) {
+	assert.Equal(t, errors.ErrQueryTimeout, internalError(context.DeadlineExceeded))
+	assert.Equal(t, errors.ErrQueryTimeout, internalError(fmt.Errorf("timeout: %w", context.DeadlineExceeded)))
+	assert.Equal(t, errors.ErrInternalServer, internalError(context.Canceled))
+	assert.Equal(t, errors.ErrInternalServer, internalError(errors.ErrNotFound))
+}
+
+func TestGetTasksWithFilter(t *testing.T) {
+	deleted := true
+	archived := true
+	tests := []struct {
+		name       string
+		query      string
+		filter     *models.TaskFilter
+		statusCode int
+	}{
+		{
+			name:       "status filter",
+			query:      "?status=done",
+			filter:     &models.TaskFilter{Status: "done"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "deleted and title filters",
+			query:      "?deleted=true&title_contains=milk",
+			filter:     &models.TaskFilter{Deleted: &deleted, TitleContains: "milk"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid status",
+			query:      "?status=unknown",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid deleted flag",
+			query:      "?deleted=maybe",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "archived filter",
+			query:      "?archived=true",
+			filter:     &models.TaskFilter{Archived: &archived},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid archived flag",
+			query:      "?archived=maybe",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "assigned view",
+			query:      "?view=assigned",
+			filter:     &models.TaskFilter{View: models.TaskViewAssigned},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid view",
+			query:      "?view=everything",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "sort descending with page",
+			query:      "?sort=-due_date&limit=20&offset=40",
+			filter:     &models.TaskFilter{Sort: models.TaskSortDueDate, Descending: true, Limit: 20, Offset: 40},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid sort field",
+			query:      "?sort=password",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "limit above maximum",
+			query:      "?limit=1000",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "negative offset",
+			query:      "?offset=-1",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			if tt.filter != nil {
+				mockTaskRepo.On("GetTasks", mock.Anything, "user123", *tt.filter).Return([]models.Task{{ID: "task1", UserID: "user123"}}, nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTasksNextOffset(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		filter     models.TaskFilter
+		tasks      []models.Task
+		nextOffset interface{}
+	}{
+		{
+			name:       "full page",
+			query:      "?limit=2&offset=4",
+			filter:     models.TaskFilter{Limit: 2, Offset: 4},
+			tasks:      []models.Task{{ID: "task5"}, {ID: "task6"}},
+			nextOffset: float64(6),
+		},
+		{
+			name:   "last page",
+			query:  "?limit=2&offset=6",
+			filter: models.TaskFilter{Limit: 2, Offset: 6},
+			tasks:  []models.Task{{ID: "task7"}},
+		},
+		{
+			name:  "no limit",
+			tasks: []models.Task{{ID: "task1"}, {ID: "task2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTasks", mock.Anything, "user123", tt.filter).Return(tt.tasks, nil)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.nextOffset, response["next_offset"])
+		})
+	}
+}
+
+func TestSearchTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "successful search",
+			query:      "?q=milk",
+			statusCode: http.StatusOK,
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				mockTaskRepo.On("SearchTasks", mock.Anything, "user123", "milk").Return([]models.Task{{ID: "task1", Title: "Buy milk", UserID: "user123"}}, nil)
+			},
+		},
+		{
+			name:       "empty result is not an error",
+			query:      "?q=nothing",
+			statusCode: http.StatusOK,
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				mockTaskRepo.On("SearchTasks", mock.Anything, "user123", "nothing").Return([]models.Task{}, nil)
+			},
+		},
+		{
+			name:       "missing query",
+			query:      "?q=%20",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(mockTaskRepo *MockTaskStore) {},
+		},
+		{
+			name:       "database error",
+			query:      "?q=milk",
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				mockTaskRepo.On("SearchTasks", mock.Anything, "user123", "milk").Return(nil, errors.ErrInternalServer)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks/search"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUpdateTask(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -480,7 +1359,7 @@ func TestUpdateTask(t *testing.T) {
 			statusCode int
 			success    bool
 		}
-		mockSetup func(*MockTaskRepository)
+		mockSetup func(*MockTaskStore)
 	}{
 		{
 			name:   "successful task update",
@@ -498,7 +1377,7 @@ func TestUpdateTask(t *testing.T) {
 				statusCode: 200,
 				success:    true,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				task := &models.Task{
 					ID:          "task123",
 					Title:       "Original Task",
@@ -524,7 +1403,7 @@ func TestUpdateTask(t *testing.T) {
 				statusCode: 404,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				mockTaskRepo.On("GetTaskByID", mock.Anything, "nonexistent").Return(nil, errors.ErrNotFound)
 			},
 		},
@@ -542,7 +1421,7 @@ func TestUpdateTask(t *testing.T) {
 				statusCode: 403,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				task := &models.Task{
 					ID:          "task123",
 					Title:       "Original Task",
@@ -551,6 +1430,46 @@ func TestUpdateTask(t *testing.T) {
 					UserID:      "user123",
 				}
 				mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(task, nil)
+				mockTaskRepo.On("GetTaskPermission", mock.Anything, "task123", "user456").Return("read", nil)
+			},
+		},
+		{
+			name:   "trashed task",
+			taskID: "task123",
+			request: models.UpdateTaskRequest{
+				Title: "Updated Task",
+			},
+			userID: "user123",
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: 404,
+				success:    false,
+			},
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				task := &models.Task{ID: "task123", Title: "Original Task", Status: "new", UserID: "user123", Deleted: true}
+				mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(task, nil)
+			},
+		},
+		{
+			name:   "task deleted before update",
+			taskID: "task123",
+			request: models.UpdateTaskRequest{
+				Title: "Updated Task",
+			},
+			userID: "user123",
+			want: struct {
+				statusCode int
+				success    bool
+			}{
+				statusCode: 404,
+				success:    false,
+			},
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
+				task := &models.Task{ID: "task123", Title: "Original Task", Status: "new", UserID: "user123"}
+				mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(task, nil)
+				mockTaskRepo.On("UpdateTask", mock.Anything, "task123", mock.AnythingOfType("*models.Task")).Return(errors.ErrNotFound)
 			},
 		},
 	}
@@ -558,11 +1477,11 @@ func TestUpdateTask(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockTaskRepo)
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			jsonData, _ := json.Marshal(tt.request)
 			req, _ := http.NewRequest("PUT", "/tasks/"+tt.taskID, bytes.NewBuffer(jsonData))
@@ -594,7 +1513,7 @@ func TestDeleteTask(t *testing.T) {
 			statusCode int
 			success    bool
 		}
-		mockSetup func(*MockTaskRepository)
+		mockSetup func(*MockTaskStore)
 	}{
 		{
 			name:   "successful task deletion",
@@ -607,7 +1526,7 @@ func TestDeleteTask(t *testing.T) {
 				statusCode: 200,
 				success:    true,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				task := &models.Task{
 					ID:          "task123",
 					Title:       "Test Task",
@@ -617,7 +1536,6 @@ func TestDeleteTask(t *testing.T) {
 				}
 				mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(task, nil)
 				mockTaskRepo.On("DeleteTask", mock.Anything, "task123").Return(nil)
-				mockTaskRepo.On("EnqueueHardDelete", "task123").Return()
 			},
 		},
 		{
@@ -631,7 +1549,7 @@ func TestDeleteTask(t *testing.T) {
 				statusCode: 404,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				mockTaskRepo.On("GetTaskByID", mock.Anything, "nonexistent").Return(nil, errors.ErrNotFound)
 			},
 		},
@@ -646,7 +1564,7 @@ func TestDeleteTask(t *testing.T) {
 				statusCode: 403,
 				success:    false,
 			},
-			mockSetup: func(mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockTaskRepo *MockTaskStore) {
 				task := &models.Task{
 					ID:          "task123",
 					Title:       "Test Task",
@@ -655,6 +1573,7 @@ func TestDeleteTask(t *testing.T) {
 					UserID:      "user123",
 				}
 				mockTaskRepo.On("GetTaskByID", mock.Anything, "task123").Return(task, nil)
+				mockTaskRepo.On("GetTaskPermission", mock.Anything, "task123", "user456").Return("", nil)
 			},
 		},
 	}
@@ -662,11 +1581,11 @@ func TestDeleteTask(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockTaskRepo)
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			req, _ := http.NewRequest("DELETE", "/tasks/"+tt.taskID, nil)
 			req.AddCookie(&http.Cookie{
@@ -706,7 +1625,7 @@ func TestServerErrorHandling(t *testing.T) {
 			statusCode int
 			hasError   bool
 		}
-		mockSetup func(*MockRepository, *MockTaskRepository)
+		mockSetup func(*MockUserStore, *MockTaskStore)
 	}{
 		{
 			name:    "invalid JSON in request",
@@ -720,7 +1639,7 @@ func TestServerErrorHandling(t *testing.T) {
 				statusCode: 400,
 				hasError:   true,
 			},
-			mockSetup: func(mockRepo *MockRepository, mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockRepo *MockUserStore, mockTaskRepo *MockTaskStore) {
 			},
 		},
 		{
@@ -737,7 +1656,7 @@ func TestServerErrorHandling(t *testing.T) {
 				statusCode: 400,
 				hasError:   true,
 			},
-			mockSetup: func(mockRepo *MockRepository, mockTaskRepo *MockTaskRepository) {
+			mockSetup: func(mockRepo *MockUserStore, mockTaskRepo *MockTaskStore) {
 			},
 		},
 	}
@@ -745,11 +1664,11 @@ func TestServerErrorHandling(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
-			mockRepo := &MockRepository{}
-			mockTaskRepo := &MockTaskRepository{}
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
 			tt.mockSetup(mockRepo, mockTaskRepo)
 
-			api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 			var req *http.Request
 			if tt.request == "invalid json" {
@@ -773,9 +1692,9 @@ func TestServerErrorHandling(t *testing.T) {
 
 func TestServerMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	req, _ := http.NewRequest("OPTIONS", "/users/register", nil)
 	req.Header.Set("Origin", "http://localhost:3000")
@@ -789,12 +1708,12 @@ func TestServerMiddleware(t *testing.T) {
 
 func TestServerRateLimiting(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
 
-	mockTaskRepo.On("GetTasks", mock.Anything, "user123").Return([]models.Task{}, nil)
+	mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{}).Return([]models.Task{}, nil)
 
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	for i := 0; i < 3; i++ {
 		req, _ := http.NewRequest("GET", "/tasks", nil)
@@ -809,9 +1728,9 @@ func TestServerRateLimiting(t *testing.T) {
 
 func TestServerGracefulShutdown(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	assert.NotNil(t, api)
 	assert.NotNil(t, api.httpSrv)
@@ -819,8 +1738,8 @@ func TestServerGracefulShutdown(t *testing.T) {
 
 func BenchmarkLogin(b *testing.B) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 	user := &models.User{
@@ -832,7 +1751,7 @@ func BenchmarkLogin(b *testing.B) {
 	}
 	mockRepo.On("GetUserByUsername", "testuser").Return(user, nil)
 
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	loginRequest := models.LoginRequest{
 		Username: "testuser",
@@ -852,19 +1771,19 @@ func BenchmarkLogin(b *testing.B) {
 
 func BenchmarkRegister(b *testing.B) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
 
 	mockRepo.On("GetUserByUsername", "testuser").Return(nil, errors.ErrUserNotFound)
 	mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+	mockTaskRepo.On("CreateProject", mock.Anything, mock.AnythingOfType("*models.Project")).Return(nil)
 
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	registerRequest := models.RegisterRequest{
 		Username: "testuser",
 		Email:    "test@example.com",
 		Password: "password123",
-		Role:     "user",
 	}
 	jsonData, _ := json.Marshal(registerRequest)
 
@@ -880,12 +1799,12 @@ func BenchmarkRegister(b *testing.B) {
 
 func BenchmarkCreateTask(b *testing.B) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
 
 	mockTaskRepo.On("CreateTask", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
 
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	createTaskRequest := models.CreateTaskRequest{
 		Title:       "Test Task",
@@ -909,8 +1828,8 @@ func BenchmarkCreateTask(b *testing.B) {
 
 func BenchmarkGetTasks(b *testing.B) {
 	gin.SetMode(gin.TestMode)
-	mockRepo := &MockRepository{}
-	mockTaskRepo := &MockTaskRepository{}
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
 
 	tasks := []models.Task{
 		{
@@ -928,9 +1847,9 @@ func BenchmarkGetTasks(b *testing.B) {
 			UserID:      "user123",
 		},
 	}
-	mockTaskRepo.On("GetTasks", mock.Anything, "user123").Return(tasks, nil)
+	mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{}).Return(tasks, nil)
 
-	api := NewTaskAPI(mockRepo, mockTaskRepo, &Config{})
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -944,3 +1863,36 @@ func BenchmarkGetTasks(b *testing.B) {
 		api.httpSrv.Handler.ServeHTTP(w, req)
 	}
 }
+
+func BenchmarkGetTasksLargeList(b *testing.B) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+
+	due := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tasks := make([]models.Task, 5000)
+	for i := range tasks {
+		tasks[i] = models.Task{
+			ID:          fmt.Sprintf("task%d", i),
+			Title:       fmt.Sprintf("Task %d", i),
+			Description: "Описание задачи для проверки сериализации больших списков",
+			Status:      "in_progress",
+			UserID:      "user123",
+			Tags:        []string{"work", "urgent"},
+			Position:    i,
+			DueDate:     &due,
+		}
+	}
+	mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{}).Return(tasks, nil)
+
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+	token := generateTestToken("user123")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest("GET", "/tasks", nil)
+		req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+		w := httptest.NewRecorder()
+		api.httpSrv.Handler.ServeHTTP(w, req)
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PermissionRead  = "read"
+	PermissionWrite = "write"
+)
+
+func (api *TaskAPI) loadAccessibleTask(ctx *gin.Context, userID, taskID string, needWrite bool) (*models.Task, bool) {
+	task, err := api.accessibleTask(ctx.Request.Context(), userID, taskID, needWrite)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return nil, false
+	}
+	if needWrite {
+		ctx.Set(writtenTaskKey, task)
+	}
+	return task, true
+}
+
+func (api *TaskAPI) accessibleTask(ctx context.Context, userID, taskID string, needWrite bool) (*models.Task, error) {
+	task, err := api.storage.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrTaskNotFound
+		}
+		return nil, internalError(err)
+	}
+	allowed, err := api.canAccessTask(ctx, task, userID, needWrite)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	if !allowed {
+		return nil, errors.ErrForbidden
+	}
+	return task, nil
+}
+
+func respondTaskError(ctx *gin.Context, err error) {
+	switch err {
+	case errors.ErrTaskNotFound:
+		respondError(ctx, http.StatusNotFound, err)
+	case errors.ErrForbidden:
+		respondError(ctx, http.StatusForbidden, err)
+	default:
+		respondStatusError(ctx, err)
+	}
+}
+
+func (api *TaskAPI) canAccessTask(ctx context.Context, task *models.Task, userID string, needWrite bool) (bool, error) {
+	if task.UserID == userID {
+		return true, nil
+	}
+	if task.AssigneeID != "" && task.AssigneeID == userID && !needWrite {
+		return true, nil
+	}
+	if task.WorkspaceID != "" {
+		role, err := api.storage.GetWorkspaceRole(ctx, task.WorkspaceID, userID)
+		if err != nil {
+			return false, err
+		}
+		if hasWorkspaceRole(role, models.WorkspaceRoleMember) || (hasWorkspaceRole(role, models.WorkspaceRoleViewer) && !needWrite) {
+			return true, nil
+		}
+	}
+	permission, err := api.storage.GetTaskPermission(ctx, task.ID, userID)
+	if err != nil {
+		return false, err
+	}
+	return permission == PermissionWrite || (permission == PermissionRead && !needWrite), nil
+}
+
+func (api *TaskAPI) shareTask(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadOwnTask(ctx, userID, ctx.Param("taskID"))
+	if !ok {
+		return
+	}
+	var req models.ShareTaskRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	if req.UserID == userID {
+		respondError(ctx, http.StatusBadRequest, errors.ErrShareWithSelf)
+		return
+	}
+	if _, err := api.storage.GetUserByID(ctx.Request.Context(), req.UserID); err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	share := models.TaskShare{
+		TaskID:     task.ID,
+		UserID:     req.UserID,
+		Permission: req.Permission,
+	}
+	if err := api.storage.ShareTask(ctx.Request.Context(), &share); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"share": share})
+}
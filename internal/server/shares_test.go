@@ -0,0 +1,153 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShareTask(t *testing.T) {
+	ownTask := &models.Task{ID: "task1", Title: "Task", Status: "new", UserID: "user123"}
+	foreignTask := &models.Task{ID: "task2", Title: "Task", Status: "new", UserID: "user456"}
+	collaborator := "6f1c2a9e-7d4b-4e2a-9c1f-3b5d8e7a6c40"
+
+	tests := []struct {
+		name       string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockUserStore, *MockTaskStore)
+	}{
+		{
+			name:       "share with write permission",
+			path:       "/tasks/task1/share",
+			body:       models.ShareTaskRequest{UserID: collaborator, Permission: "write"},
+			statusCode: http.StatusOK,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				r.On("GetUserByID", collaborator).Return(&models.User{ID: collaborator}, nil)
+				m.On("ShareTask", mock.Anything, &models.TaskShare{TaskID: "task1", UserID: collaborator, Permission: "write"}).Return(nil)
+			},
+		},
+		{
+			name:       "invalid permission",
+			path:       "/tasks/task1/share",
+			body:       models.ShareTaskRequest{UserID: collaborator, Permission: "admin"},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+			},
+		},
+		{
+			name:       "unknown user",
+			path:       "/tasks/task1/share",
+			body:       models.ShareTaskRequest{UserID: collaborator, Permission: "read"},
+			statusCode: http.StatusNotFound,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				r.On("GetUserByID", collaborator).Return(nil, errors.ErrUserNotFound)
+			},
+		},
+		{
+			name:       "only owner can share",
+			path:       "/tasks/task2/share",
+			body:       models.ShareTaskRequest{UserID: collaborator, Permission: "read"},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task2").Return(foreignTask, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockRepo, mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			_ = json.NewEncoder(&body).Encode(tt.body)
+			req, _ := http.NewRequest("POST", tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSharedTaskAccess(t *testing.T) {
+	sharedTask := &models.Task{ID: "task1", Title: "Task", Status: "new", UserID: "user456"}
+
+	tests := []struct {
+		name       string
+		method     string
+		permission string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{name: "reader can view", method: "GET", permission: "read", statusCode: http.StatusOK},
+		{name: "reader cannot update", method: "PUT", permission: "read", statusCode: http.StatusForbidden},
+		{name: "reader cannot delete", method: "DELETE", permission: "read", statusCode: http.StatusForbidden},
+		{name: "stranger cannot view", method: "GET", permission: "", statusCode: http.StatusForbidden},
+		{
+			name:       "writer can update",
+			method:     "PUT",
+			permission: "write",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "writer can delete",
+			method:     "DELETE",
+			permission: "write",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("DeleteTask", mock.Anything, "task1").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			task := *sharedTask
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "task1").Return(&task, nil)
+			mockTaskRepo.On("GetTaskPermission", mock.Anything, "task1", "user123").Return(tt.permission, nil)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockTaskRepo)
+			}
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest(tt.method, "/tasks/task1", bytes.NewBufferString(`{"title":"Shared"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSLORoutes  = "GET /tasks,POST /users/login"
+	defaultSLOWindows = "5m,1h"
+
+	sloWindowSlots = 60
+)
+
+type sloWindow struct {
+	label  string
+	slot   time.Duration
+	epochs []int64
+	totals []float64
+	errors []float64
+}
+
+func newSLOWindow(label string, window time.Duration) *sloWindow {
+	slot := window / sloWindowSlots
+	if slot <= 0 {
+		slot = time.Nanosecond
+	}
+	return &sloWindow{
+		label:  label,
+		slot:   slot,
+		epochs: make([]int64, sloWindowSlots),
+		totals: make([]float64, sloWindowSlots),
+		errors: make([]float64, sloWindowSlots),
+	}
+}
+
+func (w *sloWindow) add(now time.Time, failed bool) {
+	epoch := now.UnixNano() / int64(w.slot)
+	i := int(epoch % sloWindowSlots)
+	if w.epochs[i] != epoch {
+		w.epochs[i] = epoch
+		w.totals[i] = 0
+		w.errors[i] = 0
+	}
+	w.totals[i]++
+	if failed {
+		w.errors[i]++
+	}
+}
+
+func (w *sloWindow) sums(now time.Time) (total, failed float64) {
+	current := now.UnixNano() / int64(w.slot)
+	for i, epoch := range w.epochs {
+		if epoch > current-sloWindowSlots && epoch <= current {
+			total += w.totals[i]
+			failed += w.errors[i]
+		}
+	}
+	return total, failed
+}
+
+type sloTracker struct {
+	mu      sync.Mutex
+	windows map[string][]*sloWindow
+	now     func() time.Time
+}
+
+func parseSLOWindows(raw string) ([]string, []time.Duration, error) {
+	var labels []string
+	var windows []time.Duration
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		d, err := time.ParseDuration(item)
+		if err != nil || d <= 0 {
+			return nil, nil, errors.ErrConfigInvalidFormat
+		}
+		labels = append(labels, item)
+		windows = append(windows, d)
+	}
+	return labels, windows, nil
+}
+
+func parseLatencyBuckets(raw string) ([]float64, error) {
+	var buckets []float64
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(item, 64)
+		if err != nil || bound <= 0 {
+			return nil, errors.ErrConfigInvalidFormat
+		}
+		buckets = append(buckets, bound)
+	}
+	return buckets, nil
+}
+
+func newSLOTracker(cfg *Config) *sloTracker {
+	routes, windows := cfg.SLORoutes, cfg.SLOWindows
+	if routes == "" {
+		routes = defaultSLORoutes
+	}
+	if windows == "" {
+		windows = defaultSLOWindows
+	}
+	labels, durations, err := parseSLOWindows(windows)
+	if err != nil {
+		log.Println("[WARN] Некорректные окна SLO, используются окна по умолчанию:", windows)
+		labels, durations, _ = parseSLOWindows(defaultSLOWindows)
+	}
+
+	tracker := &sloTracker{windows: map[string][]*sloWindow{}, now: time.Now}
+	for _, route := range strings.Split(routes, ",") {
+		route = strings.Join(strings.Fields(route), " ")
+		if route == "" {
+			continue
+		}
+		for i, d := range durations {
+			tracker.windows[route] = append(tracker.windows[route], newSLOWindow(labels[i], d))
+		}
+	}
+	return tracker
+}
+
+func (t *sloTracker) observe(route string, status int) {
+	windows, ok := t.windows[route]
+	if !ok {
+		return
+	}
+	now := t.now()
+	t.mu.Lock()
+	for _, w := range windows {
+		w.add(now, status >= http.StatusInternalServerError)
+	}
+	t.mu.Unlock()
+}
+
+func (t *sloTracker) samples(ratio bool) func() []metrics.Sample {
+	return func() []metrics.Sample {
+		now := t.now()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		var samples []metrics.Sample
+		for route, windows := range t.windows {
+			for _, w := range windows {
+				total, failed := w.sums(now)
+				value := total
+				if ratio {
+					value = 0
+					if total > 0 {
+						value = failed / total
+					}
+				}
+				samples = append(samples, metrics.Sample{Labels: []string{route, w.label}, Value: value})
+			}
+		}
+		return samples
+	}
+}
+
+func newLatencyHistogram(cfg *Config) *metrics.HistogramVec {
+	buckets, err := parseLatencyBuckets(cfg.MetricsLatencyBuckets)
+	if err != nil {
+		log.Println("[WARN] Некорректные границы гистограммы задержек, используются границы по умолчанию:", cfg.MetricsLatencyBuckets)
+		buckets = nil
+	}
+	return metrics.NewHistogramVec("route", buckets)
+}
+
+func (api *TaskAPI) registerRequestMetrics() {
+	api.metrics.Histogram("http_request_duration_seconds", "Длительность обработки запросов по маршрутам", api.latency)
+	api.metrics.Counter("http_request_errors_total", "Количество запросов, завершившихся ошибкой сервера", api.serverErrors)
+	api.metrics.GaugeVecFunc("http_slo_requests", "Количество запросов к маршрутам SLO в скользящем окне", []string{"route", "window"}, api.slo.samples(false))
+	api.metrics.GaugeVecFunc("http_slo_error_ratio", "Доля ошибок сервера для маршрутов SLO в скользящем окне", []string{"route", "window"}, api.slo.samples(true))
+}
+
+func (api *TaskAPI) requestMetricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := "unmatched"
+		if path := ctx.FullPath(); path != "" {
+			route = ctx.Request.Method + " " + path
+		}
+		status := ctx.Writer.Status()
+		api.latency.Observe(route, time.Since(start).Seconds())
+		if status >= http.StatusInternalServerError {
+			api.serverErrors.Inc(route)
+		}
+		api.slo.observe(route, status)
+	}
+}
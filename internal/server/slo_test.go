@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLatencyBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []float64
+		wantErr bool
+	}{
+		{name: "empty uses defaults", raw: ""},
+		{name: "list", raw: "0.05, 0.1,1", want: []float64{0.05, 0.1, 1}},
+		{name: "not a number", raw: "0.1,fast", wantErr: true},
+		{name: "non positive", raw: "0,0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLatencyBuckets(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSLOTracker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tracker := newSLOTracker(&Config{SLORoutes: "GET /tasks", SLOWindows: "1m,10m"})
+	tracker.now = func() time.Time { return now }
+
+	tracker.observe("GET /tasks", http.StatusOK)
+	tracker.observe("GET /tasks", http.StatusInternalServerError)
+	tracker.observe("GET /tasks", http.StatusNotFound)
+	tracker.observe("POST /tasks", http.StatusInternalServerError)
+	now = now.Add(5 * time.Minute)
+	tracker.observe("GET /tasks", http.StatusOK)
+
+	values := map[string]float64{}
+	for _, sample := range tracker.samples(true)() {
+		values[strings.Join(sample.Labels, " ")] = sample.Value
+	}
+	assert.Equal(t, map[string]float64{"GET /tasks 1m": 0, "GET /tasks 10m": 0.25}, values)
+
+	now = now.Add(time.Hour)
+	for _, sample := range tracker.samples(false)() {
+		assert.Zero(t, sample.Value, sample.Labels)
+	}
+}
+
+func TestRequestMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{MetricsLatencyBuckets: "0.5,1"})
+	router := gin.New()
+	router.Use(api.requestMetricsMiddleware())
+	router.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/users/login", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	for _, target := range []struct{ method, path string }{{"GET", "/tasks"}, {"POST", "/users/login"}, {"GET", "/missing"}} {
+		req, _ := http.NewRequest(target.method, target.path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var out strings.Builder
+	require.NoError(t, api.metrics.Write(&out))
+	assert.Contains(t, out.String(), `http_request_duration_seconds_bucket{route="GET /tasks",le="0.5"} 1`)
+	assert.Contains(t, out.String(), `http_request_duration_seconds_count{route="unmatched"} 1`)
+	assert.Contains(t, out.String(), `http_request_errors_total{route="POST /users/login"} 1`)
+	assert.NotContains(t, out.String(), `http_request_errors_total{route="GET /tasks"}`)
+	assert.Contains(t, out.String(), `http_slo_error_ratio{route="POST /users/login",window="5m"} 1`)
+	assert.Contains(t, out.String(), `http_slo_requests{route="GET /tasks",window="1h"} 1`)
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"time"
+
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) slowRequestMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if api.slowRequestThreshold <= 0 {
+			ctx.Next()
+			return
+		}
+		start := time.Now()
+		ctx.Next()
+
+		elapsed := time.Since(start)
+		if elapsed < api.slowRequestThreshold {
+			return
+		}
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		api.slowRequests.Inc(route)
+		userID, _ := api.getUserIDFromJWT(ctx)
+		requestid.Printf(ctx.Request.Context(), "[WARN] Медленный запрос %s %s (маршрут %s, пользователь %s, статус %d): %v",
+			ctx.Request.Method, ctx.Request.URL.Path, route, dashIfEmpty(userID), ctx.Writer.Status(), elapsed)
+	}
+}
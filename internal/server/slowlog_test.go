@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowRequestMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		delay     time.Duration
+		slow      bool
+	}{
+		{name: "slow request counted", threshold: 5 * time.Millisecond, delay: 10 * time.Millisecond, slow: true},
+		{name: "fast request ignored", threshold: time.Second},
+		{name: "disabled threshold", delay: 5 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{SlowRequestThreshold: tt.threshold})
+			router := gin.New()
+			router.Use(requestIDMiddleware(), api.slowRequestMiddleware())
+			router.GET("/reports/:id", func(c *gin.Context) {
+				time.Sleep(tt.delay)
+				c.Status(http.StatusNoContent)
+			})
+
+			req, _ := http.NewRequest("GET", "/reports/42", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusNoContent, w.Code)
+
+			var out strings.Builder
+			require.NoError(t, api.metrics.Write(&out))
+			if tt.slow {
+				assert.Contains(t, out.String(), `http_slow_requests_total{route="/reports/:id"} 1`)
+			} else {
+				assert.NotContains(t, out.String(), `route="/reports/:id"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"project/internal/web"
+)
+
+const (
+	apiPrefix = "/api"
+	spaIndex  = "index.html"
+)
+
+var rootRoutes = map[string]bool{
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+type spaHandler struct {
+	files      fs.FS
+	fileServer http.Handler
+}
+
+func newSPAHandler(files fs.FS) *spaHandler {
+	return &spaHandler{files: files, fileServer: http.FileServerFS(files)}
+}
+
+func uiFiles(cfg *Config) fs.FS {
+	switch {
+	case cfg.UIDir != "":
+		log.Println("[INFO] Интерфейс раздается из каталога:", cfg.UIDir)
+		return os.DirFS(cfg.UIDir)
+	case cfg.UIEmbedded:
+		log.Println("[INFO] Интерфейс раздается из встроенных файлов")
+		return web.FS()
+	default:
+		return nil
+	}
+}
+
+func (h *spaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name != "" && name != spaIndex {
+		if info, err := fs.Stat(h.files, name); err == nil && !info.IsDir() {
+			h.fileServer.ServeHTTP(w, r)
+			return
+		}
+		if path.Ext(name) != "" {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	index, err := fs.ReadFile(h.files, spaIndex)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(index)
+	}
+}
+
+func withUI(router http.Handler, ui http.Handler) http.Handler {
+	apiRoutes := http.StripPrefix(apiPrefix, router)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		urlPath := r.URL.Path
+		switch {
+		case urlPath == apiPrefix || strings.HasPrefix(urlPath, apiPrefix+"/"):
+			apiRoutes.ServeHTTP(w, r)
+		case rootRoutes[urlPath]:
+			router.ServeHTTP(w, r)
+		default:
+			ui.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"project/internal/web"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPAHandler(t *testing.T) {
+	files := fstest.MapFS{
+		"index.html":    {Data: []byte("<html>app</html>")},
+		"assets/app.js": {Data: []byte("console.log(1)")},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "root serves index", method: "GET", path: "/", expectedCode: http.StatusOK, expectedBody: "<html>app</html>"},
+		{name: "asset", method: "GET", path: "/assets/app.js", expectedCode: http.StatusOK, expectedBody: "console.log(1)"},
+		{name: "client route falls back to index", method: "GET", path: "/tasks/42/edit", expectedCode: http.StatusOK, expectedBody: "<html>app</html>"},
+		{name: "missing asset", method: "GET", path: "/assets/missing.js", expectedCode: http.StatusNotFound},
+		{name: "index by name", method: "GET", path: "/index.html", expectedCode: http.StatusOK, expectedBody: "<html>app</html>"},
+		{name: "traversal stays inside", method: "GET", path: "/../../etc/passwd", expectedCode: http.StatusOK, expectedBody: "<html>app</html>"},
+		{name: "head", method: "HEAD", path: "/projects", expectedCode: http.StatusOK},
+		{name: "post rejected", method: "POST", path: "/tasks", expectedCode: http.StatusMethodNotAllowed},
+	}
+
+	handler := newSPAHandler(files)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUIRouting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>ui</html>"), 0o600))
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{UIDir: dir})
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+		ui           bool
+	}{
+		{name: "api route under prefix", path: "/api/livez", expectedCode: http.StatusOK},
+		{name: "api requires auth", path: "/api/tasks", expectedCode: http.StatusUnauthorized},
+		{name: "probe stays at root", path: "/readyz", expectedCode: http.StatusOK},
+		{name: "root path serves ui", path: "/tasks", expectedCode: http.StatusOK, ui: true},
+		{name: "prefix lookalike serves ui", path: "/apiary", expectedCode: http.StatusOK, ui: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.ui {
+				assert.Equal(t, "<html>ui</html>", w.Body.String())
+			} else {
+				assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestEmbeddedUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{UIEmbedded: true})
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<title>")
+	_, err := web.FS().Open("index.html")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) getSubtasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	parent, ok := api.loadAccessibleTask(ctx, userID, ctx.Param("taskID"), false)
+	if !ok {
+		return
+	}
+	subtasks, err := api.storage.GetSubtasks(ctx.Request.Context(), parent.ID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	done := 0
+	for _, t := range subtasks {
+		if t.Status == "done" {
+			done++
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"subtasks": subtasks,
+		"total":    len(subtasks),
+		"done":     done,
+	})
+}
+
+func (api *TaskAPI) reopenStatus(ctx context.Context, userID string) string {
+	workflow, err := api.loadWorkflow(ctx, userID)
+	if err != nil || workflow.HasStatus(models.StatusInProgress) {
+		return models.StatusInProgress
+	}
+	return workflow.InitialStatus
+}
+
+func (api *TaskAPI) rollUpStatus(ctx context.Context, parentID string) {
+	for parentID != "" {
+		parent, err := api.storage.GetTaskByID(ctx, parentID)
+		if err != nil || parent.Deleted {
+			return
+		}
+		subtasks, err := api.storage.GetSubtasks(ctx, parentID)
+		if err != nil || len(subtasks) == 0 {
+			return
+		}
+		allDone := true
+		for _, t := range subtasks {
+			if t.Status != "done" {
+				allDone = false
+				break
+			}
+		}
+		status := parent.Status
+		if allDone {
+			status = models.StatusDone
+		} else if parent.Status == models.StatusDone {
+			status = api.reopenStatus(ctx, parent.UserID)
+		}
+		if status == parent.Status {
+			return
+		}
+		parent.Status = status
+		if err := api.storage.UpdateTask(ctx, parent.ID, parent); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось обновить статус родительской задачи:", err)
+			return
+		}
+		parentID = parent.ParentID
+	}
+}
+
+func (api *TaskAPI) validateParent(ctx *gin.Context, userID string, task *models.Task) bool {
+	if err := api.checkParent(ctx.Request.Context(), userID, task); err != nil {
+		respondTaskError(ctx, err)
+		return false
+	}
+	return true
+}
+
+func (api *TaskAPI) checkParent(ctx context.Context, userID string, task *models.Task) error {
+	if task.ParentID == "" {
+		return nil
+	}
+	parent, err := api.storage.GetTaskByID(ctx, task.ParentID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrParentTaskNotFound
+		}
+		return internalError(err)
+	}
+	if parent.Deleted {
+		return errors.ErrParentTaskNotFound
+	}
+	if parent.UserID != userID {
+		if parent.WorkspaceID == "" {
+			return errors.ErrForbidden
+		}
+		if _, err := api.checkWorkspace(ctx, userID, parent.WorkspaceID, models.WorkspaceRoleMember); err != nil {
+			if err == errors.ErrWorkspaceNotFound {
+				return errors.ErrForbidden
+			}
+			return err
+		}
+	}
+	if task.WorkspaceID == "" {
+		task.WorkspaceID = parent.WorkspaceID
+	}
+	if task.WorkspaceID != parent.WorkspaceID {
+		return errors.ErrWorkspaceMismatch
+	}
+	return nil
+}
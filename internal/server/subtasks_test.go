@@ -0,0 +1,198 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSubtasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "subtasks with progress",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(&models.Task{ID: "parent", UserID: "user123", Status: "new"}, nil)
+				m.On("GetSubtasks", mock.Anything, "parent").Return([]models.Task{
+					{ID: "child1", ParentID: "parent", UserID: "user123", Status: "done"},
+					{ID: "child2", ParentID: "parent", UserID: "user123", Status: "new"},
+				}, nil)
+			},
+		},
+		{
+			name:       "foreign parent",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(&models.Task{ID: "parent", UserID: "user456"}, nil)
+				m.On("GetTaskPermission", mock.Anything, "parent", "user123").Return("", nil)
+			},
+		},
+		{
+			name:       "missing parent",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(nil, errors.ErrNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/tasks/parent/subtasks", nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"total":2`)
+				assert.Contains(t, w.Body.String(), `"done":1`)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateSubtask(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "subtask of own task",
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(&models.Task{ID: "parent", UserID: "user123"}, nil)
+				m.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.ParentID == "parent"
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "unknown parent",
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(nil, errors.ErrNotFound)
+			},
+		},
+		{
+			name:       "deleted parent",
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(&models.Task{ID: "parent", UserID: "user123", Deleted: true}, nil)
+			},
+		},
+		{
+			name:       "foreign parent",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "parent").Return(&models.Task{ID: "parent", UserID: "user456"}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			jsonData, _ := json.Marshal(models.CreateTaskRequest{Title: "Child", ParentID: "parent"})
+			req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubtaskStatusRollUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		newStatus    string
+		parentStatus string
+		siblings     []models.Task
+		wantParent   string
+	}{
+		{
+			name:         "last subtask done completes parent",
+			newStatus:    "done",
+			parentStatus: "in_progress",
+			siblings:     []models.Task{{ID: "child", Status: "done"}, {ID: "other", Status: "done"}},
+			wantParent:   "done",
+		},
+		{
+			name:         "reopened subtask reopens parent",
+			newStatus:    "new",
+			parentStatus: "done",
+			siblings:     []models.Task{{ID: "child", Status: "new"}, {ID: "other", Status: "done"}},
+			wantParent:   "in_progress",
+		},
+		{
+			name:         "unfinished siblings keep parent status",
+			newStatus:    "done",
+			parentStatus: "new",
+			siblings:     []models.Task{{ID: "child", Status: "done"}, {ID: "other", Status: "new"}},
+			wantParent:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "child").Return(&models.Task{ID: "child", UserID: "user123", Status: "in_progress", ParentID: "parent"}, nil)
+			mockTaskRepo.On("UpdateTask", mock.Anything, "child", mock.AnythingOfType("*models.Task")).Return(nil)
+			mockTaskRepo.On("GetTaskByID", mock.Anything, "parent").Return(&models.Task{ID: "parent", UserID: "user123", Status: tt.parentStatus}, nil)
+			mockTaskRepo.On("GetSubtasks", mock.Anything, "parent").Return(tt.siblings, nil)
+			if tt.wantParent != "" {
+				mockTaskRepo.On("UpdateTask", mock.Anything, "parent", mock.MatchedBy(func(task *models.Task) bool {
+					return task.Status == tt.wantParent
+				})).Return(nil)
+			}
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			jsonData, _ := json.Marshal(models.UpdateTaskRequest{Status: tt.newStatus})
+			req, _ := http.NewRequest("PUT", "/tasks/child", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+			if tt.wantParent == "" {
+				mockTaskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, "parent", mock.Anything)
+			}
+		})
+	}
+}
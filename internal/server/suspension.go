@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) activeUserMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := api.getUserIDFromJWT(ctx)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+		traceUser(ctx, userID)
+		active, err := api.storage.IsUserActive(ctx.Request.Context(), userID)
+		if err != nil && err != errors.ErrUserNotFound {
+			respondError(ctx, http.StatusInternalServerError, errors.ErrInternalServer)
+			return
+		}
+		if err == nil && !active {
+			respondError(ctx, http.StatusForbidden, errors.ErrUserSuspended)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func (api *TaskAPI) suspendUser(ctx *gin.Context) {
+	api.setUserActive(ctx, false)
+}
+
+func (api *TaskAPI) reactivateUser(ctx *gin.Context) {
+	api.setUserActive(ctx, true)
+}
+
+func (api *TaskAPI) setUserActive(ctx *gin.Context, active bool) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	admin, err := api.storage.GetUserByID(ctx.Request.Context(), userID)
+	if err != nil || admin.Role != models.RoleAdmin {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return
+	}
+	targetID := ctx.Param("userID")
+	if targetID == userID && !active {
+		respondError(ctx, http.StatusBadRequest, errors.ErrSuspendSelf)
+		return
+	}
+	if err := api.storage.SetUserActive(ctx.Request.Context(), targetID, active); err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrUserNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	message := "пользователь заблокирован"
+	if active {
+		message = "пользователь разблокирован"
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": message, "user": gin.H{"id": targetID, "active": active}})
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestSuspendUserHandlers(t *testing.T) {
+	admin := &models.User{ID: "user123", Username: "admin", Role: "admin", Active: true}
+	regular := &models.User{ID: "user123", Username: "regular", Role: "user", Active: true}
+
+	tests := []struct {
+		name       string
+		path       string
+		statusCode int
+		mockSetup  func(*MockUserStore)
+		want       error
+	}{
+		{
+			name:       "admin suspends user",
+			path:       "/users/user456/suspend",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(admin, nil)
+				m.On("SetUserActive", "user456", false).Return(nil)
+			},
+		},
+		{
+			name:       "admin reactivates user",
+			path:       "/users/user456/reactivate",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(admin, nil)
+				m.On("SetUserActive", "user456", true).Return(nil)
+			},
+		},
+		{
+			name:       "regular user cannot suspend",
+			path:       "/users/user456/suspend",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(regular, nil)
+			},
+		},
+		{
+			name:       "admin cannot suspend self",
+			path:       "/users/user123/suspend",
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(admin, nil)
+			},
+			want: errors.ErrSuspendSelf,
+		},
+		{
+			name:       "unknown user",
+			path:       "/users/missing/suspend",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(admin, nil)
+				m.On("SetUserActive", "missing", false).Return(errors.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			tt.mockSetup(mockRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				assert.Contains(t, w.Body.String(), tt.want.Error())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSuspendedUserIsRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := &MockUserStore{suspended: map[string]bool{"user123": true}}
+	mockTaskRepo := &MockTaskStore{}
+
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+	req, _ := http.NewRequest("GET", "/tasks", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), errors.ErrUserSuspended.Error())
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestSuspendedUserCannotLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockRepo := &MockUserStore{suspended: map[string]bool{"user1": true}}
+	mockRepo.On("GetUserByUsername", "testuser").Return(&models.User{ID: "user1", Username: "testuser", Password: string(hash)}, nil)
+
+	api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+	body, _ := json.Marshal(models.LoginRequest{Username: "testuser", Password: "password123"})
+	req, _ := http.NewRequest("POST", "/users/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), errors.ErrUserSuspended.Error())
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+}
+
+func TestLoginFailsWhenActiveCheckFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockRepo := &MockUserStore{activeErr: fmt.Errorf("connection reset")}
+	mockRepo.On("GetUserByUsername", "testuser").Return(&models.User{ID: "user1", Username: "testuser", Password: string(hash)}, nil)
+
+	api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+	body, _ := json.Marshal(models.LoginRequest{Username: "testuser", Password: "password123"})
+	req, _ := http.NewRequest("POST", "/users/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+}
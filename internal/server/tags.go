@@ -0,0 +1,180 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) loadOwnTag(ctx *gin.Context, userID, tagID string) (*models.Tag, bool) {
+	tag, err := api.storage.GetTagByID(ctx.Request.Context(), tagID)
+	if err != nil {
+		if err == errors.ErrTagNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTagNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return nil, false
+	}
+	if tag.UserID != userID {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return nil, false
+	}
+	return tag, true
+}
+
+func (api *TaskAPI) loadOwnTask(ctx *gin.Context, userID, taskID string) (*models.Task, bool) {
+	task, err := api.storage.GetTaskByID(ctx.Request.Context(), taskID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return nil, false
+	}
+	if task.UserID != userID {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return nil, false
+	}
+	return task, true
+}
+
+func bindTagRequest(ctx *gin.Context) (*models.TagRequest, bool) {
+	var req models.TagRequest
+	if !bindAndValidate(ctx, &req) {
+		return nil, false
+	}
+	return &req, true
+}
+
+func (api *TaskAPI) getTags(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	tags, err := api.storage.GetTags(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (api *TaskAPI) createTag(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindTagRequest(ctx)
+	if !ok {
+		return
+	}
+	tag := models.Tag{Name: req.Name, UserID: userID}
+	if err := api.storage.CreateTag(ctx.Request.Context(), &tag); err != nil {
+		if err == errors.ErrTagAlreadyExists {
+			respondError(ctx, http.StatusConflict, errors.ErrTagAlreadyExists)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"tag": tag})
+}
+
+func (api *TaskAPI) updateTag(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindTagRequest(ctx)
+	if !ok {
+		return
+	}
+	tag, ok := api.loadOwnTag(ctx, userID, ctx.Param("tagID"))
+	if !ok {
+		return
+	}
+	tag.Name = req.Name
+	if err := api.storage.UpdateTag(ctx.Request.Context(), tag.ID, tag); err != nil {
+		switch err {
+		case errors.ErrTagAlreadyExists:
+			respondError(ctx, http.StatusConflict, errors.ErrTagAlreadyExists)
+		case errors.ErrTagNotFound:
+			respondError(ctx, http.StatusNotFound, errors.ErrTagNotFound)
+		default:
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"tag": tag})
+}
+
+func (api *TaskAPI) deleteTag(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	tag, ok := api.loadOwnTag(ctx, userID, ctx.Param("tagID"))
+	if !ok {
+		return
+	}
+	if err := api.storage.DeleteTag(ctx.Request.Context(), tag.ID); err != nil {
+		if err == errors.ErrTagNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTagNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "тег успешно удален"})
+}
+
+func (api *TaskAPI) attachTag(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadOwnTask(ctx, userID, ctx.Param("taskID"))
+	if !ok {
+		return
+	}
+	tag, ok := api.loadOwnTag(ctx, userID, ctx.Param("tagID"))
+	if !ok {
+		return
+	}
+	if err := api.storage.AttachTag(ctx.Request.Context(), task.ID, tag.ID); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "тег привязан к задаче"})
+}
+
+func (api *TaskAPI) detachTag(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	task, ok := api.loadOwnTask(ctx, userID, ctx.Param("taskID"))
+	if !ok {
+		return
+	}
+	if err := api.storage.DetachTag(ctx.Request.Context(), task.ID, ctx.Param("tagID")); err != nil {
+		if err == errors.ErrTagNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTagNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "тег отвязан от задачи"})
+}
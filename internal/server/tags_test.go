@@ -0,0 +1,175 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTagHandlers(t *testing.T) {
+	ownTag := &models.Tag{ID: "tag1", Name: "work", UserID: "user123"}
+	foreignTag := &models.Tag{ID: "tag2", Name: "home", UserID: "user456"}
+	ownTask := &models.Task{ID: "task1", Title: "Task", Status: "new", UserID: "user123"}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "list tags",
+			method:     "GET",
+			path:       "/tags",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTags", mock.Anything, "user123").Return([]models.Tag{*ownTag}, nil)
+			},
+		},
+		{
+			name:       "create tag",
+			method:     "POST",
+			path:       "/tags",
+			body:       models.TagRequest{Name: "work"},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateTag", mock.Anything, mock.MatchedBy(func(tag *models.Tag) bool {
+					return tag.Name == "work" && tag.UserID == "user123"
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "create duplicate tag",
+			method:     "POST",
+			path:       "/tags",
+			body:       models.TagRequest{Name: "work"},
+			statusCode: http.StatusConflict,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateTag", mock.Anything, mock.AnythingOfType("*models.Tag")).Return(errors.ErrTagAlreadyExists)
+			},
+		},
+		{
+			name:       "create tag with empty name",
+			method:     "POST",
+			path:       "/tags",
+			body:       models.TagRequest{Name: ""},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "rename tag",
+			method:     "PUT",
+			path:       "/tags/tag1",
+			body:       models.TagRequest{Name: "office"},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTagByID", mock.Anything, "tag1").Return(&models.Tag{ID: "tag1", Name: "work", UserID: "user123"}, nil)
+				m.On("UpdateTag", mock.Anything, "tag1", mock.AnythingOfType("*models.Tag")).Return(nil)
+			},
+		},
+		{
+			name:       "delete foreign tag",
+			method:     "DELETE",
+			path:       "/tags/tag2",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTagByID", mock.Anything, "tag2").Return(foreignTag, nil)
+			},
+		},
+		{
+			name:       "delete missing tag",
+			method:     "DELETE",
+			path:       "/tags/missing",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTagByID", mock.Anything, "missing").Return(nil, errors.ErrTagNotFound)
+			},
+		},
+		{
+			name:       "attach tag to task",
+			method:     "POST",
+			path:       "/tasks/task1/tags/tag1",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetTagByID", mock.Anything, "tag1").Return(ownTag, nil)
+				m.On("AttachTag", mock.Anything, "task1", "tag1").Return(nil)
+			},
+		},
+		{
+			name:       "attach foreign tag",
+			method:     "POST",
+			path:       "/tasks/task1/tags/tag2",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("GetTagByID", mock.Anything, "tag2").Return(foreignTag, nil)
+			},
+		},
+		{
+			name:       "detach tag that is not attached",
+			method:     "DELETE",
+			path:       "/tasks/task1/tags/tag1",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(ownTask, nil)
+				m.On("DetachTag", mock.Anything, "task1", "tag1").Return(errors.ErrTagNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTasksByTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := &MockUserStore{}
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("GetTasks", mock.Anything, "user123", models.TaskFilter{Tag: "work"}).
+		Return([]models.Task{{ID: "task1", UserID: "user123", Tags: []string{"work"}}}, nil)
+
+	api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+	req, _ := http.NewRequest("GET", "/tasks?tag=work", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tags":["work"]`)
+	mockTaskRepo.AssertExpectations(t)
+}
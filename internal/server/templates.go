@@ -0,0 +1,224 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) loadOwnTemplate(ctx *gin.Context, userID, templateID string) (*models.TaskTemplate, bool) {
+	template, err := api.storage.GetTemplateByID(ctx.Request.Context(), templateID)
+	if err != nil {
+		if err == errors.ErrTemplateNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTemplateNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return nil, false
+	}
+	if template.UserID != userID {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return nil, false
+	}
+	return template, true
+}
+
+func bindTemplateRequest(ctx *gin.Context) (*models.TaskTemplateRequest, bool) {
+	var req models.TaskTemplateRequest
+	if !bindAndValidate(ctx, &req) {
+		return nil, false
+	}
+	return &req, true
+}
+
+func (api *TaskAPI) getTemplates(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	templates, err := api.storage.GetTemplates(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (api *TaskAPI) getTemplateByID(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	template, ok := api.loadOwnTemplate(ctx, userID, ctx.Param("templateID"))
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+func (api *TaskAPI) createTemplate(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindTemplateRequest(ctx)
+	if !ok {
+		return
+	}
+	template := models.TaskTemplate{
+		UserID:      userID,
+		Name:        req.Name,
+		Title:       req.Title,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Checklist:   req.Checklist,
+	}
+	if err := api.storage.CreateTemplate(ctx.Request.Context(), &template); err != nil {
+		if err == errors.ErrTemplateAlreadyExists {
+			respondError(ctx, http.StatusConflict, errors.ErrTemplateAlreadyExists)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"template": template})
+}
+
+func (api *TaskAPI) updateTemplate(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindTemplateRequest(ctx)
+	if !ok {
+		return
+	}
+	template, ok := api.loadOwnTemplate(ctx, userID, ctx.Param("templateID"))
+	if !ok {
+		return
+	}
+	template.Name = req.Name
+	template.Title = req.Title
+	template.Description = req.Description
+	template.Tags = req.Tags
+	template.Checklist = req.Checklist
+	if err := api.storage.UpdateTemplate(ctx.Request.Context(), template.ID, template); err != nil {
+		switch err {
+		case errors.ErrTemplateAlreadyExists:
+			respondError(ctx, http.StatusConflict, errors.ErrTemplateAlreadyExists)
+		case errors.ErrTemplateNotFound:
+			respondError(ctx, http.StatusNotFound, errors.ErrTemplateNotFound)
+		default:
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+func (api *TaskAPI) deleteTemplate(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	template, ok := api.loadOwnTemplate(ctx, userID, ctx.Param("templateID"))
+	if !ok {
+		return
+	}
+	if err := api.storage.DeleteTemplate(ctx.Request.Context(), template.ID); err != nil {
+		if err == errors.ErrTemplateNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTemplateNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "шаблон успешно удален"})
+}
+
+func (api *TaskAPI) attachTagsByName(ctx *gin.Context, userID, taskID string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	tags, err := api.storage.GetTags(ctx.Request.Context(), userID)
+	if err != nil {
+		return err
+	}
+	tagIDs := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagIDs[tag.Name] = tag.ID
+	}
+	for _, name := range names {
+		tagID, exists := tagIDs[name]
+		if !exists {
+			tag := models.Tag{UserID: userID, Name: name}
+			if err := api.storage.CreateTag(ctx.Request.Context(), &tag); err != nil {
+				return err
+			}
+			tagID = tag.ID
+			tagIDs[name] = tagID
+		}
+		if err := api.storage.AttachTag(ctx.Request.Context(), taskID, tagID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (api *TaskAPI) createTaskFromTemplate(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	template, ok := api.loadOwnTemplate(ctx, userID, ctx.Param("templateID"))
+	if !ok {
+		return
+	}
+	status, err := api.initialStatus(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	task := models.Task{
+		Title:       template.Title,
+		Description: template.Description,
+		Status:      status,
+		UserID:      userID,
+	}
+	if err := api.storage.CreateTask(ctx.Request.Context(), &task); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventCreate, nil, &task)
+	if err := api.attachTagsByName(ctx, userID, task.ID, template.Tags); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	task.Tags = template.Tags
+
+	subtasks := []models.Task{}
+	for _, item := range template.Checklist {
+		subtask := models.Task{
+			Title:    item,
+			Status:   status,
+			UserID:   userID,
+			ParentID: task.ID,
+		}
+		if err := api.storage.CreateTask(ctx.Request.Context(), &subtask); err != nil {
+			respondInternalError(ctx, err)
+			return
+		}
+		api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventCreate, nil, &subtask)
+		subtasks = append(subtasks, subtask)
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"task": task, "subtasks": subtasks})
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTemplateHandlers(t *testing.T) {
+	ownTemplate := &models.TaskTemplate{ID: "template1", UserID: "user123", Name: "Weekly", Title: "Weekly review"}
+	foreignTemplate := &models.TaskTemplate{ID: "template2", UserID: "user456", Name: "Other", Title: "Other"}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "list templates",
+			method:     "GET",
+			path:       "/templates",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTemplates", mock.Anything, "user123").Return([]models.TaskTemplate{*ownTemplate}, nil)
+			},
+		},
+		{
+			name:       "create template",
+			method:     "POST",
+			path:       "/templates",
+			body:       models.TaskTemplateRequest{Name: "Weekly", Title: "Weekly review", Tags: []string{"work"}, Checklist: []string{"Inbox zero"}},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateTemplate", mock.Anything, &models.TaskTemplate{UserID: "user123", Name: "Weekly", Title: "Weekly review", Tags: []string{"work"}, Checklist: []string{"Inbox zero"}}).Return(nil)
+			},
+		},
+		{
+			name:       "create template without title",
+			method:     "POST",
+			path:       "/templates",
+			body:       models.TaskTemplateRequest{Name: "Weekly"},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "create duplicate template",
+			method:     "POST",
+			path:       "/templates",
+			body:       models.TaskTemplateRequest{Name: "Weekly", Title: "Weekly review"},
+			statusCode: http.StatusConflict,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateTemplate", mock.Anything, mock.AnythingOfType("*models.TaskTemplate")).Return(errors.ErrTemplateAlreadyExists)
+			},
+		},
+		{
+			name:       "get foreign template",
+			method:     "GET",
+			path:       "/templates/template2",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTemplateByID", mock.Anything, "template2").Return(foreignTemplate, nil)
+			},
+		},
+		{
+			name:       "update template",
+			method:     "PUT",
+			path:       "/templates/template1",
+			body:       models.TaskTemplateRequest{Name: "Weekly", Title: "Weekly planning"},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTemplateByID", mock.Anything, "template1").Return(&models.TaskTemplate{ID: "template1", UserID: "user123", Name: "Weekly", Title: "Weekly review"}, nil)
+				m.On("UpdateTemplate", mock.Anything, "template1", mock.AnythingOfType("*models.TaskTemplate")).Return(nil)
+			},
+		},
+		{
+			name:       "delete missing template",
+			method:     "DELETE",
+			path:       "/templates/missing",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTemplateByID", mock.Anything, "missing").Return(nil, errors.ErrTemplateNotFound)
+			},
+		},
+		{
+			name:       "instantiate foreign template",
+			method:     "POST",
+			path:       "/tasks/from-template/template2",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTemplateByID", mock.Anything, "template2").Return(foreignTemplate, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateTaskFromTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockTaskRepo := &MockTaskStore{}
+	mockTaskRepo.On("GetTemplateByID", mock.Anything, "template1").Return(&models.TaskTemplate{
+		ID:          "template1",
+		UserID:      "user123",
+		Name:        "Weekly",
+		Title:       "Weekly review",
+		Description: "Look back at the week",
+		Tags:        []string{"work", "review"},
+		Checklist:   []string{"Inbox zero", "Plan next week"},
+	}, nil)
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Weekly review" && task.ParentID == ""
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Task).ID = "task1"
+	}).Return(nil)
+	mockTaskRepo.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+		return task.ParentID == "task1"
+	})).Return(nil).Twice()
+	mockTaskRepo.On("GetTags", mock.Anything, "user123").Return([]models.Tag{{ID: "tag1", UserID: "user123", Name: "work"}}, nil)
+	mockTaskRepo.On("CreateTag", mock.Anything, &models.Tag{UserID: "user123", Name: "review"}).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Tag).ID = "tag2"
+	}).Return(nil)
+	mockTaskRepo.On("AttachTag", mock.Anything, "task1", "tag1").Return(nil)
+	mockTaskRepo.On("AttachTag", mock.Anything, "task1", "tag2").Return(nil)
+
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+	req, _ := http.NewRequest("POST", "/tasks/from-template/template1", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Task     models.Task   `json:"task"`
+		Subtasks []models.Task `json:"subtasks"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Look back at the week", resp.Task.Description)
+	assert.Equal(t, []string{"work", "review"}, resp.Task.Tags)
+	if assert.Len(t, resp.Subtasks, 2) {
+		assert.Equal(t, "Inbox zero", resp.Subtasks[0].Title)
+		assert.Equal(t, "task1", resp.Subtasks[1].ParentID)
+	}
+	assert.Len(t, mockTaskRepo.events, 3)
+	mockTaskRepo.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"project/internal/domain/errors"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+type tlsOptions struct {
+	certFile     string
+	keyFile      string
+	manager      *autocert.Manager
+	redirectAddr string
+	httpsPort    int
+}
+
+func newTLSOptions(cfg *Config) *tlsOptions {
+	opts := &tlsOptions{
+		certFile:     cfg.TLSCertFile,
+		keyFile:      cfg.TLSKeyFile,
+		redirectAddr: cfg.TLSRedirectAddr,
+		httpsPort:    cfg.Port,
+	}
+
+	var hosts []string
+	for _, host := range strings.Split(cfg.TLSAutocertHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) > 0 {
+		cacheDir := cfg.TLSAutocertCacheDir
+		if cacheDir == "" {
+			cacheDir = defaultTLSAutocertCacheDir
+		}
+		opts.manager = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(hosts...),
+			Cache:      autocert.DirCache(cacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+	}
+	return opts
+}
+
+func (o *tlsOptions) enabled() bool {
+	return o.manager != nil || o.certFile != "" || o.keyFile != ""
+}
+
+func (o *tlsOptions) redirectHandler() http.Handler {
+	redirect := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if o.httpsPort != 0 && o.httpsPort != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(o.httpsPort))
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+	if o.manager != nil {
+		return o.manager.HTTPHandler(redirect)
+	}
+	return redirect
+}
+
+func (api *TaskAPI) startRedirectServer() {
+	if api.tls.redirectAddr == "" {
+		return
+	}
+	api.redirectSrv = &http.Server{
+		Addr:              api.tls.redirectAddr,
+		Handler:           api.tls.redirectHandler(),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		log.Printf("[INFO] Перенаправление HTTP -> HTTPS запущено на %s", api.tls.redirectAddr)
+		if err := api.redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Println("[ERROR] Ошибка сервера перенаправления HTTP -> HTTPS:", err)
+		}
+	}()
+}
+
+func (api *TaskAPI) serveTLS(listener net.Listener) error {
+	if api.tls.manager != nil {
+		if api.tls.certFile != "" || api.tls.keyFile != "" {
+			log.Println("[WARN] Заданы и статический сертификат, и autocert; используется autocert")
+		}
+		api.httpSrv.TLSConfig = api.tls.manager.TLSConfig()
+		api.alignNextProtos(api.httpSrv.TLSConfig)
+		api.startRedirectServer()
+		return api.httpSrv.ServeTLS(listener, "", "")
+	}
+	if api.tls.certFile == "" || api.tls.keyFile == "" {
+		_ = listener.Close()
+		return errors.ErrTLSKeyPairIncomplete
+	}
+	api.startRedirectServer()
+	return api.httpSrv.ServeTLS(listener, api.tls.certFile, api.tls.keyFile)
+}
+
+func (api *TaskAPI) shutdownRedirectServer(ctx context.Context) {
+	if api.redirectSrv == nil {
+		return
+	}
+	if err := api.redirectSrv.Shutdown(ctx); err != nil {
+		log.Println("[ERROR] Ошибка остановки сервера перенаправления HTTP -> HTTPS:", err)
+	}
+}
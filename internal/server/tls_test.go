@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		enabled  bool
+		autocert bool
+	}{
+		{name: "plain http", cfg: Config{}, enabled: false},
+		{name: "static certificate", cfg: Config{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"}, enabled: true},
+		{name: "autocert", cfg: Config{TLSAutocertHosts: "tasks.example.com, api.example.com"}, enabled: true, autocert: true},
+		{name: "blank hosts", cfg: Config{TLSAutocertHosts: " , "}, enabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := newTLSOptions(&tt.cfg)
+			assert.Equal(t, tt.enabled, opts.enabled())
+			assert.Equal(t, tt.autocert, opts.manager != nil)
+		})
+	}
+}
+
+func TestAutocertHostWhitelist(t *testing.T) {
+	opts := newTLSOptions(&Config{TLSAutocertHosts: "Tasks.Example.com", TLSAutocertCacheDir: t.TempDir()})
+	require.NotNil(t, opts.manager)
+
+	assert.NoError(t, opts.manager.HostPolicy(context.Background(), "tasks.example.com"))
+	assert.Error(t, opts.manager.HostPolicy(context.Background(), "evil.example.com"))
+}
+
+func TestTLSRedirectHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		port     int
+		host     string
+		target   string
+		expected string
+	}{
+		{name: "default https port", port: 443, host: "tasks.example.com", target: "/tasks?page=2", expected: "https://tasks.example.com/tasks?page=2"},
+		{name: "strips http port", port: 443, host: "tasks.example.com:80", target: "/health", expected: "https://tasks.example.com/health"},
+		{name: "custom https port", port: 8443, host: "tasks.example.com:8080", target: "/", expected: "https://tasks.example.com:8443/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := newTLSOptions(&Config{Port: tt.port, TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"})
+
+			req := httptest.NewRequest("GET", tt.target, nil)
+			req.Host = tt.host
+			w := httptest.NewRecorder()
+			opts.redirectHandler().ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusMovedPermanently, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestStartRejectsIncompleteKeyPair(t *testing.T) {
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{TLSCertFile: "cert.pem"})
+
+	assert.Equal(t, errors.ErrTLSKeyPairIncomplete, api.Start())
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"project/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func configureTransport(srv *http.Server, cfg *Config) {
+	srv.ReadTimeout = cfg.HTTPReadTimeout
+	srv.ReadHeaderTimeout = cfg.HTTPReadHeaderTimeout
+	if srv.ReadHeaderTimeout <= 0 {
+		srv.ReadHeaderTimeout = defaultHTTPReadHeaderTimeout
+	}
+	srv.WriteTimeout = cfg.HTTPWriteTimeout
+	srv.IdleTimeout = cfg.HTTPIdleTimeout
+	srv.MaxHeaderBytes = cfg.HTTPMaxHeaderBytes
+	srv.SetKeepAlivesEnabled(!cfg.HTTPKeepAlivesDisabled)
+}
+
+func clearDeadlines(ctx *gin.Context) {
+	rc := http.NewResponseController(ctx.Writer)
+	for _, set := range []func(time.Time) error{rc.SetReadDeadline, rc.SetWriteDeadline} {
+		if err := set(time.Time{}); err != nil && !stderrors.Is(err, http.ErrNotSupported) {
+			requestid.Println(ctx.Request.Context(), "[WARN] Не удалось снять таймаут соединения для потоковой передачи:", err)
+		}
+	}
+}
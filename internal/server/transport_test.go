@@ -0,0 +1,125 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureTransport(t *testing.T) {
+	type limits struct {
+		read, readHeader, write, idle time.Duration
+		maxHeaderBytes                int
+	}
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		want      limits
+		keepAlive bool
+	}{
+		{
+			name:      "zero config keeps header timeout",
+			want:      limits{readHeader: defaultHTTPReadHeaderTimeout},
+			keepAlive: true,
+		},
+		{
+			name: "configured",
+			cfg: Config{
+				HTTPReadTimeout:       20 * time.Second,
+				HTTPReadHeaderTimeout: 5 * time.Second,
+				HTTPWriteTimeout:      time.Minute,
+				HTTPIdleTimeout:       90 * time.Second,
+				HTTPMaxHeaderBytes:    65536,
+			},
+			want: limits{
+				read:           20 * time.Second,
+				readHeader:     5 * time.Second,
+				write:          time.Minute,
+				idle:           90 * time.Second,
+				maxHeaderBytes: 65536,
+			},
+			keepAlive: true,
+		},
+		{
+			name: "keep-alives disabled",
+			cfg:  Config{HTTPKeepAlivesDisabled: true},
+			want: limits{readHeader: defaultHTTPReadHeaderTimeout},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			configureTransport(srv.Config, &tt.cfg)
+			srv.Start()
+			defer srv.Close()
+
+			got := limits{
+				read:           srv.Config.ReadTimeout,
+				readHeader:     srv.Config.ReadHeaderTimeout,
+				write:          srv.Config.WriteTimeout,
+				idle:           srv.Config.IdleTimeout,
+				maxHeaderBytes: srv.Config.MaxHeaderBytes,
+			}
+			assert.Equal(t, tt.want, got)
+
+			resp, err := srv.Client().Get(srv.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, !tt.keepAlive, resp.Close)
+		})
+	}
+}
+
+func TestClearDeadlines(t *testing.T) {
+	tests := []struct {
+		name  string
+		clear bool
+	}{
+		{name: "write timeout cuts slow response"},
+		{name: "cleared deadline lets stream finish", clear: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(GzipResponseCompress())
+			router.GET("/stream", func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+				if tt.clear {
+					clearDeadlines(ctx)
+				}
+				time.Sleep(150 * time.Millisecond)
+				_, _ = ctx.Writer.WriteString("done")
+			})
+
+			srv := httptest.NewUnstartedServer(router)
+			configureTransport(srv.Config, &Config{HTTPWriteTimeout: 50 * time.Millisecond})
+			srv.Start()
+			defer srv.Close()
+
+			resp, err := srv.Client().Get(srv.URL + "/stream")
+			if !tt.clear {
+				if err == nil {
+					_, err = io.ReadAll(resp.Body)
+					resp.Body.Close()
+				}
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "done", string(body))
+		})
+	}
+}
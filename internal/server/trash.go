@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) getTrash(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	tasks, err := api.storage.GetTrash(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks})
+}
+
+func (api *TaskAPI) restoreTask(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	id := ctx.Param("taskID")
+	trash, err := api.storage.GetTrash(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	var deleted *models.Task
+	for i := range trash {
+		if trash[i].ID == id {
+			deleted = &trash[i]
+			break
+		}
+	}
+	if deleted == nil {
+		respondError(ctx, http.StatusNotFound, errors.ErrTaskNotInTrash)
+		return
+	}
+	if err := api.storage.RestoreTask(ctx.Request.Context(), id); err != nil {
+		if err == errors.ErrTaskNotInTrash {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotInTrash)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	restored := *deleted
+	restored.Deleted = false
+	restored.DeletedAt = nil
+	api.recordTaskEvent(ctx.Request.Context(), userID, models.TaskEventUpdate, deleted, &restored)
+	ctx.JSON(http.StatusOK, gin.H{"message": "задача восстановлена"})
+}
+
+func (api *TaskAPI) purgeTask(ctx *gin.Context, userID, id string) {
+	task, err := api.storage.GetTaskByID(ctx.Request.Context(), id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	if task.UserID != userID {
+		user, err := api.storage.GetUserByID(ctx.Request.Context(), userID)
+		if err != nil || user.Role != models.RoleAdmin {
+			respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+			return
+		}
+	}
+	if err := api.storage.HardDeleteTask(ctx.Request.Context(), id); err != nil {
+		if err == errors.ErrNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrTaskNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "задача удалена безвозвратно"})
+}
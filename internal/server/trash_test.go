@@ -0,0 +1,160 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrashHandlers(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	trashed := []models.Task{{ID: "task1", Title: "Old", Status: "new", UserID: "user123", Deleted: true, DeletedAt: &deletedAt}}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "list trash",
+			method:     "GET",
+			path:       "/tasks/trash",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTrash", mock.Anything, "user123").Return(trashed, nil)
+			},
+		},
+		{
+			name:       "restore task from trash",
+			method:     "POST",
+			path:       "/tasks/task1/restore",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTrash", mock.Anything, "user123").Return(trashed, nil)
+				m.On("RestoreTask", mock.Anything, "task1").Return(nil)
+			},
+		},
+		{
+			name:       "restore task that is not in own trash",
+			method:     "POST",
+			path:       "/tasks/task2/restore",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTrash", mock.Anything, "user123").Return(trashed, nil)
+			},
+		},
+		{
+			name:       "trash storage error",
+			method:     "GET",
+			path:       "/tasks/trash",
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTrash", mock.Anything, "user123").Return(nil, errors.ErrInternalServer)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.path == "/tasks/trash" && tt.statusCode == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"deleted_at":"2025-01-01T12:00:00Z"`)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestForceDeleteTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		statusCode int
+		mockSetup  func(*MockUserStore, *MockTaskStore)
+	}{
+		{
+			name:       "owner purges task",
+			path:       "/tasks/task1?force=true",
+			statusCode: http.StatusOK,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123"}, nil)
+				m.On("HardDeleteTask", mock.Anything, "task1").Return(nil)
+			},
+		},
+		{
+			name:       "admin purges foreign task",
+			path:       "/tasks/task2?force=true",
+			statusCode: http.StatusOK,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task2").Return(&models.Task{ID: "task2", UserID: "user456", Deleted: true}, nil)
+				r.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "admin"}, nil)
+				m.On("HardDeleteTask", mock.Anything, "task2").Return(nil)
+			},
+		},
+		{
+			name:       "regular user cannot purge foreign task",
+			path:       "/tasks/task2?force=true",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task2").Return(&models.Task{ID: "task2", UserID: "user456"}, nil)
+				r.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "user"}, nil)
+			},
+		},
+		{
+			name:       "purge missing task",
+			path:       "/tasks/missing?force=true",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(r *MockUserStore, m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "missing").Return(nil, errors.ErrNotFound)
+			},
+		},
+		{
+			name:       "invalid force value",
+			path:       "/tasks/task1?force=maybe",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(r *MockUserStore, m *MockTaskStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockRepo, mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, mockTaskRepo}, &Config{})
+
+			req, _ := http.NewRequest("DELETE", tt.path, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockRepo.AssertExpectations(t)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
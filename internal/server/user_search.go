@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"project/internal/domain/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	userSearchMinQuery        = 2
+	userSearchMaxQuery        = 100
+	userSearchLimit           = 10
+	userSearchPrivilegedLimit = 50
+)
+
+var userSearchPrivilegedRoles = map[string]bool{
+	"admin":     true,
+	"moderator": true,
+}
+
+func (api *TaskAPI) searchUsers(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	query := strings.TrimSpace(ctx.Query("q"))
+	if n := utf8.RuneCountInString(query); n < userSearchMinQuery || n > userSearchMaxQuery {
+		respondError(ctx, http.StatusBadRequest, errors.ErrUserSearchQuery)
+		return
+	}
+	requester, err := api.storage.GetUserByID(ctx.Request.Context(), userID)
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	privileged := userSearchPrivilegedRoles[requester.Role]
+	limit := userSearchLimit
+	if privileged {
+		limit = userSearchPrivilegedLimit
+	}
+
+	users, err := api.storage.SearchUsers(ctx.Request.Context(), query, limit)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	results := make([]gin.H, 0, len(users))
+	for _, user := range users {
+		result := gin.H{"id": user.ID, "username": user.Username}
+		if privileged {
+			result["email"] = user.Email
+			result["role"] = user.Role
+		}
+		results = append(results, result)
+	}
+	ctx.JSON(http.StatusOK, gin.H{"users": results})
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUsers(t *testing.T) {
+	found := []models.User{
+		{ID: "user456", Username: "alexey", Email: "alexey@example.com", Password: "hash", Role: "user"},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		mockSetup  func(*MockUserStore)
+		want       []map[string]string
+	}{
+		{
+			name:       "regular user sees usernames only",
+			query:      "?q=ale",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "user"}, nil)
+				m.On("SearchUsers", "ale", userSearchLimit).Return(found, nil)
+			},
+			want: []map[string]string{{"id": "user456", "username": "alexey"}},
+		},
+		{
+			name:       "admin sees emails",
+			query:      "?q=%20ale%20",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "admin"}, nil)
+				m.On("SearchUsers", "ale", userSearchPrivilegedLimit).Return(found, nil)
+			},
+			want: []map[string]string{{"id": "user456", "username": "alexey", "email": "alexey@example.com", "role": "user"}},
+		},
+		{
+			name:       "query too short",
+			query:      "?q=a",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore) {},
+		},
+		{
+			name:       "missing query",
+			query:      "",
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockUserStore) {},
+		},
+		{
+			name:       "repository error",
+			query:      "?q=ale",
+			statusCode: http.StatusInternalServerError,
+			mockSetup: func(m *MockUserStore) {
+				m.On("GetUserByID", "user123").Return(&models.User{ID: "user123", Role: "user"}, nil)
+				m.On("SearchUsers", "ale", userSearchLimit).Return(nil, errors.ErrDatabaseConnection)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockRepo := &MockUserStore{}
+			tt.mockSetup(mockRepo)
+
+			api := NewTaskAPI(&MockStorage{mockRepo, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest("GET", "/users/search"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.want != nil {
+				var resp struct {
+					Users []map[string]string `json:"users"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.want, resp.Users)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchUsersUnauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+	req, _ := http.NewRequest("GET", "/users/search?q=ale", nil)
+	w := httptest.NewRecorder()
+	api.httpSrv.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+)
+
+var statusPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+var fieldMessages = map[string]string{
+	"required": "обязательное поле",
+	"min":      "значение меньше минимального (%s)",
+	"max":      "значение больше максимального (%s)",
+	"email":    "некорректный адрес электронной почты",
+	"url":      "некорректный URL",
+	"uuid":     "ожидается UUID",
+	"alphanum": "допустимы только буквы и цифры",
+	"oneof":    "допустимые значения: %s",
+	"status":   "некорректное название статуса",
+	"role":     "недопустимая роль пользователя",
+}
+
+var requestValidator = &structValidator{validate: newValidator()}
+
+type normalizer interface {
+	Normalize()
+}
+
+type structValidator struct {
+	validate *validator.Validate
+}
+
+func (v *structValidator) ValidateStruct(obj interface{}) error {
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	if n, ok := obj.(normalizer); ok {
+		n.Normalize()
+	}
+	return v.validate.Struct(obj)
+}
+
+func ValidateRequest(obj interface{}) error {
+	return requestValidator.ValidateStruct(obj)
+}
+
+func (v *structValidator) Engine() interface{} {
+	return v.validate
+}
+
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+	_ = validate.RegisterValidation("status", func(fl validator.FieldLevel) bool {
+		return statusPattern.MatchString(fl.Field().String())
+	})
+	_ = validate.RegisterValidation("role", func(fl validator.FieldLevel) bool {
+		return models.IsUserRole(fl.Field().String())
+	})
+	return validate
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return snakeCase(field.Name)
+	}
+	return name
+}
+
+func fieldMessage(rule, param string) string {
+	message, ok := fieldMessages[rule]
+	if !ok {
+		return "некорректное значение"
+	}
+	if strings.Contains(message, "%s") {
+		return fmt.Sprintf(message, param)
+	}
+	return message
+}
+
+func fieldPath(namespace string) string {
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func bindAndValidate(ctx *gin.Context, obj interface{}) bool {
+	err := bindRequest(ctx, obj)
+	if err == nil {
+		return true
+	}
+	if _, ok := err.(validator.ValidationErrors); ok {
+		respondValidationError(ctx, errors.ErrValidationFailed, err)
+	} else {
+		respondError(ctx, http.StatusBadRequest, errors.ErrBadRequest)
+	}
+	return false
+}
+
+func uuidParams(names ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var details []models.FieldError
+		for _, name := range names {
+			value := ctx.Param(name)
+			if value == "" {
+				continue
+			}
+			if requestValidator.validate.Var(value, "uuid") != nil {
+				details = append(details, models.FieldError{Field: snakeCase(name), Rule: "uuid", Message: fieldMessage("uuid", "")})
+			}
+		}
+		if len(details) > 0 {
+			respondErrorDetails(ctx, http.StatusBadRequest, errors.ErrValidationFailed, details)
+			return
+		}
+		ctx.Next()
+	}
+}
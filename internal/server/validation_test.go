@@ -0,0 +1,162 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     interface{}
+		details []models.FieldError
+	}{
+		{
+			name: "valid workflow",
+			req:  &models.WorkflowRequest{Statuses: []string{"todo", "in_review"}, InitialStatus: "todo"},
+		},
+		{
+			name: "invalid status name",
+			req:  &models.WorkflowRequest{Statuses: []string{"todo", "In Review"}, InitialStatus: "todo"},
+			details: []models.FieldError{
+				{Field: "statuses[1]", Rule: "status", Message: "некорректное название статуса"},
+			},
+		},
+		{
+			name: "unknown role",
+			req:  &models.User{ID: "2b1f0c3e-8f1a-4a57-9d1c-3b7f2e6a9c10", Username: "alexey", Email: "alexey@example.com", Password: "secret123", Role: "root"},
+			details: []models.FieldError{
+				{Field: "role", Rule: "role", Message: "недопустимая роль пользователя"},
+			},
+		},
+		{
+			name: "nested field path",
+			req:  &models.BatchCreateTasksRequest{Tasks: []models.CreateTaskRequest{{Title: "First"}, {ReminderOffsetMinutes: -1}}},
+			details: []models.FieldError{
+				{Field: "tasks[1].title", Rule: "required", Message: "обязательное поле"},
+				{Field: "tasks[1].reminder_offset_minutes", Rule: "min", Message: "значение меньше минимального (0)"},
+			},
+		},
+		{
+			name: "normalized before validation",
+			req:  &models.RegisterRequest{Username: "  Alexey ", Email: " Alexey@Example.com", Password: "secret123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requestValidator.ValidateStruct(tt.req)
+			if tt.details == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.details, validationDetails(err))
+		})
+	}
+
+	req := &models.RegisterRequest{Username: "  Alexey ", Email: " Alexey@Example.com", Password: "secret123"}
+	require.NoError(t, requestValidator.ValidateStruct(req))
+	assert.Equal(t, "alexey", req.Username)
+	assert.Equal(t, "alexey@example.com", req.Email)
+}
+
+func TestValidationResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		statusCode int
+		code       string
+		field      string
+	}{
+		{
+			name:       "malformed body",
+			method:     "POST",
+			path:       "/tags",
+			body:       `{"name":`,
+			statusCode: http.StatusBadRequest,
+			code:       "bad_request",
+		},
+		{
+			name:       "field violation",
+			method:     "POST",
+			path:       "/tags",
+			body:       `{"name":""}`,
+			statusCode: http.StatusBadRequest,
+			code:       "validation_failed",
+			field:      "name",
+		},
+		{
+			name:       "invalid uuid param",
+			method:     "GET",
+			path:       "/workspaces/not-a-uuid",
+			statusCode: http.StatusBadRequest,
+			code:       "validation_failed",
+			field:      "workspace_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			var response struct {
+				Error struct {
+					Code    string              `json:"code"`
+					Details []models.FieldError `json:"details"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Error.Code)
+			if tt.field == "" {
+				assert.Empty(t, response.Error.Details)
+				return
+			}
+			if assert.Len(t, response.Error.Details, 1) {
+				assert.Equal(t, tt.field, response.Error.Details[0].Field)
+				assert.NotEmpty(t, response.Error.Details[0].Message)
+			}
+		})
+	}
+}
+
+func BenchmarkValidateRequest(b *testing.B) {
+	req := models.RegisterRequest{Username: "benchuser", Email: "bench@example.com", Password: "password123"}
+
+	b.Run("shared validator", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := requestValidator.ValidateStruct(&req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("validator per request", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v := &structValidator{validate: newValidator()}
+			if err := v.ValidateStruct(&req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
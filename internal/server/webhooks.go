@@ -0,0 +1,146 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) dispatchWebhooks(action string, before, after *models.Task) {
+	if api.webhooks == nil || !api.FeatureEnabled(FeatureWebhooks) {
+		return
+	}
+	switch action {
+	case models.TaskEventCreate:
+		if after != nil {
+			api.webhooks.Dispatch(after.UserID, models.WebhookEventTaskCreated, *after)
+		}
+	case models.TaskEventUpdate:
+		if after == nil {
+			return
+		}
+		api.webhooks.Dispatch(after.UserID, models.WebhookEventTaskUpdated, *after)
+		if after.Status == "done" && (before == nil || before.Status != "done") {
+			api.webhooks.Dispatch(after.UserID, models.WebhookEventTaskCompleted, *after)
+		}
+	case models.TaskEventDelete:
+		if before != nil {
+			api.webhooks.Dispatch(before.UserID, models.WebhookEventTaskDeleted, *before)
+		}
+	}
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func (api *TaskAPI) loadOwnWebhook(ctx *gin.Context, userID, webhookID string) (*models.Webhook, bool) {
+	hook, err := api.storage.GetWebhookByID(ctx.Request.Context(), webhookID)
+	if err != nil {
+		if err == errors.ErrWebhookNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrWebhookNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return nil, false
+	}
+	if hook.UserID != userID {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return nil, false
+	}
+	return hook, true
+}
+
+func (api *TaskAPI) getWebhooks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	hooks, err := api.storage.GetWebhooks(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	ctx.JSON(http.StatusOK, gin.H{"webhooks": hooks})
+}
+
+func (api *TaskAPI) createWebhook(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.WebhookRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			respondInternalError(ctx, err)
+			return
+		}
+	}
+	hook := models.Webhook{UserID: userID, URL: req.URL, Secret: secret, Events: req.Events}
+	if hook.Events == nil {
+		hook.Events = []string{}
+	}
+	if err := api.storage.CreateWebhook(ctx.Request.Context(), &hook); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, hook)
+}
+
+func (api *TaskAPI) deleteWebhook(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	hook, ok := api.loadOwnWebhook(ctx, userID, ctx.Param("webhookID"))
+	if !ok {
+		return
+	}
+	if err := api.storage.DeleteWebhook(ctx.Request.Context(), hook.ID); err != nil {
+		if err == errors.ErrWebhookNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrWebhookNotFound)
+			return
+		}
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "вебхук успешно удален"})
+}
+
+func (api *TaskAPI) getWebhookDeliveries(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	hook, ok := api.loadOwnWebhook(ctx, userID, ctx.Param("webhookID"))
+	if !ok {
+		return
+	}
+	deliveries, err := api.storage.GetWebhookDeliveries(ctx.Request.Context(), hook.ID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
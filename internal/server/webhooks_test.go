@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type recordingDispatcher struct {
+	events []string
+	users  []string
+}
+
+func (d *recordingDispatcher) Dispatch(userID, event string, task models.Task) {
+	d.users = append(d.users, userID)
+	d.events = append(d.events, event)
+}
+
+func TestWebhookHandlers(t *testing.T) {
+	ownHook := &models.Webhook{ID: "hook1", UserID: "user123", URL: "https://example.com/hook", Secret: "supersecretvalue1"}
+	foreignHook := &models.Webhook{ID: "hook2", UserID: "user456", URL: "https://example.com/other", Secret: "supersecretvalue2"}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+		check      func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "list webhooks hides secrets",
+			method:     "GET",
+			path:       "/webhooks",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWebhooks", mock.Anything, "user123").Return([]models.Webhook{*ownHook}, nil)
+			},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.NotContains(t, w.Body.String(), "supersecretvalue1")
+			},
+		},
+		{
+			name:       "create webhook generates secret",
+			method:     "POST",
+			path:       "/webhooks",
+			body:       models.WebhookRequest{URL: "https://example.com/hook", Events: []string{models.WebhookEventTaskCompleted}},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(hook *models.Webhook) bool {
+					return hook.UserID == "user123" && len(hook.Secret) == 64 && hook.Events[0] == models.WebhookEventTaskCompleted
+				})).Return(nil)
+			},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var hook models.Webhook
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &hook))
+				assert.Len(t, hook.Secret, 64)
+			},
+		},
+		{
+			name:       "create webhook with invalid url",
+			method:     "POST",
+			path:       "/webhooks",
+			body:       models.WebhookRequest{URL: "not a url"},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "create webhook with unknown event",
+			method:     "POST",
+			path:       "/webhooks",
+			body:       models.WebhookRequest{URL: "https://example.com/hook", Events: []string{"task.exploded"}},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "delete webhook",
+			method:     "DELETE",
+			path:       "/webhooks/hook1",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWebhookByID", mock.Anything, "hook1").Return(ownHook, nil)
+				m.On("DeleteWebhook", mock.Anything, "hook1").Return(nil)
+			},
+		},
+		{
+			name:       "delete foreign webhook",
+			method:     "DELETE",
+			path:       "/webhooks/hook2",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWebhookByID", mock.Anything, "hook2").Return(foreignHook, nil)
+			},
+		},
+		{
+			name:       "deliveries of missing webhook",
+			method:     "GET",
+			path:       "/webhooks/missing/deliveries",
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWebhookByID", mock.Anything, "missing").Return(nil, errors.ErrWebhookNotFound)
+			},
+		},
+		{
+			name:       "deliveries of own webhook",
+			method:     "GET",
+			path:       "/webhooks/hook1/deliveries",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWebhookByID", mock.Anything, "hook1").Return(ownHook, nil)
+				m.On("GetWebhookDeliveries", mock.Anything, "hook1").Return([]models.WebhookDelivery{{ID: "d1", WebhookID: "hook1", Attempt: 1, Success: true}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.check != nil {
+				tt.check(t, w)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDispatchWebhooks(t *testing.T) {
+	task := models.Task{ID: "task1", UserID: "owner", Status: "in_progress"}
+	done := models.Task{ID: "task1", UserID: "owner", Status: "done"}
+
+	tests := []struct {
+		name   string
+		action string
+		before *models.Task
+		after  *models.Task
+		want   []string
+	}{
+		{name: "created", action: models.TaskEventCreate, after: &task, want: []string{models.WebhookEventTaskCreated}},
+		{name: "updated", action: models.TaskEventUpdate, before: &task, after: &task, want: []string{models.WebhookEventTaskUpdated}},
+		{name: "completed", action: models.TaskEventUpdate, before: &task, after: &done, want: []string{models.WebhookEventTaskUpdated, models.WebhookEventTaskCompleted}},
+		{name: "already done", action: models.TaskEventUpdate, before: &done, after: &done, want: []string{models.WebhookEventTaskUpdated}},
+		{name: "deleted", action: models.TaskEventDelete, before: &task, want: []string{models.WebhookEventTaskDeleted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := &recordingDispatcher{}
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, &MockTaskStore{}}, &Config{})
+			api.SetWebhookDispatcher(dispatcher)
+
+			api.recordTaskEvent(t.Context(), "actor", tt.action, tt.before, tt.after)
+
+			assert.Equal(t, tt.want, dispatcher.events)
+			for _, user := range dispatcher.users {
+				assert.Equal(t, "owner", user)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (api *TaskAPI) loadWorkflow(ctx context.Context, userID string) (models.Workflow, error) {
+	workflow, err := api.storage.GetWorkflow(ctx, userID)
+	if err == errors.ErrWorkflowNotFound {
+		return models.DefaultWorkflow(userID), nil
+	}
+	if err != nil {
+		return models.Workflow{}, internalError(err)
+	}
+	return *workflow, nil
+}
+
+func (api *TaskAPI) initialStatus(ctx context.Context, userID string) (string, error) {
+	workflow, err := api.loadWorkflow(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return workflow.InitialStatus, nil
+}
+
+func (api *TaskAPI) checkStatusChange(ctx context.Context, task *models.Task, status string) error {
+	workflow, err := api.loadWorkflow(ctx, task.UserID)
+	if err != nil {
+		return err
+	}
+	if !workflow.HasStatus(status) {
+		return errors.ErrTaskStatus
+	}
+	if !workflow.CanTransition(task.Status, status) {
+		return errors.ErrTaskTransition
+	}
+	return nil
+}
+
+func respondStatusError(ctx *gin.Context, err error) {
+	switch err {
+	case errors.ErrInternalServer:
+		respondError(ctx, http.StatusInternalServerError, err)
+	case errors.ErrQueryTimeout:
+		respondError(ctx, http.StatusGatewayTimeout, err)
+	case errors.ErrDatabaseUnavailable:
+		respondError(ctx, http.StatusServiceUnavailable, err)
+	default:
+		respondError(ctx, http.StatusBadRequest, err)
+	}
+}
+
+func validateWorkflow(workflow models.Workflow) error {
+	seen := make(map[string]bool, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		if seen[status] {
+			return errors.ErrWorkflowInvalid
+		}
+		seen[status] = true
+	}
+	if !seen[models.StatusDone] {
+		return errors.ErrWorkflowDoneRequired
+	}
+	if !seen[workflow.InitialStatus] {
+		return errors.ErrWorkflowInvalid
+	}
+	for from, targets := range workflow.Transitions {
+		if !seen[from] {
+			return errors.ErrWorkflowInvalid
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return errors.ErrWorkflowInvalid
+			}
+		}
+	}
+	return nil
+}
+
+func (api *TaskAPI) getWorkflow(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	workflow, err := api.loadWorkflow(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workflow": workflow})
+}
+
+func (api *TaskAPI) updateWorkflow(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.WorkflowRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	workflow := models.Workflow{UserID: userID, Statuses: req.Statuses, InitialStatus: req.InitialStatus, Transitions: req.Transitions}
+	if workflow.Transitions == nil {
+		workflow.Transitions = map[string][]string{}
+	}
+	if err := validateWorkflow(workflow); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if err := api.storage.SaveWorkflow(ctx.Request.Context(), &workflow); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workflow": workflow})
+}
+
+func (api *TaskAPI) resetWorkflow(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	if err := api.storage.DeleteWorkflow(ctx.Request.Context(), userID); err != nil && err != errors.ErrWorkflowNotFound {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workflow": models.DefaultWorkflow(userID)})
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkflowHandlers(t *testing.T) {
+	custom := models.Workflow{
+		UserID:        "user123",
+		Statuses:      []string{"backlog", "review", "done"},
+		InitialStatus: "backlog",
+		Transitions:   map[string][]string{"backlog": {"review"}, "review": {"backlog", "done"}},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		workflows  map[string]models.Workflow
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+		check      func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "get default workflow",
+			method:     "GET",
+			path:       "/workflow",
+			statusCode: http.StatusOK,
+			mockSetup:  func(m *MockTaskStore) {},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp struct {
+					Workflow models.Workflow `json:"workflow"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, []string{"new", "in_progress", "done"}, resp.Workflow.Statuses)
+				assert.Equal(t, "new", resp.Workflow.InitialStatus)
+			},
+		},
+		{
+			name:       "get custom workflow",
+			method:     "GET",
+			path:       "/workflow",
+			workflows:  map[string]models.Workflow{"user123": custom},
+			statusCode: http.StatusOK,
+			mockSetup:  func(m *MockTaskStore) {},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp struct {
+					Workflow models.Workflow `json:"workflow"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, custom, resp.Workflow)
+			},
+		},
+		{
+			name:   "save workflow",
+			method: "PUT",
+			path:   "/workflow",
+			body: models.WorkflowRequest{
+				Statuses:      custom.Statuses,
+				InitialStatus: custom.InitialStatus,
+				Transitions:   custom.Transitions,
+			},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("SaveWorkflow", mock.Anything, &custom).Return(nil)
+			},
+		},
+		{
+			name:       "workflow without done",
+			method:     "PUT",
+			path:       "/workflow",
+			body:       models.WorkflowRequest{Statuses: []string{"backlog", "review"}, InitialStatus: "backlog"},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), errors.ErrWorkflowDoneRequired.Error())
+			},
+		},
+		{
+			name:   "workflow with unknown transition target",
+			method: "PUT",
+			path:   "/workflow",
+			body: models.WorkflowRequest{
+				Statuses:      []string{"backlog", "done"},
+				InitialStatus: "backlog",
+				Transitions:   map[string][]string{"backlog": {"archived"}},
+			},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "workflow with unknown initial status",
+			method:     "PUT",
+			path:       "/workflow",
+			body:       models.WorkflowRequest{Statuses: []string{"backlog", "done"}, InitialStatus: "todo"},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "reset workflow",
+			method:     "DELETE",
+			path:       "/workflow",
+			workflows:  map[string]models.Workflow{"user123": custom},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("DeleteWorkflow", mock.Anything, "user123").Return(nil)
+			},
+		},
+		{
+			name:       "create task uses initial status",
+			method:     "POST",
+			path:       "/tasks",
+			body:       models.CreateTaskRequest{Title: "Task"},
+			workflows:  map[string]models.Workflow{"user123": custom},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Status == "backlog"
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "transition allowed by workflow",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Status: "review"},
+			workflows:  map[string]models.Workflow{"user123": custom},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123", Status: "backlog"}, nil)
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "transition rejected by workflow",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Status: "done"},
+			workflows:  map[string]models.Workflow{"user123": custom},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123", Status: "backlog"}, nil)
+			},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), errors.ErrTaskTransition.Error())
+			},
+		},
+		{
+			name:       "status unknown to workflow",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Status: "in_progress"},
+			workflows:  map[string]models.Workflow{"user123": custom},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", UserID: "user123", Status: "backlog"}, nil)
+			},
+			check: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), errors.ErrTaskStatus.Error())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{workflows: tt.workflows}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.check != nil {
+				tt.check(t, w)
+			}
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var workspaceRoleRank = map[string]int{
+	models.WorkspaceRoleViewer: 1,
+	models.WorkspaceRoleMember: 2,
+	models.WorkspaceRoleAdmin:  3,
+	models.WorkspaceRoleOwner:  4,
+}
+
+func hasWorkspaceRole(role, minRole string) bool {
+	return workspaceRoleRank[role] > 0 && workspaceRoleRank[role] >= workspaceRoleRank[minRole]
+}
+
+func (api *TaskAPI) checkWorkspace(ctx context.Context, userID, workspaceID, minRole string) (string, error) {
+	role, err := api.storage.GetWorkspaceRole(ctx, workspaceID, userID)
+	if err != nil {
+		return "", internalError(err)
+	}
+	if role == "" {
+		return "", errors.ErrWorkspaceNotFound
+	}
+	if !hasWorkspaceRole(role, minRole) {
+		return role, errors.ErrForbidden
+	}
+	return role, nil
+}
+
+func (api *TaskAPI) checkTaskWorkspace(ctx context.Context, userID, workspaceID string) error {
+	if workspaceID == "" {
+		return nil
+	}
+	_, err := api.checkWorkspace(ctx, userID, workspaceID, models.WorkspaceRoleMember)
+	return err
+}
+
+func (api *TaskAPI) validateWorkspace(ctx *gin.Context, userID, workspaceID string) bool {
+	if err := api.checkTaskWorkspace(ctx.Request.Context(), userID, workspaceID); err != nil {
+		respondWorkspaceError(ctx, err)
+		return false
+	}
+	return true
+}
+
+func respondWorkspaceError(ctx *gin.Context, err error) {
+	switch err {
+	case errors.ErrWorkspaceNotFound, errors.ErrUserNotFound, errors.ErrNotWorkspaceMember:
+		respondError(ctx, http.StatusNotFound, err)
+	case errors.ErrForbidden:
+		respondError(ctx, http.StatusForbidden, err)
+	default:
+		respondStatusError(ctx, err)
+	}
+}
+
+func (api *TaskAPI) loadWorkspace(ctx *gin.Context, userID, minRole string) (*models.Workspace, bool) {
+	workspaceID := ctx.Param("workspaceID")
+	role, err := api.checkWorkspace(ctx.Request.Context(), userID, workspaceID, minRole)
+	if err != nil {
+		respondWorkspaceError(ctx, err)
+		return nil, false
+	}
+	workspace, err := api.storage.GetWorkspaceByID(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		if err == errors.ErrWorkspaceNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrWorkspaceNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return nil, false
+	}
+	workspace.Role = role
+	return workspace, true
+}
+
+func bindWorkspaceRequest(ctx *gin.Context) (*models.WorkspaceRequest, bool) {
+	var req models.WorkspaceRequest
+	if !bindAndValidate(ctx, &req) {
+		return nil, false
+	}
+	return &req, true
+}
+
+func (api *TaskAPI) getWorkspaces(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	workspaces, err := api.storage.GetWorkspaces(ctx.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workspaces": workspaces})
+}
+
+func (api *TaskAPI) createWorkspace(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindWorkspaceRequest(ctx)
+	if !ok {
+		return
+	}
+	workspace := models.Workspace{Name: req.Name, OwnerID: userID}
+	if err := api.storage.CreateWorkspace(ctx.Request.Context(), &workspace); err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"workspace": workspace})
+}
+
+func (api *TaskAPI) getWorkspace(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, models.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workspace": workspace})
+}
+
+func (api *TaskAPI) updateWorkspace(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	req, ok := bindWorkspaceRequest(ctx)
+	if !ok {
+		return
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, models.WorkspaceRoleAdmin)
+	if !ok {
+		return
+	}
+	workspace.Name = req.Name
+	if err := api.storage.UpdateWorkspace(ctx.Request.Context(), workspace.ID, workspace); err != nil {
+		if err == errors.ErrWorkspaceNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrWorkspaceNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workspace": workspace})
+}
+
+func (api *TaskAPI) deleteWorkspace(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, models.WorkspaceRoleOwner)
+	if !ok {
+		return
+	}
+	if err := api.storage.DeleteWorkspace(ctx.Request.Context(), workspace.ID); err != nil {
+		if err == errors.ErrWorkspaceNotFound {
+			respondError(ctx, http.StatusNotFound, errors.ErrWorkspaceNotFound)
+		} else {
+			respondInternalError(ctx, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "рабочее пространство успешно удалено"})
+}
+
+func (api *TaskAPI) getWorkspaceMembers(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, models.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+	members, err := api.storage.GetWorkspaceMembers(ctx.Request.Context(), workspace.ID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+func (api *TaskAPI) setWorkspaceMember(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	var req models.WorkspaceMemberRequest
+	if !bindAndValidate(ctx, &req) {
+		return
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, models.WorkspaceRoleAdmin)
+	if !ok {
+		return
+	}
+	memberID := ctx.Param("userID")
+	if memberID == workspace.OwnerID {
+		respondError(ctx, http.StatusBadRequest, errors.ErrWorkspaceOwner)
+		return
+	}
+	current, err := api.storage.GetWorkspaceRole(ctx.Request.Context(), workspace.ID, memberID)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	if (req.Role == models.WorkspaceRoleAdmin || current == models.WorkspaceRoleAdmin) && workspace.Role != models.WorkspaceRoleOwner {
+		respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+		return
+	}
+	member := models.WorkspaceMember{WorkspaceID: workspace.ID, UserID: memberID, Role: req.Role}
+	if err := api.storage.SaveWorkspaceMember(ctx.Request.Context(), &member); err != nil {
+		respondWorkspaceError(ctx, internalWorkspaceError(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"member": member})
+}
+
+func (api *TaskAPI) removeWorkspaceMember(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	memberID := ctx.Param("userID")
+	minRole := models.WorkspaceRoleAdmin
+	if memberID == userID {
+		minRole = models.WorkspaceRoleViewer
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, minRole)
+	if !ok {
+		return
+	}
+	if memberID == workspace.OwnerID {
+		respondError(ctx, http.StatusBadRequest, errors.ErrWorkspaceOwner)
+		return
+	}
+	if memberID != userID && workspace.Role != models.WorkspaceRoleOwner {
+		current, err := api.storage.GetWorkspaceRole(ctx.Request.Context(), workspace.ID, memberID)
+		if err != nil {
+			respondInternalError(ctx, err)
+			return
+		}
+		if current == models.WorkspaceRoleAdmin {
+			respondError(ctx, http.StatusForbidden, errors.ErrForbidden)
+			return
+		}
+	}
+	if err := api.storage.RemoveWorkspaceMember(ctx.Request.Context(), workspace.ID, memberID); err != nil {
+		respondWorkspaceError(ctx, internalWorkspaceError(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "участник исключен из рабочего пространства"})
+}
+
+func internalWorkspaceError(err error) error {
+	switch err {
+	case errors.ErrWorkspaceNotFound, errors.ErrUserNotFound, errors.ErrNotWorkspaceMember:
+		return err
+	}
+	return internalError(err)
+}
+
+func (api *TaskAPI) getWorkspaceTasks(ctx *gin.Context) {
+	userID, err := api.getUserIDFromJWT(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, errors.ErrNotAuthorized)
+		return
+	}
+	workspace, ok := api.loadWorkspace(ctx, userID, models.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+	filter, err := api.parseTaskFilter(ctx, userID)
+	if err != nil {
+		respondWorkspaceError(ctx, err)
+		return
+	}
+	filter.WorkspaceID = workspace.ID
+	tasks, err := api.storage.GetTasks(ctx.Request.Context(), userID, filter)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"workspace": workspace, "tasks": tasks})
+}
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspaceHandlers(t *testing.T) {
+	const workspaceID = "0b6f1c7e-5d0a-4a43-9a53-2f7f0e1c9a11"
+	workspace := func() *models.Workspace {
+		return &models.Workspace{ID: workspaceID, Name: "Team", OwnerID: "owner1"}
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		statusCode int
+		mockSetup  func(*MockTaskStore)
+	}{
+		{
+			name:       "list workspaces",
+			method:     "GET",
+			path:       "/workspaces",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaces", mock.Anything, "user123").Return([]models.Workspace{{ID: workspaceID, Name: "Team", Role: models.WorkspaceRoleMember}}, nil)
+			},
+		},
+		{
+			name:       "create workspace",
+			method:     "POST",
+			path:       "/workspaces",
+			body:       models.WorkspaceRequest{Name: "Team"},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("CreateWorkspace", mock.Anything, &models.Workspace{Name: "Team", OwnerID: "user123"}).Return(nil)
+			},
+		},
+		{
+			name:       "create workspace without name",
+			method:     "POST",
+			path:       "/workspaces",
+			body:       models.WorkspaceRequest{},
+			statusCode: http.StatusBadRequest,
+			mockSetup:  func(m *MockTaskStore) {},
+		},
+		{
+			name:       "get workspace as non-member",
+			method:     "GET",
+			path:       "/workspaces/" + workspaceID,
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return("", nil)
+			},
+		},
+		{
+			name:       "get workspace as viewer",
+			method:     "GET",
+			path:       "/workspaces/" + workspaceID,
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleViewer, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+			},
+		},
+		{
+			name:       "rename workspace as member",
+			method:     "PUT",
+			path:       "/workspaces/" + workspaceID,
+			body:       models.WorkspaceRequest{Name: "Renamed"},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleMember, nil)
+			},
+		},
+		{
+			name:       "rename workspace as admin",
+			method:     "PUT",
+			path:       "/workspaces/" + workspaceID,
+			body:       models.WorkspaceRequest{Name: "Renamed"},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleAdmin, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+				m.On("UpdateWorkspace", mock.Anything, workspaceID, mock.AnythingOfType("*models.Workspace")).Return(nil)
+			},
+		},
+		{
+			name:       "delete workspace as admin",
+			method:     "DELETE",
+			path:       "/workspaces/" + workspaceID,
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleAdmin, nil)
+			},
+		},
+		{
+			name:       "add member as admin",
+			method:     "PUT",
+			path:       "/workspaces/" + workspaceID + "/members/user456",
+			body:       models.WorkspaceMemberRequest{Role: models.WorkspaceRoleMember},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleAdmin, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user456").Return("", nil)
+				m.On("SaveWorkspaceMember", mock.Anything, mock.AnythingOfType("*models.WorkspaceMember")).Return(nil)
+			},
+		},
+		{
+			name:       "grant admin as admin",
+			method:     "PUT",
+			path:       "/workspaces/" + workspaceID + "/members/user456",
+			body:       models.WorkspaceMemberRequest{Role: models.WorkspaceRoleAdmin},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleAdmin, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user456").Return(models.WorkspaceRoleMember, nil)
+			},
+		},
+		{
+			name:       "change owner role",
+			method:     "PUT",
+			path:       "/workspaces/" + workspaceID + "/members/owner1",
+			body:       models.WorkspaceMemberRequest{Role: models.WorkspaceRoleViewer},
+			statusCode: http.StatusBadRequest,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleAdmin, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+			},
+		},
+		{
+			name:       "add missing user",
+			method:     "PUT",
+			path:       "/workspaces/" + workspaceID + "/members/ghost",
+			body:       models.WorkspaceMemberRequest{Role: models.WorkspaceRoleViewer},
+			statusCode: http.StatusNotFound,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleAdmin, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "ghost").Return("", nil)
+				m.On("SaveWorkspaceMember", mock.Anything, mock.AnythingOfType("*models.WorkspaceMember")).Return(errors.ErrUserNotFound)
+			},
+		},
+		{
+			name:       "leave workspace as viewer",
+			method:     "DELETE",
+			path:       "/workspaces/" + workspaceID + "/members/user123",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleViewer, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+				m.On("RemoveWorkspaceMember", mock.Anything, workspaceID, "user123").Return(nil)
+			},
+		},
+		{
+			name:       "remove another member as viewer",
+			method:     "DELETE",
+			path:       "/workspaces/" + workspaceID + "/members/user456",
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleViewer, nil)
+			},
+		},
+		{
+			name:       "list workspace tasks",
+			method:     "GET",
+			path:       "/workspaces/" + workspaceID + "/tasks?status=new",
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleViewer, nil)
+				m.On("GetWorkspaceByID", mock.Anything, workspaceID).Return(workspace(), nil)
+				m.On("GetTasks", mock.Anything, "user123", models.TaskFilter{Status: "new", WorkspaceID: workspaceID}).Return([]models.Task{}, nil)
+			},
+		},
+		{
+			name:       "create task in workspace as viewer",
+			method:     "POST",
+			path:       "/tasks",
+			body:       models.CreateTaskRequest{Title: "Task", WorkspaceID: workspaceID},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleViewer, nil)
+			},
+		},
+		{
+			name:       "create task in workspace as member",
+			method:     "POST",
+			path:       "/tasks",
+			body:       models.CreateTaskRequest{Title: "Task", WorkspaceID: workspaceID},
+			statusCode: http.StatusCreated,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleMember, nil)
+				m.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *models.Task) bool { return task.WorkspaceID == workspaceID })).Return(nil)
+			},
+		},
+		{
+			name:       "update workspace task as member",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Title: "Updated"},
+			statusCode: http.StatusOK,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", Title: "Task", Status: "new", UserID: "user456", WorkspaceID: workspaceID}, nil)
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleMember, nil)
+				m.On("UpdateTask", mock.Anything, "task1", mock.AnythingOfType("*models.Task")).Return(nil)
+			},
+		},
+		{
+			name:       "update workspace task as viewer",
+			method:     "PUT",
+			path:       "/tasks/task1",
+			body:       models.UpdateTaskRequest{Title: "Updated"},
+			statusCode: http.StatusForbidden,
+			mockSetup: func(m *MockTaskStore) {
+				m.On("GetTaskByID", mock.Anything, "task1").Return(&models.Task{ID: "task1", Title: "Task", Status: "new", UserID: "user456", WorkspaceID: workspaceID}, nil)
+				m.On("GetWorkspaceRole", mock.Anything, workspaceID, "user123").Return(models.WorkspaceRoleViewer, nil)
+				m.On("GetTaskPermission", mock.Anything, "task1", "user123").Return("", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockTaskRepo := &MockTaskStore{}
+			tt.mockSetup(mockTaskRepo)
+
+			api := NewTaskAPI(&MockStorage{&MockUserStore{}, mockTaskRepo}, &Config{})
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				_ = json.NewEncoder(&body).Encode(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "jwt_token", Value: generateTestToken("user123")})
+
+			w := httptest.NewRecorder()
+			api.httpSrv.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			mockTaskRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHasWorkspaceRole(t *testing.T) {
+	tests := []struct {
+		role    string
+		minRole string
+		want    bool
+	}{
+		{models.WorkspaceRoleOwner, models.WorkspaceRoleAdmin, true},
+		{models.WorkspaceRoleAdmin, models.WorkspaceRoleAdmin, true},
+		{models.WorkspaceRoleMember, models.WorkspaceRoleAdmin, false},
+		{models.WorkspaceRoleViewer, models.WorkspaceRoleViewer, true},
+		{"", models.WorkspaceRoleViewer, false},
+		{"unknown", models.WorkspaceRoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, hasWorkspaceRole(tt.role, tt.minRole), tt.role+" >= "+tt.minRole)
+	}
+}
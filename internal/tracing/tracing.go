@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentation = "project"
+
+	DefaultServiceName = "tasks"
+)
+
+type Options struct {
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+	ServiceName string
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+func exporterOptions(opts Options) []otlptracehttp.Option {
+	var exporter []otlptracehttp.Option
+	if strings.Contains(opts.Endpoint, "://") {
+		exporter = append(exporter, otlptracehttp.WithEndpointURL(opts.Endpoint))
+	} else {
+		exporter = append(exporter, otlptracehttp.WithEndpoint(opts.Endpoint))
+	}
+	if opts.Insecure {
+		exporter = append(exporter, otlptracehttp.WithInsecure())
+	}
+	return exporter
+}
+
+func sampler(ratio float64) sdktrace.Sampler {
+	if ratio >= 1 {
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	if ratio <= 0 {
+		return sdktrace.ParentBased(sdktrace.NeverSample())
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+}
+
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, exporterOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	name := opts.ServiceName
+	if name == "" {
+		name = DefaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", name)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler(opts.SampleRatio)),
+	)
+	otel.SetTracerProvider(provider)
+	log.Printf("[SUCCESS] Трассировка OpenTelemetry включена (%s, доля выборки %v)", opts.Endpoint, opts.SampleRatio)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampler(t *testing.T) {
+	tests := []struct {
+		name  string
+		ratio float64
+		want  string
+	}{
+		{name: "always", ratio: 1, want: "ParentBased{root:AlwaysOnSampler"},
+		{name: "never", ratio: 0, want: "ParentBased{root:AlwaysOffSampler"},
+		{name: "ratio", ratio: 0.25, want: "ParentBased{root:TraceIDRatioBased{0.25}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, sampler(tt.ratio).Description(), tt.want)
+		})
+	}
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
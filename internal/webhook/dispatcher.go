@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/jobs"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	defaultWorkers     = 4
+	queueSize          = 256
+)
+
+type Store interface {
+	GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error)
+	AddWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+type Event struct {
+	Name   string
+	UserID string
+	Task   models.Task
+}
+
+type payload struct {
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Task       models.Task `json:"task"`
+}
+
+type Dispatcher struct {
+	store       Store
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	now         func() time.Time
+
+	pool    *jobs.Pool
+	skipped atomic.Int64
+	once    sync.Once
+}
+
+func NewDispatcher(store Store, maxAttempts int, backoff time.Duration) *Dispatcher {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	return &Dispatcher{
+		store:       store,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		now:         time.Now,
+		pool:        jobs.NewPool("вебхуки", defaultWorkers, queueSize),
+	}
+}
+
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) Start() {
+	log.Printf("[INFO] Доставка вебхуков запущена, обработчиков %d", defaultWorkers)
+}
+
+func (d *Dispatcher) stopped() {
+	if skipped := d.skipped.Load(); skipped > 0 {
+		log.Println("[WARN] Не доставлено событий вебхуков при остановке:", skipped)
+	}
+	log.Println("[INFO] Доставка вебхуков остановлена")
+}
+
+func (d *Dispatcher) Stop() {
+	d.once.Do(func() {
+		d.pool.Stop()
+		d.stopped()
+	})
+}
+
+func (d *Dispatcher) Close(ctx context.Context) error {
+	var err error
+	d.once.Do(func() {
+		err = d.pool.Close(ctx)
+		d.stopped()
+	})
+	return err
+}
+
+func (d *Dispatcher) Dispatch(userID, event string, task models.Task) {
+	e := Event{Name: event, UserID: userID, Task: task}
+	queued := d.pool.Submit(func(ctx context.Context) {
+		if ctx.Err() != nil {
+			d.skipped.Add(1)
+			return
+		}
+		d.Deliver(ctx, e)
+	})
+	if !queued {
+		log.Println("[ERROR] Очередь вебхуков переполнена, событие пропущено:", event, task.ID)
+	}
+}
+
+func (d *Dispatcher) Deliver(ctx context.Context, event Event) int {
+	hooks, err := d.store.GetWebhooks(ctx, event.UserID)
+	if err != nil {
+		log.Println("[ERROR] Не удалось получить вебхуки пользователя:", event.UserID, err)
+		return 0
+	}
+	body, err := json.Marshal(payload{Event: event.Name, OccurredAt: d.now().UTC(), Task: event.Task})
+	if err != nil {
+		log.Println("[ERROR] Не удалось сформировать тело вебхука:", err)
+		return 0
+	}
+
+	delivered := 0
+	for _, hook := range hooks {
+		if !subscribed(hook, event.Name) {
+			continue
+		}
+		if d.deliverWithRetry(ctx, hook, event.Name, body) {
+			delivered++
+		}
+	}
+	return delivered
+}
+
+func subscribed(hook models.Webhook, event string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, e := range hook.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) deliverWithRetry(ctx context.Context, hook models.Webhook, event string, body []byte) bool {
+	wait := d.backoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		statusCode, err := d.send(ctx, hook, event, body)
+		delivery := models.WebhookDelivery{
+			WebhookID:  hook.ID,
+			Event:      event,
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Success:    err == nil,
+			CreatedAt:  d.now(),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if recErr := d.store.AddWebhookDelivery(ctx, &delivery); recErr != nil {
+			log.Println("[ERROR] Не удалось записать журнал доставки вебхука:", recErr)
+		}
+		if err == nil {
+			return true
+		}
+		log.Printf("[ERROR] Попытка %d доставки вебхука %s не удалась: %v", attempt, hook.ID, err)
+		if attempt == d.maxAttempts {
+			break
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return false
+		}
+		wait *= 2
+	}
+	return false
+}
+
+func (d *Dispatcher) send(ctx context.Context, hook models.Webhook, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%w: %d", errors.ErrWebhookDeliveryFailed, resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"project/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	mu         sync.Mutex
+	hooks      []models.Webhook
+	deliveries []models.WebhookDelivery
+}
+
+func (f *fakeStore) GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	hooks := []models.Webhook{}
+	for _, hook := range f.hooks {
+		if hook.UserID == userID {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks, nil
+}
+
+func (f *fakeStore) AddWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deliveries = append(f.deliveries, *delivery)
+	return nil
+}
+
+func (f *fakeStore) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.deliveries)
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+	assert.NotEqual(t, Sign("key", []byte("a")), Sign("other", []byte("a")))
+}
+
+func TestDispatcherDeliver(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		maxAttempts   int
+		events        []string
+		wantDelivered int
+		wantAttempts  int
+		wantSuccess   bool
+	}{
+		{name: "first attempt succeeds", failures: 0, maxAttempts: 3, wantDelivered: 1, wantAttempts: 1, wantSuccess: true},
+		{name: "succeeds after retries", failures: 2, maxAttempts: 3, wantDelivered: 1, wantAttempts: 3, wantSuccess: true},
+		{name: "gives up after max attempts", failures: 5, maxAttempts: 3, wantDelivered: 0, wantAttempts: 3, wantSuccess: false},
+		{name: "not subscribed", maxAttempts: 3, events: []string{models.WebhookEventTaskDeleted}, wantDelivered: 0, wantAttempts: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, Sign("secret", body), r.Header.Get(SignatureHeader))
+				assert.Equal(t, models.WebhookEventTaskCreated, r.Header.Get(EventHeader))
+
+				var p payload
+				assert.NoError(t, json.Unmarshal(body, &p))
+				assert.Equal(t, "task1", p.Task.ID)
+
+				if calls <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			store := &fakeStore{hooks: []models.Webhook{
+				{ID: "hook1", UserID: "user1", URL: srv.URL, Secret: "secret", Events: tt.events},
+				{ID: "hook2", UserID: "user2", URL: srv.URL, Secret: "secret"},
+			}}
+			d := NewDispatcher(store, tt.maxAttempts, time.Millisecond)
+
+			delivered := d.Deliver(context.Background(), Event{
+				Name:   models.WebhookEventTaskCreated,
+				UserID: "user1",
+				Task:   models.Task{ID: "task1", UserID: "user1"},
+			})
+
+			assert.Equal(t, tt.wantDelivered, delivered)
+			assert.Equal(t, tt.wantAttempts, calls)
+			if assert.Len(t, store.deliveries, tt.wantAttempts) && tt.wantAttempts > 0 {
+				last := store.deliveries[len(store.deliveries)-1]
+				assert.Equal(t, tt.wantAttempts, last.Attempt)
+				assert.Equal(t, tt.wantSuccess, last.Success)
+				assert.Equal(t, "hook1", last.WebhookID)
+			}
+		})
+	}
+}
+
+func TestDispatcherStartStop(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{hooks: []models.Webhook{{ID: "hook1", UserID: "user1", URL: srv.URL, Secret: "secret"}}}
+	d := NewDispatcher(store, 1, time.Millisecond)
+	d.Start()
+	d.Dispatch("user1", models.WebhookEventTaskUpdated, models.Task{ID: "task1"})
+
+	assert.Eventually(t, func() bool { return store.count() == 1 }, time.Second, 5*time.Millisecond)
+	d.Stop()
+	assert.NotPanics(t, d.Stop)
+}
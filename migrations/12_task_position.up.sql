@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE tasks SET position = ranked.rn
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY title, id) - 1 AS rn FROM tasks) ranked
+WHERE tasks.id = ranked.id;
+
+CREATE INDEX IF NOT EXISTS tasks_user_position_idx ON tasks (user_id, position);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS task_templates (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    checklist TEXT[] NOT NULL DEFAULT '{}',
+    UNIQUE (user_id, name)
+);
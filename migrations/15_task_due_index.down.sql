@@ -0,0 +1 @@
+DROP INDEX IF EXISTS tasks_user_open_due_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS tasks_user_open_due_idx ON tasks (user_id, due_date)
+    WHERE deleted = false AND archived = false AND status <> 'done' AND due_date IS NOT NULL;
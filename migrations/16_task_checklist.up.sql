@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT false,
+    position INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS task_checklist_items_task_id_idx ON task_checklist_items (task_id, position);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS workflows (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    statuses TEXT[] NOT NULL,
+    initial_status VARCHAR(20) NOT NULL,
+    transitions JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
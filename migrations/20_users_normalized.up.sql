@@ -0,0 +1,20 @@
+DO $$
+DECLARE
+    collisions TEXT;
+BEGIN
+    SELECT string_agg(value, ', ' ORDER BY value) INTO collisions FROM (
+        SELECT 'username ' || lower(normalize(username, NFC)) AS value FROM users GROUP BY lower(normalize(username, NFC)) HAVING count(*) > 1
+        UNION ALL
+        SELECT 'email ' || lower(normalize(email, NFC)) FROM users GROUP BY lower(normalize(email, NFC)) HAVING count(*) > 1
+    ) duplicates;
+    IF collisions IS NOT NULL THEN
+        RAISE EXCEPTION 'Пользователи различаются только регистром или формой Unicode, нормализация невозможна: %', collisions
+            USING HINT = 'Переименуйте или объедините дубликаты, затем верните версию схемы на 19 без флага dirty и повторите миграцию';
+    END IF;
+END;
+$$;
+
+UPDATE users SET username = lower(normalize(username, NFC)), email = lower(normalize(email, NFC));
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_lower_idx ON users (lower(username));
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_idx ON users (lower(email));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS login_history;
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_purge_idx;
+DROP INDEX IF EXISTS tasks_user_due_date_idx;
+
+CREATE INDEX IF NOT EXISTS tasks_user_archived_idx ON tasks (user_id, archived);
+DROP INDEX IF EXISTS tasks_user_deleted_idx;
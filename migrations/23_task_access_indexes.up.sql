@@ -0,0 +1,8 @@
+CREATE INDEX IF NOT EXISTS tasks_user_deleted_idx ON tasks (user_id, deleted, archived, position);
+DROP INDEX IF EXISTS tasks_user_archived_idx;
+
+CREATE INDEX IF NOT EXISTS tasks_user_due_date_idx ON tasks (user_id, due_date)
+    WHERE deleted = false;
+
+CREATE INDEX IF NOT EXISTS tasks_purge_idx ON tasks (deleted_at)
+    WHERE deleted = true;
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS tasks_search_idx ON tasks
+    USING GIN (to_tsvector('simple', title || ' ' || coalesce(description, '')));
+
+DROP INDEX IF EXISTS tasks_search_vector_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
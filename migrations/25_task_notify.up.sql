@@ -0,0 +1,33 @@
+CREATE OR REPLACE FUNCTION notify_task_event() RETURNS trigger AS $$
+DECLARE
+    task RECORD;
+    event TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        task := OLD;
+        event := 'task.deleted';
+    ELSIF TG_OP = 'INSERT' THEN
+        task := NEW;
+        event := 'task.created';
+    ELSIF NEW.deleted AND NOT OLD.deleted THEN
+        task := NEW;
+        event := 'task.deleted';
+    ELSE
+        task := NEW;
+        event := 'task.updated';
+    END IF;
+
+    PERFORM pg_notify('task_events', json_build_object(
+        'event', event,
+        'task_id', task.id,
+        'user_id', task.user_id,
+        'assignee_id', COALESCE(task.assignee_id::text, '')
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_notify ON tasks;
+CREATE TRIGGER tasks_notify
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_event();
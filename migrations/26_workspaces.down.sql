@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS tasks_workspace_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+
+DROP INDEX IF EXISTS workspace_members_user_id_idx;
+
+DROP TABLE IF EXISTS workspace_members;
+
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS tasks_version ON tasks;
+DROP FUNCTION IF EXISTS bump_task_version();
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
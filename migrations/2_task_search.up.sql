@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS tasks_search_idx ON tasks
+    USING GIN (to_tsvector('simple', title || ' ' || coalesce(description, '')));
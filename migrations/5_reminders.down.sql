@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_pending_reminders_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS reminded_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS reminder_offset_minutes;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_date;
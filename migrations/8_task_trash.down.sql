@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_trash_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+UPDATE tasks SET deleted_at = now() WHERE deleted = true AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_trash_idx ON tasks (user_id, deleted_at) WHERE deleted = true;
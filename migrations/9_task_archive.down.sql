@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_user_archived_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS tasks_user_archived_idx ON tasks (user_id, archived);
@@ -0,0 +1,599 @@
+package conformancetest
+
+import (
+	"context"
+	"testing"
+
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Factory func(t *testing.T) repository.Storage
+
+type testCase struct {
+	name string
+	run  func(t *testing.T, ctx context.Context, s repository.Storage)
+}
+
+func Run(t *testing.T, newStorage Factory) {
+	groups := []struct {
+		name  string
+		cases []testCase
+	}{
+		{name: "users", cases: userCases},
+		{name: "tasks", cases: taskCases},
+		{name: "tags", cases: tagCases},
+		{name: "shares", cases: shareCases},
+		{name: "projects", cases: projectCases},
+		{name: "templates", cases: templateCases},
+		{name: "checklist", cases: checklistCases},
+		{name: "workflows", cases: workflowCases},
+		{name: "webhooks", cases: webhookCases},
+		{name: "workspaces", cases: workspaceCases},
+		{name: "transactions", cases: txCases},
+	}
+
+	for _, group := range groups {
+		t.Run(group.name, func(t *testing.T) {
+			for _, tc := range group.cases {
+				t.Run(tc.name, func(t *testing.T) {
+					tc.run(t, context.Background(), newStorage(t))
+				})
+			}
+		})
+	}
+}
+
+func missingID() string {
+	return uuid.New().String()
+}
+
+func createUser(t *testing.T, ctx context.Context, s repository.Storage) *models.User {
+	t.Helper()
+	name := "conformance_" + uuid.New().String()[:8]
+	user := &models.User{
+		ID:       uuid.New().String(),
+		Username: name,
+		Email:    name + "@example.com",
+		Password: "password123",
+		Role:     "user",
+	}
+	require.NoError(t, s.CreateUser(ctx, user))
+	return user
+}
+
+func createTask(t *testing.T, ctx context.Context, s repository.Storage, userID string) *models.Task {
+	t.Helper()
+	task := &models.Task{Title: "Conformance task", Description: "description", Status: "new", UserID: userID}
+	require.NoError(t, s.CreateTask(ctx, task))
+	require.NotEmpty(t, task.ID)
+	return task
+}
+
+var userCases = []testCase{
+	{
+		name: "created user is found by id and username",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+
+			byID, err := s.GetUserByID(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, user.Username, byID.Username)
+			assert.Equal(t, user.Email, byID.Email)
+
+			byName, err := s.GetUserByUsername(ctx, user.Username)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, byName.ID)
+		},
+	},
+	{
+		name: "duplicate username is rejected",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			duplicate := &models.User{
+				ID:       uuid.New().String(),
+				Username: user.Username,
+				Email:    "other_" + user.Email,
+				Password: "password123",
+				Role:     "user",
+			}
+			assert.Equal(t, errors.ErrUserAlreadyExists, s.CreateUser(ctx, duplicate))
+		},
+	},
+	{
+		name: "missing user returns ErrUserNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			id := missingID()
+
+			_, err := s.GetUserByID(ctx, id)
+			assert.Equal(t, errors.ErrUserNotFound, err)
+			_, err = s.GetUserByUsername(ctx, "conformance_missing")
+			assert.Equal(t, errors.ErrUserNotFound, err)
+			assert.Equal(t, errors.ErrUserNotFound, s.UpdateUser(ctx, id, &models.User{Username: "conformance_missing", Email: "missing@example.com", Password: "password123"}))
+			assert.Equal(t, errors.ErrUserNotFound, s.DeleteUser(ctx, id))
+			_, err = s.GetUserPreferences(ctx, id)
+			assert.Equal(t, errors.ErrUserNotFound, err)
+			_, err = s.IsUserActive(ctx, id)
+			assert.Equal(t, errors.ErrUserNotFound, err)
+			assert.Equal(t, errors.ErrUserNotFound, s.SetUserActive(ctx, id, false))
+		},
+	},
+	{
+		name: "deleted user is no longer found",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			require.NoError(t, s.DeleteUser(ctx, user.ID))
+
+			_, err := s.GetUserByID(ctx, user.ID)
+			assert.Equal(t, errors.ErrUserNotFound, err)
+		},
+	},
+	{
+		name: "suspension toggles active flag",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+
+			active, err := s.IsUserActive(ctx, user.ID)
+			require.NoError(t, err)
+			assert.True(t, active)
+
+			require.NoError(t, s.SetUserActive(ctx, user.ID, false))
+			active, err = s.IsUserActive(ctx, user.ID)
+			require.NoError(t, err)
+			assert.False(t, active)
+		},
+	},
+}
+
+var taskCases = []testCase{
+	{
+		name: "created task is found by id",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+
+			found, err := s.GetTaskByID(ctx, task.ID)
+			require.NoError(t, err)
+			assert.Equal(t, task.Title, found.Title)
+			assert.Equal(t, task.Status, found.Status)
+			assert.Equal(t, user.ID, found.UserID)
+			assert.False(t, found.Deleted)
+		},
+	},
+	{
+		name: "updates bump task version",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+			assert.Equal(t, int64(1), task.Version)
+
+			task.Title = "renamed"
+			require.NoError(t, s.UpdateTask(ctx, task.ID, task))
+			assert.Equal(t, int64(2), task.Version)
+			require.NoError(t, s.SetTaskArchived(ctx, task.ID, true))
+
+			found, err := s.GetTaskByID(ctx, task.ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), found.Version)
+			assert.False(t, found.UpdatedAt.IsZero())
+		},
+	},
+	{
+		name: "missing task returns ErrNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			id := missingID()
+
+			_, err := s.GetTaskByID(ctx, id)
+			assert.Equal(t, errors.ErrNotFound, err)
+			assert.Equal(t, errors.ErrNotFound, s.UpdateTask(ctx, id, &models.Task{Title: "missing", Status: "new"}))
+			assert.Equal(t, errors.ErrNotFound, s.DeleteTask(ctx, id))
+			assert.Equal(t, errors.ErrNotFound, s.HardDeleteTask(ctx, id))
+			assert.Equal(t, errors.ErrNotFound, s.SetTaskArchived(ctx, id, true))
+			assert.Equal(t, errors.ErrNotFound, s.AssignTask(ctx, id, ""))
+		},
+	},
+	{
+		name: "deleting a task twice returns ErrNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+
+			require.NoError(t, s.DeleteTask(ctx, task.ID))
+			assert.Equal(t, errors.ErrNotFound, s.DeleteTask(ctx, task.ID))
+		},
+	},
+	{
+		name: "deleted task moves to trash and can be restored",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+			require.NoError(t, s.DeleteTask(ctx, task.ID))
+
+			tasks, err := s.GetTasks(ctx, user.ID, models.TaskFilter{})
+			require.NoError(t, err)
+			assert.Empty(t, tasks)
+			trash, err := s.GetTrash(ctx, user.ID)
+			require.NoError(t, err)
+			require.Len(t, trash, 1)
+			assert.Equal(t, task.ID, trash[0].ID)
+
+			require.NoError(t, s.RestoreTask(ctx, task.ID))
+			tasks, err = s.GetTasks(ctx, user.ID, models.TaskFilter{})
+			require.NoError(t, err)
+			assert.Len(t, tasks, 1)
+		},
+	},
+	{
+		name: "deleted filter lists only trashed tasks",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			kept := createTask(t, ctx, s, user.ID)
+			trashed := createTask(t, ctx, s, user.ID)
+			require.NoError(t, s.DeleteTask(ctx, trashed.ID))
+
+			deleted := true
+			tasks, err := s.GetTasks(ctx, user.ID, models.TaskFilter{Deleted: &deleted})
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, trashed.ID, tasks[0].ID)
+			assert.True(t, tasks[0].Deleted)
+
+			tasks, err = s.GetTasks(ctx, user.ID, models.TaskFilter{})
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, kept.ID, tasks[0].ID)
+		},
+	},
+	{
+		name: "restoring a task outside the trash returns ErrTaskNotInTrash",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+
+			assert.Equal(t, errors.ErrTaskNotInTrash, s.RestoreTask(ctx, task.ID))
+			assert.Equal(t, errors.ErrTaskNotInTrash, s.RestoreTask(ctx, missingID()))
+		},
+	},
+	{
+		name: "tasks of other users are not listed",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			owner := createUser(t, ctx, s)
+			other := createUser(t, ctx, s)
+			createTask(t, ctx, s, owner.ID)
+
+			tasks, err := s.GetTasks(ctx, other.ID, models.TaskFilter{})
+			require.NoError(t, err)
+			assert.Empty(t, tasks)
+		},
+	},
+}
+
+var tagCases = []testCase{
+	{
+		name: "duplicate tag name is rejected",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			require.NoError(t, s.CreateTag(ctx, &models.Tag{Name: "work", UserID: user.ID}))
+
+			assert.Equal(t, errors.ErrTagAlreadyExists, s.CreateTag(ctx, &models.Tag{Name: "work", UserID: user.ID}))
+		},
+	},
+	{
+		name: "missing tag returns ErrTagNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			id := missingID()
+
+			_, err := s.GetTagByID(ctx, id)
+			assert.Equal(t, errors.ErrTagNotFound, err)
+			assert.Equal(t, errors.ErrTagNotFound, s.UpdateTag(ctx, id, &models.Tag{Name: "missing"}))
+			assert.Equal(t, errors.ErrTagNotFound, s.DeleteTag(ctx, id))
+		},
+	},
+	{
+		name: "attach reports missing task and missing tag",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+			tag := &models.Tag{Name: "home", UserID: user.ID}
+			require.NoError(t, s.CreateTag(ctx, tag))
+
+			assert.Equal(t, errors.ErrNotFound, s.AttachTag(ctx, missingID(), tag.ID))
+			assert.Equal(t, errors.ErrTagNotFound, s.AttachTag(ctx, task.ID, missingID()))
+		},
+	},
+	{
+		name: "detaching a tag that is not attached returns ErrTagNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+			tag := &models.Tag{Name: "later", UserID: user.ID}
+			require.NoError(t, s.CreateTag(ctx, tag))
+
+			require.NoError(t, s.AttachTag(ctx, task.ID, tag.ID))
+			require.NoError(t, s.DetachTag(ctx, task.ID, tag.ID))
+			assert.Equal(t, errors.ErrTagNotFound, s.DetachTag(ctx, task.ID, tag.ID))
+		},
+	},
+}
+
+var shareCases = []testCase{
+	{
+		name: "sharing grants permission",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			owner := createUser(t, ctx, s)
+			reader := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, owner.ID)
+
+			permission, err := s.GetTaskPermission(ctx, task.ID, reader.ID)
+			require.NoError(t, err)
+			assert.Empty(t, permission)
+
+			require.NoError(t, s.ShareTask(ctx, &models.TaskShare{TaskID: task.ID, UserID: reader.ID, Permission: "read"}))
+			permission, err = s.GetTaskPermission(ctx, task.ID, reader.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "read", permission)
+		},
+	},
+	{
+		name: "sharing a missing task returns ErrNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			reader := createUser(t, ctx, s)
+
+			assert.Equal(t, errors.ErrNotFound, s.ShareTask(ctx, &models.TaskShare{TaskID: missingID(), UserID: reader.ID, Permission: "read"}))
+		},
+	},
+}
+
+var projectCases = []testCase{
+	{
+		name: "duplicate project name is rejected",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			require.NoError(t, s.CreateProject(ctx, &models.Project{Name: "Home", UserID: user.ID}))
+
+			assert.Equal(t, errors.ErrProjectAlreadyExists, s.CreateProject(ctx, &models.Project{Name: "Home", UserID: user.ID}))
+		},
+	},
+	{
+		name: "missing project returns ErrProjectNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			id := missingID()
+
+			_, err := s.GetProjectByID(ctx, id)
+			assert.Equal(t, errors.ErrProjectNotFound, err)
+			assert.Equal(t, errors.ErrProjectNotFound, s.UpdateProject(ctx, id, &models.Project{Name: "missing"}))
+			assert.Equal(t, errors.ErrProjectNotFound, s.DeleteProject(ctx, id))
+		},
+	},
+}
+
+var templateCases = []testCase{
+	{
+		name: "duplicate template name is rejected",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			require.NoError(t, s.CreateTemplate(ctx, &models.TaskTemplate{Name: "weekly", Title: "Weekly review", UserID: user.ID}))
+
+			assert.Equal(t, errors.ErrTemplateAlreadyExists, s.CreateTemplate(ctx, &models.TaskTemplate{Name: "weekly", Title: "Another", UserID: user.ID}))
+		},
+	},
+	{
+		name: "missing template returns ErrTemplateNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			id := missingID()
+
+			_, err := s.GetTemplateByID(ctx, id)
+			assert.Equal(t, errors.ErrTemplateNotFound, err)
+			assert.Equal(t, errors.ErrTemplateNotFound, s.UpdateTemplate(ctx, id, &models.TaskTemplate{Name: "missing", Title: "missing"}))
+			assert.Equal(t, errors.ErrTemplateNotFound, s.DeleteTemplate(ctx, id))
+		},
+	},
+}
+
+var checklistCases = []testCase{
+	{
+		name: "toggled item flips done",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+			item := &models.ChecklistItem{TaskID: task.ID, Title: "step"}
+			require.NoError(t, s.AddChecklistItem(ctx, item))
+
+			toggled, err := s.ToggleChecklistItem(ctx, task.ID, item.ID)
+			require.NoError(t, err)
+			assert.True(t, toggled.Done)
+		},
+	},
+	{
+		name: "missing item returns ErrChecklistItemNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			task := createTask(t, ctx, s, user.ID)
+
+			_, err := s.ToggleChecklistItem(ctx, task.ID, missingID())
+			assert.Equal(t, errors.ErrChecklistItemNotFound, err)
+			assert.Equal(t, errors.ErrChecklistItemNotFound, s.DeleteChecklistItem(ctx, task.ID, missingID()))
+		},
+	},
+}
+
+var workflowCases = []testCase{
+	{
+		name: "saved workflow is returned and removable",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			workflow := &models.Workflow{
+				UserID:        user.ID,
+				Statuses:      []string{"new", "done"},
+				InitialStatus: "new",
+				Transitions:   map[string][]string{"new": {"done"}},
+			}
+			require.NoError(t, s.SaveWorkflow(ctx, workflow))
+
+			found, err := s.GetWorkflow(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, workflow.Statuses, found.Statuses)
+
+			require.NoError(t, s.DeleteWorkflow(ctx, user.ID))
+			_, err = s.GetWorkflow(ctx, user.ID)
+			assert.Equal(t, errors.ErrWorkflowNotFound, err)
+		},
+	},
+	{
+		name: "missing workflow returns ErrWorkflowNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+
+			_, err := s.GetWorkflow(ctx, user.ID)
+			assert.Equal(t, errors.ErrWorkflowNotFound, err)
+			assert.Equal(t, errors.ErrWorkflowNotFound, s.DeleteWorkflow(ctx, user.ID))
+		},
+	},
+}
+
+var webhookCases = []testCase{
+	{
+		name: "created webhook is listed",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			hook := &models.Webhook{UserID: user.ID, URL: "http://localhost/hook", Secret: "secret", Events: []string{"task.created"}}
+			require.NoError(t, s.CreateWebhook(ctx, hook))
+
+			hooks, err := s.GetWebhooks(ctx, user.ID)
+			require.NoError(t, err)
+			require.Len(t, hooks, 1)
+			assert.Equal(t, hook.ID, hooks[0].ID)
+		},
+	},
+	{
+		name: "missing webhook returns ErrWebhookNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			id := missingID()
+
+			_, err := s.GetWebhookByID(ctx, id)
+			assert.Equal(t, errors.ErrWebhookNotFound, err)
+			assert.Equal(t, errors.ErrWebhookNotFound, s.DeleteWebhook(ctx, id))
+		},
+	},
+}
+
+var workspaceCases = []testCase{
+	{
+		name: "creator becomes owner and scoped tasks are listed",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			owner := createUser(t, ctx, s)
+			member := createUser(t, ctx, s)
+			workspace := &models.Workspace{Name: "Team", OwnerID: owner.ID}
+			require.NoError(t, s.CreateWorkspace(ctx, workspace))
+			assert.Equal(t, models.WorkspaceRoleOwner, workspace.Role)
+
+			require.NoError(t, s.SaveWorkspaceMember(ctx, &models.WorkspaceMember{WorkspaceID: workspace.ID, UserID: member.ID, Role: models.WorkspaceRoleViewer}))
+			role, err := s.GetWorkspaceRole(ctx, workspace.ID, member.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.WorkspaceRoleViewer, role)
+
+			workspaces, err := s.GetWorkspaces(ctx, member.ID)
+			require.NoError(t, err)
+			require.Len(t, workspaces, 1)
+			assert.Equal(t, models.WorkspaceRoleViewer, workspaces[0].Role)
+
+			task := &models.Task{Title: "Shared", Status: "new", UserID: owner.ID, WorkspaceID: workspace.ID}
+			require.NoError(t, s.CreateTask(ctx, task))
+			createTask(t, ctx, s, owner.ID)
+			tasks, err := s.GetTasks(ctx, member.ID, models.TaskFilter{WorkspaceID: workspace.ID})
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, task.ID, tasks[0].ID)
+			assert.Equal(t, workspace.ID, tasks[0].WorkspaceID)
+
+			require.NoError(t, s.RemoveWorkspaceMember(ctx, workspace.ID, member.ID))
+			role, err = s.GetWorkspaceRole(ctx, workspace.ID, member.ID)
+			require.NoError(t, err)
+			assert.Empty(t, role)
+		},
+	},
+	{
+		name: "missing workspace returns ErrWorkspaceNotFound",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			user := createUser(t, ctx, s)
+			id := missingID()
+
+			_, err := s.GetWorkspaceByID(ctx, id)
+			assert.Equal(t, errors.ErrWorkspaceNotFound, err)
+			assert.Equal(t, errors.ErrWorkspaceNotFound, s.UpdateWorkspace(ctx, id, &models.Workspace{Name: "Missing"}))
+			assert.Equal(t, errors.ErrWorkspaceNotFound, s.DeleteWorkspace(ctx, id))
+			assert.Equal(t, errors.ErrWorkspaceNotFound, s.SaveWorkspaceMember(ctx, &models.WorkspaceMember{WorkspaceID: id, UserID: user.ID, Role: models.WorkspaceRoleMember}))
+			assert.Equal(t, errors.ErrNotWorkspaceMember, s.RemoveWorkspaceMember(ctx, id, user.ID))
+		},
+	},
+	{
+		name: "deleting a workspace removes its tasks",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			owner := createUser(t, ctx, s)
+			workspace := &models.Workspace{Name: "Temporary", OwnerID: owner.ID}
+			require.NoError(t, s.CreateWorkspace(ctx, workspace))
+			task := &models.Task{Title: "Scoped", Status: "new", UserID: owner.ID, WorkspaceID: workspace.ID}
+			require.NoError(t, s.CreateTask(ctx, task))
+
+			require.NoError(t, s.DeleteWorkspace(ctx, workspace.ID))
+			_, err := s.GetTaskByID(ctx, task.ID)
+			assert.Equal(t, errors.ErrNotFound, err)
+			workspaces, err := s.GetWorkspaces(ctx, owner.ID)
+			require.NoError(t, err)
+			assert.Empty(t, workspaces)
+		},
+	},
+}
+
+var txCases = []testCase{
+	{
+		name: "committed transaction applies every write",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			name := "conformance_" + uuid.New().String()[:8]
+			user := &models.User{ID: uuid.New().String(), Username: name, Email: name + "@example.com", Password: "password123", Role: "user"}
+			project := &models.Project{Name: "Inbox"}
+			require.NoError(t, s.WithTx(ctx, func(tx repository.Storage) error {
+				if err := tx.CreateUser(ctx, user); err != nil {
+					return err
+				}
+				project.UserID = user.ID
+				return tx.CreateProject(ctx, project)
+			}))
+
+			_, err := s.GetUserByID(ctx, user.ID)
+			require.NoError(t, err)
+			projects, err := s.GetProjects(ctx, user.ID)
+			require.NoError(t, err)
+			require.Len(t, projects, 1)
+			assert.Equal(t, project.ID, projects[0].ID)
+		},
+	},
+	{
+		name: "failed transaction rolls back earlier writes",
+		run: func(t *testing.T, ctx context.Context, s repository.Storage) {
+			name := "conformance_" + uuid.New().String()[:8]
+			user := &models.User{ID: uuid.New().String(), Username: name, Email: name + "@example.com", Password: "password123", Role: "user"}
+			err := s.WithTx(ctx, func(tx repository.Storage) error {
+				if err := tx.CreateUser(ctx, user); err != nil {
+					return err
+				}
+				if err := tx.CreateProject(ctx, &models.Project{Name: "Inbox", UserID: user.ID}); err != nil {
+					return err
+				}
+				return errors.ErrProjectAlreadyExists
+			})
+			assert.Equal(t, errors.ErrProjectAlreadyExists, err)
+
+			_, err = s.GetUserByID(ctx, user.ID)
+			assert.Equal(t, errors.ErrUserNotFound, err)
+			projects, err := s.GetProjects(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Empty(t, projects)
+		},
+	},
+}
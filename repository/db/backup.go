@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepBackupUsers      = `SELECT id, username, email, password, role, active, last_login_at FROM users ORDER BY id`
+	prepBackupTasks      = `SELECT ` + taskColumns + ` FROM tasks ORDER BY id`
+	prepRestoreTaskLinks = `UPDATE tasks SET parent_id = NULLIF($2, '')::uuid, project_id = (SELECT id FROM projects WHERE id = NULLIF($3, '')::uuid) WHERE id = $1`
+)
+
+var (
+	restoreUserColumns = []string{"id", "username", "email", "password", "role", "active", "last_login_at"}
+	restoreTaskColumns = []string{"id", "title", "description", "status", "user_id", "deleted", "archived", "assignee_id", "position", "due_date", "reminder_offset_minutes", "reminded_at", "deleted_at"}
+)
+
+func (s *Storage) BackupUsers(ctx context.Context, fn func(models.User) error) error {
+	ctx, cancel := s.readContext(ctx, "BackupUsers")
+	defer cancel()
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	rows, err := conn.Query(ctx, "backup_users")
+	if err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось получить пользователей для резервной копии:", err)
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		user := models.User{}
+		if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.Active, &user.LastLoginAt); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении пользователей для резервной копии:", err)
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		requestid.Println(ctx, "[ERROR] Ошибка при чтении пользователей для резервной копии:", err)
+		return err
+	}
+	requestid.Println(ctx, "[SUCCESS] В резервную копию выгружено пользователей:", count)
+	return nil
+}
+
+func (s *Storage) BackupTasks(ctx context.Context, fn func(models.Task) error) error {
+	ctx, cancel := s.readContext(ctx, "BackupTasks")
+	defer cancel()
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	rows, err := conn.Query(ctx, "backup_tasks")
+	if err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось получить задачи для резервной копии:", err)
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		task := models.Task{}
+		if err := scanTask(rows, &task); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для резервной копии:", err)
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для резервной копии:", err)
+		return err
+	}
+	requestid.Println(ctx, "[SUCCESS] В резервную копию выгружено задач:", count)
+	return nil
+}
+
+func (s *Storage) RestoreBackup(ctx context.Context, users []models.User, tasks []models.Task) error {
+	ctx, cancel := s.writeContext(ctx, "RestoreBackup")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию восстановления:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		userRows := make([][]interface{}, 0, len(users))
+		for _, user := range users {
+			userRows = append(userRows, []interface{}{user.ID, models.NormalizeUsername(user.Username), models.NormalizeEmail(user.Email), user.Password, user.Role, user.Active, user.LastLoginAt})
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"users"}, restoreUserColumns, pgx.CopyFromRows(userRows)); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось восстановить пользователей:", err)
+			if isUniqueViolation(err) {
+				return errors.ErrUserAlreadyExists
+			}
+			return err
+		}
+
+		taskRows := make([][]interface{}, 0, len(tasks))
+		for _, task := range tasks {
+			taskRows = append(taskRows, []interface{}{task.ID, task.Title, task.Description, task.Status, task.UserID, task.Deleted, task.Archived, nullableUUID(task.AssigneeID),
+				task.Position, task.DueDate, task.ReminderOffsetMinutes, task.RemindedAt, task.DeletedAt})
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tasks"}, restoreTaskColumns, pgx.CopyFromRows(taskRows)); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось восстановить задачи:", err)
+			if isUniqueViolation(err) {
+				return errors.ErrTaskAlreadyExists
+			}
+			return err
+		}
+
+		for _, task := range tasks {
+			if task.ParentID == "" && task.ProjectID == "" {
+				continue
+			}
+			if _, err := tx.Exec(ctx, "restore_task_links", task.ID, task.ParentID, task.ProjectID); err != nil {
+				requestid.Println(ctx, "[ERROR] Не удалось восстановить связи задачи:", err)
+				return err
+			}
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать восстановление:", err)
+			return err
+		}
+		requestid.Printf(ctx, "[SUCCESS] Восстановлено пользователей: %d, задач: %d", len(users), len(tasks))
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var batchTaskColumns = []string{"id", "title", "description", "status", "user_id", "parent_id", "project_id", "workspace_id", "due_date", "reminder_offset_minutes", "position"}
+
+const nextTaskPosition = `SELECT COALESCE(MAX(position), -1) + 1 FROM tasks WHERE user_id = $1`
+
+func nullableUUID(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func (s *Storage) CreateTasks(ctx context.Context, tasks []models.Task) error {
+	ctx, cancel := s.writeContext(ctx, "CreateTasks")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию для пакетного создания задач:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		positions := map[string]int{}
+		rows := make([][]interface{}, 0, len(tasks))
+		for i := range tasks {
+			task := &tasks[i]
+			next, ok := positions[task.UserID]
+			if !ok {
+				if err := tx.QueryRow(ctx, nextTaskPosition, task.UserID).Scan(&next); err != nil {
+					requestid.Println(ctx, "[ERROR] Не удалось определить позицию новых задач:", err)
+					return err
+				}
+			}
+			positions[task.UserID] = next + 1
+
+			task.ID = uuid.New().String()
+			task.Deleted = false
+			task.Position = next
+			rows = append(rows, []interface{}{task.ID, task.Title, task.Description, task.Status, task.UserID, nullableUUID(task.ParentID), nullableUUID(task.ProjectID), nullableUUID(task.WorkspaceID), task.DueDate, task.ReminderOffsetMinutes, task.Position})
+		}
+
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tasks"}, batchTaskColumns, pgx.CopyFromRows(rows)); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось создать задачи пакетом:", err)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать пакетное создание задач:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Задачи созданы пакетом, задач:", len(tasks))
+		return nil
+	})
+}
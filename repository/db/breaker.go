@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+	"project/internal/domain/errors"
+	"sync"
+	"time"
+)
+
+const defaultBreakerCooldown = 10 * time.Second
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type BreakerPolicy struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(policy BreakerPolicy) *circuitBreaker {
+	if policy.Threshold < 1 {
+		return nil
+	}
+	cooldown := policy.Cooldown
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &circuitBreaker{threshold: policy.Threshold, cooldown: cooldown, now: time.Now}
+}
+
+func isBreakerFailure(err error) bool {
+	return isTransient(err) || stderrors.Is(err, context.DeadlineExceeded)
+}
+
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return errors.ErrDatabaseUnavailable
+		}
+		b.state = breakerHalfOpen
+		log.Println("[INFO] Проверяем доступность базы данных пробным запросом")
+		return nil
+	case breakerHalfOpen:
+		return errors.ErrDatabaseUnavailable
+	}
+	return nil
+}
+
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stderrors.Is(err, context.Canceled) {
+		if b.state == breakerHalfOpen {
+			b.state = breakerOpen
+		}
+		return
+	}
+	if !isBreakerFailure(err) {
+		if b.state != breakerClosed {
+			log.Println("[SUCCESS] База данных снова доступна, запросы возобновлены")
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || (b.state == breakerClosed && b.failures >= b.threshold) {
+		if b.state == breakerClosed {
+			log.Printf("[ERROR] База данных недоступна после %d ошибок подряд, запросы отклоняются на %v: %v", b.failures, b.cooldown, err)
+		}
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *circuitBreaker) isOpen() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != breakerClosed
+}
+
+func (s *Storage) guard(fn func() error) error {
+	if err := s.breaker.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	s.breaker.record(err)
+	return err
+}
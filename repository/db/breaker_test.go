@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingQuerier struct {
+	querier
+	err error
+}
+
+func (q failingQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, q.err
+}
+
+func (q failingQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return failingRow{err: q.err}
+}
+
+type failingRow struct {
+	err error
+}
+
+func (r failingRow) Scan(dest ...any) error {
+	return r.err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	failure := fmt.Errorf("dial: %w", syscall.ECONNREFUSED)
+
+	type step struct {
+		advance time.Duration
+		err     error
+		blocked bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+		open  bool
+	}{
+		{
+			name:  "stays closed below threshold",
+			steps: []step{{err: failure}, {err: failure}},
+		},
+		{
+			name:  "success resets failure count",
+			steps: []step{{err: failure}, {err: failure}, {}, {err: failure}, {err: failure}},
+		},
+		{
+			name:  "domain errors are not failures",
+			steps: []step{{err: errors.ErrTaskNotFound}, {err: errors.ErrTaskNotFound}, {err: errors.ErrTaskNotFound}},
+		},
+		{
+			name:  "opens after threshold and fails fast",
+			steps: []step{{err: failure}, {err: failure}, {err: failure}, {blocked: true}, {advance: 5 * time.Second, blocked: true}},
+			open:  true,
+		},
+		{
+			name:  "query timeouts are failures",
+			steps: []step{{err: context.DeadlineExceeded}, {err: context.DeadlineExceeded}, {err: context.DeadlineExceeded}, {blocked: true}},
+			open:  true,
+		},
+		{
+			name:  "successful probe closes",
+			steps: []step{{err: failure}, {err: failure}, {err: failure}, {advance: 10 * time.Second}, {}},
+		},
+		{
+			name:  "failed probe reopens",
+			steps: []step{{err: failure}, {err: failure}, {err: failure}, {advance: 10 * time.Second, err: failure}, {blocked: true}},
+			open:  true,
+		},
+		{
+			name:  "canceled probe allows another probe",
+			steps: []step{{err: failure}, {err: failure}, {err: failure}, {advance: 10 * time.Second, err: context.Canceled}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			breaker := newCircuitBreaker(BreakerPolicy{Threshold: 3, Cooldown: 10 * time.Second})
+			breaker.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				err := breaker.allow()
+				if s.blocked {
+					assert.Equal(t, errors.ErrDatabaseUnavailable, err, "step %d", i)
+					continue
+				}
+				assert.NoError(t, err, "step %d", i)
+				breaker.record(s.err)
+			}
+			assert.Equal(t, tt.open, breaker.isOpen())
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenAllowsSingleProbe(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	breaker := newCircuitBreaker(BreakerPolicy{Threshold: 1})
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(fmt.Errorf("read: %w", syscall.ECONNRESET))
+	assert.Equal(t, errors.ErrDatabaseUnavailable, breaker.allow())
+
+	now = now.Add(defaultBreakerCooldown)
+	assert.NoError(t, breaker.allow())
+	assert.Equal(t, errors.ErrDatabaseUnavailable, breaker.allow())
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := newCircuitBreaker(BreakerPolicy{})
+	assert.Nil(t, breaker)
+	for i := 0; i < 10; i++ {
+		breaker.record(fmt.Errorf("dial: %w", syscall.ECONNREFUSED))
+	}
+	assert.NoError(t, breaker.allow())
+	assert.False(t, breaker.isOpen())
+}
+
+func TestCircuitBreakerProbeWithWrites(t *testing.T) {
+	transient := fmt.Errorf("write: %w", syscall.ECONNRESET)
+	duplicate := &pgconn.PgError{Code: pgUniqueViolation}
+
+	tests := []struct {
+		name    string
+		write   func(ctx context.Context, s *Storage) error
+		err     error
+		wantErr error
+		open    bool
+	}{
+		{
+			name:    "create task transient failure keeps breaker open",
+			write:   func(ctx context.Context, s *Storage) error { return s.CreateTask(ctx, &models.Task{Title: "probe"}) },
+			err:     transient,
+			wantErr: syscall.ECONNRESET,
+			open:    true,
+		},
+		{
+			name:    "create user transient failure keeps breaker open",
+			write:   func(ctx context.Context, s *Storage) error { return s.CreateUser(ctx, &models.User{Username: "probe"}) },
+			err:     transient,
+			wantErr: syscall.ECONNRESET,
+			open:    true,
+		},
+		{
+			name:    "create task conflict closes breaker",
+			write:   func(ctx context.Context, s *Storage) error { return s.CreateTask(ctx, &models.Task{Title: "probe"}) },
+			err:     duplicate,
+			wantErr: errors.ErrConflict,
+		},
+		{
+			name:    "create user conflict closes breaker",
+			write:   func(ctx context.Context, s *Storage) error { return s.CreateUser(ctx, &models.User{Username: "probe"}) },
+			err:     duplicate,
+			wantErr: errors.ErrUserAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			breaker := newCircuitBreaker(BreakerPolicy{Threshold: 1})
+			breaker.now = func() time.Time { return now }
+			breaker.record(transient)
+			now = now.Add(defaultBreakerCooldown)
+
+			attempts := 0
+			s := &Storage{
+				retry:   RetryPolicy{MaxAttempts: 3},
+				breaker: breaker,
+				connect: func(ctx context.Context) (querier, func(), error) {
+					attempts++
+					return failingQuerier{err: tt.err}, func() {}, nil
+				},
+			}
+			err := tt.write(context.Background(), s)
+
+			assert.True(t, stderrors.Is(err, tt.wantErr), "got %v", err)
+			assert.Equal(t, 1, attempts, "write must not be retried after it was sent")
+			assert.Equal(t, tt.open, breaker.isOpen())
+		})
+	}
+}
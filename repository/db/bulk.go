@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+)
+
+const (
+	bulkUpdateStatus = `UPDATE tasks SET status = $1 WHERE id = $2 AND deleted = false`
+	bulkMoveTask     = `UPDATE tasks SET project_id = NULLIF($1, '')::uuid WHERE id = $2 AND deleted = false`
+)
+
+func (s *Storage) ApplyBulk(ctx context.Context, ops []models.BulkOperation) ([]models.BulkResult, error) {
+	ctx, cancel := s.writeContext(ctx, "ApplyBulk")
+	defer cancel()
+	var result []models.BulkResult
+	err := s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию для пакетной операции:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		results := make([]models.BulkResult, len(ops))
+		for i, op := range ops {
+			results[i] = models.BulkResult{TaskID: op.TaskID, Action: op.Action}
+			var query string
+			var args []interface{}
+			switch op.Action {
+			case models.BulkActionUpdateStatus:
+				query, args = bulkUpdateStatus, []interface{}{op.Status, op.TaskID}
+			case models.BulkActionDelete:
+				query, args = s.prepDeleteTask, []interface{}{op.TaskID}
+			case models.BulkActionMove:
+				query, args = bulkMoveTask, []interface{}{op.ProjectID, op.TaskID}
+			default:
+				results[i].Error = errors.ErrBulkUnknownAction.Error()
+				continue
+			}
+			ct, err := tx.Exec(ctx, query, args...)
+			if err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при выполнении пакетной операции:", err)
+				return err
+			}
+			if ct.RowsAffected() == 0 {
+				results[i].Error = errors.ErrTaskNotFound.Error()
+				continue
+			}
+			results[i].Success = true
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать пакетную операцию:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Пакетная операция выполнена, операций:", len(ops))
+		result = results
+		return nil
+	})
+	return result, err
+}
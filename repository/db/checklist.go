@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepGetChecklist        = `SELECT id, task_id, title, done, position FROM task_checklist_items WHERE task_id = $1 ORDER BY position, id`
+	prepAddChecklistItem    = `INSERT INTO task_checklist_items (id, task_id, title, done, position) VALUES ($1, $2, $3, false, (SELECT COALESCE(MAX(position), -1) + 1 FROM task_checklist_items WHERE task_id = $2)) RETURNING position`
+	prepToggleChecklistItem = `UPDATE task_checklist_items SET done = NOT done WHERE id = $1 AND task_id = $2 RETURNING id, task_id, title, done, position`
+	prepDeleteChecklistItem = `DELETE FROM task_checklist_items WHERE id = $1 AND task_id = $2`
+	reorderChecklistItem    = `UPDATE task_checklist_items SET position = $1 WHERE id = $2 AND task_id = $3`
+)
+
+func scanChecklistItem(row pgx.Row, item *models.ChecklistItem) error {
+	return row.Scan(&item.ID, &item.TaskID, &item.Title, &item.Done, &item.Position)
+}
+
+func (s *Storage) GetChecklist(ctx context.Context, taskID string) ([]models.ChecklistItem, error) {
+	ctx, cancel := s.readContext(ctx, "GetChecklist")
+	defer cancel()
+	var result []models.ChecklistItem
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_checklist", taskID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить чек-лист задачи:", err)
+			return err
+		}
+		defer rows.Close()
+
+		items := []models.ChecklistItem{}
+		for rows.Next() {
+			item := models.ChecklistItem{}
+			if err := scanChecklistItem(rows, &item); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении чек-листа:", err)
+				return err
+			}
+			items = append(items, item)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении чек-листа:", err)
+			return err
+		}
+		result = items
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) error {
+	ctx, cancel := s.writeContext(ctx, "AddChecklistItem")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		item.ID = uuid.New().String()
+		item.Done = false
+		if err := conn.QueryRow(ctx, "add_checklist_item", item.ID, item.TaskID, item.Title).Scan(&item.Position); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось добавить пункт чек-листа:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Пункт чек-листа добавлен:", item.ID)
+		return nil
+	})
+}
+
+func (s *Storage) ToggleChecklistItem(ctx context.Context, taskID, itemID string) (*models.ChecklistItem, error) {
+	ctx, cancel := s.writeContext(ctx, "ToggleChecklistItem")
+	defer cancel()
+	var result *models.ChecklistItem
+	err := s.withConn(ctx, func(conn querier) error {
+		item := &models.ChecklistItem{}
+		if err := scanChecklistItem(conn.QueryRow(ctx, "toggle_checklist_item", itemID, taskID), item); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Пункт чек-листа не найден:", itemID)
+				return errors.ErrChecklistItemNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось переключить пункт чек-листа:", err)
+			return err
+		}
+		result = item
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) error {
+	ctx, cancel := s.writeContext(ctx, "ReorderChecklist")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию для изменения порядка чек-листа:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		for position, id := range itemIDs {
+			ct, err := tx.Exec(ctx, reorderChecklistItem, position, id, taskID)
+			if err != nil {
+				requestid.Println(ctx, "[ERROR] Не удалось изменить позицию пункта чек-листа:", err)
+				return err
+			}
+			if ct.RowsAffected() == 0 {
+				requestid.Println(ctx, "[ERROR] Пункт чек-листа для изменения порядка не найден:", id)
+				return errors.ErrChecklistItemNotFound
+			}
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать порядок чек-листа:", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Storage) DeleteChecklistItem(ctx context.Context, taskID, itemID string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteChecklistItem")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_checklist_item", itemID, taskID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить пункт чек-листа:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Пункт чек-листа для удаления не найден:", itemID)
+			return errors.ErrChecklistItemNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Пункт чек-листа удален:", itemID)
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"project/internal/requestid"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type correlatedQuerier struct {
+	querier
+	statements map[string]string
+}
+
+func (s *Storage) correlate(conn querier) querier {
+	if s.sqlComments == nil {
+		return conn
+	}
+	return correlatedQuerier{querier: conn, statements: s.sqlComments}
+}
+
+func (q correlatedQuerier) annotate(ctx context.Context, sql string, args []any) (context.Context, string, []any) {
+	id := requestid.FromContext(ctx)
+	if !requestid.Valid(id) {
+		return ctx, sql, args
+	}
+	if text, ok := q.statements[sql]; ok {
+		ctx = context.WithValue(ctx, statementNameKey{}, sql)
+		sql = text
+	}
+	return ctx, sql + " /* req:" + id + " */", append([]any{pgx.QueryExecModeDescribeExec}, args...)
+}
+
+func (q correlatedQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	ctx, sql, args = q.annotate(ctx, sql, args)
+	return q.querier.Exec(ctx, sql, args...)
+}
+
+func (q correlatedQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, sql, args = q.annotate(ctx, sql, args)
+	return q.querier.Query(ctx, sql, args...)
+}
+
+func (q correlatedQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, sql, args = q.annotate(ctx, sql, args)
+	return q.querier.QueryRow(ctx, sql, args...)
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"project/internal/requestid"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingQuerier struct {
+	querier
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (q *recordingQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	q.ctx, q.sql, q.args = ctx, sql, args
+	return pgconn.CommandTag{}, nil
+}
+
+func TestCorrelatedQuerier(t *testing.T) {
+	statements := map[string]string{"get_task_by_id": "SELECT 1 WHERE $1 = $1"}
+
+	tests := []struct {
+		name      string
+		requestID string
+		sql       string
+		wantSQL   string
+		wantArgs  []any
+		wantName  string
+	}{
+		{
+			name:      "prepared statement is expanded with comment",
+			requestID: "abc-123",
+			sql:       "get_task_by_id",
+			wantSQL:   "SELECT 1 WHERE $1 = $1 /* req:abc-123 */",
+			wantArgs:  []any{pgx.QueryExecModeDescribeExec, "42"},
+			wantName:  "get_task_by_id",
+		},
+		{
+			name:      "dynamic query gets comment",
+			requestID: "abc-123",
+			sql:       "SELECT $1",
+			wantSQL:   "SELECT $1 /* req:abc-123 */",
+			wantArgs:  []any{pgx.QueryExecModeDescribeExec, "42"},
+		},
+		{
+			name:     "no request id",
+			sql:      "get_task_by_id",
+			wantSQL:  "get_task_by_id",
+			wantArgs: []any{"42"},
+		},
+		{
+			name:      "unsafe request id is ignored",
+			requestID: "x */ DROP TABLE tasks; /*",
+			sql:       "get_task_by_id",
+			wantSQL:   "get_task_by_id",
+			wantArgs:  []any{"42"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &recordingQuerier{}
+			s := &Storage{sqlComments: statements}
+			ctx := requestid.NewContext(context.Background(), tt.requestID)
+
+			_, err := s.correlate(inner).Exec(ctx, tt.sql, "42")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, inner.sql)
+			assert.Equal(t, tt.wantArgs, inner.args)
+			name, _ := inner.ctx.Value(statementNameKey{}).(string)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestCorrelateDisabled(t *testing.T) {
+	inner := &recordingQuerier{}
+	s := &Storage{}
+	assert.Same(t, inner, s.correlate(inner))
+}
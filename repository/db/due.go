@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"time"
+)
+
+const (
+	openDueTasks        = `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1 AND deleted = false AND archived = false AND status <> 'done' AND due_date IS NOT NULL`
+	prepGetOverdueTasks = openDueTasks + ` AND due_date < $2 ORDER BY due_date, id`
+	prepGetDueTasks     = openDueTasks + ` AND due_date >= $2 AND due_date <= $3 ORDER BY due_date, id`
+)
+
+func (s *Storage) GetOverdueTasks(ctx context.Context, userID string, now time.Time) ([]models.Task, error) {
+	return s.queryDueTasks(ctx, "GetOverdueTasks", "get_overdue_tasks", userID, now)
+}
+
+func (s *Storage) GetDueTasks(ctx context.Context, userID string, from, to time.Time) ([]models.Task, error) {
+	return s.queryDueTasks(ctx, "GetDueTasks", "get_due_tasks", userID, from, to)
+}
+
+func (s *Storage) queryDueTasks(ctx context.Context, operation, name string, args ...interface{}) ([]models.Task, error) {
+	ctx, cancel := s.readContext(ctx, operation)
+	defer cancel()
+	var result []models.Task
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, name, args...)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить задачи по сроку:", err)
+			return err
+		}
+		defer rows.Close()
+
+		tasks := []models.Task{}
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении задач по сроку:", err)
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении задач по сроку:", err)
+			return err
+		}
+		result = tasks
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	prepAddTaskEvent  = `INSERT INTO task_events (id, task_id, user_id, action, old_value, new_value, created_at) VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7)`
+	prepGetTaskEvents = `SELECT id, task_id, COALESCE(user_id::text, ''), action, old_value, new_value, created_at FROM task_events WHERE task_id = $1 ORDER BY created_at, id`
+
+	prepGetUserTaskEvents = `SELECT id, task_id, user_id::text, action, old_value, new_value, created_at FROM task_events WHERE user_id = $1 AND ($2::timestamptz IS NULL OR created_at < $2) ORDER BY created_at DESC, id LIMIT $3`
+)
+
+func nullableTime(value time.Time) interface{} {
+	if value.IsZero() {
+		return nil
+	}
+	return value
+}
+
+func (s *Storage) AddTaskEvent(ctx context.Context, event *models.TaskEvent) error {
+	ctx, cancel := s.writeContext(ctx, "AddTaskEvent")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		event.ID = uuid.New().String()
+		if event.CreatedAt.IsZero() {
+			event.CreatedAt = time.Now()
+		}
+		if _, err := conn.Exec(ctx, "add_task_event", event.ID, event.TaskID, event.UserID, event.Action, event.OldValue, event.NewValue, event.CreatedAt); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось записать историю задачи:", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Storage) GetTaskEvents(ctx context.Context, taskID string) ([]models.TaskEvent, error) {
+	ctx, cancel := s.readContext(ctx, "GetTaskEvents")
+	defer cancel()
+	var result []models.TaskEvent
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_task_events", taskID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить историю задачи:", err)
+			return err
+		}
+		defer rows.Close()
+
+		events := []models.TaskEvent{}
+		for rows.Next() {
+			event := models.TaskEvent{}
+			if err := rows.Scan(&event.ID, &event.TaskID, &event.UserID, &event.Action, &event.OldValue, &event.NewValue, &event.CreatedAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении истории задачи:", err)
+				return err
+			}
+			events = append(events, event)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении истории задачи:", err)
+			return err
+		}
+		result = events
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetUserTaskEvents(ctx context.Context, userID string, before time.Time, limit int) ([]models.TaskEvent, error) {
+	ctx, cancel := s.readContext(ctx, "GetUserTaskEvents")
+	defer cancel()
+	var result []models.TaskEvent
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_user_task_events", userID, nullableTime(before), limit)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить действия пользователя:", err)
+			return err
+		}
+		defer rows.Close()
+
+		events := []models.TaskEvent{}
+		for rows.Next() {
+			event := models.TaskEvent{}
+			if err := rows.Scan(&event.ID, &event.TaskID, &event.UserID, &event.Action, &event.OldValue, &event.NewValue, &event.CreatedAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении действий пользователя:", err)
+				return err
+			}
+			events = append(events, event)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении действий пользователя:", err)
+			return err
+		}
+		result = events
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+)
+
+const prepExportTasks = `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1 AND deleted = false ORDER BY id`
+
+func (s *Storage) ExportTasks(ctx context.Context, userID string, fn func(models.Task) error) error {
+	ctx, cancel := s.readContext(ctx, "ExportTasks")
+	defer cancel()
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	rows, err := conn.Query(ctx, "export_tasks", userID)
+	if err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось получить задачи для экспорта:", err)
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		task := models.Task{}
+		if err := scanTask(rows, &task); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для экспорта:", err)
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для экспорта:", err)
+		return err
+	}
+	requestid.Println(ctx, "[SUCCESS] Экспортировано задач:", count)
+	return nil
+}
+
+func (s *Storage) StreamTasks(ctx context.Context, userID string, filter models.TaskFilter, fn func(models.Task) error) error {
+	ctx, cancel := s.readContext(ctx, "StreamTasks")
+	defer cancel()
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	query, args := buildGetTasksQuery(userID, filter)
+	rows, err := s.correlate(conn).Query(ctx, query, args...)
+	if err != nil {
+		requestid.Println(ctx, "[ERROR] Не удалось получить задачи для потоковой выдачи:", err)
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		task := models.Task{}
+		if err := scanTask(rows, &task); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для потоковой выдачи:", err)
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для потоковой выдачи:", err)
+		return err
+	}
+	requestid.Println(ctx, "[SUCCESS] Передано задач потоком:", count)
+	return nil
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"project/internal/realtime"
+	"time"
+)
+
+const taskEventsChannel = "task_events"
+
+var listenBackoff = RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 30 * time.Second}
+
+func (s *Storage) ListenTaskEvents(ctx context.Context, publish func(realtime.Event)) error {
+	for attempt := 1; ; attempt++ {
+		listening, err := s.listenTaskEvents(ctx, publish)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if listening {
+			attempt = 1
+		}
+		delay := listenBackoff.backoff(attempt)
+		log.Printf("[WARN] Подписка на события задач прервана, переподключение через %v: %v", delay, err)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil
+		}
+	}
+}
+
+func (s *Storage) listenTaskEvents(ctx context.Context, publish func(realtime.Event)) (bool, error) {
+	pooled, err := s.acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	conn := pooled.Hijack()
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+taskEventsChannel); err != nil {
+		return false, err
+	}
+	log.Println("[SUCCESS] Подписка на события задач в PostgreSQL активна")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		var event realtime.Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Println("[WARN] Некорректное уведомление о событии задачи:", err)
+			continue
+		}
+		publish(event)
+	}
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"project/internal/logging"
+	"project/internal/metrics"
+	"project/internal/requestid"
+	"project/internal/tracing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSlowQuery = 500 * time.Millisecond
+	dynamicStatement = "dynamic"
+)
+
+type queryStartKey struct{}
+
+type statementNameKey struct{}
+
+type queryStart struct {
+	statement string
+	at        time.Time
+	span      trace.Span
+}
+
+type queryMetrics struct {
+	names    map[string]bool
+	queries  *metrics.CounterVec
+	errors   *metrics.CounterVec
+	duration *metrics.HistogramVec
+	now      func() time.Time
+
+	slow        time.Duration
+	slowQueries *metrics.CounterVec
+}
+
+func newQueryMetrics(statements map[string]string) *queryMetrics {
+	names := make(map[string]bool, len(statements))
+	for name := range statements {
+		names[name] = true
+	}
+	return &queryMetrics{
+		names:    names,
+		queries:  metrics.NewCounterVec("statement"),
+		errors:   metrics.NewCounterVec("statement"),
+		duration: metrics.NewHistogramVec("statement", metrics.DefaultBuckets),
+		now:      time.Now,
+
+		slow:        defaultSlowQuery,
+		slowQueries: metrics.NewCounterVec("statement"),
+	}
+}
+
+func (m *queryMetrics) statement(sql string) string {
+	if m.names[sql] {
+		return sql
+	}
+	return dynamicStatement
+}
+
+func (m *queryMetrics) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	statement := m.statement(data.SQL)
+	if name, ok := ctx.Value(statementNameKey{}).(string); ok {
+		statement = m.statement(name)
+	}
+	attrs := []attribute.KeyValue{attribute.String("db.system", "postgresql"), attribute.String("db.statement.name", statement)}
+	if statement == dynamicStatement {
+		attrs = append(attrs, attribute.String("db.query.text", data.SQL))
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "db "+statement, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return context.WithValue(ctx, queryStartKey{}, queryStart{statement: statement, at: m.now(), span: span})
+}
+
+func (m *queryMetrics) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	if data.Err != nil {
+		start.span.RecordError(data.Err)
+		start.span.SetStatus(codes.Error, data.Err.Error())
+	}
+	start.span.End()
+
+	elapsed := m.now().Sub(start.at)
+	m.queries.Inc(start.statement)
+	m.duration.Observe(start.statement, elapsed.Seconds())
+	if data.Err != nil {
+		m.errors.Inc(start.statement)
+	}
+	if elapsed >= m.slow {
+		m.slowQueries.Inc(start.statement)
+		if operation := operationFromContext(ctx); operation != "" {
+			requestid.Printf(ctx, "[WARN] Медленный запрос к БД %s (%s): %v", start.statement, operation, elapsed)
+		} else {
+			requestid.Printf(ctx, "[WARN] Медленный запрос к БД %s: %v", start.statement, elapsed)
+		}
+	} else if logging.Enabled(logging.LevelDebug) {
+		requestid.Printf(ctx, "[DEBUG] Запрос к БД %s выполнен за %v", start.statement, elapsed)
+	}
+}
+
+func (s *Storage) pools() map[string]*pgxpool.Pool {
+	pools := map[string]*pgxpool.Pool{"primary": s.pool}
+	if s.replica != nil {
+		pools["replica"] = s.replica
+	}
+	return pools
+}
+
+func (s *Storage) poolStat(fn func(*pgxpool.Stat) float64) func() map[string]float64 {
+	return func() map[string]float64 {
+		values := map[string]float64{}
+		for name, pool := range s.pools() {
+			values[name] = fn(pool.Stat())
+		}
+		return values
+	}
+}
+
+func (s *Storage) RegisterMetrics(registry *metrics.Registry) {
+	registry.Counter("db_queries_total", "Количество выполненных запросов к БД", s.queries.queries)
+	registry.Counter("db_query_errors_total", "Количество запросов к БД, завершившихся ошибкой", s.queries.errors)
+	registry.Histogram("db_query_duration_seconds", "Длительность запросов к БД", s.queries.duration)
+	registry.Counter("db_slow_queries_total", "Количество запросов к БД дольше порога медленных запросов", s.queries.slowQueries)
+
+	registry.GaugeFunc("db_pool_total_conns", "Открытые соединения пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return float64(st.TotalConns()) }))
+	registry.GaugeFunc("db_pool_acquired_conns", "Занятые соединения пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return float64(st.AcquiredConns()) }))
+	registry.GaugeFunc("db_pool_idle_conns", "Свободные соединения пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return float64(st.IdleConns()) }))
+	registry.GaugeFunc("db_pool_max_conns", "Максимум соединений пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return float64(st.MaxConns()) }))
+	registry.CounterFunc("db_pool_acquires_total", "Количество выдач соединений из пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return float64(st.AcquireCount()) }))
+	registry.CounterFunc("db_pool_empty_acquires_total", "Выдачи соединений, которым пришлось ждать освобождения пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return float64(st.EmptyAcquireCount()) }))
+	registry.CounterFunc("db_pool_acquire_seconds_total", "Суммарное время ожидания соединений пула", "pool", s.poolStat(func(st *pgxpool.Stat) float64 { return st.AcquireDuration().Seconds() }))
+	registry.GaugeFunc("db_circuit_open", "Отклоняются ли запросы к БД автоматом защиты", "", func() map[string]float64 {
+		if s.breaker.isOpen() {
+			return map[string]float64{"": 1}
+		}
+		return map[string]float64{"": 0}
+	})
+}
@@ -1,12 +1,20 @@
 package db
 
 import (
+	"log"
+
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
-func Migration(dbDSN string, migratePath string) error {
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+	Applied bool
+}
+
+func withMigrator(dbDSN string, migratePath string, fn func(m *migrate.Migrate) error) error {
 	m, err := migrate.New(
 		"file://"+migratePath,
 		dbDSN,
@@ -14,8 +22,47 @@ func Migration(dbDSN string, migratePath string) error {
 	if err != nil {
 		return err
 	}
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+	defer func() {
+		if sourceErr, dbErr := m.Close(); sourceErr != nil || dbErr != nil {
+			log.Println("[WARN] Ошибка закрытия мигратора:", sourceErr, dbErr)
+		}
+	}()
+	if err := fn(m); err != nil && err != migrate.ErrNoChange {
 		return err
 	}
 	return nil
 }
+
+func Migration(dbDSN string, migratePath string) error {
+	return withMigrator(dbDSN, migratePath, func(m *migrate.Migrate) error {
+		return m.Up()
+	})
+}
+
+func MigrateDown(dbDSN string, migratePath string, steps int) error {
+	return withMigrator(dbDSN, migratePath, func(m *migrate.Migrate) error {
+		return m.Steps(-steps)
+	})
+}
+
+func MigrateTo(dbDSN string, migratePath string, version uint) error {
+	return withMigrator(dbDSN, migratePath, func(m *migrate.Migrate) error {
+		return m.Migrate(version)
+	})
+}
+
+func GetMigrationStatus(dbDSN string, migratePath string) (MigrationStatus, error) {
+	var status MigrationStatus
+	err := withMigrator(dbDSN, migratePath, func(m *migrate.Migrate) error {
+		version, dirty, err := m.Version()
+		if err == migrate.ErrNilVersion {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		status = MigrationStatus{Version: version, Dirty: dirty, Applied: true}
+		return nil
+	})
+	return status, err
+}
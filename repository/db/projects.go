@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepCreateProject  = `INSERT INTO projects (id, user_id, name) VALUES ($1, $2, $3)`
+	prepGetProjects    = `SELECT id, user_id, name FROM projects WHERE user_id = $1 ORDER BY name`
+	prepGetProjectByID = `SELECT id, user_id, name FROM projects WHERE id = $1`
+	prepUpdateProject  = `UPDATE projects SET name = $1 WHERE id = $2`
+	prepDeleteProject  = `DELETE FROM projects WHERE id = $1`
+)
+
+func (s *Storage) CreateProject(ctx context.Context, project *models.Project) error {
+	ctx, cancel := s.writeContext(ctx, "CreateProject")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		project.ID = uuid.New().String()
+		if _, err := conn.Exec(ctx, "create_project", project.ID, project.UserID, project.Name); err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Проект уже существует:", project.Name)
+				return errors.ErrProjectAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось создать проект:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Проект успешно создан:", project.ID)
+		return nil
+	})
+}
+
+func (s *Storage) GetProjects(ctx context.Context, userID string) ([]models.Project, error) {
+	ctx, cancel := s.readContext(ctx, "GetProjects")
+	defer cancel()
+	var result []models.Project
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_projects", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить проекты:", err)
+			return err
+		}
+		defer rows.Close()
+
+		projects := []models.Project{}
+		for rows.Next() {
+			project := models.Project{}
+			if err := rows.Scan(&project.ID, &project.UserID, &project.Name); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении проектов:", err)
+				return err
+			}
+			projects = append(projects, project)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении проектов:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено проектов:", len(projects))
+		result = projects
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	ctx, cancel := s.readContext(ctx, "GetProjectByID")
+	defer cancel()
+	var result *models.Project
+	err := s.withConn(ctx, func(conn querier) error {
+		project := &models.Project{}
+		if err := conn.QueryRow(ctx, "get_project_by_id", id).Scan(&project.ID, &project.UserID, &project.Name); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Проект не найден:", id)
+				return errors.ErrProjectNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении проекта:", err)
+			return err
+		}
+		result = project
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) UpdateProject(ctx context.Context, id string, project *models.Project) error {
+	ctx, cancel := s.writeContext(ctx, "UpdateProject")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "update_project", project.Name, id)
+		if err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Проект уже существует:", project.Name)
+				return errors.ErrProjectAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось обновить проект:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Проект для обновления не найден:", id)
+			return errors.ErrProjectNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Проект успешно обновлен:", id)
+		return nil
+	})
+}
+
+func (s *Storage) DeleteProject(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteProject")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_project", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить проект:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Проект для удаления не найден:", id)
+			return errors.ErrProjectNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Проект успешно удален:", id)
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"time"
+)
+
+const (
+	prepGetDueReminders = `SELECT ` + taskColumns + ` FROM tasks WHERE deleted = false AND status <> 'done' AND due_date IS NOT NULL AND reminded_at IS NULL AND due_date - make_interval(mins => CASE WHEN reminder_offset_minutes > 0 THEN reminder_offset_minutes ELSE $2 END) <= $1 ORDER BY due_date LIMIT 100`
+	prepMarkReminded    = `UPDATE tasks SET reminded_at = $2 WHERE id = $1`
+)
+
+func (s *Storage) GetDueReminders(ctx context.Context, now time.Time, defaultOffset time.Duration) ([]models.Task, error) {
+	ctx, cancel := s.readContext(ctx, "GetDueReminders")
+	defer cancel()
+	var result []models.Task
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_due_reminders", now, int(defaultOffset.Minutes()))
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить задачи для напоминаний:", err)
+			return err
+		}
+		defer rows.Close()
+
+		tasks := []models.Task{}
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для напоминаний:", err)
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении задач для напоминаний:", err)
+			return err
+		}
+		result = tasks
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) MarkReminded(ctx context.Context, taskID string, at time.Time) error {
+	ctx, cancel := s.writeContext(ctx, "MarkReminded")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "mark_reminded", taskID, at)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось отметить напоминание:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			return errors.ErrNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/requestid"
+)
+
+const reorderTask = `UPDATE tasks SET position = $1 WHERE id = $2 AND user_id = $3 AND deleted = false`
+
+func (s *Storage) ReorderTasks(ctx context.Context, userID string, taskIDs []string) error {
+	ctx, cancel := s.writeContext(ctx, "ReorderTasks")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию для изменения порядка задач:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		for position, id := range taskIDs {
+			ct, err := tx.Exec(ctx, reorderTask, position, id, userID)
+			if err != nil {
+				requestid.Println(ctx, "[ERROR] Не удалось изменить позицию задачи:", err)
+				return err
+			}
+			if ct.RowsAffected() == 0 {
+				requestid.Println(ctx, "[ERROR] Задача для изменения порядка не найдена:", id)
+				return errors.ErrTaskNotFound
+			}
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать порядок задач:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Порядок задач сохранен, задач:", len(taskIDs))
+		return nil
+	})
+}
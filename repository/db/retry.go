@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"project/internal/requestid"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	defaultRetryInitialBackoff = 50 * time.Millisecond
+	defaultRetryMaxBackoff     = time.Second
+)
+
+var transientPgCodes = map[string]bool{
+	"40001": true,
+	"40P01": true,
+	"53300": true,
+	"57P01": true,
+	"57P02": true,
+	"57P03": true,
+}
+
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return transientPgCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08")
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isSafeToRetry(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	initial, limit := p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	if limit <= 0 {
+		limit = defaultRetryMaxBackoff
+	}
+	d := initial
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (p RetryPolicy) do(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	sleep := p.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !retryable(err) || attempt >= p.attempts() {
+			return err
+		}
+		delay := p.backoff(attempt)
+		requestid.Printf(ctx, "[WARN] Временная ошибка базы данных (попытка %d из %d), повтор через %v: %v", attempt, p.attempts(), delay, err)
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+func (s *Storage) withConn(ctx context.Context, fn func(conn querier) error) error {
+	return s.withPrimaryConn(ctx, isSafeToRetry, fn)
+}
+
+func (s *Storage) withPrimaryConn(ctx context.Context, retryable func(error) bool, fn func(conn querier) error) error {
+	if s.tx != nil {
+		return fn(s.correlate(s.tx))
+	}
+	return s.guard(func() error {
+		return s.retry.do(ctx, retryable, func() error {
+			conn, release, err := s.primaryConn(ctx)
+			if err != nil {
+				return err
+			}
+			defer release()
+			return fn(s.correlate(conn))
+		})
+	})
+}
+
+func (s *Storage) primaryConn(ctx context.Context) (querier, func(), error) {
+	if s.connect != nil {
+		return s.connect(ctx)
+	}
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, conn.Release, nil
+}
+
+func (s *Storage) withReadConn(ctx context.Context, fn func(conn querier) error) error {
+	if s.replica == nil || s.tx != nil {
+		return s.withPrimaryConn(ctx, isTransient, fn)
+	}
+	err := func() error {
+		conn, err := s.replica.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Release()
+		return fn(s.correlate(conn))
+	}()
+	if err == nil || !isTransient(err) {
+		return err
+	}
+	requestid.Println(ctx, "[WARN] Реплика для чтения недоступна, повторяем запрос в основной БД:", err)
+	return s.withPrimaryConn(ctx, isTransient, fn)
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"project/internal/domain/errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: true},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: pgUniqueViolation}, want: false},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "no rows", err: pgx.ErrNoRows, want: false},
+		{name: "domain error", err: errors.ErrTaskNotFound, want: false},
+		{name: "context canceled", err: context.Canceled, want: false},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransient(tt.err))
+		})
+	}
+}
+
+func TestIsSafeToRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "connect error", err: &pgconn.ConnectError{}, want: true},
+		{name: "wrapped connect error", err: fmt.Errorf("acquire: %w", &pgconn.ConnectError{}), want: true},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: false},
+		{name: "broken pipe", err: fmt.Errorf("write: %w", syscall.EPIPE), want: false},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: false},
+		{name: "domain error", err: errors.ErrTaskNotFound, want: false},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isSafeToRetry(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := &pgconn.PgError{Code: "40001"}
+
+	tests := []struct {
+		name         string
+		maxAttempts  int
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "success first try", maxAttempts: 3, errs: []error{nil}, wantAttempts: 1},
+		{name: "recovers after transient errors", maxAttempts: 3, errs: []error{transient, transient, nil}, wantAttempts: 3},
+		{name: "gives up after max attempts", maxAttempts: 3, errs: []error{transient, transient, transient, nil}, wantErr: transient, wantAttempts: 3},
+		{name: "permanent error is not retried", maxAttempts: 3, errs: []error{errors.ErrTaskNotFound, nil}, wantErr: errors.ErrTaskNotFound, wantAttempts: 1},
+		{name: "zero attempts means single try", maxAttempts: 0, errs: []error{transient, nil}, wantErr: transient, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delays []time.Duration
+			policy := RetryPolicy{
+				MaxAttempts:    tt.maxAttempts,
+				InitialBackoff: 10 * time.Millisecond,
+				MaxBackoff:     15 * time.Millisecond,
+				sleep: func(ctx context.Context, d time.Duration) error {
+					delays = append(delays, d)
+					return nil
+				},
+			}
+			attempts := 0
+			err := policy.do(context.Background(), isTransient, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Len(t, delays, attempts-1)
+			for _, d := range delays {
+				assert.LessOrEqual(t, d, 15*time.Millisecond)
+				assert.GreaterOrEqual(t, d, 5*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	transient := &pgconn.PgError{Code: "40001"}
+
+	attempts := 0
+	err := RetryPolicy{MaxAttempts: 5}.do(ctx, isTransient, func() error {
+		attempts++
+		return transient
+	})
+
+	assert.Equal(t, transient, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicyBackoffGrows(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: 100 * time.Millisecond},
+		{attempt: 2, max: 200 * time.Millisecond},
+		{attempt: 3, max: 300 * time.Millisecond},
+		{attempt: 10, max: 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempt), func(t *testing.T) {
+			d := policy.backoff(tt.attempt)
+			assert.LessOrEqual(t, d, tt.max)
+			assert.GreaterOrEqual(t, d, tt.max/2)
+		})
+	}
+}
+
+func TestQueryTimeouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeouts  QueryTimeouts
+		operation string
+		write     bool
+		want      time.Duration
+	}{
+		{name: "default read", operation: "GetTasks", want: defaultReadTimeout},
+		{name: "default write", operation: "UpdateTask", write: true, want: defaultWriteTimeout},
+		{name: "configured read", timeouts: QueryTimeouts{Read: 2 * time.Second}, operation: "GetTasks", want: 2 * time.Second},
+		{name: "configured write", timeouts: QueryTimeouts{Write: 3 * time.Second}, operation: "UpdateTask", write: true, want: 3 * time.Second},
+		{name: "built-in bulk override", timeouts: QueryTimeouts{Read: time.Second}, operation: "ExportTasks", want: 5 * time.Minute},
+		{
+			name:      "configured override wins",
+			timeouts:  QueryTimeouts{Read: time.Second, Operations: map[string]time.Duration{"ExportTasks": time.Minute, "GetTasks": 10 * time.Second}},
+			operation: "GetTasks",
+			want:      10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.timeouts.timeout(tt.operation, tt.write))
+		})
+	}
+}
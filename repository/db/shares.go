@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepShareTask         = `INSERT INTO task_shares (task_id, user_id, permission) VALUES ($1, $2, $3) ON CONFLICT (task_id, user_id) DO UPDATE SET permission = EXCLUDED.permission`
+	prepGetTaskPermission = `SELECT permission FROM task_shares WHERE task_id = $1 AND user_id = $2`
+)
+
+func (s *Storage) ShareTask(ctx context.Context, share *models.TaskShare) error {
+	ctx, cancel := s.writeContext(ctx, "ShareTask")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		if _, err := conn.Exec(ctx, "share_task", share.TaskID, share.UserID, share.Permission); err != nil {
+			switch violatedForeignKey(err) {
+			case "task_shares_task_id_fkey":
+				requestid.Println(ctx, "[ERROR] Задача для предоставления доступа не найдена:", share.TaskID)
+				return errors.ErrNotFound
+			case "task_shares_user_id_fkey":
+				requestid.Println(ctx, "[ERROR] Пользователь для предоставления доступа не найден:", share.UserID)
+				return errors.ErrUserNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось предоставить доступ к задаче:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Доступ к задаче предоставлен:", share.TaskID, share.UserID, share.Permission)
+		return nil
+	})
+}
+
+func (s *Storage) GetTaskPermission(ctx context.Context, taskID, userID string) (string, error) {
+	ctx, cancel := s.readContext(ctx, "GetTaskPermission")
+	defer cancel()
+	var result string
+	err := s.withConn(ctx, func(conn querier) error {
+		var permission string
+		if err := conn.QueryRow(ctx, "get_task_permission", taskID, userID).Scan(&permission); err != nil {
+			if err == pgx.ErrNoRows {
+				return nil
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении прав доступа к задаче:", err)
+			return err
+		}
+		result = permission
+		return nil
+	})
+	return result, err
+}
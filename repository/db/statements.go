@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Storage) statements() map[string]string {
+	return map[string]string{
+		"get_checklist":          prepGetChecklist,
+		"add_checklist_item":     prepAddChecklistItem,
+		"toggle_checklist_item":  prepToggleChecklistItem,
+		"delete_checklist_item":  prepDeleteChecklistItem,
+		"add_task_event":         prepAddTaskEvent,
+		"get_task_events":        prepGetTaskEvents,
+		"get_user_task_events":   prepGetUserTaskEvents,
+		"export_tasks":           prepExportTasks,
+		"backup_users":           prepBackupUsers,
+		"backup_tasks":           prepBackupTasks,
+		"restore_task_links":     prepRestoreTaskLinks,
+		"create_project":         prepCreateProject,
+		"get_projects":           prepGetProjects,
+		"get_project_by_id":      prepGetProjectByID,
+		"update_project":         prepUpdateProject,
+		"delete_project":         prepDeleteProject,
+		"get_due_reminders":      prepGetDueReminders,
+		"mark_reminded":          prepMarkReminded,
+		"share_task":             prepShareTask,
+		"get_task_permission":    prepGetTaskPermission,
+		"create_task":            s.prepCreateTask,
+		"get_task_by_id":         s.prepGetTaskByID,
+		"update_task":            s.prepUpdateTask,
+		"delete_task_soft":       s.prepDeleteTask,
+		"delete_task_hard":       s.prepHardDeleteTask,
+		"set_task_archived":      s.prepSetTaskArchived,
+		"assign_task":            s.prepAssignTask,
+		"get_trash":              s.prepGetTrash,
+		"restore_task":           s.prepRestoreTask,
+		"search_tasks":           s.prepSearchTasks,
+		"get_subtasks":           s.prepGetSubtasks,
+		"create_user":            s.prepCreateUser,
+		"get_user_by_id":         s.prepGetUserByID,
+		"get_user_by_username":   s.prepGetUserByUsername,
+		"update_user":            s.prepUpdateUser,
+		"delete_user":            s.prepDeleteUser,
+		"purge_deleted":          s.prepPurgeDeleted,
+		"create_tag":             prepCreateTag,
+		"get_tags":               prepGetTags,
+		"get_tag_by_id":          prepGetTagByID,
+		"update_tag":             prepUpdateTag,
+		"delete_tag":             prepDeleteTag,
+		"attach_tag":             prepAttachTag,
+		"detach_tag":             prepDetachTag,
+		"create_template":        prepCreateTemplate,
+		"get_templates":          prepGetTemplates,
+		"get_template_by_id":     prepGetTemplateByID,
+		"update_template":        prepUpdateTemplate,
+		"delete_template":        prepDeleteTemplate,
+		"search_users":           prepSearchUsers,
+		"get_user_preferences":   prepGetUserPreferences,
+		"save_user_preferences":  prepSaveUserPreferences,
+		"is_user_active":         prepIsUserActive,
+		"set_user_active":        prepSetUserActive,
+		"get_login_history":      prepGetLoginHistory,
+		"create_webhook":         prepCreateWebhook,
+		"get_webhooks":           prepGetWebhooks,
+		"get_webhook_by_id":      prepGetWebhookByID,
+		"delete_webhook":         prepDeleteWebhook,
+		"add_webhook_delivery":   prepAddWebhookDelivery,
+		"get_webhook_deliveries": prepGetWebhookDeliveries,
+		"get_workflow":           prepGetWorkflow,
+		"save_workflow":          prepSaveWorkflow,
+		"delete_workflow":        prepDeleteWorkflow,
+		"get_overdue_tasks":      prepGetOverdueTasks,
+		"get_due_tasks":          prepGetDueTasks,
+		"create_workspace":       prepCreateWorkspace,
+		"add_workspace_owner":    prepAddWorkspaceOwner,
+		"get_workspaces":         prepGetWorkspaces,
+		"get_workspace_by_id":    prepGetWorkspaceByID,
+		"update_workspace":       prepUpdateWorkspace,
+		"delete_workspace":       prepDeleteWorkspace,
+		"get_workspace_members":  prepGetWorkspaceMembers,
+		"get_workspace_role":     prepGetWorkspaceRole,
+		"save_workspace_member":  prepSaveWorkspaceMember,
+		"drop_workspace_member":  prepRemoveWorkspaceMember,
+	}
+}
+
+func (s *Storage) prepareStatements(ctx context.Context, conn *pgx.Conn) error {
+	for name, sql := range s.statements() {
+		if _, err := conn.Prepare(ctx, name, sql); err != nil {
+			log.Printf("[ERROR] Не удалось подготовить запрос %s: %v", name, err)
+			return err
+		}
+	}
+	return nil
+}
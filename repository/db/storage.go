@@ -2,320 +2,724 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"project/internal/domain/errors"
 	"project/internal/domain/models"
+	"project/internal/purge"
+	"project/internal/requestid"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const taskColumns = `tasks.id, tasks.title, tasks.description, tasks.status, tasks.user_id, tasks.deleted, tasks.archived, COALESCE(tasks.parent_id::text, ''),
+	COALESCE(tasks.project_id::text, ''), COALESCE(tasks.workspace_id::text, ''), COALESCE(tasks.assignee_id::text, ''), tasks.position, tasks.due_date, tasks.reminder_offset_minutes, tasks.reminded_at, tasks.deleted_at, tasks.version, tasks.updated_at,
+	ARRAY(SELECT tags.name FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id ORDER BY tags.name),
+	(SELECT COUNT(*) FROM task_checklist_items c WHERE c.task_id = tasks.id), (SELECT COUNT(*) FROM task_checklist_items c WHERE c.task_id = tasks.id AND c.done)`
+
+const purgeBatchSize = 500
+
+func scanTask(row pgx.Row, task *models.Task) error {
+	var checklistTotal, checklistDone int
+	if err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.UserID, &task.Deleted, &task.Archived, &task.ParentID,
+		&task.ProjectID, &task.WorkspaceID, &task.AssigneeID, &task.Position, &task.DueDate, &task.ReminderOffsetMinutes, &task.RemindedAt, &task.DeletedAt, &task.Version, &task.UpdatedAt, &task.Tags,
+		&checklistTotal, &checklistDone); err != nil {
+		return err
+	}
+	task.Checklist = models.NewChecklistProgress(checklistTotal, checklistDone)
+	return nil
+}
+
+type PoolConfig struct {
+	MinConns          int32
+	MaxConns          int32
+	HealthCheckPeriod time.Duration
+	Retry             RetryPolicy
+	ReplicaDSN        string
+	Timeouts          QueryTimeouts
+	Breaker           BreakerPolicy
+	SlowQuery         time.Duration
+	SQLComments       bool
+}
+
 type Storage struct {
-	conn                  *pgx.Conn
+	pool                  *pgxpool.Pool
+	replica               *pgxpool.Pool
 	prepCreateTask        string
 	prepGetTaskByID       string
-	prepGetTasks          string
 	prepUpdateTask        string
 	prepDeleteTask        string
+	prepSearchTasks       string
+	prepGetSubtasks       string
+	prepGetTrash          string
+	prepRestoreTask       string
+	prepHardDeleteTask    string
+	prepSetTaskArchived   string
+	prepAssignTask        string
 	prepCreateUser        string
 	prepGetUserByID       string
 	prepGetUserByUsername string
 	prepUpdateUser        string
 	prepDeleteUser        string
-	deleteQueue           chan struct{}
+	prepPurgeDeleted      string
+
+	retry    RetryPolicy
+	breaker  *circuitBreaker
+	timeouts QueryTimeouts
+	purger   *purge.Worker
+	queries  *queryMetrics
+	tx       pgx.Tx
+	connect  func(ctx context.Context) (querier, func(), error)
+
+	sqlComments map[string]string
 }
 
-func NewStorage(connStr string) (*Storage, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-	conn, err := pgx.Connect(ctx, connStr)
+func newPool(ctx context.Context, connStr string, poolCfg PoolConfig, afterConnect func(context.Context, *pgx.Conn) error, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connStr)
 	if err != nil {
-		log.Println("[ERROR] Не удалось подключиться к базе данных:", err)
+		log.Println("[ERROR] Некорректная строка подключения к базе данных:", err)
 		return nil, err
 	}
-
-	s := &Storage{
-		conn:                  conn,
-		prepCreateTask:        `INSERT INTO tasks (id, title, description, status, user_id) VALUES ($1, $2, $3, $4, $5)`,
-		prepGetTaskByID:       `SELECT id, title, description, status, user_id, deleted FROM tasks WHERE id = $1`,
-		prepGetTasks:          `SELECT id, title, description, status, user_id FROM tasks WHERE user_id = $1 AND deleted = false`,
-		prepUpdateTask:        `UPDATE tasks SET title = $1, description = $2, status = $3 WHERE id = $4`,
-		prepDeleteTask:        `UPDATE tasks SET deleted = true WHERE id = $1 AND deleted = false`,
-		prepCreateUser:        `INSERT INTO users (id, username, email, password, role) VALUES ($1, $2, $3, $4, $5)`,
-		prepGetUserByID:       `SELECT id, username, email, password, role FROM users WHERE id = $1`,
-		prepGetUserByUsername: `SELECT id, username, email, password, role FROM users WHERE username = $1`,
-		prepUpdateUser:        `UPDATE users SET username = $1, email = $2, password = $3, role = $4 WHERE id = $5`,
-		prepDeleteUser:        `DELETE FROM users WHERE id = $1`,
-		deleteQueue:           make(chan struct{}, 10),
+	if poolCfg.MaxConns > 0 {
+		config.MaxConns = poolCfg.MaxConns
 	}
-	log.Println("[SUCCESS] Соединение с базой данных установлено успешно")
-	return s, nil
+	if poolCfg.MinConns > 0 {
+		config.MinConns = min(poolCfg.MinConns, config.MaxConns)
+	}
+	if poolCfg.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = poolCfg.HealthCheckPeriod
+	}
+	config.AfterConnect = afterConnect
+	config.ConnConfig.Tracer = tracer
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		log.Println("[ERROR] Не удалось создать пул соединений с базой данных:", err)
+		return nil, err
+	}
+	return pool, nil
 }
 
-func (s *Storage) CreateTask(ctx context.Context, task *models.Task) error {
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
-	id := uuid.New().String()
-	task.ID = id
-	task.Deleted = false
-	stmt, err := s.conn.Prepare(ctx, "create_task", s.prepCreateTask)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на создание задачи:", err)
-		return err
+func newReplicaPool(ctx context.Context, poolCfg PoolConfig, afterConnect func(context.Context, *pgx.Conn) error, tracer pgx.QueryTracer) *pgxpool.Pool {
+	if poolCfg.ReplicaDSN == "" {
+		return nil
 	}
-	_, err = s.conn.Exec(ctx, stmt.Name, task.ID, task.Title, task.Description, task.Status, task.UserID)
+	replica, err := newPool(ctx, poolCfg.ReplicaDSN, poolCfg, afterConnect, tracer)
 	if err != nil {
-		log.Println("[ERROR] Не удалось создать задачу:", err)
-		return errors.ErrConflict
+		log.Println("[WARN] Реплика для чтения не настроена, все запросы идут в основную БД:", err)
+		return nil
 	}
-	log.Println("[SUCCESS] Задача успешно создана:", task.ID)
-	return nil
+	if err := replica.Ping(ctx); err != nil {
+		log.Println("[WARN] Реплика для чтения сейчас недоступна, чтение временно идет из основной БД:", err)
+	} else {
+		log.Println("[SUCCESS] Подключена реплика для чтения")
+	}
+	return replica
 }
 
-func (s *Storage) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+func NewStorage(connStr string, poolCfg PoolConfig) (*Storage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "get_task_by_id", s.prepGetTaskByID)
+
+	s := &Storage{
+		retry:                 poolCfg.Retry,
+		breaker:               newCircuitBreaker(poolCfg.Breaker),
+		timeouts:              poolCfg.Timeouts,
+		prepCreateTask:        `INSERT INTO tasks (id, title, description, status, user_id, parent_id, due_date, reminder_offset_minutes, project_id, workspace_id, position) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, $7, $8, NULLIF($9, '')::uuid, NULLIF($10, '')::uuid, (SELECT COALESCE(MAX(position), -1) + 1 FROM tasks WHERE user_id = $5)) RETURNING position, version, updated_at`,
+		prepGetTaskByID:       `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1`,
+		prepUpdateTask:        `UPDATE tasks SET title = $1, description = $2, status = $3, due_date = $5, reminder_offset_minutes = $6, project_id = NULLIF($7, '')::uuid, reminded_at = CASE WHEN due_date IS DISTINCT FROM $5 OR reminder_offset_minutes <> $6 THEN NULL ELSE reminded_at END WHERE id = $4 RETURNING version, updated_at`,
+		prepDeleteTask:        `WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE id = $1 AND deleted = false UNION ALL SELECT tasks.id FROM tasks JOIN tree ON tasks.parent_id = tree.id) UPDATE tasks SET deleted = true, deleted_at = now() WHERE id IN (SELECT id FROM tree) AND deleted = false`,
+		prepSearchTasks:       `SELECT ` + taskColumns + ` FROM tasks, websearch_to_tsquery('simple', $2) q WHERE user_id = $1 AND deleted = false AND search_vector @@ q ORDER BY ts_rank(search_vector, q) DESC, title`,
+		prepGetSubtasks:       `SELECT ` + taskColumns + ` FROM tasks WHERE parent_id = $1 AND deleted = false ORDER BY title`,
+		prepGetTrash:          `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1 AND deleted = true ORDER BY deleted_at DESC NULLS LAST, title`,
+		prepRestoreTask:       `WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE id = $1 AND deleted = true UNION ALL SELECT tasks.id FROM tasks JOIN tree ON tasks.parent_id = tree.id WHERE tasks.deleted = true) UPDATE tasks SET deleted = false, deleted_at = NULL WHERE id IN (SELECT id FROM tree)`,
+		prepHardDeleteTask:    `DELETE FROM tasks WHERE id = $1`,
+		prepSetTaskArchived:   `UPDATE tasks SET archived = $2 WHERE id = $1 AND deleted = false`,
+		prepAssignTask:        `UPDATE tasks SET assignee_id = NULLIF($2, '')::uuid WHERE id = $1 AND deleted = false`,
+		prepCreateUser:        `INSERT INTO users (id, username, email, password, role) VALUES ($1, $2, $3, $4, $5)`,
+		prepGetUserByID:       `SELECT id, username, email, password, role, active, last_login_at FROM users WHERE id = $1`,
+		prepGetUserByUsername: `SELECT id, username, email, password, role, active, last_login_at FROM users WHERE lower(username) = $1`,
+		prepUpdateUser:        `UPDATE users SET username = $1, email = $2, password = $3, role = $4 WHERE id = $5`,
+		prepDeleteUser:        `DELETE FROM users WHERE id = $1`,
+		prepPurgeDeleted:      `DELETE FROM tasks WHERE id IN (SELECT id FROM tasks WHERE deleted = true AND deleted_at < $1 ORDER BY deleted_at LIMIT $2)`,
+	}
+
+	s.queries = newQueryMetrics(s.statements())
+	if poolCfg.SQLComments {
+		s.sqlComments = s.statements()
+	}
+	if poolCfg.SlowQuery > 0 {
+		s.queries.slow = poolCfg.SlowQuery
+	}
+	pool, err := newPool(ctx, connStr, poolCfg, s.prepareStatements, s.queries)
 	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на получение задачи по ID:", err)
 		return nil, err
 	}
-	row := s.conn.QueryRow(ctx, stmt.Name, id)
-	task := &models.Task{}
-	if err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.UserID, &task.Deleted); err != nil {
-		if err == pgx.ErrNoRows {
-			log.Println("[ERROR] Задача не найдена:", id)
-			return nil, errors.ErrNotFound
-		}
-		log.Println("[ERROR] Ошибка при получении задачи:", err)
+	if err := poolCfg.Retry.do(ctx, isTransient, func() error { return pool.Ping(ctx) }); err != nil {
+		pool.Close()
+		log.Println("[ERROR] Не удалось подключиться к базе данных:", err)
 		return nil, err
 	}
-	log.Println("[SUCCESS] Задача найдена:", id)
-	return task, nil
+	s.pool = pool
+	s.replica = newReplicaPool(ctx, poolCfg, s.prepareStatements, s.queries)
+	log.Println("[SUCCESS] Соединение с базой данных установлено успешно, максимум соединений в пуле:", pool.Config().MaxConns)
+	return s, nil
 }
 
-func (s *Storage) GetTasks(ctx context.Context, userID string) ([]models.Task, error) {
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "get_tasks", s.prepGetTasks)
+func (s *Storage) acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := s.pool.Acquire(ctx)
 	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на получение всех задач:", err)
+		requestid.Println(ctx, "[ERROR] Не удалось получить соединение из пула:", err)
 		return nil, err
 	}
-	rows, err := s.conn.Query(ctx, stmt.Name, userID)
+	return conn, nil
+}
+
+func (s *Storage) Close() {
+	if s.replica != nil {
+		s.replica.Close()
+	}
+	s.pool.Close()
+}
+
+func (s *Storage) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+func (s *Storage) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := s.pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
 	if err != nil {
-		log.Println("[ERROR] Не удалось получить задачи:", err)
-		return nil, err
+		return 0, false, err
 	}
-	defer rows.Close()
+	return uint(version), dirty, nil
+}
 
-	tasks := []models.Task{}
-	for rows.Next() {
-		task := models.Task{}
-		if err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.UserID); err != nil {
-			log.Println("[ERROR] Ошибка при чтении задач:", err)
-			return nil, err
+func (s *Storage) CreateTask(ctx context.Context, task *models.Task) error {
+	ctx, cancel := s.writeContext(ctx, "CreateTask")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		id := uuid.New().String()
+		task.ID = id
+		task.Deleted = false
+		err := conn.QueryRow(ctx, "create_task", task.ID, task.Title, task.Description, task.Status, task.UserID, task.ParentID, task.DueDate, task.ReminderOffsetMinutes, task.ProjectID, task.WorkspaceID).Scan(&task.Position, &task.Version, &task.UpdatedAt)
+		if err != nil {
+			if isUniqueViolation(err) || violatedForeignKey(err) != "" {
+				requestid.Println(ctx, "[ERROR] Задача конфликтует с существующими данными:", err)
+				return errors.ErrConflict
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось создать задачу:", err)
+			return err
 		}
-		tasks = append(tasks, task)
-	}
-	log.Println("[SUCCESS] Получено задач:", len(tasks))
-	return tasks, nil
+		requestid.Println(ctx, "[SUCCESS] Задача успешно создана:", task.ID)
+		return nil
+	})
 }
 
-func (s *Storage) UpdateTask(ctx context.Context, id string, task *models.Task) error {
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+func (s *Storage) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
+	ctx, cancel := s.readContext(ctx, "GetTaskByID")
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "update_task", s.prepUpdateTask)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на обновление задачи:", err)
-		return err
+	var result *models.Task
+	err := s.withReadConn(ctx, func(conn querier) error {
+		row := conn.QueryRow(ctx, "get_task_by_id", id)
+		task := &models.Task{}
+		if err := scanTask(row, task); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Задача не найдена:", id)
+				return errors.ErrNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении задачи:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Задача найдена:", id)
+		result = task
+		return nil
+	})
+	return result, err
+}
+
+var taskSortColumns = map[string]string{
+	models.TaskSortPosition: "position",
+	models.TaskSortTitle:    "title",
+	models.TaskSortStatus:   "status",
+	models.TaskSortDueDate:  "due_date",
+}
+
+func buildGetTasksQuery(userID string, filter models.TaskFilter) (string, []interface{}) {
+	var sb strings.Builder
+	args := []interface{}{userID}
+	switch {
+	case filter.WorkspaceID != "":
+		sb.WriteString(`SELECT ` + taskColumns + ` FROM tasks WHERE workspace_id = $1`)
+		args[0] = filter.WorkspaceID
+	case filter.View == models.TaskViewAssigned:
+		sb.WriteString(`SELECT ` + taskColumns + ` FROM tasks WHERE assignee_id = $1`)
+	default:
+		sb.WriteString(`SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1`)
 	}
-	ct, err := s.conn.Exec(ctx, stmt.Name, task.Title, task.Description, task.Status, id)
-	if err != nil {
-		log.Println("[ERROR] Не удалось обновить задачу:", err)
-		return err
+
+	deleted := false
+	if filter.Deleted != nil {
+		deleted = *filter.Deleted
 	}
-	if ct.RowsAffected() == 0 {
-		log.Println("[ERROR] Задача для обновления не найдена:", id)
-		return errors.ErrNotFound
+	args = append(args, deleted)
+	fmt.Fprintf(&sb, ` AND deleted = $%d`, len(args))
+
+	archived := false
+	if filter.Archived != nil {
+		archived = *filter.Archived
 	}
-	log.Println("[SUCCESS] Задача успешно обновлена:", id)
-	return nil
-}
+	args = append(args, archived)
+	fmt.Fprintf(&sb, ` AND archived = $%d`, len(args))
 
-func (s *Storage) DeleteTask(ctx context.Context, id string) error {
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "delete_task_soft", s.prepDeleteTask)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на пометку задачи как удалённой:", err)
-		return err
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		fmt.Fprintf(&sb, ` AND status = $%d`, len(args))
 	}
-	ct, err := s.conn.Exec(ctx, stmt.Name, id)
-	if err != nil {
-		log.Println("[ERROR] Не удалось пометить задачу как удалённую:", err)
-		return err
+	if filter.TitleContains != "" {
+		args = append(args, "%"+escapeLike(filter.TitleContains)+"%")
+		fmt.Fprintf(&sb, ` AND title ILIKE $%d`, len(args))
 	}
-	if ct.RowsAffected() == 0 {
-		log.Println("[ERROR] Задача для удаления не найдена:", id)
-		return errors.ErrNotFound
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		fmt.Fprintf(&sb, ` AND EXISTS (SELECT 1 FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id AND tags.name = $%d)`, len(args))
 	}
-	log.Println("[SUCCESS] Задача помечена как удалённая:", id)
-	s.tryEnqueueOrFlush()
-	return nil
+	if filter.ProjectID != "" {
+		args = append(args, filter.ProjectID)
+		fmt.Fprintf(&sb, ` AND project_id = $%d`, len(args))
+	}
+	column, ok := taskSortColumns[filter.Sort]
+	if !ok {
+		column = "position"
+	}
+	order := column
+	if filter.Descending {
+		order += " DESC"
+	}
+	if column == "due_date" {
+		order += " NULLS LAST"
+	}
+	if column != "position" {
+		order += ", position"
+	}
+	sb.WriteString(` ORDER BY ` + order + `, id`)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		fmt.Fprintf(&sb, ` LIMIT $%d`, len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		fmt.Fprintf(&sb, ` OFFSET $%d`, len(args))
+	}
+	return sb.String(), args
 }
 
-func (s *Storage) CreateUser(user *models.User) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (s *Storage) GetTasks(ctx context.Context, userID string, filter models.TaskFilter) ([]models.Task, error) {
+	ctx, cancel := s.readContext(ctx, "GetTasks")
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "create_user", s.prepCreateUser)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на создание пользователя:", err)
-		return err
-	}
-	_, err = s.conn.Exec(ctx, stmt.Name, user.ID, user.Username, user.Email, user.Password, user.Role)
-	if err != nil {
-		log.Println("[ERROR] Не удалось создать пользователя:", err)
-		return errors.ErrUserAlreadyExists
-	}
-	log.Println("[SUCCESS] Пользователь успешно создан:", user.ID)
-	return nil
+	var result []models.Task
+	err := s.withReadConn(ctx, func(conn querier) error {
+		query, args := buildGetTasksQuery(userID, filter)
+		rows, err := conn.Query(ctx, query, args...)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить задачи:", err)
+			return err
+		}
+		defer rows.Close()
+
+		tasks := []models.Task{}
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении задач:", err)
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении задач:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено задач:", len(tasks))
+		result = tasks
+		return nil
+	})
+	return result, err
 }
 
-func (s *Storage) GetUserByID(id string) (*models.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+func (s *Storage) UpdateTask(ctx context.Context, id string, task *models.Task) error {
+	ctx, cancel := s.writeContext(ctx, "UpdateTask")
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "get_user_by_id", s.prepGetUserByID)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на получение пользователя по ID:", err)
-		return nil, err
-	}
-	row := s.conn.QueryRow(ctx, stmt.Name, id)
-	user := &models.User{}
-	if err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role); err != nil {
+	return s.withConn(ctx, func(conn querier) error {
+		err := conn.QueryRow(ctx, "update_task", task.Title, task.Description, task.Status, id, task.DueDate, task.ReminderOffsetMinutes, task.ProjectID).Scan(&task.Version, &task.UpdatedAt)
 		if err == pgx.ErrNoRows {
-			log.Println("[ERROR] Пользователь не найден:", id)
-			return nil, errors.ErrUserNotFound
+			requestid.Println(ctx, "[ERROR] Задача для обновления не найдена:", id)
+			return errors.ErrNotFound
 		}
-		log.Println("[ERROR] Ошибка при получении пользователя:", err)
-		return nil, err
-	}
-	log.Println("[SUCCESS] Пользователь найден:", id)
-	return user, nil
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось обновить задачу:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Задача успешно обновлена:", id)
+		return nil
+	})
 }
 
-func (s *Storage) GetUserByUsername(username string) (*models.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+func (s *Storage) DeleteTask(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteTask")
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "get_user_by_username", s.prepGetUserByUsername)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на получение пользователя по имени:", err)
-		return nil, err
-	}
-	row := s.conn.QueryRow(ctx, stmt.Name, username)
-	user := &models.User{}
-	if err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role); err != nil {
-		if err == pgx.ErrNoRows {
-			log.Println("[ERROR] Пользователь не найден:", username)
-			return nil, errors.ErrUserNotFound
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_task_soft", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось пометить задачу как удалённую:", err)
+			return err
 		}
-		log.Println("[ERROR] Ошибка при получении пользователя:", err)
-		return nil, err
-	}
-	log.Println("[SUCCESS] Пользователь найден:", username)
-	return user, nil
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Задача для удаления не найдена:", id)
+			return errors.ErrNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Задача помечена как удалённая:", id)
+		return nil
+	})
 }
 
-func (s *Storage) UpdateUser(id string, user *models.User) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+func (s *Storage) HardDeleteTask(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "HardDeleteTask")
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "update_user", s.prepUpdateUser)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на обновление пользователя:", err)
-		return err
-	}
-	ct, err := s.conn.Exec(ctx, stmt.Name, user.Username, user.Email, user.Password, user.Role, id)
-	if err != nil {
-		log.Println("[ERROR] Не удалось обновить пользователя:", err)
-		return err
-	}
-	if ct.RowsAffected() == 0 {
-		log.Println("[ERROR] Пользователь для обновления не найден:", id)
-		return errors.ErrUserNotFound
-	}
-	log.Println("[SUCCESS] Пользователь успешно обновлен:", id)
-	return nil
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_task_hard", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось безвозвратно удалить задачу:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Задача для безвозвратного удаления не найдена:", id)
+			return errors.ErrNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Задача удалена безвозвратно:", id)
+		return nil
+	})
 }
 
-func (s *Storage) DeleteUser(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+func (s *Storage) SetTaskArchived(ctx context.Context, id string, archived bool) error {
+	ctx, cancel := s.writeContext(ctx, "SetTaskArchived")
 	defer cancel()
-	stmt, err := s.conn.Prepare(ctx, "delete_user", s.prepDeleteUser)
-	if err != nil {
-		log.Println("[ERROR] Не удалось подготовить запрос на удаление пользователя:", err)
-		return err
-	}
-	ct, err := s.conn.Exec(ctx, stmt.Name, id)
-	if err != nil {
-		log.Println("[ERROR] Не удалось удалить пользователя:", err)
-		return err
-	}
-	if ct.RowsAffected() == 0 {
-		log.Println("[ERROR] Пользователь для удаления не найден:", id)
-		return errors.ErrUserNotFound
-	}
-	log.Println("[SUCCESS] Пользователь успешно удален:", id)
-	return nil
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "set_task_archived", id, archived)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось изменить признак архивации задачи:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Задача для архивации не найдена:", id)
+			return errors.ErrNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Признак архивации задачи изменен:", id, archived)
+		return nil
+	})
 }
 
-func (s *Storage) EnqueueHardDelete(_ string) {
-	s.tryEnqueueOrFlush()
+func (s *Storage) AssignTask(ctx context.Context, id, assigneeID string) error {
+	ctx, cancel := s.writeContext(ctx, "AssignTask")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "assign_task", id, assigneeID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось назначить исполнителя задачи:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Задача для назначения не найдена:", id)
+			return errors.ErrNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Исполнитель задачи изменен:", id, assigneeID)
+		return nil
+	})
 }
 
-func (s *Storage) tryEnqueueOrFlush() {
-	if s.deleteQueue == nil {
-		return
-	}
-	select {
-	case s.deleteQueue <- struct{}{}:
-	default:
-		s.drainDeleteQueue()
-		if affected, err := s.hardDeleteAllFlagged(context.Background()); err != nil {
-			log.Println("[ERROR] Ошибка при удалении задач с признаком deleted:", err)
-		} else if affected > 0 {
-			log.Println("[SUCCESS] Жёстко удалено задач:", affected)
+func (s *Storage) GetTrash(ctx context.Context, userID string) ([]models.Task, error) {
+	ctx, cancel := s.readContext(ctx, "GetTrash")
+	defer cancel()
+	var result []models.Task
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_trash", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить корзину:", err)
+			return err
 		}
-	}
+		defer rows.Close()
+
+		tasks := []models.Task{}
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении корзины:", err)
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении корзины:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Задач в корзине:", len(tasks))
+		result = tasks
+		return nil
+	})
+	return result, err
 }
 
-func (s *Storage) drainDeleteQueue() {
-	if s.deleteQueue == nil {
-		return
-	}
+func (s *Storage) RestoreTask(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "RestoreTask")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "restore_task", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось восстановить задачу:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Задача для восстановления не найдена:", id)
+			return errors.ErrTaskNotInTrash
+		}
+		requestid.Println(ctx, "[SUCCESS] Задача восстановлена:", id)
+		return nil
+	})
+}
+
+func (s *Storage) SearchTasks(ctx context.Context, userID, query string) ([]models.Task, error) {
+	ctx, cancel := s.readContext(ctx, "SearchTasks")
+	defer cancel()
+	var result []models.Task
+	err := s.withReadConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "search_tasks", userID, query)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось выполнить поиск задач:", err)
+			return err
+		}
+		defer rows.Close()
+
+		tasks := []models.Task{}
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении результатов поиска:", err)
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении результатов поиска:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Найдено задач:", len(tasks))
+		result = tasks
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetSubtasks(ctx context.Context, parentID string) ([]models.Task, error) {
+	ctx, cancel := s.readContext(ctx, "GetSubtasks")
+	defer cancel()
+	var result []models.Task
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_subtasks", parentID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить подзадачи:", err)
+			return err
+		}
+		defer rows.Close()
+
+		tasks := []models.Task{}
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении подзадач:", err)
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении подзадач:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено подзадач:", len(tasks))
+		result = tasks
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) CreateUser(ctx context.Context, user *models.User) error {
+	ctx, cancel := s.writeContext(ctx, "CreateUser")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		user.Username = models.NormalizeUsername(user.Username)
+		user.Email = models.NormalizeEmail(user.Email)
+		_, err := conn.Exec(ctx, "create_user", user.ID, user.Username, user.Email, user.Password, user.Role)
+		if err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Пользователь с таким именем или email уже существует:", user.Username)
+				return errors.ErrUserAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось создать пользователя:", err)
+			return err
+		}
+		user.Active = true
+		requestid.Println(ctx, "[SUCCESS] Пользователь успешно создан:", user.ID)
+		return nil
+	})
+}
+
+func (s *Storage) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	ctx, cancel := s.readContext(ctx, "GetUserByID")
+	defer cancel()
+	var result *models.User
+	err := s.withReadConn(ctx, func(conn querier) error {
+		row := conn.QueryRow(ctx, "get_user_by_id", id)
+		user := &models.User{}
+		if err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.Active, &user.LastLoginAt); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Пользователь не найден:", id)
+				return errors.ErrUserNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении пользователя:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Пользователь найден:", id)
+		result = user
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	ctx, cancel := s.readContext(ctx, "GetUserByUsername")
+	defer cancel()
+	var result *models.User
+	err := s.withConn(ctx, func(conn querier) error {
+		row := conn.QueryRow(ctx, "get_user_by_username", models.NormalizeUsername(username))
+		user := &models.User{}
+		if err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.Active, &user.LastLoginAt); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Пользователь не найден:", username)
+				return errors.ErrUserNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении пользователя:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Пользователь найден:", username)
+		result = user
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, id string, user *models.User) error {
+	ctx, cancel := s.writeContext(ctx, "UpdateUser")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		user.Username = models.NormalizeUsername(user.Username)
+		user.Email = models.NormalizeEmail(user.Email)
+		ct, err := conn.Exec(ctx, "update_user", user.Username, user.Email, user.Password, user.Role, id)
+		if err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Пользователь с таким именем или email уже существует:", user.Username)
+				return errors.ErrUserAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось обновить пользователя:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Пользователь для обновления не найден:", id)
+			return errors.ErrUserNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Пользователь успешно обновлен:", id)
+		return nil
+	})
+}
+
+func (s *Storage) DeleteUser(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteUser")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_user", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить пользователя:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Пользователь для удаления не найден:", id)
+			return errors.ErrUserNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Пользователь успешно удален:", id)
+		return nil
+	})
+}
+
+func (s *Storage) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
 	for {
-		select {
-		case <-s.deleteQueue:
-		default:
-			return
+		purged, err := s.purgeDeletedBatch(ctx, before)
+		total += purged
+		if err != nil {
+			return total, err
+		}
+		if purged < purgeBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
 		}
 	}
 }
 
-func (s *Storage) hardDeleteAllFlagged(ctx context.Context) (int64, error) {
-	c, cancel := context.WithTimeout(ctx, 15*time.Second)
+func (s *Storage) purgeDeletedBatch(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := s.writeContext(ctx, "PurgeDeleted")
 	defer cancel()
-	tx, err := s.conn.Begin(c)
-	if err != nil {
-		return 0, err
+	var result int64
+	err := s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "purge_deleted", before, purgeBatchSize)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось очистить корзину:", err)
+			return err
+		}
+		result = ct.RowsAffected()
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) StartPurgeWorker(interval, retention time.Duration) {
+	if s.purger != nil {
+		return
 	}
-	ct, err := tx.Exec(c, `DELETE FROM tasks WHERE deleted = true`)
-	if err != nil {
-		_ = tx.Rollback(c)
-		return 0, err
+	s.purger = purge.NewWorker(s, interval, retention)
+	s.purger.Start()
+}
+
+func (s *Storage) StopPurgeWorker(ctx context.Context) error {
+	if s.purger == nil {
+		return nil
 	}
-	if err := tx.Commit(c); err != nil {
-		return 0, err
+	return s.purger.Close(ctx)
+}
+
+func (s *Storage) PurgeStats() purge.Stats {
+	if s.purger == nil {
+		return purge.Stats{}
 	}
-	return ct.RowsAffected(), nil
+	return s.purger.Stats()
 }
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	prepCreateTag  = `INSERT INTO tags (id, user_id, name) VALUES ($1, $2, $3)`
+	prepGetTags    = `SELECT id, user_id, name FROM tags WHERE user_id = $1 ORDER BY name`
+	prepGetTagByID = `SELECT id, user_id, name FROM tags WHERE id = $1`
+	prepUpdateTag  = `UPDATE tags SET name = $1 WHERE id = $2`
+	prepDeleteTag  = `DELETE FROM tags WHERE id = $1`
+	prepAttachTag  = `INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	prepDetachTag  = `DELETE FROM task_tags WHERE task_id = $1 AND tag_id = $2`
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+func isUniqueViolation(err error) bool {
+	pgErr, ok := err.(*pgconn.PgError)
+	return ok && pgErr.Code == pgUniqueViolation
+}
+
+func violatedForeignKey(err error) string {
+	pgErr, ok := err.(*pgconn.PgError)
+	if !ok || pgErr.Code != pgForeignKeyViolation {
+		return ""
+	}
+	return pgErr.ConstraintName
+}
+
+func (s *Storage) CreateTag(ctx context.Context, tag *models.Tag) error {
+	ctx, cancel := s.writeContext(ctx, "CreateTag")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tag.ID = uuid.New().String()
+		if _, err := conn.Exec(ctx, "create_tag", tag.ID, tag.UserID, tag.Name); err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Тег уже существует:", tag.Name)
+				return errors.ErrTagAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось создать тег:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Тег успешно создан:", tag.ID)
+		return nil
+	})
+}
+
+func (s *Storage) GetTags(ctx context.Context, userID string) ([]models.Tag, error) {
+	ctx, cancel := s.readContext(ctx, "GetTags")
+	defer cancel()
+	var result []models.Tag
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_tags", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить теги:", err)
+			return err
+		}
+		defer rows.Close()
+
+		tags := []models.Tag{}
+		for rows.Next() {
+			tag := models.Tag{}
+			if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении тегов:", err)
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении тегов:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено тегов:", len(tags))
+		result = tags
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetTagByID(ctx context.Context, id string) (*models.Tag, error) {
+	ctx, cancel := s.readContext(ctx, "GetTagByID")
+	defer cancel()
+	var result *models.Tag
+	err := s.withConn(ctx, func(conn querier) error {
+		tag := &models.Tag{}
+		if err := conn.QueryRow(ctx, "get_tag_by_id", id).Scan(&tag.ID, &tag.UserID, &tag.Name); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Тег не найден:", id)
+				return errors.ErrTagNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении тега:", err)
+			return err
+		}
+		result = tag
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) UpdateTag(ctx context.Context, id string, tag *models.Tag) error {
+	ctx, cancel := s.writeContext(ctx, "UpdateTag")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "update_tag", tag.Name, id)
+		if err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Тег уже существует:", tag.Name)
+				return errors.ErrTagAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось обновить тег:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Тег для обновления не найден:", id)
+			return errors.ErrTagNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Тег успешно обновлен:", id)
+		return nil
+	})
+}
+
+func (s *Storage) DeleteTag(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteTag")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_tag", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить тег:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Тег для удаления не найден:", id)
+			return errors.ErrTagNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Тег успешно удален:", id)
+		return nil
+	})
+}
+
+func (s *Storage) AttachTag(ctx context.Context, taskID, tagID string) error {
+	ctx, cancel := s.writeContext(ctx, "AttachTag")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		if _, err := conn.Exec(ctx, "attach_tag", taskID, tagID); err != nil {
+			switch violatedForeignKey(err) {
+			case "task_tags_task_id_fkey":
+				requestid.Println(ctx, "[ERROR] Задача для привязки тега не найдена:", taskID)
+				return errors.ErrNotFound
+			case "task_tags_tag_id_fkey":
+				requestid.Println(ctx, "[ERROR] Тег для привязки не найден:", tagID)
+				return errors.ErrTagNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось привязать тег к задаче:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Тег привязан к задаче:", taskID, tagID)
+		return nil
+	})
+}
+
+func (s *Storage) DetachTag(ctx context.Context, taskID, tagID string) error {
+	ctx, cancel := s.writeContext(ctx, "DetachTag")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "detach_tag", taskID, tagID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось отвязать тег от задачи:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Тег не привязан к задаче:", taskID, tagID)
+			return errors.ErrTagNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Тег отвязан от задачи:", taskID, tagID)
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	templateColumns     = `id, user_id, name, title, description, tags, checklist`
+	prepCreateTemplate  = `INSERT INTO task_templates (id, user_id, name, title, description, tags, checklist) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	prepGetTemplates    = `SELECT ` + templateColumns + ` FROM task_templates WHERE user_id = $1 ORDER BY name`
+	prepGetTemplateByID = `SELECT ` + templateColumns + ` FROM task_templates WHERE id = $1`
+	prepUpdateTemplate  = `UPDATE task_templates SET name = $1, title = $2, description = $3, tags = $4, checklist = $5 WHERE id = $6`
+	prepDeleteTemplate  = `DELETE FROM task_templates WHERE id = $1`
+)
+
+func scanTemplate(row pgx.Row, template *models.TaskTemplate) error {
+	return row.Scan(&template.ID, &template.UserID, &template.Name, &template.Title, &template.Description, &template.Tags, &template.Checklist)
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func (s *Storage) CreateTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	ctx, cancel := s.writeContext(ctx, "CreateTemplate")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		template.ID = uuid.New().String()
+		template.Tags = nonNilStrings(template.Tags)
+		template.Checklist = nonNilStrings(template.Checklist)
+		if _, err := conn.Exec(ctx, "create_template", template.ID, template.UserID, template.Name, template.Title, template.Description, template.Tags, template.Checklist); err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Шаблон уже существует:", template.Name)
+				return errors.ErrTemplateAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось создать шаблон:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Шаблон успешно создан:", template.ID)
+		return nil
+	})
+}
+
+func (s *Storage) GetTemplates(ctx context.Context, userID string) ([]models.TaskTemplate, error) {
+	ctx, cancel := s.readContext(ctx, "GetTemplates")
+	defer cancel()
+	var result []models.TaskTemplate
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_templates", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить шаблоны:", err)
+			return err
+		}
+		defer rows.Close()
+
+		templates := []models.TaskTemplate{}
+		for rows.Next() {
+			template := models.TaskTemplate{}
+			if err := scanTemplate(rows, &template); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении шаблонов:", err)
+				return err
+			}
+			templates = append(templates, template)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении шаблонов:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено шаблонов:", len(templates))
+		result = templates
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetTemplateByID(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	ctx, cancel := s.readContext(ctx, "GetTemplateByID")
+	defer cancel()
+	var result *models.TaskTemplate
+	err := s.withConn(ctx, func(conn querier) error {
+		template := &models.TaskTemplate{}
+		if err := scanTemplate(conn.QueryRow(ctx, "get_template_by_id", id), template); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Шаблон не найден:", id)
+				return errors.ErrTemplateNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении шаблона:", err)
+			return err
+		}
+		result = template
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) UpdateTemplate(ctx context.Context, id string, template *models.TaskTemplate) error {
+	ctx, cancel := s.writeContext(ctx, "UpdateTemplate")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		template.Tags = nonNilStrings(template.Tags)
+		template.Checklist = nonNilStrings(template.Checklist)
+		ct, err := conn.Exec(ctx, "update_template", template.Name, template.Title, template.Description, template.Tags, template.Checklist, id)
+		if err != nil {
+			if isUniqueViolation(err) {
+				requestid.Println(ctx, "[ERROR] Шаблон уже существует:", template.Name)
+				return errors.ErrTemplateAlreadyExists
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось обновить шаблон:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Шаблон для обновления не найден:", id)
+			return errors.ErrTemplateNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Шаблон успешно обновлен:", id)
+		return nil
+	})
+}
+
+func (s *Storage) DeleteTemplate(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteTemplate")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_template", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить шаблон:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Шаблон для удаления не найден:", id)
+			return errors.ErrTemplateNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Шаблон успешно удален:", id)
+		return nil
+	})
+}
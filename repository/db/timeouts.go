@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"project/internal/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultReadTimeout  = 5 * time.Second
+	defaultWriteTimeout = 10 * time.Second
+)
+
+var defaultOperationTimeouts = map[string]time.Duration{
+	"ExportTasks":   5 * time.Minute,
+	"StreamTasks":   5 * time.Minute,
+	"BackupUsers":   5 * time.Minute,
+	"BackupTasks":   5 * time.Minute,
+	"RestoreBackup": 10 * time.Minute,
+	"CreateTasks":   time.Minute,
+	"ApplyBulk":     time.Minute,
+	"ReorderTasks":  time.Minute,
+	"PurgeDeleted":  time.Minute,
+}
+
+type QueryTimeouts struct {
+	Read       time.Duration
+	Write      time.Duration
+	Operations map[string]time.Duration
+}
+
+func (t QueryTimeouts) timeout(operation string, write bool) time.Duration {
+	if d, ok := t.Operations[operation]; ok && d > 0 {
+		return d
+	}
+	if d, ok := defaultOperationTimeouts[operation]; ok {
+		return d
+	}
+	if write {
+		if t.Write > 0 {
+			return t.Write
+		}
+		return defaultWriteTimeout
+	}
+	if t.Read > 0 {
+		return t.Read
+	}
+	return defaultReadTimeout
+}
+
+type operationKey struct{}
+
+func operationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+func (s *Storage) operationContext(ctx context.Context, operation string, write bool) (context.Context, context.CancelFunc) {
+	ctx, span := tracing.Tracer().Start(ctx, "storage."+operation, trace.WithAttributes(
+		attribute.String("db.operation.name", operation),
+		attribute.Bool("db.write", write),
+	))
+	ctx = context.WithValue(ctx, operationKey{}, operation)
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.timeout(operation, write))
+	return ctx, func() {
+		cancel()
+		span.End()
+	}
+}
+
+func (s *Storage) readContext(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	return s.operationContext(ctx, operation, false)
+}
+
+func (s *Storage) writeContext(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	return s.operationContext(ctx, operation, true)
+}
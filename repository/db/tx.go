@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"project/internal/requestid"
+	"project/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (s *Storage) WithTx(ctx context.Context, fn func(repository.Storage) error) error {
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		scoped := *s
+		scoped.tx = tx
+		if err := fn(&scoped); err != nil {
+			requestid.Println(ctx, "[WARN] Транзакция отменена:", err)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать транзакцию:", err)
+			return err
+		}
+		return nil
+	})
+}
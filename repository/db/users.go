@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepGetUserPreferences  = `SELECT preferences FROM users WHERE id = $1`
+	prepSaveUserPreferences = `UPDATE users SET preferences = $1 WHERE id = $2`
+	prepIsUserActive        = `SELECT active FROM users WHERE id = $1`
+	prepSetUserActive       = `UPDATE users SET active = $1 WHERE id = $2`
+	prepRecordLogin         = `INSERT INTO login_history (id, user_id, ip, user_agent, success, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	prepUpdateLastLogin     = `UPDATE users SET last_login_at = $1 WHERE id = $2`
+	prepGetLoginHistory     = `SELECT id, user_id, ip, user_agent, success, created_at FROM login_history WHERE user_id = $1 AND ($2::timestamptz IS NULL OR created_at < $2) ORDER BY created_at DESC LIMIT $3`
+)
+
+const prepSearchUsers = `SELECT id, username, email, password, role, active, last_login_at FROM users
+	WHERE lower(username) LIKE $1 OR lower(email) LIKE $1 OR lower(username) % $2 OR lower(email) % $2
+	ORDER BY (lower(username) LIKE $1 OR lower(email) LIKE $1) DESC,
+		greatest(similarity(lower(username), $2), similarity(lower(email), $2)) DESC, username
+	LIMIT $3`
+
+func (s *Storage) SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error) {
+	ctx, cancel := s.readContext(ctx, "SearchUsers")
+	defer cancel()
+	var result []models.User
+	err := s.withConn(ctx, func(conn querier) error {
+		query = strings.ToLower(query)
+		rows, err := conn.Query(ctx, "search_users", escapeLike(query)+"%", query, limit)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось выполнить поиск пользователей:", err)
+			return err
+		}
+		defer rows.Close()
+
+		users := []models.User{}
+		for rows.Next() {
+			user := models.User{}
+			if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.Active, &user.LastLoginAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении пользователей:", err)
+				return err
+			}
+			users = append(users, user)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении пользователей:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Найдено пользователей:", len(users))
+		result = users
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetUserPreferences(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	ctx, cancel := s.readContext(ctx, "GetUserPreferences")
+	defer cancel()
+	var result *models.UserPreferences
+	err := s.withConn(ctx, func(conn querier) error {
+		var raw []byte
+		if err := conn.QueryRow(ctx, "get_user_preferences", userID).Scan(&raw); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Пользователь не найден:", userID)
+				return errors.ErrUserNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении настроек пользователя:", err)
+			return err
+		}
+		prefs := models.DefaultUserPreferences()
+		if raw != nil {
+			if err := json.Unmarshal(raw, &prefs); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении настроек пользователя:", err)
+				return err
+			}
+		}
+		result = &prefs
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) SaveUserPreferences(ctx context.Context, userID string, prefs *models.UserPreferences) error {
+	ctx, cancel := s.writeContext(ctx, "SaveUserPreferences")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		raw, err := json.Marshal(prefs)
+		if err != nil {
+			return err
+		}
+		ct, err := conn.Exec(ctx, "save_user_preferences", raw, userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось сохранить настройки пользователя:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Пользователь для сохранения настроек не найден:", userID)
+			return errors.ErrUserNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Настройки пользователя сохранены:", userID)
+		return nil
+	})
+}
+
+func (s *Storage) IsUserActive(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := s.readContext(ctx, "IsUserActive")
+	defer cancel()
+	var result bool
+	err := s.withConn(ctx, func(conn querier) error {
+		var active bool
+		if err := conn.QueryRow(ctx, "is_user_active", id).Scan(&active); err != nil {
+			if err == pgx.ErrNoRows {
+				return errors.ErrUserNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при проверке активности пользователя:", err)
+			return err
+		}
+		result = active
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) SetUserActive(ctx context.Context, id string, active bool) error {
+	ctx, cancel := s.writeContext(ctx, "SetUserActive")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "set_user_active", active, id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось изменить активность пользователя:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Пользователь не найден:", id)
+			return errors.ErrUserNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Активность пользователя изменена:", id, active)
+		return nil
+	})
+}
+
+func (s *Storage) RecordLogin(ctx context.Context, record *models.LoginRecord) error {
+	ctx, cancel := s.writeContext(ctx, "RecordLogin")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию для записи входа:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		record.ID = uuid.New().String()
+		if record.CreatedAt.IsZero() {
+			record.CreatedAt = time.Now().UTC()
+		}
+		if record.Success {
+			ct, err := tx.Exec(ctx, prepUpdateLastLogin, record.CreatedAt, record.UserID)
+			if err != nil {
+				requestid.Println(ctx, "[ERROR] Не удалось обновить время последнего входа:", err)
+				return err
+			}
+			if ct.RowsAffected() == 0 {
+				requestid.Println(ctx, "[ERROR] Пользователь не найден:", record.UserID)
+				return errors.ErrUserNotFound
+			}
+		}
+		if _, err := tx.Exec(ctx, prepRecordLogin, record.ID, record.UserID, record.IP, record.UserAgent, record.Success, record.CreatedAt); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось записать вход пользователя:", err)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать запись входа:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Вход пользователя записан:", record.UserID, record.Success)
+		return nil
+	})
+}
+
+func (s *Storage) GetLoginHistory(ctx context.Context, userID string, before time.Time, limit int) ([]models.LoginRecord, error) {
+	ctx, cancel := s.readContext(ctx, "GetLoginHistory")
+	defer cancel()
+	var result []models.LoginRecord
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_login_history", userID, nullableTime(before), limit)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить историю входов:", err)
+			return err
+		}
+		defer rows.Close()
+
+		records := []models.LoginRecord{}
+		for rows.Next() {
+			record := models.LoginRecord{}
+			if err := rows.Scan(&record.ID, &record.UserID, &record.IP, &record.UserAgent, &record.Success, &record.CreatedAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении истории входов:", err)
+				return err
+			}
+			records = append(records, record)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении истории входов:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено записей истории входов:", len(records))
+		result = records
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	webhookColumns            = `id, user_id, url, secret, events, created_at`
+	prepCreateWebhook         = `INSERT INTO webhooks (id, user_id, url, secret, events, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	prepGetWebhooks           = `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = $1 ORDER BY created_at, id`
+	prepGetWebhookByID        = `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+	prepDeleteWebhook         = `DELETE FROM webhooks WHERE id = $1`
+	prepAddWebhookDelivery    = `INSERT INTO webhook_deliveries (id, webhook_id, event, attempt, status_code, success, error, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	prepGetWebhookDeliveries  = `SELECT id, webhook_id, event, attempt, status_code, success, error, created_at FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY created_at DESC, attempt DESC LIMIT $2`
+	webhookDeliveriesPageSize = 100
+)
+
+func scanWebhook(row pgx.Row, hook *models.Webhook) error {
+	return row.Scan(&hook.ID, &hook.UserID, &hook.URL, &hook.Secret, &hook.Events, &hook.CreatedAt)
+}
+
+func (s *Storage) CreateWebhook(ctx context.Context, hook *models.Webhook) error {
+	ctx, cancel := s.writeContext(ctx, "CreateWebhook")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		hook.ID = uuid.New().String()
+		hook.Events = nonNilStrings(hook.Events)
+		if hook.CreatedAt.IsZero() {
+			hook.CreatedAt = time.Now()
+		}
+		if _, err := conn.Exec(ctx, "create_webhook", hook.ID, hook.UserID, hook.URL, hook.Secret, hook.Events, hook.CreatedAt); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось создать вебхук:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Вебхук успешно создан:", hook.ID)
+		return nil
+	})
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	ctx, cancel := s.readContext(ctx, "GetWebhooks")
+	defer cancel()
+	var result []models.Webhook
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_webhooks", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить вебхуки:", err)
+			return err
+		}
+		defer rows.Close()
+
+		hooks := []models.Webhook{}
+		for rows.Next() {
+			hook := models.Webhook{}
+			if err := scanWebhook(rows, &hook); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении вебхуков:", err)
+				return err
+			}
+			hooks = append(hooks, hook)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении вебхуков:", err)
+			return err
+		}
+		result = hooks
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetWebhookByID(ctx context.Context, id string) (*models.Webhook, error) {
+	ctx, cancel := s.readContext(ctx, "GetWebhookByID")
+	defer cancel()
+	var result *models.Webhook
+	err := s.withConn(ctx, func(conn querier) error {
+		hook := &models.Webhook{}
+		if err := scanWebhook(conn.QueryRow(ctx, "get_webhook_by_id", id), hook); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Вебхук не найден:", id)
+				return errors.ErrWebhookNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении вебхука:", err)
+			return err
+		}
+		result = hook
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteWebhook")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_webhook", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить вебхук:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Вебхук для удаления не найден:", id)
+			return errors.ErrWebhookNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Вебхук успешно удален:", id)
+		return nil
+	})
+}
+
+func (s *Storage) AddWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ctx, cancel := s.writeContext(ctx, "AddWebhookDelivery")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		delivery.ID = uuid.New().String()
+		if delivery.CreatedAt.IsZero() {
+			delivery.CreatedAt = time.Now()
+		}
+		if _, err := conn.Exec(ctx, "add_webhook_delivery", delivery.ID, delivery.WebhookID, delivery.Event, delivery.Attempt, delivery.StatusCode, delivery.Success, delivery.Error, delivery.CreatedAt); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось записать доставку вебхука:", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Storage) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]models.WebhookDelivery, error) {
+	ctx, cancel := s.readContext(ctx, "GetWebhookDeliveries")
+	defer cancel()
+	var result []models.WebhookDelivery
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_webhook_deliveries", webhookID, webhookDeliveriesPageSize)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить доставки вебхука:", err)
+			return err
+		}
+		defer rows.Close()
+
+		deliveries := []models.WebhookDelivery{}
+		for rows.Next() {
+			d := models.WebhookDelivery{}
+			if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Attempt, &d.StatusCode, &d.Success, &d.Error, &d.CreatedAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении доставок вебхука:", err)
+				return err
+			}
+			deliveries = append(deliveries, d)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении доставок вебхука:", err)
+			return err
+		}
+		result = deliveries
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepGetWorkflow    = `SELECT user_id, statuses, initial_status, transitions FROM workflows WHERE user_id = $1`
+	prepSaveWorkflow   = `INSERT INTO workflows (user_id, statuses, initial_status, transitions, updated_at) VALUES ($1, $2, $3, $4, now()) ON CONFLICT (user_id) DO UPDATE SET statuses = EXCLUDED.statuses, initial_status = EXCLUDED.initial_status, transitions = EXCLUDED.transitions, updated_at = now()`
+	prepDeleteWorkflow = `DELETE FROM workflows WHERE user_id = $1`
+)
+
+func (s *Storage) GetWorkflow(ctx context.Context, userID string) (*models.Workflow, error) {
+	ctx, cancel := s.readContext(ctx, "GetWorkflow")
+	defer cancel()
+	var result *models.Workflow
+	err := s.withConn(ctx, func(conn querier) error {
+		workflow := &models.Workflow{}
+		if err := conn.QueryRow(ctx, "get_workflow", userID).Scan(&workflow.UserID, &workflow.Statuses, &workflow.InitialStatus, &workflow.Transitions); err != nil {
+			if err == pgx.ErrNoRows {
+				return errors.ErrWorkflowNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении рабочего процесса:", err)
+			return err
+		}
+		if workflow.Transitions == nil {
+			workflow.Transitions = map[string][]string{}
+		}
+		result = workflow
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) SaveWorkflow(ctx context.Context, workflow *models.Workflow) error {
+	ctx, cancel := s.writeContext(ctx, "SaveWorkflow")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		if workflow.Transitions == nil {
+			workflow.Transitions = map[string][]string{}
+		}
+		if _, err := conn.Exec(ctx, "save_workflow", workflow.UserID, workflow.Statuses, workflow.InitialStatus, workflow.Transitions); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось сохранить рабочий процесс:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Рабочий процесс сохранен:", workflow.UserID)
+		return nil
+	})
+}
+
+func (s *Storage) DeleteWorkflow(ctx context.Context, userID string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteWorkflow")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_workflow", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить рабочий процесс:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			return errors.ErrWorkflowNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Рабочий процесс сброшен:", userID)
+		return nil
+	})
+}
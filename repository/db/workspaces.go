@@ -0,0 +1,231 @@
+package db
+
+import (
+	"context"
+	"project/internal/domain/errors"
+	"project/internal/domain/models"
+	"project/internal/requestid"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	prepCreateWorkspace       = `INSERT INTO workspaces (id, name, owner_id) VALUES ($1, $2, $3) RETURNING created_at`
+	prepAddWorkspaceOwner     = `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, 'owner')`
+	prepGetWorkspaces         = `SELECT w.id, w.name, w.owner_id, m.role, w.created_at FROM workspaces w JOIN workspace_members m ON m.workspace_id = w.id WHERE m.user_id = $1 ORDER BY w.name, w.id`
+	prepGetWorkspaceByID      = `SELECT id, name, owner_id, '', created_at FROM workspaces WHERE id = $1`
+	prepUpdateWorkspace       = `UPDATE workspaces SET name = $1 WHERE id = $2`
+	prepDeleteWorkspace       = `DELETE FROM workspaces WHERE id = $1`
+	prepGetWorkspaceMembers   = `SELECT workspace_id, user_id, role, joined_at FROM workspace_members WHERE workspace_id = $1 ORDER BY joined_at, user_id`
+	prepGetWorkspaceRole      = `SELECT role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`
+	prepSaveWorkspaceMember   = `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role RETURNING joined_at`
+	prepRemoveWorkspaceMember = `DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`
+)
+
+func (s *Storage) CreateWorkspace(ctx context.Context, workspace *models.Workspace) error {
+	ctx, cancel := s.writeContext(ctx, "CreateWorkspace")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось начать транзакцию для создания рабочего пространства:", err)
+			return err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		workspace.ID = uuid.New().String()
+		if err := tx.QueryRow(ctx, "create_workspace", workspace.ID, workspace.Name, workspace.OwnerID).Scan(&workspace.CreatedAt); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось создать рабочее пространство:", err)
+			return err
+		}
+		if _, err := tx.Exec(ctx, "add_workspace_owner", workspace.ID, workspace.OwnerID); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось добавить владельца рабочего пространства:", err)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось зафиксировать создание рабочего пространства:", err)
+			return err
+		}
+		workspace.Role = models.WorkspaceRoleOwner
+		requestid.Println(ctx, "[SUCCESS] Рабочее пространство успешно создано:", workspace.ID)
+		return nil
+	})
+}
+
+func (s *Storage) GetWorkspaces(ctx context.Context, userID string) ([]models.Workspace, error) {
+	ctx, cancel := s.readContext(ctx, "GetWorkspaces")
+	defer cancel()
+	var result []models.Workspace
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_workspaces", userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить рабочие пространства:", err)
+			return err
+		}
+		defer rows.Close()
+
+		workspaces := []models.Workspace{}
+		for rows.Next() {
+			workspace := models.Workspace{}
+			if err := rows.Scan(&workspace.ID, &workspace.Name, &workspace.OwnerID, &workspace.Role, &workspace.CreatedAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении рабочих пространств:", err)
+				return err
+			}
+			workspaces = append(workspaces, workspace)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении рабочих пространств:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Получено рабочих пространств:", len(workspaces))
+		result = workspaces
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetWorkspaceByID(ctx context.Context, id string) (*models.Workspace, error) {
+	ctx, cancel := s.readContext(ctx, "GetWorkspaceByID")
+	defer cancel()
+	var result *models.Workspace
+	err := s.withConn(ctx, func(conn querier) error {
+		workspace := &models.Workspace{}
+		if err := conn.QueryRow(ctx, "get_workspace_by_id", id).Scan(&workspace.ID, &workspace.Name, &workspace.OwnerID, &workspace.Role, &workspace.CreatedAt); err != nil {
+			if err == pgx.ErrNoRows {
+				requestid.Println(ctx, "[ERROR] Рабочее пространство не найдено:", id)
+				return errors.ErrWorkspaceNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении рабочего пространства:", err)
+			return err
+		}
+		result = workspace
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) UpdateWorkspace(ctx context.Context, id string, workspace *models.Workspace) error {
+	ctx, cancel := s.writeContext(ctx, "UpdateWorkspace")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "update_workspace", workspace.Name, id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось обновить рабочее пространство:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Рабочее пространство для обновления не найдено:", id)
+			return errors.ErrWorkspaceNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Рабочее пространство успешно обновлено:", id)
+		return nil
+	})
+}
+
+func (s *Storage) DeleteWorkspace(ctx context.Context, id string) error {
+	ctx, cancel := s.writeContext(ctx, "DeleteWorkspace")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "delete_workspace", id)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось удалить рабочее пространство:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Рабочее пространство для удаления не найдено:", id)
+			return errors.ErrWorkspaceNotFound
+		}
+		requestid.Println(ctx, "[SUCCESS] Рабочее пространство успешно удалено:", id)
+		return nil
+	})
+}
+
+func (s *Storage) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error) {
+	ctx, cancel := s.readContext(ctx, "GetWorkspaceMembers")
+	defer cancel()
+	var result []models.WorkspaceMember
+	err := s.withConn(ctx, func(conn querier) error {
+		rows, err := conn.Query(ctx, "get_workspace_members", workspaceID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось получить участников рабочего пространства:", err)
+			return err
+		}
+		defer rows.Close()
+
+		members := []models.WorkspaceMember{}
+		for rows.Next() {
+			member := models.WorkspaceMember{}
+			if err := rows.Scan(&member.WorkspaceID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+				requestid.Println(ctx, "[ERROR] Ошибка при чтении участников рабочего пространства:", err)
+				return err
+			}
+			members = append(members, member)
+		}
+		if err := rows.Err(); err != nil {
+			requestid.Println(ctx, "[ERROR] Ошибка при чтении участников рабочего пространства:", err)
+			return err
+		}
+		result = members
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) GetWorkspaceRole(ctx context.Context, workspaceID, userID string) (string, error) {
+	ctx, cancel := s.readContext(ctx, "GetWorkspaceRole")
+	defer cancel()
+	var result string
+	err := s.withConn(ctx, func(conn querier) error {
+		var role string
+		if err := conn.QueryRow(ctx, "get_workspace_role", workspaceID, userID).Scan(&role); err != nil {
+			if err == pgx.ErrNoRows {
+				return nil
+			}
+			requestid.Println(ctx, "[ERROR] Ошибка при получении роли в рабочем пространстве:", err)
+			return err
+		}
+		result = role
+		return nil
+	})
+	return result, err
+}
+
+func (s *Storage) SaveWorkspaceMember(ctx context.Context, member *models.WorkspaceMember) error {
+	ctx, cancel := s.writeContext(ctx, "SaveWorkspaceMember")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		if err := conn.QueryRow(ctx, "save_workspace_member", member.WorkspaceID, member.UserID, member.Role).Scan(&member.JoinedAt); err != nil {
+			switch violatedForeignKey(err) {
+			case "workspace_members_workspace_id_fkey":
+				requestid.Println(ctx, "[ERROR] Рабочее пространство не найдено:", member.WorkspaceID)
+				return errors.ErrWorkspaceNotFound
+			case "workspace_members_user_id_fkey":
+				requestid.Println(ctx, "[ERROR] Пользователь для добавления в рабочее пространство не найден:", member.UserID)
+				return errors.ErrUserNotFound
+			}
+			requestid.Println(ctx, "[ERROR] Не удалось сохранить участника рабочего пространства:", err)
+			return err
+		}
+		requestid.Println(ctx, "[SUCCESS] Участник рабочего пространства сохранен:", member.WorkspaceID, member.UserID, member.Role)
+		return nil
+	})
+}
+
+func (s *Storage) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	ctx, cancel := s.writeContext(ctx, "RemoveWorkspaceMember")
+	defer cancel()
+	return s.withConn(ctx, func(conn querier) error {
+		ct, err := conn.Exec(ctx, "drop_workspace_member", workspaceID, userID)
+		if err != nil {
+			requestid.Println(ctx, "[ERROR] Не удалось исключить участника рабочего пространства:", err)
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			requestid.Println(ctx, "[ERROR] Участник рабочего пространства не найден:", workspaceID, userID)
+			return errors.ErrNotWorkspaceMember
+		}
+		requestid.Println(ctx, "[SUCCESS] Участник исключен из рабочего пространства:", workspaceID, userID)
+		return nil
+	})
+}
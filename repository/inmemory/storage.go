@@ -2,25 +2,77 @@ package storage
 
 import (
 	"context"
+	"maps"
 	"project/internal/domain/errors"
 	"project/internal/domain/models"
+	"project/internal/purge"
+	"project/repository"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
+const webhookDeliveriesPageSize = 100
+
 type Storage struct {
-	users map[string]models.User
-	tasks map[string]models.Task
+	mu       sync.RWMutex
+	users    map[string]models.User
+	tasks    map[string]models.Task
+	tags     map[string]models.Tag
+	taskTags map[string]map[string]bool
+	shares   map[string]map[string]string
+	projects map[string]models.Project
+	trash    map[string]models.Task
+	events   map[string][]models.TaskEvent
+
+	checklists map[string][]models.ChecklistItem
+	workflows  map[string]models.Workflow
+
+	preferences map[string]models.UserPreferences
+	logins      map[string][]models.LoginRecord
+
+	templates  map[string]models.TaskTemplate
+	webhooks   map[string]models.Webhook
+	deliveries map[string][]models.WebhookDelivery
+	workspaces map[string]models.Workspace
+	members    map[string]map[string]models.WorkspaceMember
+
+	purger *purge.Worker
 }
 
 func NewStorage() *Storage {
 	return &Storage{
-		users: make(map[string]models.User),
-		tasks: make(map[string]models.Task),
+		users:    make(map[string]models.User),
+		tasks:    make(map[string]models.Task),
+		tags:     make(map[string]models.Tag),
+		taskTags: make(map[string]map[string]bool),
+		shares:   make(map[string]map[string]string),
+		projects: make(map[string]models.Project),
+		trash:    make(map[string]models.Task),
+		events:   make(map[string][]models.TaskEvent),
+
+		checklists: make(map[string][]models.ChecklistItem),
+		workflows:  make(map[string]models.Workflow),
+
+		preferences: make(map[string]models.UserPreferences),
+		logins:      make(map[string][]models.LoginRecord),
+
+		templates:  make(map[string]models.TaskTemplate),
+		webhooks:   make(map[string]models.Webhook),
+		deliveries: make(map[string][]models.WebhookDelivery),
+		workspaces: make(map[string]models.Workspace),
+		members:    make(map[string]map[string]models.WorkspaceMember),
 	}
 }
 
-func (s *Storage) GetUserByID(id string) (*models.User, error) {
+func (s *Storage) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	user, exists := s.users[id]
 	if !exists {
 		return nil, errors.ErrUserNotFound
@@ -28,40 +80,241 @@ func (s *Storage) GetUserByID(id string) (*models.User, error) {
 	return &user, nil
 }
 
-func (s *Storage) GetUserByUsername(username string) (*models.User, error) {
+func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	username = models.NormalizeUsername(username)
 	for _, user := range s.users {
-		if user.Username == username {
+		if models.NormalizeUsername(user.Username) == username {
 			return &user, nil
 		}
 	}
 	return nil, errors.ErrUserNotFound
 }
 
-func (s *Storage) CreateUser(user *models.User) error {
-	for _, existingUser := range s.users {
-		if existingUser.Username == user.Username {
-			return errors.ErrUserAlreadyExists
+func (s *Storage) GetUserPreferences(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, exists := s.users[userID]; !exists {
+		return nil, errors.ErrUserNotFound
+	}
+	prefs, exists := s.preferences[userID]
+	if !exists {
+		prefs = models.DefaultUserPreferences()
+	}
+	prefs.Notifications.Channels = append([]string(nil), prefs.Notifications.Channels...)
+	return &prefs, nil
+}
+
+func (s *Storage) SaveUserPreferences(ctx context.Context, userID string, prefs *models.UserPreferences) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.users[userID]; !exists {
+		return errors.ErrUserNotFound
+	}
+	saved := *prefs
+	saved.Notifications.Channels = append([]string(nil), prefs.Notifications.Channels...)
+	s.preferences[userID] = saved
+	return nil
+}
+
+const userSimilarityThreshold = 0.3
+
+func (s *Storage) SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	query = strings.ToLower(query)
+	type match struct {
+		user   models.User
+		prefix bool
+		score  float64
+	}
+	matches := []match{}
+	for _, user := range s.users {
+		username, email := strings.ToLower(user.Username), strings.ToLower(user.Email)
+		m := match{
+			user:   user,
+			prefix: strings.HasPrefix(username, query) || strings.HasPrefix(email, query),
+			score:  trigramSimilarity(username, query),
+		}
+		if score := trigramSimilarity(email, query); score > m.score {
+			m.score = score
+		}
+		if m.prefix || m.score >= userSimilarityThreshold {
+			matches = append(matches, m)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].prefix != matches[j].prefix {
+			return matches[i].prefix
+		}
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].user.Username < matches[j].user.Username
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	users := make([]models.User, 0, len(matches))
+	for _, m := range matches {
+		users = append(users, m.user)
+	}
+	return users, nil
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+func trigramSimilarity(a, b string) float64 {
+	left, right := trigrams(a), trigrams(b)
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	common := 0
+	for trigram := range left {
+		if right[trigram] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(left)+len(right)-common)
+}
+
+func (s *Storage) userTaken(exceptID, username, email string) bool {
+	for id, existing := range s.users {
+		if id == exceptID {
+			continue
+		}
+		if username != "" && models.NormalizeUsername(existing.Username) == username {
+			return true
+		}
+		if email != "" && models.NormalizeEmail(existing.Email) == email {
+			return true
 		}
 	}
+	return false
+}
+
+func (s *Storage) CreateUser(ctx context.Context, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user.Username = models.NormalizeUsername(user.Username)
+	user.Email = models.NormalizeEmail(user.Email)
+	if s.userTaken("", user.Username, user.Email) {
+		return errors.ErrUserAlreadyExists
+	}
+	user.Active = true
 	userID := uuid.New().String()
 	user.ID = userID
 	s.users[userID] = *user
 	return nil
 }
 
-func (s *Storage) UpdateUser(id string, user *models.User) error {
-	if _, exists := s.users[id]; !exists {
+func (s *Storage) UpdateUser(ctx context.Context, id string, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, exists := s.users[id]
+	if !exists {
 		return errors.ErrUserNotFound
 	}
+	user.Active = existing.Active
+	user.LastLoginAt = existing.LastLoginAt
+	user.Username = models.NormalizeUsername(user.Username)
+	user.Email = models.NormalizeEmail(user.Email)
+	if s.userTaken(id, user.Username, user.Email) {
+		return errors.ErrUserAlreadyExists
+	}
 	s.users[id] = *user
 	return nil
 }
 
-func (s *Storage) DeleteUser(id string) error {
+func (s *Storage) IsUserActive(ctx context.Context, id string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	user, exists := s.users[id]
+	if !exists {
+		return false, errors.ErrUserNotFound
+	}
+	return user.Active, nil
+}
+
+func (s *Storage) SetUserActive(ctx context.Context, id string, active bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, exists := s.users[id]
+	if !exists {
+		return errors.ErrUserNotFound
+	}
+	user.Active = active
+	s.users[id] = user
+	return nil
+}
+
+func (s *Storage) RecordLogin(ctx context.Context, record *models.LoginRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, exists := s.users[record.UserID]
+	if !exists {
+		return errors.ErrUserNotFound
+	}
+	record.ID = uuid.New().String()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+	if record.Success {
+		at := record.CreatedAt
+		user.LastLoginAt = &at
+		s.users[record.UserID] = user
+	}
+	s.logins[record.UserID] = append(s.logins[record.UserID], *record)
+	return nil
+}
+
+func (s *Storage) GetLoginHistory(ctx context.Context, userID string, before time.Time, limit int) ([]models.LoginRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := []models.LoginRecord{}
+	for _, record := range s.logins[userID] {
+		if before.IsZero() || record.CreatedAt.Before(before) {
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].CreatedAt.After(records[j].CreatedAt) })
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+func (s *Storage) DeleteUser(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if _, exists := s.users[id]; !exists {
 		return errors.ErrUserNotFound
 	}
 	delete(s.users, id)
+	delete(s.preferences, id)
+	delete(s.logins, id)
+	for _, users := range s.shares {
+		delete(users, id)
+	}
+	for workspaceID, members := range s.members {
+		delete(members, id)
+		if s.workspaces[workspaceID].OwnerID == id {
+			s.deleteWorkspace(workspaceID)
+		}
+	}
 	return nil
 }
 
@@ -73,8 +326,61 @@ func (s *Storage) GetTaskByID(ctx context.Context, id string) (*models.Task, err
 	return s.GetTaskByIDNoCtx(id)
 }
 
-func (s *Storage) GetTasks(ctx context.Context, userID string) ([]models.Task, error) {
-	return s.GetTasksByUserIDNoCtx(userID)
+func (s *Storage) GetTasks(ctx context.Context, userID string, filter models.TaskFilter) ([]models.Task, error) {
+	deleted := filter.Deleted != nil && *filter.Deleted
+	var tasks []models.Task
+	if filter.WorkspaceID != "" {
+		tasks = s.collectTasks(deleted, func(t models.Task) bool { return t.WorkspaceID == filter.WorkspaceID })
+	} else if filter.View == models.TaskViewAssigned {
+		tasks = s.collectTasks(deleted, func(t models.Task) bool { return t.AssigneeID == userID })
+	} else {
+		tasks = s.collectTasks(deleted, func(t models.Task) bool { return t.UserID == userID })
+	}
+	filtered := make([]models.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if matchesFilter(t, filter) {
+			filtered = append(filtered, t)
+		}
+	}
+	sortTasks(filtered, filter.Sort, filter.Descending)
+	if filter.Offset >= len(filtered) {
+		return []models.Task{}, nil
+	}
+	filtered = filtered[filter.Offset:]
+	if filter.Limit > 0 && len(filtered) > filter.Limit {
+		filtered = filtered[:filter.Limit]
+	}
+	return filtered, nil
+}
+
+func compareTasks(a, b models.Task, field string) int {
+	switch field {
+	case models.TaskSortTitle:
+		return strings.Compare(a.Title, b.Title)
+	case models.TaskSortStatus:
+		return strings.Compare(a.Status, b.Status)
+	case models.TaskSortDueDate:
+		return a.DueDate.Compare(*b.DueDate)
+	}
+	return a.Position - b.Position
+}
+
+func sortTasks(tasks []models.Task, field string, descending bool) {
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if field == models.TaskSortDueDate && (a.DueDate == nil) != (b.DueDate == nil) {
+			return b.DueDate == nil
+		}
+		if field != models.TaskSortDueDate || a.DueDate != nil {
+			if c := compareTasks(a, b, field); c != 0 {
+				return (c < 0) != descending
+			}
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	})
 }
 
 func (s *Storage) UpdateTask(ctx context.Context, id string, task *models.Task) error {
@@ -86,43 +392,1278 @@ func (s *Storage) DeleteTask(ctx context.Context, id string) error {
 }
 
 func (s *Storage) CreateTaskNoCtx(task *models.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	id := uuid.New().String()
 	task.ID = id
+	task.Position = 0
+	for _, t := range s.tasks {
+		if t.UserID == task.UserID && t.Position >= task.Position {
+			task.Position = t.Position + 1
+		}
+	}
+	task.Version = 0
+	touchTask(task)
 	s.tasks[id] = *task
 	return nil
 }
 
+func (s *Storage) CreateTasks(ctx context.Context, tasks []models.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	positions := map[string]int{}
+	for i := range tasks {
+		task := &tasks[i]
+		next, ok := positions[task.UserID]
+		if !ok {
+			for _, t := range s.tasks {
+				if t.UserID == task.UserID && t.Position >= next {
+					next = t.Position + 1
+				}
+			}
+		}
+		positions[task.UserID] = next + 1
+		task.ID = uuid.New().String()
+		task.Position = next
+		task.Version = 0
+		touchTask(task)
+		s.tasks[task.ID] = *task
+	}
+	return nil
+}
+
 func (s *Storage) GetTaskByIDNoCtx(id string) (*models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	task, exists := s.tasks[id]
+	if !exists {
+		task, exists = s.trash[id]
+	}
 	if !exists {
 		return nil, errors.ErrNotFound
 	}
+	s.decorateTask(&task)
 	return &task, nil
 }
 
 func (s *Storage) GetTasksByUserIDNoCtx(userID string) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	var tasks []models.Task
 	for _, t := range s.tasks {
 		if t.UserID == userID {
+			s.decorateTask(&t)
 			tasks = append(tasks, t)
 		}
 	}
 	return tasks, nil
 }
 
+func (s *Storage) collectTasks(deleted bool, match func(models.Task) bool) []models.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	source := s.tasks
+	if deleted {
+		source = s.trash
+	}
+	var tasks []models.Task
+	for _, t := range source {
+		if match(t) {
+			s.decorateTask(&t)
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
 func (s *Storage) UpdateTaskNoCtx(id string, task *models.Task) error {
-	if _, exists := s.tasks[id]; !exists {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.tasks[id]
+	if !exists {
 		return errors.ErrNotFound
 	}
+	if !sameTime(current.DueDate, task.DueDate) || current.ReminderOffsetMinutes != task.ReminderOffsetMinutes {
+		task.RemindedAt = nil
+	}
 	task.ID = id
+	task.WorkspaceID = current.WorkspaceID
+	task.Version = current.Version
+	touchTask(task)
 	s.tasks[id] = *task
 	return nil
 }
 
 func (s *Storage) DeleteTaskNoCtx(id string) error {
-	if _, exists := s.tasks[id]; !exists {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deleteTask(id)
+}
+
+func (s *Storage) deleteTask(id string) error {
+	task, exists := s.tasks[id]
+	if !exists {
 		return errors.ErrNotFound
 	}
+	for childID, child := range s.tasks {
+		if child.ParentID == id {
+			_ = s.deleteTask(childID)
+		}
+	}
+	now := time.Now()
+	task.Deleted = true
+	task.DeletedAt = &now
+	touchTask(&task)
+	s.trash[id] = task
 	delete(s.tasks, id)
+	delete(s.shares, id)
+	return nil
+}
+
+func (s *Storage) GetTrash(ctx context.Context, userID string) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := []models.Task{}
+	for _, t := range s.trash {
+		if t.UserID == userID {
+			s.decorateTask(&t)
+			tasks = append(tasks, t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].DeletedAt.After(*tasks[j].DeletedAt) })
+	return tasks, nil
+}
+
+func (s *Storage) GetOverdueTasks(ctx context.Context, userID string, now time.Time) ([]models.Task, error) {
+	return s.openDueTasks(userID, func(due time.Time) bool { return due.Before(now) }), nil
+}
+
+func (s *Storage) GetDueTasks(ctx context.Context, userID string, from, to time.Time) ([]models.Task, error) {
+	return s.openDueTasks(userID, func(due time.Time) bool { return !due.Before(from) && !due.After(to) }), nil
+}
+
+func (s *Storage) openDueTasks(userID string, match func(due time.Time) bool) []models.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := []models.Task{}
+	for _, t := range s.tasks {
+		if t.UserID != userID || t.Archived || t.Status == "done" || t.DueDate == nil || !match(*t.DueDate) {
+			continue
+		}
+		s.decorateTask(&t)
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(*tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
+func (s *Storage) RestoreTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.trash[id]; !exists {
+		return errors.ErrTaskNotInTrash
+	}
+	s.restoreTask(id)
+	return nil
+}
+
+func (s *Storage) restoreTask(id string) {
+	task := s.trash[id]
+	task.Deleted = false
+	task.DeletedAt = nil
+	touchTask(&task)
+	s.tasks[id] = task
+	delete(s.trash, id)
+	for childID, child := range s.trash {
+		if child.ParentID == id {
+			s.restoreTask(childID)
+		}
+	}
+}
+
+func (s *Storage) GetSubtasks(ctx context.Context, parentID string) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := []models.Task{}
+	for _, t := range s.tasks {
+		if t.ParentID == parentID && !t.Deleted {
+			s.decorateTask(&t)
+			tasks = append(tasks, t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Title < tasks[j].Title })
+	return tasks, nil
+}
+
+func (s *Storage) SearchTasks(ctx context.Context, userID, query string) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	terms := strings.Fields(strings.ToLower(query))
+	type scored struct {
+		task  models.Task
+		score int
+	}
+	var matches []scored
+	for _, t := range s.tasks {
+		if t.UserID != userID || t.Deleted {
+			continue
+		}
+		title := strings.ToLower(t.Title)
+		description := strings.ToLower(t.Description)
+		score := 0
+		for _, term := range terms {
+			hits := 2*strings.Count(title, term) + strings.Count(description, term)
+			if hits == 0 {
+				score = 0
+				break
+			}
+			score += hits
+		}
+		if score > 0 {
+			s.decorateTask(&t)
+			matches = append(matches, scored{task: t, score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].task.Title < matches[j].task.Title
+	})
+	tasks := make([]models.Task, 0, len(matches))
+	for _, m := range matches {
+		tasks = append(tasks, m.task)
+	}
+	return tasks, nil
+}
+
+func matchesFilter(task models.Task, filter models.TaskFilter) bool {
+	deleted := false
+	if filter.Deleted != nil {
+		deleted = *filter.Deleted
+	}
+	if task.Deleted != deleted {
+		return false
+	}
+	archived := false
+	if filter.Archived != nil {
+		archived = *filter.Archived
+	}
+	if task.Archived != archived {
+		return false
+	}
+	if filter.Status != "" && task.Status != filter.Status {
+		return false
+	}
+	if filter.TitleContains != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(filter.TitleContains)) {
+		return false
+	}
+	if filter.Tag != "" && !containsString(task.Tags, filter.Tag) {
+		return false
+	}
+	if filter.ProjectID != "" && task.ProjectID != filter.ProjectID {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) decorateTask(task *models.Task) {
+	task.Tags = s.tagNames(task.ID)
+	items := s.checklists[task.ID]
+	done := 0
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	task.Checklist = models.NewChecklistProgress(len(items), done)
+}
+
+func (s *Storage) tagNames(taskID string) []string {
+	var names []string
+	for tagID := range s.taskTags[taskID] {
+		if tag, exists := s.tags[tagID]; exists {
+			names = append(names, tag.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Storage) CreateTag(ctx context.Context, tag *models.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.tags {
+		if existing.UserID == tag.UserID && existing.Name == tag.Name {
+			return errors.ErrTagAlreadyExists
+		}
+	}
+	tag.ID = uuid.New().String()
+	s.tags[tag.ID] = *tag
+	return nil
+}
+
+func (s *Storage) GetTags(ctx context.Context, userID string) ([]models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tags := []models.Tag{}
+	for _, tag := range s.tags {
+		if tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (s *Storage) GetTagByID(ctx context.Context, id string) (*models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tag, exists := s.tags[id]
+	if !exists {
+		return nil, errors.ErrTagNotFound
+	}
+	return &tag, nil
+}
+
+func (s *Storage) UpdateTag(ctx context.Context, id string, tag *models.Tag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.tags[id]
+	if !exists {
+		return errors.ErrTagNotFound
+	}
+	for otherID, existing := range s.tags {
+		if otherID != id && existing.UserID == current.UserID && existing.Name == tag.Name {
+			return errors.ErrTagAlreadyExists
+		}
+	}
+	current.Name = tag.Name
+	s.tags[id] = current
+	return nil
+}
+
+func (s *Storage) DeleteTag(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.tags[id]; !exists {
+		return errors.ErrTagNotFound
+	}
+	delete(s.tags, id)
+	for _, tagIDs := range s.taskTags {
+		delete(tagIDs, id)
+	}
+	return nil
+}
+
+func (s *Storage) AttachTag(ctx context.Context, taskID, tagID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.tasks[taskID]; !exists {
+		return errors.ErrNotFound
+	}
+	if _, exists := s.tags[tagID]; !exists {
+		return errors.ErrTagNotFound
+	}
+	if s.taskTags[taskID] == nil {
+		s.taskTags[taskID] = make(map[string]bool)
+	}
+	s.taskTags[taskID][tagID] = true
+	return nil
+}
+
+func (s *Storage) DetachTag(ctx context.Context, taskID, tagID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.taskTags[taskID][tagID] {
+		return errors.ErrTagNotFound
+	}
+	delete(s.taskTags[taskID], tagID)
+	return nil
+}
+
+func touchTask(task *models.Task) {
+	task.Version++
+	task.UpdatedAt = time.Now().UTC()
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func (s *Storage) GetDueReminders(ctx context.Context, now time.Time, defaultOffset time.Duration) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := []models.Task{}
+	for _, t := range s.tasks {
+		if t.Deleted || t.Status == "done" || t.DueDate == nil || t.RemindedAt != nil {
+			continue
+		}
+		offset := defaultOffset
+		if t.ReminderOffsetMinutes > 0 {
+			offset = time.Duration(t.ReminderOffsetMinutes) * time.Minute
+		}
+		if !t.DueDate.Add(-offset).After(now) {
+			tasks = append(tasks, t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return tasks, nil
+}
+
+func (s *Storage) MarkReminded(ctx context.Context, taskID string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	task.RemindedAt = &at
+	touchTask(&task)
+	s.tasks[taskID] = task
+	return nil
+}
+
+func (s *Storage) ShareTask(ctx context.Context, share *models.TaskShare) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.tasks[share.TaskID]; !exists {
+		return errors.ErrNotFound
+	}
+	if s.shares[share.TaskID] == nil {
+		s.shares[share.TaskID] = make(map[string]string)
+	}
+	s.shares[share.TaskID][share.UserID] = share.Permission
+	return nil
+}
+
+func (s *Storage) GetTaskPermission(ctx context.Context, taskID, userID string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shares[taskID][userID], nil
+}
+
+func (s *Storage) CreateProject(ctx context.Context, project *models.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.projects {
+		if existing.UserID == project.UserID && existing.Name == project.Name {
+			return errors.ErrProjectAlreadyExists
+		}
+	}
+	project.ID = uuid.New().String()
+	s.projects[project.ID] = *project
+	return nil
+}
+
+func (s *Storage) GetProjects(ctx context.Context, userID string) ([]models.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	projects := []models.Project{}
+	for _, project := range s.projects {
+		if project.UserID == userID {
+			projects = append(projects, project)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, nil
+}
+
+func (s *Storage) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	project, exists := s.projects[id]
+	if !exists {
+		return nil, errors.ErrProjectNotFound
+	}
+	return &project, nil
+}
+
+func (s *Storage) UpdateProject(ctx context.Context, id string, project *models.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.projects[id]
+	if !exists {
+		return errors.ErrProjectNotFound
+	}
+	for otherID, existing := range s.projects {
+		if otherID != id && existing.UserID == current.UserID && existing.Name == project.Name {
+			return errors.ErrProjectAlreadyExists
+		}
+	}
+	current.Name = project.Name
+	s.projects[id] = current
+	return nil
+}
+
+func (s *Storage) DeleteProject(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.projects[id]; !exists {
+		return errors.ErrProjectNotFound
+	}
+	delete(s.projects, id)
+	for taskID, task := range s.tasks {
+		if task.ProjectID == id {
+			task.ProjectID = ""
+			touchTask(&task)
+			s.tasks[taskID] = task
+		}
+	}
+	return nil
+}
+
+func (s *Storage) ApplyBulk(ctx context.Context, ops []models.BulkOperation) ([]models.BulkResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make([]models.BulkResult, len(ops))
+	for i, op := range ops {
+		results[i] = models.BulkResult{TaskID: op.TaskID, Action: op.Action}
+		task, exists := s.tasks[op.TaskID]
+		if !exists || task.Deleted {
+			results[i].Error = errors.ErrTaskNotFound.Error()
+			continue
+		}
+		switch op.Action {
+		case models.BulkActionUpdateStatus:
+			task.Status = op.Status
+			touchTask(&task)
+			s.tasks[op.TaskID] = task
+		case models.BulkActionDelete:
+			_ = s.deleteTask(op.TaskID)
+		case models.BulkActionMove:
+			task.ProjectID = op.ProjectID
+			touchTask(&task)
+			s.tasks[op.TaskID] = task
+		default:
+			results[i].Error = errors.ErrBulkUnknownAction.Error()
+			continue
+		}
+		results[i].Success = true
+	}
+	return results, nil
+}
+
+func (s *Storage) HardDeleteTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, active := s.tasks[id]
+	_, trashed := s.trash[id]
+	if !active && !trashed {
+		return errors.ErrNotFound
+	}
+	s.purgeTask(id)
+	return nil
+}
+
+func (s *Storage) purgeTask(id string) {
+	for childID, child := range s.tasks {
+		if child.ParentID == id {
+			s.purgeTask(childID)
+		}
+	}
+	for childID, child := range s.trash {
+		if child.ParentID == id {
+			s.purgeTask(childID)
+		}
+	}
+	delete(s.tasks, id)
+	delete(s.trash, id)
+	delete(s.taskTags, id)
+	delete(s.shares, id)
+	delete(s.events, id)
+	delete(s.checklists, id)
+}
+
+func (s *Storage) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := len(s.tasks) + len(s.trash)
+	for id, task := range s.trash {
+		if _, exists := s.trash[id]; exists && task.DeletedAt != nil && task.DeletedAt.Before(before) {
+			s.purgeTask(id)
+		}
+	}
+	return int64(total - len(s.tasks) - len(s.trash)), nil
+}
+
+func (s *Storage) StartPurgeWorker(interval, retention time.Duration) {
+	if s.purger != nil {
+		return
+	}
+	s.purger = purge.NewWorker(s, interval, retention)
+	s.purger.Start()
+}
+
+func (s *Storage) StopPurgeWorker(ctx context.Context) error {
+	if s.purger == nil {
+		return nil
+	}
+	return s.purger.Close(ctx)
+}
+
+func (s *Storage) PurgeStats() purge.Stats {
+	if s.purger == nil {
+		return purge.Stats{}
+	}
+	return s.purger.Stats()
+}
+
+func (s *Storage) SetTaskArchived(ctx context.Context, id string, archived bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, exists := s.tasks[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	task.Archived = archived
+	touchTask(&task)
+	s.tasks[id] = task
+	return nil
+}
+
+func (s *Storage) AssignTask(ctx context.Context, id, assigneeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, exists := s.tasks[id]
+	if !exists {
+		return errors.ErrNotFound
+	}
+	task.AssigneeID = assigneeID
+	touchTask(&task)
+	s.tasks[id] = task
+	return nil
+}
+
+func (s *Storage) AddTaskEvent(ctx context.Context, event *models.TaskEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event.ID = uuid.New().String()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	s.events[event.TaskID] = append(s.events[event.TaskID], *event)
+	return nil
+}
+
+func (s *Storage) GetTaskEvents(ctx context.Context, taskID string) ([]models.TaskEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events := make([]models.TaskEvent, len(s.events[taskID]))
+	copy(events, s.events[taskID])
+	return events, nil
+}
+
+func (s *Storage) GetUserTaskEvents(ctx context.Context, userID string, before time.Time, limit int) ([]models.TaskEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events := []models.TaskEvent{}
+	for _, taskEvents := range s.events {
+		for _, event := range taskEvents {
+			if event.UserID == userID && (before.IsZero() || event.CreatedAt.Before(before)) {
+				events = append(events, event)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (s *Storage) ExportTasks(ctx context.Context, userID string, fn func(models.Task) error) error {
+	tasks, err := s.GetTasksByUserIDNoCtx(userID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	for _, t := range tasks {
+		if t.Deleted {
+			continue
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) StreamTasks(ctx context.Context, userID string, filter models.TaskFilter, fn func(models.Task) error) error {
+	tasks, err := s.GetTasks(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) BackupUsers(ctx context.Context, fn func(models.User) error) error {
+	s.mu.RLock()
+	users := make([]models.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, user)
+	}
+	s.mu.RUnlock()
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	for _, user := range users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) BackupTasks(ctx context.Context, fn func(models.Task) error) error {
+	s.mu.RLock()
+	tasks := make([]models.Task, 0, len(s.tasks)+len(s.trash))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	for _, task := range s.trash {
+		tasks = append(tasks, task)
+	}
+	s.mu.RUnlock()
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	for _, task := range tasks {
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) RestoreBackup(ctx context.Context, users []models.User, tasks []models.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	restored := map[string]bool{}
+	names := map[string]bool{}
+	for _, user := range users {
+		username, email := models.NormalizeUsername(user.Username), models.NormalizeEmail(user.Email)
+		if _, exists := s.users[user.ID]; exists || restored[user.ID] || names[username] || names[email] || s.userTaken("", username, email) {
+			return errors.ErrUserAlreadyExists
+		}
+		restored[user.ID] = true
+		names[username], names[email] = true, true
+	}
+	ids := map[string]bool{}
+	for _, task := range tasks {
+		_, active := s.tasks[task.ID]
+		_, trashed := s.trash[task.ID]
+		if active || trashed || ids[task.ID] {
+			return errors.ErrTaskAlreadyExists
+		}
+		if _, exists := s.users[task.UserID]; !exists && !restored[task.UserID] {
+			return errors.ErrUserNotFound
+		}
+		ids[task.ID] = true
+	}
+
+	for _, user := range users {
+		user.Username = models.NormalizeUsername(user.Username)
+		user.Email = models.NormalizeEmail(user.Email)
+		s.users[user.ID] = user
+	}
+	for _, task := range tasks {
+		task.Tags = nil
+		task.Checklist = nil
+		if _, exists := s.projects[task.ProjectID]; !exists {
+			task.ProjectID = ""
+		}
+		if _, exists := s.workspaces[task.WorkspaceID]; !exists {
+			task.WorkspaceID = ""
+		}
+		if task.Version == 0 {
+			touchTask(&task)
+		}
+		if task.Deleted {
+			s.trash[task.ID] = task
+			continue
+		}
+		s.tasks[task.ID] = task
+	}
+	return nil
+}
+
+func (s *Storage) ReorderTasks(ctx context.Context, userID string, taskIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range taskIDs {
+		task, exists := s.tasks[id]
+		if !exists || task.UserID != userID {
+			return errors.ErrTaskNotFound
+		}
+	}
+	for position, id := range taskIDs {
+		task := s.tasks[id]
+		if task.Position != position {
+			task.Position = position
+			touchTask(&task)
+		}
+		s.tasks[id] = task
+	}
+	return nil
+}
+
+func (s *Storage) CreateTemplate(ctx context.Context, template *models.TaskTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.templates {
+		if existing.UserID == template.UserID && existing.Name == template.Name {
+			return errors.ErrTemplateAlreadyExists
+		}
+	}
+	template.ID = uuid.New().String()
+	s.templates[template.ID] = *template
+	return nil
+}
+
+func (s *Storage) GetTemplates(ctx context.Context, userID string) ([]models.TaskTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	templates := []models.TaskTemplate{}
+	for _, template := range s.templates {
+		if template.UserID == userID {
+			templates = append(templates, template)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+func (s *Storage) GetTemplateByID(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	template, exists := s.templates[id]
+	if !exists {
+		return nil, errors.ErrTemplateNotFound
+	}
+	return &template, nil
+}
+
+func (s *Storage) UpdateTemplate(ctx context.Context, id string, template *models.TaskTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.templates[id]
+	if !exists {
+		return errors.ErrTemplateNotFound
+	}
+	for otherID, existing := range s.templates {
+		if otherID != id && existing.UserID == current.UserID && existing.Name == template.Name {
+			return errors.ErrTemplateAlreadyExists
+		}
+	}
+	current.Name = template.Name
+	current.Title = template.Title
+	current.Description = template.Description
+	current.Tags = template.Tags
+	current.Checklist = template.Checklist
+	s.templates[id] = current
+	return nil
+}
+
+func (s *Storage) DeleteTemplate(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.templates[id]; !exists {
+		return errors.ErrTemplateNotFound
+	}
+	delete(s.templates, id)
+	return nil
+}
+
+func (s *Storage) CreateWebhook(ctx context.Context, hook *models.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hook.ID = uuid.New().String()
+	if hook.CreatedAt.IsZero() {
+		hook.CreatedAt = time.Now()
+	}
+	s.webhooks[hook.ID] = *hook
+	return nil
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hooks := []models.Webhook{}
+	for _, hook := range s.webhooks {
+		if hook.UserID == userID {
+			hooks = append(hooks, hook)
+		}
+	}
+	sort.Slice(hooks, func(i, j int) bool {
+		if hooks[i].CreatedAt.Equal(hooks[j].CreatedAt) {
+			return hooks[i].ID < hooks[j].ID
+		}
+		return hooks[i].CreatedAt.Before(hooks[j].CreatedAt)
+	})
+	return hooks, nil
+}
+
+func (s *Storage) GetWebhookByID(ctx context.Context, id string) (*models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hook, exists := s.webhooks[id]
+	if !exists {
+		return nil, errors.ErrWebhookNotFound
+	}
+	return &hook, nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.webhooks[id]; !exists {
+		return errors.ErrWebhookNotFound
+	}
+	delete(s.webhooks, id)
+	delete(s.deliveries, id)
+	return nil
+}
+
+func (s *Storage) AddWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.webhooks[delivery.WebhookID]; !exists {
+		return errors.ErrWebhookNotFound
+	}
+	delivery.ID = uuid.New().String()
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	s.deliveries[delivery.WebhookID] = append(s.deliveries[delivery.WebhookID], *delivery)
+	return nil
+}
+
+func (s *Storage) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]models.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.deliveries[webhookID]
+	deliveries := make([]models.WebhookDelivery, 0, len(stored))
+	for i := len(stored) - 1; i >= 0 && len(deliveries) < webhookDeliveriesPageSize; i-- {
+		deliveries = append(deliveries, stored[i])
+	}
+	return deliveries, nil
+}
+
+func (s *Storage) GetChecklist(ctx context.Context, taskID string) ([]models.ChecklistItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := append([]models.ChecklistItem{}, s.checklists[taskID]...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items, nil
+}
+
+func (s *Storage) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item.ID = uuid.New().String()
+	item.Done = false
+	item.Position = 0
+	for _, existing := range s.checklists[item.TaskID] {
+		if existing.Position >= item.Position {
+			item.Position = existing.Position + 1
+		}
+	}
+	s.checklists[item.TaskID] = append(s.checklists[item.TaskID], *item)
+	return nil
+}
+
+func (s *Storage) ToggleChecklistItem(ctx context.Context, taskID, itemID string) (*models.ChecklistItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.checklists[taskID]
+	for i := range items {
+		if items[i].ID == itemID {
+			items[i].Done = !items[i].Done
+			item := items[i]
+			return &item, nil
+		}
+	}
+	return nil, errors.ErrChecklistItemNotFound
+}
+
+func (s *Storage) ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.checklists[taskID]
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[item.ID] = i
+	}
+	for _, id := range itemIDs {
+		if _, exists := index[id]; !exists {
+			return errors.ErrChecklistItemNotFound
+		}
+	}
+	for position, id := range itemIDs {
+		items[index[id]].Position = position
+	}
+	return nil
+}
+
+func (s *Storage) DeleteChecklistItem(ctx context.Context, taskID, itemID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.checklists[taskID]
+	for i, item := range items {
+		if item.ID == itemID {
+			s.checklists[taskID] = append(items[:i], items[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrChecklistItemNotFound
+}
+
+func (s *Storage) GetWorkflow(ctx context.Context, userID string) (*models.Workflow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	workflow, exists := s.workflows[userID]
+	if !exists {
+		return nil, errors.ErrWorkflowNotFound
+	}
+	return &workflow, nil
+}
+
+func (s *Storage) SaveWorkflow(ctx context.Context, workflow *models.Workflow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if workflow.Transitions == nil {
+		workflow.Transitions = map[string][]string{}
+	}
+	s.workflows[workflow.UserID] = *workflow
+	return nil
+}
+
+func (s *Storage) DeleteWorkflow(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.workflows[userID]; !exists {
+		return errors.ErrWorkflowNotFound
+	}
+	delete(s.workflows, userID)
+	return nil
+}
+
+func (s *Storage) CreateWorkspace(ctx context.Context, workspace *models.Workspace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.users[workspace.OwnerID]; !exists {
+		return errors.ErrUserNotFound
+	}
+	workspace.ID = uuid.New().String()
+	workspace.CreatedAt = time.Now()
+	workspace.Role = ""
+	s.workspaces[workspace.ID] = *workspace
+	s.members[workspace.ID] = map[string]models.WorkspaceMember{
+		workspace.OwnerID: {WorkspaceID: workspace.ID, UserID: workspace.OwnerID, Role: models.WorkspaceRoleOwner, JoinedAt: workspace.CreatedAt},
+	}
+	workspace.Role = models.WorkspaceRoleOwner
+	return nil
+}
+
+func (s *Storage) GetWorkspaces(ctx context.Context, userID string) ([]models.Workspace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	workspaces := []models.Workspace{}
+	for id, workspace := range s.workspaces {
+		if member, ok := s.members[id][userID]; ok {
+			workspace.Role = member.Role
+			workspaces = append(workspaces, workspace)
+		}
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		if workspaces[i].Name != workspaces[j].Name {
+			return workspaces[i].Name < workspaces[j].Name
+		}
+		return workspaces[i].ID < workspaces[j].ID
+	})
+	return workspaces, nil
+}
+
+func (s *Storage) GetWorkspaceByID(ctx context.Context, id string) (*models.Workspace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	workspace, exists := s.workspaces[id]
+	if !exists {
+		return nil, errors.ErrWorkspaceNotFound
+	}
+	return &workspace, nil
+}
+
+func (s *Storage) UpdateWorkspace(ctx context.Context, id string, workspace *models.Workspace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.workspaces[id]
+	if !exists {
+		return errors.ErrWorkspaceNotFound
+	}
+	current.Name = workspace.Name
+	s.workspaces[id] = current
+	return nil
+}
+
+func (s *Storage) DeleteWorkspace(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.workspaces[id]; !exists {
+		return errors.ErrWorkspaceNotFound
+	}
+	s.deleteWorkspace(id)
+	return nil
+}
+
+func (s *Storage) deleteWorkspace(id string) {
+	delete(s.workspaces, id)
+	delete(s.members, id)
+	for taskID, task := range s.trash {
+		if task.WorkspaceID == id {
+			delete(s.trash, taskID)
+		}
+	}
+	for taskID, task := range s.tasks {
+		if task.WorkspaceID == id {
+			delete(s.tasks, taskID)
+			delete(s.taskTags, taskID)
+			delete(s.shares, taskID)
+			delete(s.checklists, taskID)
+		}
+	}
+}
+
+func (s *Storage) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	members := []models.WorkspaceMember{}
+	for _, member := range s.members[workspaceID] {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if !members[i].JoinedAt.Equal(members[j].JoinedAt) {
+			return members[i].JoinedAt.Before(members[j].JoinedAt)
+		}
+		return members[i].UserID < members[j].UserID
+	})
+	return members, nil
+}
+
+func (s *Storage) GetWorkspaceRole(ctx context.Context, workspaceID, userID string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.members[workspaceID][userID].Role, nil
+}
+
+func (s *Storage) SaveWorkspaceMember(ctx context.Context, member *models.WorkspaceMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.workspaces[member.WorkspaceID]; !exists {
+		return errors.ErrWorkspaceNotFound
+	}
+	if _, exists := s.users[member.UserID]; !exists {
+		return errors.ErrUserNotFound
+	}
+	members := s.members[member.WorkspaceID]
+	if current, ok := members[member.UserID]; ok {
+		member.JoinedAt = current.JoinedAt
+	} else {
+		member.JoinedAt = time.Now()
+	}
+	members[member.UserID] = *member
+	return nil
+}
+
+func (s *Storage) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.members[workspaceID][userID]; !exists {
+		return errors.ErrNotWorkspaceMember
+	}
+	delete(s.members[workspaceID], userID)
+	return nil
+}
+
+func cloneNested[K, N comparable, V any](m map[K]map[N]V) map[K]map[N]V {
+	out := make(map[K]map[N]V, len(m))
+	for k, v := range m {
+		out[k] = maps.Clone(v)
+	}
+	return out
+}
+
+func cloneSlices[K comparable, V any](m map[K][]V) map[K][]V {
+	out := make(map[K][]V, len(m))
+	for k, v := range m {
+		out[k] = slices.Clone(v)
+	}
+	return out
+}
+
+func (s *Storage) clone() *Storage {
+	return &Storage{
+		users:    maps.Clone(s.users),
+		tasks:    maps.Clone(s.tasks),
+		tags:     maps.Clone(s.tags),
+		taskTags: cloneNested(s.taskTags),
+		shares:   cloneNested(s.shares),
+		projects: maps.Clone(s.projects),
+		trash:    maps.Clone(s.trash),
+		events:   cloneSlices(s.events),
+
+		checklists: cloneSlices(s.checklists),
+		workflows:  maps.Clone(s.workflows),
+
+		preferences: maps.Clone(s.preferences),
+		logins:      cloneSlices(s.logins),
+
+		templates:  maps.Clone(s.templates),
+		webhooks:   maps.Clone(s.webhooks),
+		deliveries: cloneSlices(s.deliveries),
+		workspaces: maps.Clone(s.workspaces),
+		members:    cloneNested(s.members),
+
+		purger: s.purger,
+	}
+}
+
+func (s *Storage) WithTx(ctx context.Context, fn func(tx repository.Storage) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.clone()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.users, s.tasks, s.tags, s.taskTags = tx.users, tx.tasks, tx.tags, tx.taskTags
+	s.shares, s.projects, s.trash, s.events = tx.shares, tx.projects, tx.trash, tx.events
+	s.checklists, s.workflows = tx.checklists, tx.workflows
+	s.preferences, s.logins = tx.preferences, tx.logins
+	s.templates, s.webhooks, s.deliveries = tx.templates, tx.webhooks, tx.deliveries
+	s.workspaces, s.members = tx.workspaces, tx.members
 	return nil
 }
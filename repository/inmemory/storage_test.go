@@ -1,8 +1,13 @@
 package storage
 
 import (
+	"context"
+	"project/internal/domain/errors"
 	"project/internal/domain/models"
+	"project/repository"
+	"project/repository/conformancetest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -108,7 +113,7 @@ func TestStorageCreateUser(t *testing.T) {
 			want: struct {
 				error bool
 			}{
-				error: false,
+				error: true,
 			},
 			setup: func(s *Storage) {
 				s.users["existinguser"] = models.User{
@@ -120,6 +125,40 @@ func TestStorageCreateUser(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "username differing only in case",
+			user: &models.User{
+				Username: "TestUser",
+				Email:    "other@example.com",
+				Password: "password456",
+				Role:     "user",
+			},
+			want: struct {
+				error bool
+			}{
+				error: true,
+			},
+			setup: func(s *Storage) {
+				s.users["user1"] = models.User{ID: "user1", Username: "testuser", Email: "test@example.com"}
+			},
+		},
+		{
+			name: "email differing only in case and normalization form",
+			user: &models.User{
+				Username: "otheruser",
+				Email:    "Jose\u0301@Example.com",
+				Password: "password456",
+				Role:     "user",
+			},
+			want: struct {
+				error bool
+			}{
+				error: true,
+			},
+			setup: func(s *Storage) {
+				s.users["user1"] = models.User{ID: "user1", Username: "testuser", Email: "jos\u00e9@example.com"}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -127,7 +166,7 @@ func TestStorageCreateUser(t *testing.T) {
 			storage := NewStorage()
 			tt.setup(storage)
 
-			err := storage.CreateUser(tt.user)
+			err := storage.CreateUser(context.Background(), tt.user)
 
 			if tt.want.error {
 				assert.Error(t, err)
@@ -189,7 +228,7 @@ func TestStorageGetUserByID(t *testing.T) {
 			storage := NewStorage()
 			tt.setup(storage)
 
-			user, err := storage.GetUserByID(tt.userID)
+			user, err := storage.GetUserByID(context.Background(), tt.userID)
 
 			if tt.want.error {
 				assert.Error(t, err)
@@ -253,7 +292,7 @@ func TestStorageGetUserByUsername(t *testing.T) {
 			storage := NewStorage()
 			tt.setup(storage)
 
-			user, err := storage.GetUserByUsername(tt.username)
+			user, err := storage.GetUserByUsername(context.Background(), tt.username)
 
 			if tt.want.error {
 				assert.Error(t, err)
@@ -325,7 +364,7 @@ func TestStorageUpdateUser(t *testing.T) {
 			storage := NewStorage()
 			tt.setup(storage)
 
-			err := storage.UpdateUser(tt.userID, tt.user)
+			err := storage.UpdateUser(context.Background(), tt.userID, tt.user)
 
 			if tt.want.error {
 				assert.Error(t, err)
@@ -381,7 +420,7 @@ func TestStorageDeleteUser(t *testing.T) {
 			storage := NewStorage()
 			tt.setup(storage)
 
-			err := storage.DeleteUser(tt.userID)
+			err := storage.DeleteUser(context.Background(), tt.userID)
 
 			if tt.want.error {
 				assert.Error(t, err)
@@ -711,3 +750,1033 @@ func TestStorageDeleteTaskNoCtx(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageGetTasksWithFilter(t *testing.T) {
+	deleted := true
+	tests := []struct {
+		name   string
+		filter models.TaskFilter
+		want   []string
+	}{
+		{
+			name:   "no filter excludes deleted",
+			filter: models.TaskFilter{},
+			want:   []string{"task1", "task2"},
+		},
+		{
+			name:   "status filter",
+			filter: models.TaskFilter{Status: "done"},
+			want:   []string{"task2"},
+		},
+		{
+			name:   "deleted filter",
+			filter: models.TaskFilter{Deleted: &deleted},
+			want:   []string{"task3"},
+		},
+		{
+			name:   "title contains is case-insensitive",
+			filter: models.TaskFilter{TitleContains: "MILK"},
+			want:   []string{"task1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewStorage()
+			storage.tasks["task1"] = models.Task{ID: "task1", Title: "Buy milk", Status: "new", UserID: "user1"}
+			storage.tasks["task2"] = models.Task{ID: "task2", Title: "Write report", Status: "done", UserID: "user1"}
+			storage.trash["task3"] = models.Task{ID: "task3", Title: "Old task", Status: "new", UserID: "user1", Deleted: true}
+			storage.tasks["task4"] = models.Task{ID: "task4", Title: "Buy milk", Status: "new", UserID: "user2"}
+
+			tasks, err := storage.GetTasks(context.Background(), "user1", tt.filter)
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			assert.ElementsMatch(t, tt.want, ids)
+		})
+	}
+}
+
+func TestStorageSearchTasks(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "title matches rank above description matches",
+			query: "report",
+			want:  []string{"task2", "task1"},
+		},
+		{
+			name:  "case-insensitive match",
+			query: "MILK",
+			want:  []string{"task1"},
+		},
+		{
+			name:  "no matches",
+			query: "nothing",
+			want:  []string{},
+		},
+		{
+			name:  "every term must match",
+			query: "milk report",
+			want:  []string{"task1"},
+		},
+		{
+			name:  "one missing term excludes task",
+			query: "report nothing",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewStorage()
+			storage.tasks["task1"] = models.Task{ID: "task1", Title: "Buy milk", Description: "before the report", Status: "new", UserID: "user1"}
+			storage.tasks["task2"] = models.Task{ID: "task2", Title: "Write report", Status: "new", UserID: "user1"}
+			storage.tasks["task3"] = models.Task{ID: "task3", Title: "Old report", Status: "new", UserID: "user1", Deleted: true}
+			storage.tasks["task4"] = models.Task{ID: "task4", Title: "Other report", Status: "new", UserID: "user2"}
+
+			tasks, err := storage.SearchTasks(context.Background(), "user1", tt.query)
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestStorageTags(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	storage.tasks["task1"] = models.Task{ID: "task1", Title: "Task 1", Status: "new", UserID: "user1"}
+	storage.tasks["task2"] = models.Task{ID: "task2", Title: "Task 2", Status: "new", UserID: "user1"}
+
+	work := &models.Tag{Name: "work", UserID: "user1"}
+	assert.NoError(t, storage.CreateTag(ctx, work))
+	assert.NotEmpty(t, work.ID)
+	assert.Equal(t, errors.ErrTagAlreadyExists, storage.CreateTag(ctx, &models.Tag{Name: "work", UserID: "user1"}))
+	assert.NoError(t, storage.CreateTag(ctx, &models.Tag{Name: "work", UserID: "user2"}))
+
+	assert.NoError(t, storage.AttachTag(ctx, "task1", work.ID))
+	assert.Equal(t, errors.ErrNotFound, storage.AttachTag(ctx, "missing", work.ID))
+	assert.Equal(t, errors.ErrTagNotFound, storage.AttachTag(ctx, "task1", "missing"))
+
+	task, err := storage.GetTaskByID(ctx, "task1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"work"}, task.Tags)
+
+	tasks, err := storage.GetTasks(ctx, "user1", models.TaskFilter{Tag: "work"})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "task1", tasks[0].ID)
+
+	assert.NoError(t, storage.UpdateTag(ctx, work.ID, &models.Tag{Name: "office"}))
+	task, _ = storage.GetTaskByID(ctx, "task1")
+	assert.Equal(t, []string{"office"}, task.Tags)
+
+	tags, err := storage.GetTags(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+
+	assert.NoError(t, storage.DetachTag(ctx, "task1", work.ID))
+	assert.Equal(t, errors.ErrTagNotFound, storage.DetachTag(ctx, "task1", work.ID))
+
+	assert.NoError(t, storage.AttachTag(ctx, "task2", work.ID))
+	assert.NoError(t, storage.DeleteTag(ctx, work.ID))
+	task, _ = storage.GetTaskByID(ctx, "task2")
+	assert.Empty(t, task.Tags)
+	assert.Equal(t, errors.ErrTagNotFound, storage.DeleteTag(ctx, work.ID))
+}
+
+func TestStorageSubtasks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	parent := &models.Task{Title: "Parent", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, parent))
+	child := &models.Task{Title: "Child", Status: "new", UserID: "user1", ParentID: parent.ID}
+	assert.NoError(t, storage.CreateTask(ctx, child))
+	grandchild := &models.Task{Title: "Grandchild", Status: "new", UserID: "user1", ParentID: child.ID}
+	assert.NoError(t, storage.CreateTask(ctx, grandchild))
+	unrelated := &models.Task{Title: "Unrelated", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, unrelated))
+
+	subtasks, err := storage.GetSubtasks(ctx, parent.ID)
+	assert.NoError(t, err)
+	assert.Len(t, subtasks, 1)
+	assert.Equal(t, child.ID, subtasks[0].ID)
+
+	assert.NoError(t, storage.DeleteTask(ctx, parent.ID))
+	for _, id := range []string{parent.ID, child.ID, grandchild.ID} {
+		task, err := storage.GetTaskByID(ctx, id)
+		assert.NoError(t, err)
+		assert.True(t, task.Deleted)
+	}
+	task, err := storage.GetTaskByID(ctx, unrelated.ID)
+	assert.NoError(t, err)
+	assert.False(t, task.Deleted)
+}
+
+func TestStorageDueReminders(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(30 * time.Minute)
+	later := now.Add(3 * time.Hour)
+
+	dueSoon := &models.Task{Title: "Soon", Status: "new", UserID: "user1", DueDate: &soon}
+	assert.NoError(t, storage.CreateTask(ctx, dueSoon))
+	customOffset := &models.Task{Title: "Custom", Status: "new", UserID: "user1", DueDate: &later, ReminderOffsetMinutes: 240}
+	assert.NoError(t, storage.CreateTask(ctx, customOffset))
+	notYet := &models.Task{Title: "Later", Status: "new", UserID: "user1", DueDate: &later}
+	assert.NoError(t, storage.CreateTask(ctx, notYet))
+	done := &models.Task{Title: "Done", Status: "done", UserID: "user1", DueDate: &soon}
+	assert.NoError(t, storage.CreateTask(ctx, done))
+	noDue := &models.Task{Title: "No due date", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, noDue))
+
+	tasks, err := storage.GetDueReminders(ctx, now, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, dueSoon.ID, tasks[0].ID)
+	assert.Equal(t, customOffset.ID, tasks[1].ID)
+
+	assert.NoError(t, storage.MarkReminded(ctx, dueSoon.ID, now))
+	assert.Equal(t, errors.ErrNotFound, storage.MarkReminded(ctx, "missing", now))
+	tasks, err = storage.GetDueReminders(ctx, now, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	moved := now.Add(45 * time.Minute)
+	rescheduled, err := storage.GetTaskByID(ctx, dueSoon.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, rescheduled.RemindedAt)
+	rescheduled.DueDate = &moved
+	assert.NoError(t, storage.UpdateTask(ctx, dueSoon.ID, rescheduled))
+	tasks, err = storage.GetDueReminders(ctx, now, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func TestStorageTaskShares(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	task := &models.Task{Title: "Shared", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, task))
+
+	permission, err := storage.GetTaskPermission(ctx, task.ID, "user2")
+	assert.NoError(t, err)
+	assert.Empty(t, permission)
+
+	assert.NoError(t, storage.ShareTask(ctx, &models.TaskShare{TaskID: task.ID, UserID: "user2", Permission: "read"}))
+	assert.NoError(t, storage.ShareTask(ctx, &models.TaskShare{TaskID: task.ID, UserID: "user2", Permission: "write"}))
+	permission, err = storage.GetTaskPermission(ctx, task.ID, "user2")
+	assert.NoError(t, err)
+	assert.Equal(t, "write", permission)
+
+	assert.Equal(t, errors.ErrNotFound, storage.ShareTask(ctx, &models.TaskShare{TaskID: "missing", UserID: "user2", Permission: "read"}))
+
+	assert.NoError(t, storage.DeleteTask(ctx, task.ID))
+	permission, err = storage.GetTaskPermission(ctx, task.ID, "user2")
+	assert.NoError(t, err)
+	assert.Empty(t, permission)
+}
+
+func TestStorageProjects(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+
+	inbox := &models.Project{Name: "Inbox", UserID: "user1"}
+	assert.NoError(t, storage.CreateProject(ctx, inbox))
+	assert.NotEmpty(t, inbox.ID)
+	assert.Equal(t, errors.ErrProjectAlreadyExists, storage.CreateProject(ctx, &models.Project{Name: "Inbox", UserID: "user1"}))
+	assert.NoError(t, storage.CreateProject(ctx, &models.Project{Name: "Inbox", UserID: "user2"}))
+	work := &models.Project{Name: "Work", UserID: "user1"}
+	assert.NoError(t, storage.CreateProject(ctx, work))
+
+	projects, err := storage.GetProjects(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+	assert.Equal(t, "Inbox", projects[0].Name)
+
+	assert.Equal(t, errors.ErrProjectAlreadyExists, storage.UpdateProject(ctx, work.ID, &models.Project{Name: "Inbox"}))
+	assert.NoError(t, storage.UpdateProject(ctx, work.ID, &models.Project{Name: "Office"}))
+
+	task := &models.Task{Title: "Report", Status: "new", UserID: "user1", ProjectID: work.ID}
+	assert.NoError(t, storage.CreateTask(ctx, task))
+	assert.NoError(t, storage.CreateTask(ctx, &models.Task{Title: "Other", Status: "new", UserID: "user1"}))
+	tasks, err := storage.GetTasks(ctx, "user1", models.TaskFilter{ProjectID: work.ID})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	assert.NoError(t, storage.DeleteProject(ctx, work.ID))
+	_, err = storage.GetProjectByID(ctx, work.ID)
+	assert.Equal(t, errors.ErrProjectNotFound, err)
+	got, err := storage.GetTaskByID(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, got.ProjectID)
+}
+
+func TestStorageApplyBulk(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	first := &models.Task{Title: "First", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, first))
+	second := &models.Task{Title: "Second", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, second))
+	project := &models.Project{Name: "Work", UserID: "user1"}
+	assert.NoError(t, storage.CreateProject(ctx, project))
+
+	results, err := storage.ApplyBulk(ctx, []models.BulkOperation{
+		{TaskID: first.ID, Action: models.BulkActionUpdateStatus, Status: "done"},
+		{TaskID: first.ID, Action: models.BulkActionMove, ProjectID: project.ID},
+		{TaskID: second.ID, Action: models.BulkActionDelete},
+		{TaskID: "missing", Action: models.BulkActionDelete},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 4)
+	assert.True(t, results[0].Success)
+	assert.True(t, results[1].Success)
+	assert.True(t, results[2].Success)
+	assert.False(t, results[3].Success)
+	assert.Equal(t, errors.ErrTaskNotFound.Error(), results[3].Error)
+
+	got, err := storage.GetTaskByID(ctx, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", got.Status)
+	assert.Equal(t, project.ID, got.ProjectID)
+	got, err = storage.GetTaskByID(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.True(t, got.Deleted)
+}
+
+func TestStorageTrash(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	parent := &models.Task{Title: "Parent", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, parent))
+	child := &models.Task{Title: "Child", Status: "new", UserID: "user1", ParentID: parent.ID}
+	assert.NoError(t, storage.CreateTask(ctx, child))
+	assert.NoError(t, storage.CreateTask(ctx, &models.Task{Title: "Other user", Status: "new", UserID: "user2"}))
+
+	assert.NoError(t, storage.DeleteTask(ctx, parent.ID))
+	trash, err := storage.GetTrash(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, trash, 2)
+	for _, task := range trash {
+		assert.True(t, task.Deleted)
+		assert.NotNil(t, task.DeletedAt)
+	}
+	trash, err = storage.GetTrash(ctx, "user2")
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+
+	assert.NoError(t, storage.RestoreTask(ctx, parent.ID))
+	assert.Equal(t, errors.ErrTaskNotInTrash, storage.RestoreTask(ctx, parent.ID))
+	restored, err := storage.GetTaskByID(ctx, child.ID)
+	assert.NoError(t, err)
+	assert.False(t, restored.Deleted)
+	assert.Nil(t, restored.DeletedAt)
+	trash, err = storage.GetTrash(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
+func TestStorageHardDeleteTask(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	parent := &models.Task{Title: "Parent", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, parent))
+	child := &models.Task{Title: "Child", Status: "new", UserID: "user1", ParentID: parent.ID}
+	assert.NoError(t, storage.CreateTask(ctx, child))
+	trashed := &models.Task{Title: "Trashed", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, trashed))
+	assert.NoError(t, storage.DeleteTask(ctx, trashed.ID))
+
+	assert.NoError(t, storage.HardDeleteTask(ctx, parent.ID))
+	assert.NoError(t, storage.HardDeleteTask(ctx, trashed.ID))
+	assert.Equal(t, errors.ErrNotFound, storage.HardDeleteTask(ctx, parent.ID))
+	for _, id := range []string{parent.ID, child.ID, trashed.ID} {
+		_, err := storage.GetTaskByID(ctx, id)
+		assert.Equal(t, errors.ErrNotFound, err)
+	}
+	trash, err := storage.GetTrash(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
+func TestStorageArchive(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	done := &models.Task{Title: "Done", Status: "done", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, done))
+	assert.NoError(t, storage.CreateTask(ctx, &models.Task{Title: "Open", Status: "new", UserID: "user1"}))
+
+	assert.NoError(t, storage.SetTaskArchived(ctx, done.ID, true))
+	assert.Equal(t, errors.ErrNotFound, storage.SetTaskArchived(ctx, "missing", true))
+
+	tasks, err := storage.GetTasks(ctx, "user1", models.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "Open", tasks[0].Title)
+
+	archived := true
+	tasks, err = storage.GetTasks(ctx, "user1", models.TaskFilter{Archived: &archived})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, done.ID, tasks[0].ID)
+
+	assert.NoError(t, storage.SetTaskArchived(ctx, done.ID, false))
+	tasks, err = storage.GetTasks(ctx, "user1", models.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func TestStorageTaskEvents(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	task := &models.Task{Title: "Task", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, task))
+
+	updated := *task
+	updated.Status = "done"
+	assert.NoError(t, storage.AddTaskEvent(ctx, &models.TaskEvent{TaskID: task.ID, UserID: "user1", Action: models.TaskEventCreate, NewValue: task}))
+	assert.NoError(t, storage.AddTaskEvent(ctx, &models.TaskEvent{TaskID: task.ID, UserID: "user1", Action: models.TaskEventUpdate, OldValue: task, NewValue: &updated}))
+
+	events, err := storage.GetTaskEvents(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, models.TaskEventCreate, events[0].Action)
+	assert.NotEmpty(t, events[0].ID)
+	assert.Equal(t, "done", events[1].NewValue.Status)
+
+	assert.NoError(t, storage.HardDeleteTask(ctx, task.ID))
+	events, err = storage.GetTaskEvents(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestStorageAssignTask(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	task := &models.Task{Title: "Task", Status: "new", UserID: "owner"}
+	assert.NoError(t, storage.CreateTask(ctx, task))
+
+	assert.NoError(t, storage.AssignTask(ctx, task.ID, "assignee"))
+	assert.Equal(t, errors.ErrNotFound, storage.AssignTask(ctx, "missing", "assignee"))
+
+	tasks, err := storage.GetTasks(ctx, "assignee", models.TaskFilter{View: models.TaskViewAssigned})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+
+	tasks, err = storage.GetTasks(ctx, "assignee", models.TaskFilter{View: models.TaskViewCreated})
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	assert.NoError(t, storage.AssignTask(ctx, task.ID, ""))
+	tasks, err = storage.GetTasks(ctx, "assignee", models.TaskFilter{View: models.TaskViewAssigned})
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestStorageExportTasks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	kept := &models.Task{Title: "Kept", Status: "new", UserID: "user1"}
+	removed := &models.Task{Title: "Removed", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, kept))
+	assert.NoError(t, storage.CreateTask(ctx, removed))
+	assert.NoError(t, storage.CreateTask(ctx, &models.Task{Title: "Other", Status: "new", UserID: "user2"}))
+	assert.NoError(t, storage.DeleteTask(ctx, removed.ID))
+
+	var exported []models.Task
+	err := storage.ExportTasks(ctx, "user1", func(task models.Task) error {
+		exported = append(exported, task)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, exported, 1)
+	assert.Equal(t, kept.ID, exported[0].ID)
+
+	err = storage.ExportTasks(ctx, "user1", func(task models.Task) error { return errors.ErrInternalServer })
+	assert.Equal(t, errors.ErrInternalServer, err)
+}
+
+func TestStorageStreamTasks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	first := &models.Task{Title: "First", Status: "new", UserID: "user1"}
+	second := &models.Task{Title: "Second", Status: "done", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, first))
+	assert.NoError(t, storage.CreateTask(ctx, second))
+	assert.NoError(t, storage.CreateTask(ctx, &models.Task{Title: "Other", Status: "done", UserID: "user2"}))
+
+	var streamed []string
+	err := storage.StreamTasks(ctx, "user1", models.TaskFilter{Sort: models.TaskSortTitle, Descending: true}, func(task models.Task) error {
+		streamed = append(streamed, task.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{second.ID, first.ID}, streamed)
+
+	streamed = nil
+	err = storage.StreamTasks(ctx, "user1", models.TaskFilter{Status: "done"}, func(task models.Task) error {
+		streamed = append(streamed, task.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{second.ID}, streamed)
+
+	err = storage.StreamTasks(ctx, "user1", models.TaskFilter{}, func(task models.Task) error { return errors.ErrInternalServer })
+	assert.Equal(t, errors.ErrInternalServer, err)
+}
+
+func TestStorageReorderTasks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	first := &models.Task{Title: "First", Status: "new", UserID: "user1"}
+	second := &models.Task{Title: "Second", Status: "new", UserID: "user1"}
+	foreign := &models.Task{Title: "Foreign", Status: "new", UserID: "user2"}
+	assert.NoError(t, storage.CreateTask(ctx, first))
+	assert.NoError(t, storage.CreateTask(ctx, second))
+	assert.NoError(t, storage.CreateTask(ctx, foreign))
+	assert.Equal(t, 0, first.Position)
+	assert.Equal(t, 1, second.Position)
+	assert.Equal(t, 0, foreign.Position)
+
+	assert.NoError(t, storage.ReorderTasks(ctx, "user1", []string{second.ID, first.ID}))
+	tasks, err := storage.GetTasks(ctx, "user1", models.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, second.ID, tasks[0].ID)
+	assert.Equal(t, first.ID, tasks[1].ID)
+
+	assert.Equal(t, errors.ErrTaskNotFound, storage.ReorderTasks(ctx, "user1", []string{first.ID, foreign.ID}))
+	tasks, err = storage.GetTasks(ctx, "user1", models.TaskFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, second.ID, tasks[0].ID)
+}
+
+func TestStorageTemplates(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	weekly := &models.TaskTemplate{UserID: "user1", Name: "Weekly", Title: "Weekly review", Tags: []string{"work"}, Checklist: []string{"Inbox zero"}}
+	assert.NoError(t, storage.CreateTemplate(ctx, weekly))
+	assert.NotEmpty(t, weekly.ID)
+	assert.Equal(t, errors.ErrTemplateAlreadyExists, storage.CreateTemplate(ctx, &models.TaskTemplate{UserID: "user1", Name: "Weekly", Title: "Copy"}))
+	assert.NoError(t, storage.CreateTemplate(ctx, &models.TaskTemplate{UserID: "user2", Name: "Weekly", Title: "Other"}))
+
+	daily := &models.TaskTemplate{UserID: "user1", Name: "Daily", Title: "Daily standup"}
+	assert.NoError(t, storage.CreateTemplate(ctx, daily))
+	templates, err := storage.GetTemplates(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, templates, 2)
+	assert.Equal(t, "Daily", templates[0].Name)
+
+	assert.Equal(t, errors.ErrTemplateAlreadyExists, storage.UpdateTemplate(ctx, daily.ID, &models.TaskTemplate{Name: "Weekly", Title: "Daily standup"}))
+	assert.NoError(t, storage.UpdateTemplate(ctx, weekly.ID, &models.TaskTemplate{Name: "Weekly", Title: "Weekly planning", Checklist: []string{"Plan"}}))
+	template, err := storage.GetTemplateByID(ctx, weekly.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Weekly planning", template.Title)
+	assert.Equal(t, []string{"Plan"}, template.Checklist)
+	assert.Equal(t, "user1", template.UserID)
+
+	assert.NoError(t, storage.DeleteTemplate(ctx, weekly.ID))
+	_, err = storage.GetTemplateByID(ctx, weekly.ID)
+	assert.Equal(t, errors.ErrTemplateNotFound, err)
+	assert.Equal(t, errors.ErrTemplateNotFound, storage.DeleteTemplate(ctx, weekly.ID))
+}
+
+func TestStoragePurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	parent := &models.Task{Title: "Parent", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, parent))
+	child := &models.Task{Title: "Child", Status: "new", UserID: "user1", ParentID: parent.ID}
+	assert.NoError(t, storage.CreateTask(ctx, child))
+	kept := &models.Task{Title: "Kept", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, kept))
+	assert.NoError(t, storage.DeleteTask(ctx, parent.ID))
+
+	purged, err := storage.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	purged, err = storage.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	trash, err := storage.GetTrash(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+	_, err = storage.GetTaskByID(ctx, kept.ID)
+	assert.NoError(t, err)
+}
+
+func TestStorageWebhooks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	hook := &models.Webhook{UserID: "user1", URL: "https://example.com/hook", Secret: "supersecretvalue1"}
+	assert.NoError(t, storage.CreateWebhook(ctx, hook))
+	assert.NotEmpty(t, hook.ID)
+	assert.NoError(t, storage.CreateWebhook(ctx, &models.Webhook{UserID: "user2", URL: "https://example.com/other", Secret: "supersecretvalue2"}))
+
+	hooks, err := storage.GetWebhooks(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 1)
+
+	assert.Equal(t, errors.ErrWebhookNotFound, storage.AddWebhookDelivery(ctx, &models.WebhookDelivery{WebhookID: "missing"}))
+	for attempt := 1; attempt <= 3; attempt++ {
+		assert.NoError(t, storage.AddWebhookDelivery(ctx, &models.WebhookDelivery{WebhookID: hook.ID, Event: models.WebhookEventTaskCreated, Attempt: attempt}))
+	}
+	deliveries, err := storage.GetWebhookDeliveries(ctx, hook.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, deliveries, 3) {
+		assert.Equal(t, 3, deliveries[0].Attempt)
+	}
+
+	assert.NoError(t, storage.DeleteWebhook(ctx, hook.ID))
+	_, err = storage.GetWebhookByID(ctx, hook.ID)
+	assert.Equal(t, errors.ErrWebhookNotFound, err)
+	deliveries, err = storage.GetWebhookDeliveries(ctx, hook.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestStorageGetTasksSortAndPage(t *testing.T) {
+	early := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(48 * time.Hour)
+	tests := []struct {
+		name   string
+		filter models.TaskFilter
+		want   []string
+	}{
+		{
+			name:   "default position order",
+			filter: models.TaskFilter{},
+			want:   []string{"task1", "task2", "task3", "task4"},
+		},
+		{
+			name:   "title ascending",
+			filter: models.TaskFilter{Sort: models.TaskSortTitle},
+			want:   []string{"task3", "task1", "task4", "task2"},
+		},
+		{
+			name:   "title descending",
+			filter: models.TaskFilter{Sort: models.TaskSortTitle, Descending: true},
+			want:   []string{"task2", "task4", "task1", "task3"},
+		},
+		{
+			name:   "due date keeps empty dates last",
+			filter: models.TaskFilter{Sort: models.TaskSortDueDate, Descending: true},
+			want:   []string{"task2", "task4", "task1", "task3"},
+		},
+		{
+			name:   "limit and offset",
+			filter: models.TaskFilter{Sort: models.TaskSortTitle, Limit: 2, Offset: 1},
+			want:   []string{"task1", "task4"},
+		},
+		{
+			name:   "offset past the end",
+			filter: models.TaskFilter{Offset: 10},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewStorage()
+			storage.tasks["task1"] = models.Task{ID: "task1", Title: "Bravo", Status: "new", UserID: "user1", Position: 0}
+			storage.tasks["task2"] = models.Task{ID: "task2", Title: "Delta", Status: "new", UserID: "user1", Position: 1, DueDate: &late}
+			storage.tasks["task3"] = models.Task{ID: "task3", Title: "Alpha", Status: "new", UserID: "user1", Position: 2}
+			storage.tasks["task4"] = models.Task{ID: "task4", Title: "Charlie", Status: "new", UserID: "user1", Position: 3, DueDate: &early}
+
+			tasks, err := storage.GetTasks(context.Background(), "user1", tt.filter)
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestStorageCreateTasks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	existing := &models.Task{Title: "Existing", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, existing))
+
+	tasks := []models.Task{
+		{Title: "First", Status: "new", UserID: "user1"},
+		{Title: "Second", Status: "new", UserID: "user1"},
+		{Title: "Other", Status: "new", UserID: "user2"},
+	}
+	assert.NoError(t, storage.CreateTasks(ctx, tasks))
+	for _, task := range tasks {
+		assert.NotEmpty(t, task.ID)
+	}
+	assert.Equal(t, 1, tasks[0].Position)
+	assert.Equal(t, 2, tasks[1].Position)
+	assert.Equal(t, 0, tasks[2].Position)
+
+	listed, err := storage.GetTasks(ctx, "user1", models.TaskFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 3) {
+		assert.Equal(t, "Existing", listed[0].Title)
+		assert.Equal(t, "Second", listed[2].Title)
+	}
+}
+
+func TestStorageDueTasks(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	tasks := []*models.Task{
+		{Title: "Late", Status: "new", UserID: "user1", DueDate: at(-time.Hour)},
+		{Title: "Soon", Status: "in_progress", UserID: "user1", DueDate: at(time.Hour)},
+		{Title: "Later", Status: "new", UserID: "user1", DueDate: at(72 * time.Hour)},
+		{Title: "Done late", Status: "done", UserID: "user1", DueDate: at(-2 * time.Hour)},
+		{Title: "No date", Status: "new", UserID: "user1"},
+		{Title: "Other", Status: "new", UserID: "user2", DueDate: at(-time.Hour)},
+	}
+	for _, task := range tasks {
+		assert.NoError(t, storage.CreateTask(ctx, task))
+	}
+
+	overdue, err := storage.GetOverdueTasks(ctx, "user1", now)
+	assert.NoError(t, err)
+	if assert.Len(t, overdue, 1) {
+		assert.Equal(t, "Late", overdue[0].Title)
+	}
+
+	due, err := storage.GetDueTasks(ctx, "user1", now, now.Add(48*time.Hour))
+	assert.NoError(t, err)
+	if assert.Len(t, due, 1) {
+		assert.Equal(t, "Soon", due[0].Title)
+	}
+}
+
+func TestStorageChecklist(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	task := &models.Task{Title: "Groceries", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, task))
+
+	milk := &models.ChecklistItem{TaskID: task.ID, Title: "Milk"}
+	bread := &models.ChecklistItem{TaskID: task.ID, Title: "Bread"}
+	eggs := &models.ChecklistItem{TaskID: task.ID, Title: "Eggs"}
+	for _, item := range []*models.ChecklistItem{milk, bread, eggs} {
+		assert.NoError(t, storage.AddChecklistItem(ctx, item))
+	}
+	assert.Equal(t, 2, eggs.Position)
+
+	toggled, err := storage.ToggleChecklistItem(ctx, task.ID, bread.ID)
+	assert.NoError(t, err)
+	assert.True(t, toggled.Done)
+	_, err = storage.ToggleChecklistItem(ctx, "other", bread.ID)
+	assert.Equal(t, errors.ErrChecklistItemNotFound, err)
+
+	fetched, err := storage.GetTaskByID(ctx, task.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, fetched.Checklist) {
+		assert.Equal(t, 3, fetched.Checklist.Total)
+		assert.Equal(t, 1, fetched.Checklist.Done)
+		assert.InDelta(t, 1.0/3, fetched.Checklist.Ratio, 0.0001)
+	}
+
+	assert.NoError(t, storage.ReorderChecklist(ctx, task.ID, []string{eggs.ID, milk.ID, bread.ID}))
+	assert.Equal(t, errors.ErrChecklistItemNotFound, storage.ReorderChecklist(ctx, task.ID, []string{"missing"}))
+	items, err := storage.GetChecklist(ctx, task.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 3) {
+		assert.Equal(t, "Eggs", items[0].Title)
+		assert.Equal(t, "Bread", items[2].Title)
+	}
+
+	assert.NoError(t, storage.DeleteChecklistItem(ctx, task.ID, milk.ID))
+	assert.Equal(t, errors.ErrChecklistItemNotFound, storage.DeleteChecklistItem(ctx, task.ID, milk.ID))
+	items, err = storage.GetChecklist(ctx, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	empty := &models.Task{Title: "Empty", Status: "new", UserID: "user1"}
+	assert.NoError(t, storage.CreateTask(ctx, empty))
+	fetched, err = storage.GetTaskByID(ctx, empty.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, fetched.Checklist)
+}
+
+func TestStorageWorkflow(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+
+	_, err := storage.GetWorkflow(ctx, "user1")
+	assert.Equal(t, errors.ErrWorkflowNotFound, err)
+
+	workflow := &models.Workflow{UserID: "user1", Statuses: []string{"backlog", "done"}, InitialStatus: "backlog"}
+	assert.NoError(t, storage.SaveWorkflow(ctx, workflow))
+	fetched, err := storage.GetWorkflow(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backlog", "done"}, fetched.Statuses)
+	assert.Equal(t, "backlog", fetched.InitialStatus)
+	assert.NotNil(t, fetched.Transitions)
+
+	workflow.Statuses = []string{"todo", "done"}
+	workflow.InitialStatus = "todo"
+	assert.NoError(t, storage.SaveWorkflow(ctx, workflow))
+	fetched, err = storage.GetWorkflow(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, "todo", fetched.InitialStatus)
+
+	assert.NoError(t, storage.DeleteWorkflow(ctx, "user1"))
+	assert.Equal(t, errors.ErrWorkflowNotFound, storage.DeleteWorkflow(ctx, "user1"))
+	_, err = storage.GetWorkflow(ctx, "user1")
+	assert.Equal(t, errors.ErrWorkflowNotFound, err)
+}
+
+func TestStorageSearchUsers(t *testing.T) {
+	storage := NewStorage()
+	for _, user := range []models.User{
+		{ID: "user1", Username: "alexander", Email: "alex@example.com", Role: "user"},
+		{ID: "user2", Username: "alexey", Email: "lesha@example.com", Role: "user"},
+		{ID: "user3", Username: "maria", Email: "maria@example.com", Role: "user"},
+		{ID: "user4", Username: "jonathan", Email: "jon@example.com", Role: "user"},
+	} {
+		storage.users[user.ID] = user
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "username prefix", query: "ALE", limit: 10, want: []string{"alexey", "alexander"}},
+		{name: "email prefix", query: "lesha@", limit: 10, want: []string{"alexey"}},
+		{name: "fuzzy match", query: "jonatan", limit: 10, want: []string{"jonathan"}},
+		{name: "limit", query: "ale", limit: 1, want: []string{"alexey"}},
+		{name: "no match", query: "zzz", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := storage.SearchUsers(context.Background(), tt.query, tt.limit)
+			assert.NoError(t, err)
+			names := []string{}
+			for _, user := range users {
+				names = append(names, user.Username)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestStorageUserPreferences(t *testing.T) {
+	storage := NewStorage()
+	storage.users["user1"] = models.User{ID: "user1", Username: "testuser"}
+
+	_, err := storage.GetUserPreferences(context.Background(), "missing")
+	assert.Equal(t, errors.ErrUserNotFound, err)
+	assert.Equal(t, errors.ErrUserNotFound, storage.SaveUserPreferences(context.Background(), "missing", &models.UserPreferences{}))
+
+	prefs, err := storage.GetUserPreferences(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultUserPreferences(), *prefs)
+
+	custom := models.UserPreferences{
+		Timezone:      "Europe/Moscow",
+		Locale:        models.LocaleEN,
+		Notifications: models.NotificationPreferences{Reminders: true, Channels: []string{"email"}},
+	}
+	assert.NoError(t, storage.SaveUserPreferences(context.Background(), "user1", &custom))
+	custom.Notifications.Channels[0] = "webhook"
+	prefs, err = storage.GetUserPreferences(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Moscow", prefs.Timezone)
+	assert.Equal(t, []string{"email"}, prefs.Notifications.Channels)
+
+	assert.NoError(t, storage.DeleteUser(context.Background(), "user1"))
+	_, exists := storage.preferences["user1"]
+	assert.False(t, exists)
+}
+
+func TestStorageUserNormalization(t *testing.T) {
+	storage := NewStorage()
+	user := &models.User{Username: " Alice ", Email: "Alice@Example.COM", Password: "password123", Role: "user"}
+	assert.NoError(t, storage.CreateUser(context.Background(), user))
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, "alice@example.com", user.Email)
+
+	found, err := storage.GetUserByUsername(context.Background(), "ALICE")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, found.ID)
+
+	other := &models.User{Username: "bob", Email: "bob@example.com", Password: "password123", Role: "user"}
+	assert.NoError(t, storage.CreateUser(context.Background(), other))
+	assert.Equal(t, errors.ErrUserAlreadyExists, storage.UpdateUser(context.Background(), other.ID, &models.User{Username: "Alice", Email: "bob@example.com"}))
+	assert.NoError(t, storage.UpdateUser(context.Background(), other.ID, &models.User{Username: "Bobby", Email: "BOB@example.com"}))
+	updated, err := storage.GetUserByID(context.Background(), other.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "bobby", updated.Username)
+}
+
+func TestStorageUserActive(t *testing.T) {
+	storage := NewStorage()
+	user := &models.User{Username: "testuser", Email: "test@example.com", Password: "password123", Role: "user"}
+	assert.NoError(t, storage.CreateUser(context.Background(), user))
+	assert.True(t, user.Active)
+
+	active, err := storage.IsUserActive(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	assert.NoError(t, storage.SetUserActive(context.Background(), user.ID, false))
+	active, err = storage.IsUserActive(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	assert.NoError(t, storage.UpdateUser(context.Background(), user.ID, &models.User{Username: "renamed", Email: "test@example.com"}))
+	active, err = storage.IsUserActive(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	_, err = storage.IsUserActive(context.Background(), "missing")
+	assert.Equal(t, errors.ErrUserNotFound, err)
+	assert.Equal(t, errors.ErrUserNotFound, storage.SetUserActive(context.Background(), "missing", true))
+}
+
+func TestStorageLoginHistory(t *testing.T) {
+	storage := NewStorage()
+	user := &models.User{Username: "testuser", Email: "test@example.com", Password: "password123", Role: "user"}
+	assert.NoError(t, storage.CreateUser(context.Background(), user))
+	assert.Nil(t, user.LastLoginAt)
+
+	first := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	assert.NoError(t, storage.RecordLogin(context.Background(), &models.LoginRecord{UserID: user.ID, IP: "10.0.0.1", UserAgent: "agent", Success: true, CreatedAt: first}))
+	assert.NoError(t, storage.RecordLogin(context.Background(), &models.LoginRecord{UserID: user.ID, IP: "10.0.0.2", UserAgent: "agent", Success: false, CreatedAt: first.Add(time.Hour)}))
+
+	fetched, err := storage.GetUserByID(context.Background(), user.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, fetched.LastLoginAt) {
+		assert.Equal(t, first, *fetched.LastLoginAt)
+	}
+
+	history, err := storage.GetLoginHistory(context.Background(), user.ID, time.Time{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.False(t, history[0].Success)
+		assert.Equal(t, "10.0.0.2", history[0].IP)
+		assert.True(t, history[1].Success)
+		assert.NotEmpty(t, history[1].ID)
+	}
+
+	history, err = storage.GetLoginHistory(context.Background(), user.ID, first.Add(time.Hour), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 1) {
+		assert.True(t, history[0].Success)
+	}
+
+	history, err = storage.GetLoginHistory(context.Background(), user.ID, time.Time{}, 1)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	assert.Equal(t, errors.ErrUserNotFound, storage.RecordLogin(context.Background(), &models.LoginRecord{UserID: "missing"}))
+	history, err = storage.GetLoginHistory(context.Background(), "missing", time.Time{}, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestStorageGetUserTaskEvents(t *testing.T) {
+	storage := NewStorage()
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	events := []models.TaskEvent{
+		{TaskID: "task1", UserID: "user1", Action: models.TaskEventCreate, CreatedAt: base},
+		{TaskID: "task1", UserID: "user1", Action: models.TaskEventUpdate, CreatedAt: base.Add(time.Hour)},
+		{TaskID: "task2", UserID: "user1", Action: models.TaskEventCreate, CreatedAt: base.Add(2 * time.Hour)},
+		{TaskID: "task2", UserID: "user2", Action: models.TaskEventUpdate, CreatedAt: base.Add(3 * time.Hour)},
+	}
+	for i := range events {
+		assert.NoError(t, storage.AddTaskEvent(ctx, &events[i]))
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		before time.Time
+		limit  int
+		want   []time.Time
+	}{
+		{name: "newest first", userID: "user1", limit: 10, want: []time.Time{base.Add(2 * time.Hour), base.Add(time.Hour), base}},
+		{name: "limited", userID: "user1", limit: 2, want: []time.Time{base.Add(2 * time.Hour), base.Add(time.Hour)}},
+		{name: "before cursor", userID: "user1", before: base.Add(2 * time.Hour), limit: 10, want: []time.Time{base.Add(time.Hour), base}},
+		{name: "other user", userID: "user2", limit: 10, want: []time.Time{base.Add(3 * time.Hour)}},
+		{name: "no events", userID: "user3", limit: 10, want: []time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.GetUserTaskEvents(ctx, tt.userID, tt.before, tt.limit)
+			assert.NoError(t, err)
+			times := []time.Time{}
+			for _, event := range got {
+				assert.Equal(t, tt.userID, event.UserID)
+				times = append(times, event.CreatedAt)
+			}
+			assert.Equal(t, tt.want, times)
+		})
+	}
+}
+
+func TestStorageRestoreBackup(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	existing := &models.User{Username: "bob", Email: "bob@example.com", Password: "password123"}
+	assert.NoError(t, storage.CreateUser(ctx, existing))
+
+	users := []models.User{{ID: "user1", Username: "Alice", Email: "alice@example.com", Password: "hash", Role: "user", Active: true}}
+	tasks := []models.Task{
+		{ID: "task1", Title: "Active", Status: "new", UserID: "user1", ProjectID: "missing"},
+		{ID: "task2", Title: "Trashed", Status: "new", UserID: "user1", Deleted: true},
+	}
+	assert.NoError(t, storage.RestoreBackup(ctx, users, tasks))
+
+	user, err := storage.GetUserByID(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user.Username)
+	task, err := storage.GetTaskByIDNoCtx("task1")
+	assert.NoError(t, err)
+	assert.Empty(t, task.ProjectID)
+	trash, err := storage.GetTrash(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Len(t, trash, 1)
+
+	conflict := []models.User{{ID: "user2", Username: "carol", Email: "carol@example.com"}, {ID: "user3", Username: "bob", Email: "other@example.com"}}
+	assert.Equal(t, errors.ErrUserAlreadyExists, storage.RestoreBackup(ctx, conflict, nil))
+	_, err = storage.GetUserByID(ctx, "user2")
+	assert.Equal(t, errors.ErrUserNotFound, err)
+
+	assert.Equal(t, errors.ErrTaskAlreadyExists, storage.RestoreBackup(ctx, nil, []models.Task{{ID: "task1", UserID: "user1"}}))
+	assert.Equal(t, errors.ErrUserNotFound, storage.RestoreBackup(ctx, nil, []models.Task{{ID: "task3", UserID: "ghost"}}))
+}
+
+func TestStorageConformance(t *testing.T) {
+	conformancetest.Run(t, func(t *testing.T) repository.Storage {
+		return NewStorage()
+	})
+}
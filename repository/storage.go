@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"project/internal/domain/models"
+	"time"
+)
+
+type UserStore interface {
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	UpdateUser(ctx context.Context, id string, user *models.User) error
+	DeleteUser(ctx context.Context, id string) error
+	CreateUser(ctx context.Context, user *models.User) error
+	SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error)
+	GetUserPreferences(ctx context.Context, userID string) (*models.UserPreferences, error)
+	SaveUserPreferences(ctx context.Context, userID string, prefs *models.UserPreferences) error
+	IsUserActive(ctx context.Context, id string) (bool, error)
+	SetUserActive(ctx context.Context, id string, active bool) error
+	RecordLogin(ctx context.Context, record *models.LoginRecord) error
+	GetLoginHistory(ctx context.Context, userID string, before time.Time, limit int) ([]models.LoginRecord, error)
+}
+
+type TaskStore interface {
+	CreateTask(ctx context.Context, task *models.Task) error
+	CreateTasks(ctx context.Context, tasks []models.Task) error
+	GetTaskByID(ctx context.Context, id string) (*models.Task, error)
+	GetTasks(ctx context.Context, userID string, filter models.TaskFilter) ([]models.Task, error)
+	UpdateTask(ctx context.Context, id string, task *models.Task) error
+	DeleteTask(ctx context.Context, id string) error
+	SearchTasks(ctx context.Context, userID, query string) ([]models.Task, error)
+	GetSubtasks(ctx context.Context, parentID string) ([]models.Task, error)
+	GetOverdueTasks(ctx context.Context, userID string, now time.Time) ([]models.Task, error)
+	GetDueTasks(ctx context.Context, userID string, from, to time.Time) ([]models.Task, error)
+	ApplyBulk(ctx context.Context, ops []models.BulkOperation) ([]models.BulkResult, error)
+	GetTrash(ctx context.Context, userID string) ([]models.Task, error)
+	RestoreTask(ctx context.Context, id string) error
+	HardDeleteTask(ctx context.Context, id string) error
+	SetTaskArchived(ctx context.Context, id string, archived bool) error
+	AssignTask(ctx context.Context, id, assigneeID string) error
+	ExportTasks(ctx context.Context, userID string, fn func(models.Task) error) error
+	StreamTasks(ctx context.Context, userID string, filter models.TaskFilter, fn func(models.Task) error) error
+	ReorderTasks(ctx context.Context, userID string, taskIDs []string) error
+}
+
+type TagStore interface {
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	GetTags(ctx context.Context, userID string) ([]models.Tag, error)
+	GetTagByID(ctx context.Context, id string) (*models.Tag, error)
+	UpdateTag(ctx context.Context, id string, tag *models.Tag) error
+	DeleteTag(ctx context.Context, id string) error
+	AttachTag(ctx context.Context, taskID, tagID string) error
+	DetachTag(ctx context.Context, taskID, tagID string) error
+}
+
+type ShareStore interface {
+	ShareTask(ctx context.Context, share *models.TaskShare) error
+	GetTaskPermission(ctx context.Context, taskID, userID string) (string, error)
+}
+
+type ProjectStore interface {
+	CreateProject(ctx context.Context, project *models.Project) error
+	GetProjects(ctx context.Context, userID string) ([]models.Project, error)
+	GetProjectByID(ctx context.Context, id string) (*models.Project, error)
+	UpdateProject(ctx context.Context, id string, project *models.Project) error
+	DeleteProject(ctx context.Context, id string) error
+}
+
+type WorkspaceStore interface {
+	CreateWorkspace(ctx context.Context, workspace *models.Workspace) error
+	GetWorkspaces(ctx context.Context, userID string) ([]models.Workspace, error)
+	GetWorkspaceByID(ctx context.Context, id string) (*models.Workspace, error)
+	UpdateWorkspace(ctx context.Context, id string, workspace *models.Workspace) error
+	DeleteWorkspace(ctx context.Context, id string) error
+	GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error)
+	GetWorkspaceRole(ctx context.Context, workspaceID, userID string) (string, error)
+	SaveWorkspaceMember(ctx context.Context, member *models.WorkspaceMember) error
+	RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error
+}
+
+type TemplateStore interface {
+	CreateTemplate(ctx context.Context, template *models.TaskTemplate) error
+	GetTemplates(ctx context.Context, userID string) ([]models.TaskTemplate, error)
+	GetTemplateByID(ctx context.Context, id string) (*models.TaskTemplate, error)
+	UpdateTemplate(ctx context.Context, id string, template *models.TaskTemplate) error
+	DeleteTemplate(ctx context.Context, id string) error
+}
+
+type TaskEventStore interface {
+	AddTaskEvent(ctx context.Context, event *models.TaskEvent) error
+	GetTaskEvents(ctx context.Context, taskID string) ([]models.TaskEvent, error)
+	GetUserTaskEvents(ctx context.Context, userID string, before time.Time, limit int) ([]models.TaskEvent, error)
+}
+
+type ChecklistStore interface {
+	GetChecklist(ctx context.Context, taskID string) ([]models.ChecklistItem, error)
+	AddChecklistItem(ctx context.Context, item *models.ChecklistItem) error
+	ToggleChecklistItem(ctx context.Context, taskID, itemID string) (*models.ChecklistItem, error)
+	ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) error
+	DeleteChecklistItem(ctx context.Context, taskID, itemID string) error
+}
+
+type WorkflowStore interface {
+	GetWorkflow(ctx context.Context, userID string) (*models.Workflow, error)
+	SaveWorkflow(ctx context.Context, workflow *models.Workflow) error
+	DeleteWorkflow(ctx context.Context, userID string) error
+}
+
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, hook *models.Webhook) error
+	GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, id string) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string) ([]models.WebhookDelivery, error)
+}
+
+type TxStore interface {
+	WithTx(ctx context.Context, fn func(tx Storage) error) error
+}
+
+type Storage interface {
+	UserStore
+	TaskStore
+	TagStore
+	ShareStore
+	ProjectStore
+	WorkspaceStore
+	TemplateStore
+	TaskEventStore
+	ChecklistStore
+	WorkflowStore
+	WebhookStore
+	TxStore
+}